@@ -19,6 +19,12 @@ type Store struct {
 	KubeHost      string
 	KubeCACrt     string
 	KubeNamespace string
+
+	// KubeconfigPath and KubeconfigContext are set if the credentials were
+	// merged into a kubeconfig by `blimp kubeconfig --merge`, so that they
+	// can be refreshed by `blimp up`.
+	KubeconfigPath    string
+	KubeconfigContext string
 }
 
 func (store Store) KubeClient() (kubernetes.Interface, *rest.Config, error) {
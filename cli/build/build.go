@@ -29,6 +29,7 @@ import (
 
 func New() *cobra.Command {
 	var composePaths []string
+	var profiles []string
 	var pull bool
 	var noCache bool
 	var forceBuildkit bool
@@ -83,7 +84,7 @@ func New() *cobra.Command {
 				log.WithError(err).Fatal("Failed to get absolute path to Compose file")
 			}
 
-			parsedCompose, err := dockercompose.Load(composePath, overridePaths, services)
+			parsedCompose, err := dockercompose.Load(composePath, overridePaths, profiles, services)
 			if err != nil {
 				log.WithError(err).Fatal("Failed to load compose file")
 			}
@@ -118,6 +119,8 @@ func New() *cobra.Command {
 	}
 	cobraCmd.Flags().StringSliceVarP(&composePaths, "file", "f", nil,
 		"Specify an alternate compose file\nDefaults to docker-compose.yml and docker-compose.yaml")
+	cobraCmd.Flags().StringSliceVarP(&profiles, "profile", "", nil,
+		"Specify a profile to enable")
 	cobraCmd.Flags().BoolVarP(&pull, "pull", "", false,
 		"Always attempt to pull a newer version of the image.")
 	cobraCmd.Flags().BoolVarP(&noCache, "no-cache", "", false,
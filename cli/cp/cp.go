@@ -14,7 +14,6 @@ import (
 	"github.com/kelda/blimp/cli/cp/kubectlcp"
 	"github.com/kelda/blimp/cli/manager"
 	"github.com/kelda/blimp/pkg/errors"
-	"github.com/kelda/blimp/pkg/names"
 	"github.com/kelda/blimp/pkg/proto/auth"
 )
 
@@ -109,13 +108,13 @@ func translateSpec(fileSpec kubectlcp.FileSpec, auth *auth.BlimpAuth) (kubectlcp
 			"Specifying the remote namespace is not allowed.")
 	}
 
-	err := manager.CheckServiceRunning(fileSpec.PodName, auth)
+	podName, err := manager.CheckServiceRunning(fileSpec.PodName, auth)
 	if err != nil {
 		return kubectlcp.FileSpec{}, err
 	}
 
 	return kubectlcp.FileSpec{
-		PodName: names.ToDNS1123(fileSpec.PodName),
+		PodName: podName,
 		File:    fileSpec.File,
 	}, nil
 }
@@ -0,0 +1,154 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buger/goterm"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/config"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/auth"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	var since string
+	var jsonOutput bool
+	cobraCmd := &cobra.Command{
+		Use:   "events [options] [SERVICE...]",
+		Short: "Stream the lifecycle events of the services in the sandbox",
+		Long: "Stream the lifecycle events of the services in the sandbox, such as " +
+			"image pulls, crashes, and failed healthchecks.\n\n" +
+			"By default, only new events are shown. Use --since to show past events. " +
+			"Past events are only kept for about an hour.",
+		Run: func(_ *cobra.Command, services []string) {
+			blimpConfig, err := config.GetConfig()
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			sinceTime, err := parseSince(since, time.Now())
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			if err := run(blimpConfig.BlimpAuth(), services, sinceTime, jsonOutput); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+		// Don't append [flags] to the end of the usage string. We already have
+		// it hardcoded since the options must come before the positional
+		// arguments.
+		DisableFlagsInUseLine: true,
+	}
+	cobraCmd.Flags().StringVarP(&since, "since", "", "",
+		"Show events since a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
+	cobraCmd.Flags().BoolVarP(&jsonOutput, "json", "", false,
+		"Print each event as a line of JSON")
+	return cobraCmd
+}
+
+type eventJSON struct {
+	Time    time.Time `json:"time"`
+	Service string    `json:"service"`
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+	Warning bool      `json:"warning"`
+	Count   int32     `json:"count"`
+}
+
+func run(auth *auth.BlimpAuth, services []string, since time.Time, jsonOutput bool) error {
+	stream, err := manager.C.WatchEvents(context.Background(), &cluster.WatchEventsRequest{
+		Auth:  auth,
+		Since: since.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	showService := map[string]bool{}
+	for _, svc := range services {
+		showService[svc] = true
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		event := resp.GetEvent()
+		if len(showService) != 0 && !showService[event.GetService()] {
+			continue
+		}
+
+		if jsonOutput {
+			eventBytes, err := json.Marshal(eventJSON{
+				Time:    time.Unix(event.GetTime(), 0),
+				Service: event.GetService(),
+				Type:    TypeString(event.GetType()),
+				Message: event.GetMessage(),
+				Warning: event.GetWarning(),
+				Count:   event.GetCount(),
+			})
+			if err != nil {
+				return errors.WithContext("marshal event", err)
+			}
+			fmt.Println(string(eventBytes))
+			continue
+		}
+
+		fmt.Println(formatEvent(event))
+	}
+}
+
+func formatEvent(event *cluster.Event) string {
+	evType := TypeString(event.GetType())
+	if event.GetWarning() {
+		evType = goterm.Color(evType, goterm.YELLOW)
+	}
+
+	line := fmt.Sprintf("%s %s %s %s",
+		time.Unix(event.GetTime(), 0).Format(time.RFC3339),
+		event.GetService(), evType, event.GetMessage())
+	if event.GetCount() > 1 {
+		line += fmt.Sprintf(" (x%d)", event.GetCount())
+	}
+	return line
+}
+
+// TypeString returns the name of the event type shown to users, such as
+// `pulling-image`.
+func TypeString(evType cluster.Event_Type) string {
+	return strings.ToLower(strings.Replace(evType.String(), "_", "-", -1))
+}
+
+// parseSince parses a timestamp, Unix time, or duration relative to `now`. If
+// `since` is empty, `now` is returned.
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if since == "" {
+		return now, nil
+	}
+
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+
+	if unix, err := strconv.ParseInt(since, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+
+	return time.Time{}, errors.NewFriendlyError("Invalid --since %q. "+
+		"Expected a timestamp (e.g. 2013-01-02T13:23:37Z) or a duration (e.g. 42m).", since)
+}
@@ -16,13 +16,16 @@ import (
 )
 
 func New() *cobra.Command {
-	var unexpose bool
+	var unexpose, h2c bool
 	cobraCmd := &cobra.Command{
 		Use:   "expose SERVICE PORT",
 		Short: "Expose a service port over the internet",
 		Long: `Expose an HTTP service over a publicly-available domain.
 PORT should be the port on SERVICE's container that should be exposed, which
-might be different from the port you use locally.`,
+might be different from the port you use locally.
+
+WebSocket connections are supported. Services that speak cleartext HTTP/2, such
+as gRPC servers, should be exposed with --h2c.`,
 		Run: func(_ *cobra.Command, args []string) {
 			blimpConfig, err := config.GetConfig()
 			if err != nil {
@@ -53,21 +56,29 @@ might be different from the port you use locally.`,
 				os.Exit(1)
 			}
 
-			if err := runExpose(blimpConfig.BlimpAuth(), args[0], port); err != nil {
+			protocol := cluster.ExposeProtocol_HTTP
+			if h2c {
+				protocol = cluster.ExposeProtocol_H2C
+			}
+
+			if err := runExpose(blimpConfig.BlimpAuth(), args[0], port, protocol); err != nil {
 				errors.HandleFatalError(err)
 			}
 		},
 	}
 	cobraCmd.Flags().BoolVarP(&unexpose, "rm", "", false,
 		"Remove any currently exposed ports")
+	cobraCmd.Flags().BoolVarP(&h2c, "h2c", "", false,
+		"Proxy requests to the service using cleartext HTTP/2 (e.g. for gRPC servers)")
 	return cobraCmd
 }
 
-func runExpose(auth *auth.BlimpAuth, service string, port int) error {
+func runExpose(auth *auth.BlimpAuth, service string, port int, protocol cluster.ExposeProtocol) error {
 	resp, err := manager.C.Expose(context.Background(), &cluster.ExposeRequest{
-		Auth:    auth,
-		Service: service,
-		Port:    uint32(port),
+		Auth:     auth,
+		Service:  service,
+		Port:     uint32(port),
+		Protocol: protocol,
 	})
 	if err != nil {
 		return errors.WithContext("send expose port request", err)
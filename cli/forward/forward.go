@@ -0,0 +1,147 @@
+package forward
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/port"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// bindTimeout is how long to wait for `blimp up` to start forwarding the
+// port.
+const bindTimeout = 10 * time.Second
+
+func New() *cobra.Command {
+	var remove bool
+	cobraCmd := &cobra.Command{
+		Use:   "forward [options] SERVICE [LOCAL_PORT:]PRIVATE_PORT",
+		Short: "Forward a local port to a service while `blimp up` is running",
+		Long: "Forward a local port to a service while `blimp up` is running.\n\n" +
+			"The forward listens on localhost, and lasts until it's removed with --remove, " +
+			"or `blimp up` exits. If LOCAL_PORT isn't specified, PRIVATE_PORT is used. " +
+			"If the local port is already in use, a free port is used instead. For example:\n" +
+			"blimp forward db 5432",
+		Args: cobra.ExactArgs(2),
+		Run: func(_ *cobra.Command, args []string) {
+			if err := run(args[0], args[1], remove); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+		// Don't append [flags] to the end of the usage string. We already have
+		// it hardcoded since the options must come before the positional
+		// arguments.
+		DisableFlagsInUseLine: true,
+	}
+	cobraCmd.Flags().BoolVarP(&remove, "remove", "", false,
+		"Stop forwarding the port")
+	return cobraCmd
+}
+
+func run(svc, portsStr string, remove bool) error {
+	if !util.UpRunning() {
+		return errors.NewFriendlyError("Ports can only be forwarded while `blimp up` is running.")
+	}
+
+	hostPort, privatePort, err := parsePorts(portsStr)
+	if err != nil {
+		return err
+	}
+
+	forwards, err := util.ReadPortForwards()
+	if err != nil {
+		return err
+	}
+
+	if remove {
+		var remaining []util.PortForward
+		for _, fwd := range forwards {
+			// Remove all the forwards to the port if no local port was given.
+			matchesHostPort := hostPort == 0 || fwd.HostPort == hostPort
+			if fwd.Service == svc && fwd.PrivatePort == privatePort && matchesHostPort {
+				continue
+			}
+			remaining = append(remaining, fwd)
+		}
+
+		if len(remaining) == len(forwards) {
+			return errors.NewFriendlyError("Port %d of service %s wasn't forwarded by `blimp forward`.",
+				privatePort, svc)
+		}
+		return util.WritePortForwards(remaining)
+	}
+
+	request := util.PortForward{
+		Service:     svc,
+		PrivatePort: privatePort,
+		HostPort:    hostPort,
+	}
+
+	alreadyRequested := false
+	for _, fwd := range forwards {
+		if fwd == request {
+			alreadyRequested = true
+			break
+		}
+	}
+	if !alreadyRequested {
+		if err := util.WritePortForwards(append(forwards, request)); err != nil {
+			return err
+		}
+	}
+
+	// Wait for `blimp up` to bind the port.
+	requestedHostPort := hostPort
+	if requestedHostPort == 0 {
+		requestedHostPort = privatePort
+	}
+	deadline := time.Now().Add(bindTimeout)
+	for time.Now().Before(deadline) {
+		bindings, err := util.ReadPortBindings()
+		if err != nil {
+			return err
+		}
+
+		for _, binding := range bindings {
+			if binding.AdHoc && binding.Service == svc && binding.PrivatePort == privatePort &&
+				binding.RequestedHostPort == requestedHostPort {
+				fmt.Printf("Forwarding %s to port %d of service %s\n",
+					port.FormatAddress(binding), privatePort, svc)
+				return nil
+			}
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+
+	return errors.NewFriendlyError("Timed out waiting for `blimp up` to forward the port. " +
+		"Check the output of `blimp up` for errors.")
+}
+
+// parsePorts parses a port specification of the form
+// [LOCAL_PORT:]PRIVATE_PORT. The local port is zero if it isn't specified.
+func parsePorts(portsStr string) (hostPort, privatePort uint32, err error) {
+	parsePort := func(s string) (uint32, error) {
+		port, err := strconv.ParseUint(s, 10, 16)
+		if err != nil || port == 0 {
+			return 0, errors.NewFriendlyError("Invalid port %q. The port must be a number between 1 and 65535.", s)
+		}
+		return uint32(port), nil
+	}
+
+	parts := strings.SplitN(portsStr, ":", 2)
+	if len(parts) == 2 {
+		if hostPort, err = parsePort(parts[0]); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if privatePort, err = parsePort(parts[len(parts)-1]); err != nil {
+		return 0, 0, err
+	}
+	return hostPort, privatePort, nil
+}
@@ -0,0 +1,146 @@
+package inspecthealth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/config"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/ps"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/auth"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	var format string
+	cobraCmd := &cobra.Command{
+		Use:   "inspect-health SERVICE",
+		Short: "Print the results of a service's most recent healthchecks",
+		Long: "Print the results of a service's most recent healthchecks.\n\n" +
+			"Kubernetes doesn't record the output of successful healthchecks, so " +
+			"only the time that the service became healthy is shown for them.",
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			blimpConfig, err := config.GetConfig()
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			if err := run(blimpConfig.BlimpAuth(), args[0], format); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().StringVarP(&format, "format", "", "table",
+		"Output format. One of: table, json.")
+	return cobraCmd
+}
+
+type healthJSON struct {
+	Status string       `json:"status"`
+	Log    []resultJSON `json:"log"`
+}
+
+type resultJSON struct {
+	Time    time.Time `json:"time"`
+	Healthy bool      `json:"healthy"`
+	Output  string    `json:"output,omitempty"`
+	Count   int32     `json:"count,omitempty"`
+}
+
+func run(auth *auth.BlimpAuth, svc, format string) error {
+	if format != "table" && format != "json" {
+		return errors.NewFriendlyError("Unknown format %q. Must be one of: table, json.", format)
+	}
+
+	resp, err := manager.C.GetStatus(context.Background(), &cluster.GetStatusRequest{
+		Auth: auth,
+	})
+	if err != nil {
+		return err
+	}
+
+	svcStatus, ok := resp.GetStatus().GetServices()[svc]
+	if !ok {
+		return errors.NewFriendlyError("Unknown service %q.", svc)
+	}
+
+	status, err := getHealthStatus(svc, svcStatus)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		out := healthJSON{Status: status, Log: []resultJSON{}}
+		for _, result := range svcStatus.GetHealthLog() {
+			out.Log = append(out.Log, resultJSON{
+				Time:    time.Unix(result.GetTime(), 0),
+				Healthy: result.GetHealthy(),
+				Output:  result.GetOutput(),
+				Count:   result.GetCount(),
+			})
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			return errors.WithContext("write json", err)
+		}
+		return nil
+	}
+
+	fmt.Printf("Status: %s\n", status)
+	if len(svcStatus.GetHealthLog()) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "TIME\tRESULT\tCOUNT\tOUTPUT")
+	for _, result := range svcStatus.GetHealthLog() {
+		resultStr := "unhealthy"
+		countStr := fmt.Sprintf("%d", result.GetCount())
+		if result.GetHealthy() {
+			resultStr = "healthy"
+			countStr = "-"
+		}
+
+		// Keep the table aligned if the output spans multiple lines.
+		output := strings.Join(strings.Fields(result.GetOutput()), " ")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			time.Unix(result.GetTime(), 0).Format(time.RFC3339), resultStr, countStr, output)
+	}
+	return nil
+}
+
+// getHealthStatus returns the health of the service, using the same statuses
+// as Docker.
+func getHealthStatus(svc string, svcStatus *cluster.ServiceStatus) (string, error) {
+	healthLog := svcStatus.GetHealthLog()
+	switch svcStatus.GetPhase() {
+	case cluster.ServicePhase_RUNNING:
+		// Services without healthchecks are always ready, so they never have
+		// any results.
+		if len(healthLog) == 0 {
+			return "", errors.NewFriendlyError("Service %s doesn't have a healthcheck.", svc)
+		}
+		return "healthy", nil
+	case cluster.ServicePhase_UNHEALTHY:
+		if len(healthLog) == 0 {
+			return "starting", nil
+		}
+		return "unhealthy", nil
+	default:
+		statusStr, _, _ := ps.GetStatusString(svcStatus)
+		return "", errors.NewFriendlyError("Service %s isn't running (%s).", svc, statusStr)
+	}
+}
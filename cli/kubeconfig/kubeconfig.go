@@ -0,0 +1,140 @@
+package kubeconfig
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kelda/blimp/cli/authstore"
+	"github.com/kelda/blimp/cli/config"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// DefaultContext is the name of the kubeconfig context used for the sandbox
+// if the user doesn't pick one.
+const DefaultContext = "blimp"
+
+func New() *cobra.Command {
+	var merge bool
+	var path, contextName string
+	cobraCmd := &cobra.Command{
+		Use:   "kubeconfig",
+		Short: "Export the credentials for your sandbox's Kubernetes namespace",
+		Long: "Export the credentials for your sandbox's Kubernetes namespace, so that tools " +
+			"such as kubectl, k9s, and IDE plugins can access it.\n\n" +
+			"By default, the kubeconfig is printed to stdout. With --merge, it's added " +
+			"to your kubeconfig file as a new context instead. The merged credentials " +
+			"are refreshed every time you run `blimp up`.",
+		Run: func(_ *cobra.Command, _ []string) {
+			blimpConfig, err := config.GetConfig()
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			if err := run(blimpConfig.Auth, merge, path, contextName); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().BoolVarP(&merge, "merge", "", false,
+		"Merge the context into your kubeconfig file rather than printing it")
+	cobraCmd.Flags().StringVarP(&path, "kubeconfig", "", "",
+		"The kubeconfig file to merge into. "+
+			"Defaults to the first file in $KUBECONFIG, or ~/.kube/config")
+	cobraCmd.Flags().StringVarP(&contextName, "context", "", DefaultContext,
+		"The name of the kubeconfig context for the sandbox")
+	return cobraCmd
+}
+
+func run(store authstore.Store, merge bool, path, contextName string) error {
+	if store.KubeToken == "" {
+		return errors.NewFriendlyError("No sandbox credentials found. Please run `blimp up` first.")
+	}
+
+	if !merge {
+		kubeconfig, err := clientcmd.Write(makeConfig(store, contextName))
+		if err != nil {
+			return errors.WithContext("marshal kubeconfig", err)
+		}
+		fmt.Print(string(kubeconfig))
+		return nil
+	}
+
+	if path == "" {
+		path = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+	}
+	if err := Merge(store, path, contextName); err != nil {
+		return err
+	}
+
+	// Remember where the credentials were merged so that `blimp up` can
+	// refresh them.
+	store.KubeconfigPath = path
+	store.KubeconfigContext = contextName
+	if err := store.Save(); err != nil {
+		return errors.WithContext("save auth store", err)
+	}
+
+	fmt.Printf("Added context %q to %s.\n"+
+		"Run `kubectl --context %s get pods` to access your sandbox.\n",
+		contextName, path, contextName)
+	return nil
+}
+
+// Merge adds the sandbox's credentials to the kubeconfig at `path` under the
+// given context name. Any existing entries with the same name are replaced.
+// The current context is only changed if it isn't already set.
+func Merge(store authstore.Store, path, contextName string) error {
+	kubeconfig, err := clientcmd.LoadFromFile(path)
+	switch {
+	case os.IsNotExist(err):
+		kubeconfig = clientcmdapi.NewConfig()
+	case err != nil:
+		return errors.WithContext("load kubeconfig", err)
+	}
+
+	sandboxConfig := makeConfig(store, contextName)
+	kubeconfig.Clusters[contextName] = sandboxConfig.Clusters[contextName]
+	kubeconfig.AuthInfos[contextName] = sandboxConfig.AuthInfos[contextName]
+	kubeconfig.Contexts[contextName] = sandboxConfig.Contexts[contextName]
+	if kubeconfig.CurrentContext == "" {
+		kubeconfig.CurrentContext = contextName
+	}
+
+	if err := clientcmd.WriteToFile(*kubeconfig, path); err != nil {
+		return errors.WithContext("write kubeconfig", err)
+	}
+	return nil
+}
+
+// Refresh updates the credentials in the kubeconfig that was previously
+// merged by `blimp kubeconfig --merge`, if there is one.
+func Refresh(store authstore.Store) error {
+	if store.KubeconfigPath == "" {
+		return nil
+	}
+	return Merge(store, store.KubeconfigPath, store.KubeconfigContext)
+}
+
+// makeConfig returns a kubeconfig whose only context accesses the sandbox's
+// namespace.
+func makeConfig(store authstore.Store, contextName string) clientcmdapi.Config {
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[contextName] = &clientcmdapi.Cluster{
+		Server:                   store.KubeHost,
+		CertificateAuthorityData: []byte(store.KubeCACrt),
+	}
+	kubeconfig.AuthInfos[contextName] = &clientcmdapi.AuthInfo{
+		Token: store.KubeToken,
+	}
+	kubeconfig.Contexts[contextName] = &clientcmdapi.Context{
+		Cluster:   contextName,
+		AuthInfo:  contextName,
+		Namespace: store.KubeNamespace,
+	}
+	kubeconfig.CurrentContext = contextName
+	return *kubeconfig
+}
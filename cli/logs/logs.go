@@ -24,7 +24,7 @@ import (
 	"github.com/kelda/blimp/cli/config"
 	"github.com/kelda/blimp/cli/manager"
 	"github.com/kelda/blimp/pkg/errors"
-	"github.com/kelda/blimp/pkg/names"
+	"github.com/kelda/blimp/pkg/proto/cluster"
 )
 
 type Command struct {
@@ -33,6 +33,9 @@ type Command struct {
 	Config   config.Config
 
 	svcStatus map[string]*statusNotifier
+
+	// podNames maps the services in Services to the pods that run them.
+	podNames map[string]string
 }
 
 type rawLogLine struct {
@@ -108,13 +111,24 @@ func (cmd Command) Run(ctx context.Context) error {
 		return errors.WithContext("connect to cluster", err)
 	}
 
+	// Show the logs for each replica of services that have multiple replicas.
+	statusResp, err := manager.C.GetStatus(ctx, &cluster.GetStatusRequest{
+		Auth: cmd.Config.BlimpAuth(),
+	})
+	if err != nil {
+		return errors.WithContext("get status", err)
+	}
+	cmd.Services = manager.ExpandReplicas(statusResp.GetStatus(), cmd.Services)
+
+	cmd.podNames = map[string]string{}
 	for _, container := range cmd.Services {
 		// For logs to work, the container needs to have started, but it doesn't
 		// necessarily need to be running.
-		err = manager.CheckServiceStarted(container, cmd.Config.BlimpAuth())
+		podName, err := manager.CheckServiceStarted(container, cmd.Config.BlimpAuth())
 		if err != nil {
 			return err
 		}
+		cmd.podNames[container] = podName
 	}
 
 	// Exit gracefully when the user Ctrl-C's.
@@ -260,7 +274,7 @@ func (cmd *Command) forwardLogs(ctx context.Context, combinedLogs chan<- rawLogL
 
 		logsReq := kubeClient.CoreV1().
 			Pods(cmd.Config.Auth.KubeNamespace).
-			GetLogs(names.ToDNS1123(service), &opts)
+			GetLogs(cmd.podNames[service], &opts)
 
 		logsStream, err := logsReq.Stream()
 		if err != nil {
@@ -88,6 +88,7 @@ func (p *statusNotifier) UpdatePhase(phase cluster.ServicePhase) {
 
 func phaseExited(phase cluster.ServicePhase) bool {
 	return phase == cluster.ServicePhase_EXITED ||
+		phase == cluster.ServicePhase_STOPPED ||
 		phase == cluster.ServicePhase_UNKNOWN
 }
 
@@ -108,7 +109,7 @@ func (cmd *Command) startStatusUpdater(ctx context.Context) error {
 	}
 	for _, svc := range cmd.Services {
 		cmd.svcStatus[svc] = &statusNotifier{}
-		status, ok := initStatus.Status.Services[svc]
+		status, _, ok := manager.LookupService(initStatus.Status, svc)
 		if ok {
 			cmd.svcStatus[svc].UpdatePhase(status.Phase)
 		} else {
@@ -170,7 +171,7 @@ func watchStatus(ctx context.Context, statuses map[string]*statusNotifier, auth
 		}
 
 		for svc := range statuses {
-			status, ok := msg.Status.Services[svc]
+			status, _, ok := manager.LookupService(msg.Status, svc)
 			if ok {
 				statuses[svc].UpdatePhase(status.Phase)
 			} else {
@@ -11,14 +11,24 @@ import (
 	"github.com/kelda/blimp/cli/build"
 	"github.com/kelda/blimp/cli/cp"
 	"github.com/kelda/blimp/cli/down"
+	"github.com/kelda/blimp/cli/events"
 	"github.com/kelda/blimp/cli/exec"
 	"github.com/kelda/blimp/cli/expose"
+	"github.com/kelda/blimp/cli/forward"
+	"github.com/kelda/blimp/cli/inspecthealth"
+	"github.com/kelda/blimp/cli/kubeconfig"
 	"github.com/kelda/blimp/cli/logs"
 	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/port"
 	"github.com/kelda/blimp/cli/ps"
 	"github.com/kelda/blimp/cli/restart"
+	"github.com/kelda/blimp/cli/run"
 	"github.com/kelda/blimp/cli/ssh"
+	"github.com/kelda/blimp/cli/start"
+	"github.com/kelda/blimp/cli/stats"
+	"github.com/kelda/blimp/cli/stop"
 	"github.com/kelda/blimp/cli/up"
+	"github.com/kelda/blimp/cli/volume"
 	"github.com/kelda/blimp/pkg/cfgdir"
 	"github.com/kelda/blimp/pkg/errors"
 
@@ -52,13 +62,24 @@ func main() {
 		build.New(),
 		cp.New(),
 		down.New(),
+		events.New(),
 		exec.New(),
 		expose.New(),
+		forward.New(),
+		inspecthealth.New(),
+		kubeconfig.New(),
 		logs.New(),
+		port.New(),
 		ps.New(),
 		restart.New(),
+		run.New(),
 		ssh.New(),
+		start.New(),
+		stats.New(),
+		stop.New(),
 		up.New(),
+		up.NewAttach(),
+		volume.New(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
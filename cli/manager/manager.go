@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 
 	"google.golang.org/grpc"
 
 	"github.com/kelda/blimp/cli/util"
 	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/names"
 	"github.com/kelda/blimp/pkg/proto/auth"
 	"github.com/kelda/blimp/pkg/proto/cluster"
 	"github.com/kelda/blimp/pkg/version"
@@ -70,34 +73,83 @@ func dial(host, cert, hostname string) (Client, error) {
 	return client, nil
 }
 
+// CheckServiceStatus checks that the service's status satisfies the predicate,
+// and returns the name of the service's pod. The service may also refer to a
+// single replica, such as `web.2`. Services with multiple replicas are checked
+// using their first replica, just like `docker-compose exec`.
 func CheckServiceStatus(svc string, auth *auth.BlimpAuth,
-	predicate func(*cluster.ServiceStatus) bool) error {
+	predicate func(*cluster.ServiceStatus) bool) (podName string, err error) {
 	statusResp, err := C.GetStatus(context.Background(), &cluster.GetStatusRequest{
 		Auth: auth,
 	})
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	status := statusResp.GetStatus()
 	if status.GetPhase() != cluster.SandboxStatus_RUNNING {
-		return errors.NewFriendlyError(
+		return "", errors.NewFriendlyError(
 			"Your sandbox is not booted. Please run `blimp up` first.")
 	}
 
-	for svcName, svcStatus := range status.GetServices() {
-		if svcName == svc && predicate(svcStatus) {
-			// We are booted!
-			return nil
-		}
+	svcStatus, podName, ok := LookupService(status, svc)
+	if ok && predicate(svcStatus) {
+		// We are booted!
+		return podName, nil
 	}
 
 	// Either the service hasn't been created, or it isn't in the RUNNING phase.
-	return errors.NewFriendlyError(
+	return "", errors.NewFriendlyError(
 		"This service isn't booted. You can check its status with `blimp ps`.")
 }
 
-func CheckServiceRunning(svc string, auth *auth.BlimpAuth) error {
+// LookupService returns the status and pod name of the given service or
+// replica. Services with multiple replicas resolve to their first replica.
+func LookupService(status *cluster.SandboxStatus, name string) (
+	svcStatus *cluster.ServiceStatus, podName string, ok bool) {
+	if svcStatus, ok := status.GetServices()[name]; ok {
+		if replica, ok := svcStatus.GetReplicas()[1]; ok {
+			return replica, kube.ReplicaPodName(name, 1), true
+		}
+		return svcStatus, names.ToDNS1123(name), true
+	}
+
+	svc, replica, ok := kube.ParseReplicaName(name)
+	if !ok {
+		return nil, "", false
+	}
+
+	svcStatus, ok = status.GetServices()[svc].GetReplicas()[int32(replica)]
+	if !ok {
+		return nil, "", false
+	}
+	return svcStatus, kube.ReplicaPodName(svc, replica), true
+}
+
+// ExpandReplicas replaces the services with multiple replicas in the given
+// list with the names of each of their replicas, such as `web.1` and `web.2`.
+func ExpandReplicas(status *cluster.SandboxStatus, services []string) []string {
+	var expanded []string
+	for _, svc := range services {
+		replicas := status.GetServices()[svc].GetReplicas()
+		if len(replicas) == 0 {
+			expanded = append(expanded, svc)
+			continue
+		}
+
+		var ordinals []int
+		for i := range replicas {
+			ordinals = append(ordinals, int(i))
+		}
+		sort.Ints(ordinals)
+		for _, i := range ordinals {
+			expanded = append(expanded, kube.ReplicaName(svc, i))
+		}
+	}
+	return expanded
+}
+
+func CheckServiceRunning(svc string, auth *auth.BlimpAuth) (podName string, err error) {
 	return CheckServiceStatus(svc, auth, func(svcStatus *cluster.ServiceStatus) bool {
 		// If a service is unhealthy, we probably still want to be able to
 		// interact with it, to figure out why it's unhealthy.
@@ -107,7 +159,7 @@ func CheckServiceRunning(svc string, auth *auth.BlimpAuth) error {
 }
 
 // CheckServiceStarted checks that the service has started at some point. It may or may not be actively running.
-func CheckServiceStarted(svc string, auth *auth.BlimpAuth) error {
+func CheckServiceStarted(svc string, auth *auth.BlimpAuth) (podName string, err error) {
 	return CheckServiceStatus(svc, auth, func(svcStatus *cluster.ServiceStatus) bool {
 		return svcStatus.GetHasStarted()
 	})
@@ -0,0 +1,84 @@
+package port
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+func New() *cobra.Command {
+	return &cobra.Command{
+		Use:   "port [SERVICE PRIVATE_PORT]",
+		Short: "Print the local address that is forwarded to a service's port",
+		Long: "Print the local address that is forwarded to a service's port.\n\n" +
+			"Ports are only forwarded while `blimp up` is running. If no arguments are " +
+			"given, all the forwarded ports are printed.",
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 0 && len(args) != 2 {
+				return errors.New("expected either no arguments, or SERVICE and PRIVATE_PORT")
+			}
+			return nil
+		},
+		Run: func(_ *cobra.Command, args []string) {
+			if err := run(args); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+}
+
+func run(args []string) error {
+	if !util.UpRunning() {
+		return errors.NewFriendlyError("Ports are only forwarded while `blimp up` is running.")
+	}
+
+	bindings, err := util.ReadPortBindings()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+		defer w.Flush()
+		fmt.Fprintln(w, "SERVICE\tPRIVATE PORT\tLOCAL ADDRESS")
+		for _, binding := range bindings {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", binding.Service, binding.PrivatePort, FormatAddress(binding))
+		}
+		return nil
+	}
+
+	svc := args[0]
+	privatePort, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil {
+		return errors.NewFriendlyError("Invalid port %q. The port must be a number.", args[1])
+	}
+
+	found := false
+	for _, binding := range bindings {
+		if binding.Service == svc && binding.PrivatePort == uint32(privatePort) {
+			fmt.Println(FormatAddress(binding))
+			found = true
+		}
+	}
+
+	if !found {
+		return errors.NewFriendlyError("Port %d of service %s isn't forwarded.\n"+
+			"Use `blimp forward %s %d` to forward it.", privatePort, svc, svc, privatePort)
+	}
+	return nil
+}
+
+// FormatAddress returns the local address that the binding listens on.
+func FormatAddress(binding util.PortBinding) string {
+	hostIP := binding.HostIP
+	if hostIP == "" {
+		hostIP = "0.0.0.0"
+	}
+	return fmt.Sprintf("%s:%d", hostIP, binding.HostPort)
+}
@@ -0,0 +1,104 @@
+package ps
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/auth"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+type sandboxJSON struct {
+	Status   string        `json:"status"`
+	Services []serviceJSON `json:"services"`
+}
+
+type serviceJSON struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+
+	RestartCount   int32  `json:"restartCount"`
+	LastExitReason string `json:"lastExitReason,omitempty"`
+
+	// Stats is omitted if the service isn't running.
+	Stats *statsJSON `json:"stats,omitempty"`
+
+	// Replicas is only set for services with multiple replicas. The
+	// service's own status summarizes the status of its replicas.
+	Replicas []serviceJSON `json:"replicas,omitempty"`
+}
+
+type statsJSON struct {
+	CPUNanoCores          uint64 `json:"cpuNanoCores"`
+	MemoryWorkingSetBytes uint64 `json:"memoryWorkingSetBytes"`
+	MemoryLimitBytes      uint64 `json:"memoryLimitBytes"`
+	NetworkRxBytes        uint64 `json:"networkRxBytes"`
+	NetworkTxBytes        uint64 `json:"networkTxBytes"`
+}
+
+func printJSON(auth *auth.BlimpAuth, status cluster.SandboxStatus) error {
+	// Stats are best effort, since they depend on the cluster's metrics
+	// being available.
+	var stats map[string]*cluster.ServiceStats
+	statsResp, err := manager.C.GetStats(context.Background(), &cluster.GetStatsRequest{Auth: auth})
+	if err == nil {
+		stats = statsResp.GetServices()
+	} else if status.Phase == cluster.SandboxStatus_RUNNING {
+		log.WithError(err).Warn("Failed to get resource usage")
+	}
+
+	sandboxStr, _ := GetSandboxStatusString(status.Phase)
+	out := sandboxJSON{Status: sandboxStr, Services: []serviceJSON{}}
+	for name, svcStatus := range status.Services {
+		svc := toServiceJSON(name, svcStatus)
+		for _, replicaName := range manager.ExpandReplicas(&status, []string{name}) {
+			if replicaName == name {
+				continue
+			}
+			replicaStatus, _, _ := manager.LookupService(&status, replicaName)
+			svc.Replicas = append(svc.Replicas, toServiceJSON(replicaName, replicaStatus))
+		}
+
+		if svcStats, ok := stats[name]; ok {
+			svc.Stats = &statsJSON{
+				CPUNanoCores:          svcStats.GetCpuNanoCores(),
+				MemoryWorkingSetBytes: svcStats.GetMemoryWorkingSetBytes(),
+				MemoryLimitBytes:      svcStats.GetMemoryLimitBytes(),
+				NetworkRxBytes:        svcStats.GetNetworkRxBytes(),
+				NetworkTxBytes:        svcStats.GetNetworkTxBytes(),
+			}
+		}
+		out.Services = append(out.Services, svc)
+	}
+	sort.Slice(out.Services, func(i, j int) bool {
+		return out.Services[i].Name < out.Services[j].Name
+	})
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return errors.WithContext("write json", err)
+	}
+	return nil
+}
+
+func toServiceJSON(name string, svcStatus *cluster.ServiceStatus) serviceJSON {
+	// The message is reported separately so that the status can be
+	// matched on.
+	statusStr, _, _ := GetStatusString(&cluster.ServiceStatus{Phase: svcStatus.GetPhase()})
+	return serviceJSON{
+		Name:    name,
+		Status:  statusStr,
+		Message: svcStatus.GetMsg(),
+
+		RestartCount:   svcStatus.GetRestartCount(),
+		LastExitReason: svcStatus.GetLastExitReason(),
+	}
+}
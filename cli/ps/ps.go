@@ -18,7 +18,8 @@ import (
 )
 
 func New() *cobra.Command {
-	return &cobra.Command{
+	var format string
+	cobraCmd := &cobra.Command{
 		Use:   "ps",
 		Short: "Print the status of services in the cloud sandbox",
 		Run: func(_ *cobra.Command, args []string) {
@@ -27,14 +28,21 @@ func New() *cobra.Command {
 				errors.HandleFatalError(err)
 			}
 
-			if err := run(blimpConfig.BlimpAuth()); err != nil {
+			if err := run(blimpConfig.BlimpAuth(), format); err != nil {
 				errors.HandleFatalError(err)
 			}
 		},
 	}
+	cobraCmd.Flags().StringVarP(&format, "format", "", "table",
+		"Output format. One of: table, json. The JSON output includes resource usage.")
+	return cobraCmd
 }
 
-func run(auth *auth.BlimpAuth) error {
+func run(auth *auth.BlimpAuth, format string) error {
+	if format != "table" && format != "json" {
+		return errors.NewFriendlyError("Unknown format %q. Must be one of: table, json.", format)
+	}
+
 	status, err := manager.C.GetStatus(context.Background(), &cluster.GetStatusRequest{
 		Auth: auth,
 	})
@@ -42,6 +50,10 @@ func run(auth *auth.BlimpAuth) error {
 		return err
 	}
 
+	if format == "json" {
+		return printJSON(auth, *status.Status)
+	}
+
 	printStatus(*status.Status)
 	return nil
 }
@@ -57,7 +69,7 @@ func printStatus(status cluster.SandboxStatus) {
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
 	defer w.Flush()
-	fmt.Fprintln(w, "SERVICE\tSTATUS")
+	fmt.Fprintln(w, "SERVICE\tSTATUS\tRESTARTS")
 
 	var serviceNames []string
 	for name := range status.Services {
@@ -67,6 +79,19 @@ func printStatus(status cluster.SandboxStatus) {
 
 	for _, name := range serviceNames {
 		statusStr, statusColor, _ := GetStatusString(status.Services[name])
-		fmt.Fprintf(w, "%s\t%s\n", name, goterm.Color(statusStr, statusColor))
+		fmt.Fprintf(w, "%s\t%s\t%d\n", name, goterm.Color(statusStr, statusColor),
+			status.Services[name].GetRestartCount())
+
+		// List each replica of services with multiple replicas below the
+		// service's summary.
+		for _, replicaName := range manager.ExpandReplicas(&status, []string{name}) {
+			if replicaName == name {
+				continue
+			}
+			replicaStatus, _, _ := manager.LookupService(&status, replicaName)
+			statusStr, statusColor, _ := GetStatusString(replicaStatus)
+			fmt.Fprintf(w, "  %s\t%s\t%d\n", replicaName, goterm.Color(statusStr, statusColor),
+				replicaStatus.GetRestartCount())
+		}
 	}
 }
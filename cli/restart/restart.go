@@ -41,7 +41,7 @@ func run(svc string) error {
 
 	// Make sure the pod has booted at some point. If it has crashed or exited,
 	// that's fine.
-	err = manager.CheckServiceStarted(svc, blimpConfig.BlimpAuth())
+	_, err = manager.CheckServiceStarted(svc, blimpConfig.BlimpAuth())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,123 @@
+package run
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/kelda/blimp/cli/config"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+	"github.com/kelda/blimp/pkg/proto/node"
+)
+
+// attach connects the local terminal to the one-off container through the
+// node controller. It returns once the container's output has been fully
+// written, along with the number of bytes of output that were written.
+func attach(ctx context.Context, blimpConfig config.Config, info *cluster.RunOneOffAttach, tty bool) (
+	written int, err error) {
+	nodeConn, err := util.Dial(info.GetNodeAddress(), info.GetNodeCert(), "")
+	if err != nil {
+		return 0, errors.WithContext("connect to node controller", err)
+	}
+	defer nodeConn.Close()
+
+	stream, err := node.NewControllerClient(nodeConn).Attach(ctx)
+	if err != nil {
+		return 0, errors.WithContext("attach", err)
+	}
+
+	// gRPC streams don't support concurrent sends, so stdin and resize
+	// events share a lock.
+	var sendLock sync.Mutex
+	send := func(msg *node.AttachMsg) error {
+		sendLock.Lock()
+		defer sendLock.Unlock()
+		return stream.Send(msg)
+	}
+
+	err = send(&node.AttachMsg{Msg: &node.AttachMsg_Header{Header: &node.AttachHeader{
+		Auth:    blimpConfig.BlimpAuth(),
+		PodName: info.GetPodName(),
+		Tty:     tty,
+	}}})
+	if err != nil {
+		return 0, errors.WithContext("send header", err)
+	}
+
+	if tty {
+		// Put the terminal into raw mode to prevent it echoing characters
+		// twice.
+		oldState, err := terminal.MakeRaw(int(os.Stdin.Fd()))
+		if err != nil {
+			return 0, errors.WithContext("set terminal mode", err)
+		}
+		defer func() {
+			_ = terminal.Restore(int(os.Stdin.Fd()), oldState)
+		}()
+
+		sendSize := func() {
+			width, height, err := terminal.GetSize(int(os.Stdout.Fd()))
+			if err != nil {
+				return
+			}
+
+			//nolint:errcheck // Errors are handled by the receive loop.
+			send(&node.AttachMsg{Msg: &node.AttachMsg_Resize{Resize: &node.TerminalSize{
+				Width:  uint32(width),
+				Height: uint32(height),
+			}}})
+		}
+		sendSize()
+
+		stopWatching := watchResize(sendSize)
+		defer stopWatching()
+	}
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				msg := &node.AttachMsg{Msg: &node.AttachMsg_Stdin{Stdin: append([]byte{}, buf[:n]...)}}
+				if err := send(msg); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				//nolint:errcheck // Errors are handled by the receive loop.
+				send(&node.AttachMsg{Msg: &node.AttachMsg_StdinEof{StdinEof: &node.EOF{}}})
+				return
+			}
+		}
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return written, nil
+		}
+		if err != nil {
+			return written, err
+		}
+
+		switch {
+		case msg.GetStdout() != nil:
+			n, err := os.Stdout.Write(msg.GetStdout())
+			written += n
+			if err != nil {
+				return written, errors.WithContext("write stdout", err)
+			}
+		case msg.GetStderr() != nil:
+			n, err := os.Stderr.Write(msg.GetStderr())
+			written += n
+			if err != nil {
+				return written, errors.WithContext("write stderr", err)
+			}
+		}
+	}
+}
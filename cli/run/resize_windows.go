@@ -0,0 +1,7 @@
+package run
+
+// watchResize is a no-op on Windows since there's no signal for terminal
+// resizes.
+func watchResize(onResize func()) func() {
+	return func() {}
+}
@@ -0,0 +1,135 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/kelda/blimp/cli/config"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/ps"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// attachTimeout is how long to wait for the remaining output after the
+// command exits.
+const attachTimeout = 5 * time.Second
+
+func New() *cobra.Command {
+	var disableTTY, keep bool
+	cobraCmd := &cobra.Command{
+		Use:   "run [options] SERVICE [COMMAND] [ARGS...]",
+		Short: "Run a one-off command in a new container for a service",
+		Long: "Run a one-off command in a new container for a service.\n\n" +
+			"The container uses the image, environment, and volumes that the service " +
+			"was deployed with by `blimp up`, but doesn't affect the service's running container. " +
+			"If COMMAND is specified, it overrides the service's command. For example:\n" +
+			"blimp run web rake db:migrate",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			code, err := run(args[0], args[1:], !disableTTY, keep)
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+			if code != 0 {
+				os.Exit(code)
+			}
+		},
+		// Don't append [flags] to the end of the usage string. We already have
+		// it hardcoded since the options must come before the positional
+		// arguments.
+		DisableFlagsInUseLine: true,
+	}
+	cobraCmd.Flags().BoolVarP(&disableTTY, "disable-tty", "T", false,
+		"Disable pseudo-tty allocation. By default 'blimp run' allocates a TTY.")
+	cobraCmd.Flags().BoolVarP(&keep, "keep", "", false,
+		"Don't remove the container after the command exits")
+	cobraCmd.Flags().SetInterspersed(false)
+	return cobraCmd
+}
+
+func run(svc string, command []string, enableTTY, keep bool) (int, error) {
+	blimpConfig, err := config.GetConfig()
+	if err != nil {
+		return 0, err
+	}
+
+	tty := enableTTY && terminal.IsTerminal(int(os.Stdin.Fd()))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := manager.C.RunOneOff(ctx, &cluster.RunOneOffRequest{
+		Auth:    blimpConfig.BlimpAuth(),
+		Service: svc,
+		Command: command,
+		Tty:     tty,
+		Keep:    keep,
+	})
+	if err != nil {
+		return 0, errors.WithContext("start one-off container", err)
+	}
+
+	var attachDone chan attachResult
+	for {
+		msg, err := stream.Recv()
+		if err == nil {
+			err = errors.Unmarshal(nil, msg.GetError())
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case msg.GetStatus() != nil:
+			status, _, _ := ps.GetStatusString(msg.GetStatus())
+			fmt.Fprintln(os.Stderr, status)
+		case msg.GetAttach() != nil:
+			attachDone = make(chan attachResult, 1)
+			info := msg.GetAttach()
+			go func() {
+				written, err := attach(ctx, blimpConfig, info, tty)
+				attachDone <- attachResult{written, err}
+			}()
+		case msg.GetExit() != nil:
+			// Wait for any remaining output to be written.
+			var res attachResult
+			if attachDone != nil {
+				select {
+				case res = <-attachDone:
+				case <-time.After(attachTimeout):
+				}
+			}
+
+			// The command may have exited before the attach connected, in
+			// which case the attach never started or failed. Print the output
+			// that the attach didn't receive.
+			if attachDone == nil || res.err != nil {
+				output := msg.GetExit().GetOutput()
+				if len(output) > res.written {
+					if _, err := os.Stdout.Write(output[res.written:]); err != nil {
+						return 0, errors.WithContext("write output", err)
+					}
+				} else if res.err != nil {
+					log.WithError(res.err).Warn("Lost connection to the container. Some output may be missing.")
+				}
+			}
+
+			if keep {
+				fmt.Fprintf(os.Stderr, "Kept container %s\n", msg.GetExit().GetPodName())
+			}
+			return int(msg.GetExit().GetCode()), nil
+		}
+	}
+}
+
+type attachResult struct {
+	// written is the number of bytes of output written by the attach.
+	written int
+	err     error
+}
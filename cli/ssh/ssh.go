@@ -13,7 +13,6 @@ import (
 	"github.com/kelda/blimp/cli/config"
 	"github.com/kelda/blimp/cli/manager"
 	"github.com/kelda/blimp/pkg/errors"
-	"github.com/kelda/blimp/pkg/names"
 )
 
 func New() *cobra.Command {
@@ -40,7 +39,7 @@ func run(svc string) error {
 	}
 
 	// Make sure the pod is actually booted.
-	err = manager.CheckServiceRunning(svc, blimpConfig.BlimpAuth())
+	podName, err := manager.CheckServiceRunning(svc, blimpConfig.BlimpAuth())
 	if err != nil {
 		return err
 	}
@@ -77,7 +76,7 @@ func run(svc string) error {
 	req := kubeClient.CoreV1().RESTClient().Post().
 		Resource("pods").
 		SubResource("exec").
-		Name(names.ToDNS1123(svc)).
+		Name(podName).
 		Namespace(blimpConfig.Auth.KubeNamespace).
 		VersionedParams(&execOpts, scheme.ParameterCodec)
 	exec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
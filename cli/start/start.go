@@ -0,0 +1,45 @@
+package start
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/config"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	return &cobra.Command{
+		Use:   "start SERVICE...",
+		Short: "Start services that were stopped by `blimp stop`",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(_ *cobra.Command, services []string) {
+			if err := run(services); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+}
+
+func run(services []string) error {
+	blimpConfig, err := config.GetConfig()
+	if err != nil {
+		return errors.WithContext("parse auth config", err)
+	}
+
+	for _, svc := range services {
+		_, err := manager.C.Start(context.Background(), &cluster.StartRequest{
+			Auth:    blimpConfig.BlimpAuth(),
+			Service: svc,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Started %s\n", svc)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/buger/goterm"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/config"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/auth"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	var noStream bool
+	cobraCmd := &cobra.Command{
+		Use:     "stats [options] [SERVICE...]",
+		Aliases: []string{"top"},
+		Short:   "Display a live stream of the services' resource usage",
+		Long: "Display a live stream of the services' resource usage.\n\n" +
+			"CPU and memory usage are sampled by Kubernetes every few seconds. " +
+			"Network usage is the total since the service started.",
+		Run: func(_ *cobra.Command, services []string) {
+			blimpConfig, err := config.GetConfig()
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			if err := run(blimpConfig.BlimpAuth(), services, noStream); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+		// Don't append [flags] to the end of the usage string. We already have
+		// it hardcoded since the options must come before the positional
+		// arguments.
+		DisableFlagsInUseLine: true,
+	}
+	cobraCmd.Flags().BoolVarP(&noStream, "no-stream", "", false,
+		"Print the current usage once instead of streaming updates")
+	return cobraCmd
+}
+
+func run(auth *auth.BlimpAuth, services []string, noStream bool) error {
+	if noStream {
+		resp, err := manager.C.GetStats(context.Background(), &cluster.GetStatsRequest{Auth: auth})
+		if err != nil {
+			return err
+		}
+		printStats(os.Stdout, resp.GetServices(), services)
+		return nil
+	}
+
+	stream, err := manager.C.WatchStats(context.Background(), &cluster.GetStatsRequest{Auth: auth})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		// Render the table before clearing the screen so that it doesn't
+		// flicker.
+		var buf bytes.Buffer
+		printStats(&buf, resp.GetServices(), services)
+
+		goterm.Clear()
+		goterm.MoveCursor(1, 1)
+		goterm.Print(buf.String())
+		goterm.Flush()
+	}
+}
+
+// printStats writes a table of the usage for each service in `services`. If
+// `services` is empty, all services are included.
+func printStats(out io.Writer, stats map[string]*cluster.ServiceStats, services []string) {
+	if len(services) == 0 {
+		for svc := range stats {
+			services = append(services, svc)
+		}
+	}
+	sort.Strings(services)
+
+	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "SERVICE\tCPU %\tMEM USAGE / LIMIT\tMEM %\tNET I/O")
+	for _, svc := range services {
+		svcStats, ok := stats[svc]
+		if !ok {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\n", svc)
+			continue
+		}
+
+		// One core is 100%, just like `docker stats`.
+		cpuPercent := float64(svcStats.GetCpuNanoCores()) / 1e7
+
+		memUsage := units.BytesSize(float64(svcStats.GetMemoryWorkingSetBytes()))
+		memLimit := "-"
+		memPercent := "-"
+		if limit := svcStats.GetMemoryLimitBytes(); limit != 0 {
+			memLimit = units.BytesSize(float64(limit))
+			memPercent = fmt.Sprintf("%.2f%%",
+				100*float64(svcStats.GetMemoryWorkingSetBytes())/float64(limit))
+		}
+
+		fmt.Fprintf(w, "%s\t%.2f%%\t%s / %s\t%s\t%s / %s\n",
+			svc, cpuPercent, memUsage, memLimit, memPercent,
+			units.HumanSize(float64(svcStats.GetNetworkRxBytes())),
+			units.HumanSize(float64(svcStats.GetNetworkTxBytes())))
+	}
+}
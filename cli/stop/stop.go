@@ -0,0 +1,48 @@
+package stop
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/config"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func New() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop SERVICE...",
+		Short: "Stop services without removing them",
+		Long: "Stop services without removing them.\n\n" +
+			"Stopped services can be booted again with `blimp start`. " +
+			"Services that depend on a stopped service wait for it to be started.",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(_ *cobra.Command, services []string) {
+			if err := run(services); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+}
+
+func run(services []string) error {
+	blimpConfig, err := config.GetConfig()
+	if err != nil {
+		return errors.WithContext("parse auth config", err)
+	}
+
+	for _, svc := range services {
+		_, err := manager.C.Stop(context.Background(), &cluster.StopRequest{
+			Auth:    blimpConfig.BlimpAuth(),
+			Service: svc,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Stopped %s\n", svc)
+	}
+	return nil
+}
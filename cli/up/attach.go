@@ -0,0 +1,103 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cliConfig "github.com/kelda/blimp/cli/config"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/dockercompose"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// NewAttach creates the `blimp attach` command. It reconnects to a sandbox
+// that's already running, such as one booted by `blimp up -d`, without
+// rebuilding or redeploying anything.
+func NewAttach() *cobra.Command {
+	var cmd up
+	cobraCmd := &cobra.Command{
+		Use:   "attach",
+		Short: "Reconnect to a running sandbox",
+		Long: "Reconnect to a running sandbox\n\n" +
+			"Attach restarts file syncing and port forwarding, and streams logs for the " +
+			"services that were last deployed by `blimp up`. The deployed services aren't restarted. " +
+			"Exiting doesn't remove the sandbox.",
+		Args: cobra.NoArgs,
+		Run: func(_ *cobra.Command, _ []string) {
+			blimpConfig, err := cliConfig.GetConfig()
+			if err != nil {
+				errors.HandleFatalError(err)
+			}
+
+			cmd.config = blimpConfig
+			if err := cmd.attach(); err != nil {
+				errors.HandleFatalError(err)
+			}
+		},
+	}
+	cobraCmd.Flags().BoolVarP(&cmd.portFallback, "port-fallback", "", false,
+		"Forward from a free local port if a published port is already in use. "+
+			"Use `blimp port` to look up the port that was used.")
+	cobraCmd.Flags().StringArrayVarP(&cmd.hostPorts, "host-port", "", nil,
+		"Forward connections to `host.docker.internal` on the given port to the local machine. "+
+			"The format is PORT[:[LOCAL_HOST:]LOCAL_PORT]. Can be repeated.")
+	return cobraCmd
+}
+
+func (cmd *up) attach() error {
+	if util.UpRunning() {
+		return errors.NewFriendlyError("It looks like `blimp up` or `blimp attach` is already running.\n" +
+			"Exit it before attaching again.")
+	}
+
+	if err := util.TakeUpLock(); err != nil {
+		return err
+	}
+	defer util.ReleaseUpLock()
+
+	reverseForwards, err := parseHostPorts(cmd.hostPorts)
+	if err != nil {
+		return err
+	}
+
+	pp := util.NewProgressPrinter(os.Stdout, "Connecting to cloud sandbox")
+	go pp.Run()
+	resp, err := manager.C.AttachToSandbox(context.Background(), &cluster.AttachToSandboxRequest{
+		Auth: cmd.config.BlimpAuth(),
+	})
+	pp.Stop()
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(resp.GetComposeFile()) == "" {
+		return errors.NewFriendlyError("Nothing has been deployed to your sandbox.\n" +
+			"Use `blimp up` to deploy your Docker Compose file.")
+	}
+
+	// Use the Compose file that was deployed, rather than the local Compose
+	// file, since the local one may have changed since it was deployed.
+	parsedCompose, err := dockercompose.Unmarshal([]byte(resp.GetComposeFile()))
+	if err != nil {
+		return errors.WithContext("parse deployed compose file", err)
+	}
+
+	if err := cmd.connect(resp.GetNodeAddress(), resp.GetNodeCert(), resp.GetKubeCredentials()); err != nil {
+		return err
+	}
+	defer cmd.nodeControllerConn.Close()
+
+	// The sandbox should keep running after the user exits, since it was
+	// booted by an earlier `blimp up`.
+	cmd.detach = true
+
+	fmt.Println("Attached to sandbox. Press Ctrl-C to disconnect.")
+	return cmd.runLocal(nil, cmd.makeSyncthingClient(parsedCompose), reverseForwards,
+		deployment{project: parsedCompose})
+}
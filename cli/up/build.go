@@ -30,12 +30,7 @@ func (cmd *up) buildImages(composeFile composeTypes.Project) (map[string]string,
 
 	builtImages := cmd.getRemoteCachedImages(buildServices)
 
-	builder, err := cmd.getImageBuilder(composeFile.Name)
-	if err != nil {
-		return nil, errors.WithContext("get image builder", err)
-	}
-
-	buildOpts := map[string]build.BuildPushConfig{}
+	var toBuild composeTypes.Services
 	for _, svc := range buildServices {
 		if !cmd.alwaysBuild {
 			if _, ok := builtImages[svc.Name]; ok {
@@ -43,23 +38,17 @@ func (cmd *up) buildImages(composeFile composeTypes.Project) (map[string]string,
 				continue
 			}
 		}
-
-		imageName := build.RemoteImageName(cmd.composePath, svc.Name, cmd.imageNamespace)
-		buildOpts[svc.Name] = build.BuildPushConfig{
-			BuildConfig: *svc.Build,
-			ImageName:   imageName,
-			ForceBuild:  cmd.alwaysBuild,
-		}
+		toBuild = append(toBuild, svc)
 	}
 
-	if len(buildOpts) == 0 {
+	if len(toBuild) == 0 {
 		// All images are already present in the remote.
 		return builtImages, nil
 	}
 
-	newBuiltImages, err := builder.BuildAndPush(buildOpts)
+	newBuiltImages, err := cmd.buildServices(composeFile.Name, toBuild, cmd.alwaysBuild)
 	if err != nil {
-		return nil, errors.WithContext("build images", err)
+		return nil, err
 	}
 	for s, i := range newBuiltImages {
 		builtImages[s] = i
@@ -68,6 +57,32 @@ func (cmd *up) buildImages(composeFile composeTypes.Project) (map[string]string,
 	return builtImages, nil
 }
 
+// buildServices builds and pushes the images for the given services, without
+// checking whether they've already been pushed.
+func (cmd *up) buildServices(projectName string, services composeTypes.Services, forceBuild bool) (
+	map[string]string, error) {
+	builder, err := cmd.getImageBuilder(projectName)
+	if err != nil {
+		return nil, errors.WithContext("get image builder", err)
+	}
+
+	buildOpts := map[string]build.BuildPushConfig{}
+	for _, svc := range services {
+		imageName := build.RemoteImageName(cmd.composePath, svc.Name, cmd.imageNamespace)
+		buildOpts[svc.Name] = build.BuildPushConfig{
+			BuildConfig: *svc.Build,
+			ImageName:   imageName,
+			ForceBuild:  forceBuild,
+		}
+	}
+
+	builtImages, err := builder.BuildAndPush(buildOpts)
+	if err != nil {
+		return nil, errors.WithContext("build images", err)
+	}
+	return builtImages, nil
+}
+
 func (cmd *up) getImageBuilder(projectName string) (build.Interface, error) {
 	if !cmd.forceBuildkit {
 		dockerClient, err := docker.New(cmd.regCreds, cmd.dockerConfig, cmd.config.BlimpAuth(), docker.CacheOptions{
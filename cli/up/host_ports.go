@@ -0,0 +1,63 @@
+package up
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/tunnel"
+)
+
+// parseHostPorts parses the `--host-port` flags. Each flag is either PORT,
+// PORT:LOCAL_PORT, or PORT:LOCAL_HOST:LOCAL_PORT. PORT is the port that
+// containers connect to on `host.docker.internal`. If the local host isn't
+// specified, it defaults to localhost.
+func parseHostPorts(specs []string) ([]tunnel.ReverseForward, error) {
+	var forwards []tunnel.ReverseForward
+	seen := map[uint32]bool{}
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		if len(parts) > 3 {
+			return nil, errors.NewFriendlyError("Malformed --host-port %q.\n"+
+				"Expected format PORT[:[LOCAL_HOST:]LOCAL_PORT]", spec)
+		}
+
+		port, err := parsePort(parts[0])
+		if err != nil {
+			return nil, err
+		}
+
+		localHost := "127.0.0.1"
+		localPort := port
+		switch len(parts) {
+		case 2:
+			localPort, err = parsePort(parts[1])
+		case 3:
+			localHost = parts[1]
+			localPort, err = parsePort(parts[2])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[port] {
+			return nil, errors.NewFriendlyError("Port %d is forwarded by multiple --host-port flags", port)
+		}
+		seen[port] = true
+
+		forwards = append(forwards, tunnel.ReverseForward{
+			Port:      port,
+			LocalAddr: net.JoinHostPort(localHost, strconv.Itoa(int(localPort))),
+		})
+	}
+	return forwards, nil
+}
+
+func parsePort(str string) (uint32, error) {
+	port, err := strconv.ParseUint(str, 10, 16)
+	if err != nil || port == 0 {
+		return 0, errors.NewFriendlyError("Invalid port %q. The port must be a number between 1 and 65535.", str)
+	}
+	return uint32(port), nil
+}
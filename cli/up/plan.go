@@ -0,0 +1,75 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/buger/goterm"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// getDeployPlan asks the cluster manager what deploying the Compose file
+// would change, without deploying anything.
+func (cmd *up) getDeployPlan(composeFile string, builtImages map[string]string) (*cluster.DeployResponse, error) {
+	return manager.C.DeployToSandbox(context.Background(), &cluster.DeployRequest{
+		Auth:        cmd.config.BlimpAuth(),
+		ComposeFile: composeFile,
+		BuiltImages: builtImages,
+		DryRun:      true,
+		NoRecreate:  cmd.noRecreate,
+	})
+}
+
+// printDeployPlan prints what will happen to each service when the Compose
+// file is deployed. If onlyChanges is set, services that would be created or
+// left alone aren't shown, since they aren't surprising.
+func printDeployPlan(plan []*cluster.ServiceDeployPlan, onlyChanges bool) {
+	var toPrint []*cluster.ServiceDeployPlan
+	for _, svcPlan := range plan {
+		switch svcPlan.GetAction() {
+		case cluster.ServiceDeployPlan_UNCHANGED, cluster.ServiceDeployPlan_CREATE:
+			if onlyChanges {
+				continue
+			}
+		}
+		toPrint = append(toPrint, svcPlan)
+	}
+
+	if len(toPrint) == 0 {
+		if !onlyChanges {
+			fmt.Println("No services to deploy.")
+		}
+		return
+	}
+
+	fmt.Println("Deployment plan:")
+	for _, svcPlan := range toPrint {
+		actionStr, color := getActionString(svcPlan.GetAction())
+		fmt.Printf("  %s: %s\n", svcPlan.GetService(), goterm.Color(actionStr, color))
+		for _, line := range svcPlan.GetDiff() {
+			fmt.Printf("      %s\n", line)
+		}
+	}
+
+	if onlyChanges {
+		fmt.Println("Use `--no-recreate SERVICE` to keep a service's current container.")
+	}
+}
+
+func getActionString(action cluster.ServiceDeployPlan_Action) (string, int) {
+	switch action {
+	case cluster.ServiceDeployPlan_CREATE:
+		return "create", goterm.GREEN
+	case cluster.ServiceDeployPlan_RECREATE:
+		return "recreate", goterm.YELLOW
+	case cluster.ServiceDeployPlan_DELETE:
+		return "delete", goterm.RED
+	case cluster.ServiceDeployPlan_SKIP_RECREATE:
+		return "changed, but not recreated (--no-recreate)", goterm.BLUE
+	default:
+		return strings.ToLower(action.String()), goterm.WHITE
+	}
+}
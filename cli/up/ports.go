@@ -0,0 +1,245 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/tunnel"
+)
+
+// portForwardsPollInterval is how often `blimp up` checks for ad-hoc port
+// forwards added or removed by `blimp forward`.
+const portForwardsPollInterval = time.Second
+
+// portForwarder manages the local ports that are forwarded to services, and
+// records them in the port bindings file so that `blimp port` can look them
+// up.
+type portForwarder struct {
+	tunnelManager tunnel.Manager
+
+	// fallback is whether ports from the Compose file should be moved to a
+	// free port if they're already in use. Ad-hoc forwards always fall back.
+	fallback bool
+
+	// errs receives an error if a forward from the Compose file crashes.
+	errs chan error
+
+	lock     sync.Mutex
+	forwards []*forward
+
+	// failed contains the ad-hoc forwards that couldn't be started. They
+	// aren't retried until they're removed and requested again.
+	failed map[util.PortForward]bool
+}
+
+type forward struct {
+	binding util.PortBinding
+	ln      net.Listener
+	closed  bool
+
+	// request is the ad-hoc forward that created this forward. It's unset for
+	// forwards from the Compose file.
+	request util.PortForward
+}
+
+func newPortForwarder(tunnelManager tunnel.Manager, fallback bool) *portForwarder {
+	// Remove any state left behind by a previous `blimp up` that crashed.
+	util.ClearPortState()
+
+	return &portForwarder{
+		tunnelManager: tunnelManager,
+		fallback:      fallback,
+		errs:          make(chan error, 1),
+		failed:        map[util.PortForward]bool{},
+	}
+}
+
+// Publish forwards a port from the Compose file. It returns an error if the
+// local port can't be bound.
+func (pf *portForwarder) Publish(hostIP string, hostPort uint32, service string, privatePort uint32) error {
+	_, err := pf.start(util.PortBinding{
+		Service:     service,
+		PrivatePort: privatePort,
+		HostIP:      hostIP,
+		HostPort:    hostPort,
+	}, pf.fallback, util.PortForward{})
+	return err
+}
+
+func (pf *portForwarder) start(binding util.PortBinding, fallback bool, request util.PortForward) (*forward, error) {
+	ln, err := pf.tunnelManager.Listen(binding.HostIP, binding.HostPort, binding.Service, fallback)
+	if err != nil {
+		return nil, err
+	}
+
+	binding.RequestedHostPort = binding.HostPort
+	if tcpAddr, ok := ln.Addr().(*net.TCPAddr); ok && uint32(tcpAddr.Port) != binding.HostPort {
+		fmt.Printf("Port %d is already in use. Forwarding port %d of service %s from local port %d instead.\n",
+			binding.HostPort, binding.PrivatePort, binding.Service, tcpAddr.Port)
+		binding.HostPort = uint32(tcpAddr.Port)
+	}
+
+	fwd := &forward{binding: binding, ln: ln, request: request}
+	pf.lock.Lock()
+	pf.forwards = append(pf.forwards, fwd)
+	pf.saveLocked()
+	pf.lock.Unlock()
+
+	go func() {
+		err := pf.tunnelManager.Serve(ln, binding.Service, binding.PrivatePort)
+
+		pf.lock.Lock()
+		closed := fwd.closed
+		pf.lock.Unlock()
+		if closed {
+			return
+		}
+
+		if binding.AdHoc {
+			log.WithError(err).WithField("service", binding.Service).
+				Warn("Ad-hoc port forward crashed")
+			pf.stop(fwd)
+			return
+		}
+
+		select {
+		case pf.errs <- err:
+		default:
+		}
+	}()
+	return fwd, nil
+}
+
+func (pf *portForwarder) stop(fwd *forward) {
+	pf.lock.Lock()
+	defer pf.lock.Unlock()
+
+	fwd.closed = true
+	fwd.ln.Close()
+
+	var remaining []*forward
+	for _, other := range pf.forwards {
+		if other != fwd {
+			remaining = append(remaining, other)
+		}
+	}
+	pf.forwards = remaining
+	pf.saveLocked()
+}
+
+// SyncAdHocForwards starts and stops the ad-hoc forwards requested by `blimp
+// forward` until `ctx` is cancelled.
+func (pf *portForwarder) SyncAdHocForwards(ctx context.Context) {
+	for {
+		requests, err := util.ReadPortForwards()
+		if err != nil {
+			log.WithError(err).Debug("Failed to read ad-hoc port forwards")
+		} else {
+			pf.syncAdHocForwards(requests)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(portForwardsPollInterval):
+		}
+	}
+}
+
+func (pf *portForwarder) syncAdHocForwards(requests []util.PortForward) {
+	requested := map[util.PortForward]bool{}
+	for _, req := range requests {
+		requested[req] = true
+	}
+
+	pf.lock.Lock()
+	running := map[util.PortForward]bool{}
+	var toStop []*forward
+	for _, fwd := range pf.forwards {
+		if !fwd.binding.AdHoc {
+			continue
+		}
+
+		if requested[fwd.request] {
+			running[fwd.request] = true
+		} else {
+			toStop = append(toStop, fwd)
+		}
+	}
+	pf.lock.Unlock()
+
+	for _, fwd := range toStop {
+		pf.stop(fwd)
+	}
+
+	for req := range pf.failed {
+		if !requested[req] {
+			delete(pf.failed, req)
+		}
+	}
+
+	for _, req := range requests {
+		if running[req] || pf.failed[req] {
+			continue
+		}
+
+		hostPort := req.HostPort
+		if hostPort == 0 {
+			hostPort = req.PrivatePort
+		}
+
+		// Only listen locally since ad-hoc forwards are meant for debugging.
+		_, err := pf.start(util.PortBinding{
+			Service:     req.Service,
+			PrivatePort: req.PrivatePort,
+			HostIP:      "127.0.0.1",
+			HostPort:    hostPort,
+			AdHoc:       true,
+		}, true, req)
+		if err != nil {
+			log.WithError(err).WithField("service", req.Service).
+				Warn("Failed to start ad-hoc port forward")
+			pf.failed[req] = true
+		}
+		running[req] = true
+	}
+}
+
+// Close stops all the forwards and removes the port bindings file.
+func (pf *portForwarder) Close() {
+	pf.lock.Lock()
+	for _, fwd := range pf.forwards {
+		fwd.closed = true
+		fwd.ln.Close()
+	}
+	pf.forwards = nil
+	pf.lock.Unlock()
+
+	util.ClearPortState()
+}
+
+// saveLocked writes the current bindings to disk. The caller must hold the
+// lock.
+func (pf *portForwarder) saveLocked() {
+	var bindings []util.PortBinding
+	for _, fwd := range pf.forwards {
+		bindings = append(bindings, fwd.binding)
+	}
+	sort.Slice(bindings, func(i, j int) bool {
+		if bindings[i].Service != bindings[j].Service {
+			return bindings[i].Service < bindings[j].Service
+		}
+		return bindings[i].PrivatePort < bindings[j].PrivatePort
+	})
+
+	if err := util.WritePortBindings(bindings); err != nil {
+		log.WithError(err).Warn("Failed to save port bindings. `blimp port` may be out of date.")
+	}
+}
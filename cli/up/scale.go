@@ -0,0 +1,47 @@
+package up
+
+import (
+	"strconv"
+	"strings"
+
+	composeTypes "github.com/kelda/compose-go/types"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// applyScale overrides the number of replicas of the services in the project
+// according to the --scale flags. Each flag has the format SERVICE=NUM.
+func applyScale(project *composeTypes.Project, specs []string) error {
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		if len(parts) != 2 {
+			return errors.NewFriendlyError("Malformed --scale %q.\n"+
+				"Expected format SERVICE=NUM", spec)
+		}
+
+		replicas, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil || replicas == 0 {
+			return errors.NewFriendlyError("Invalid number of replicas in --scale %q. "+
+				"It must be a positive integer.", spec)
+		}
+
+		found := false
+		for i, svc := range project.Services {
+			if svc.Name != parts[0] {
+				continue
+			}
+
+			if svc.Deploy == nil {
+				svc.Deploy = &composeTypes.DeployConfig{}
+			}
+			svc.Deploy.Replicas = &replicas
+			project.Services[i] = svc
+			found = true
+		}
+
+		if !found {
+			return errors.NewFriendlyError("Unknown service %q in --scale %q", parts[0], spec)
+		}
+	}
+	return nil
+}
@@ -26,6 +26,11 @@ type statusPrinter struct {
 	disableOutput bool
 
 	currStatus map[string]*cluster.ServiceStatus
+
+	// latestEvents contains the most recent event for each service. It's
+	// used to explain why services are taking a while to boot.
+	latestEvents map[string]*cluster.Event
+	since        time.Time
 	sync.Mutex
 
 	prevLinesPrinted int
@@ -34,8 +39,17 @@ type statusPrinter struct {
 
 var spinnerChars = []string{"/", "-", "\\", "|"}
 
+// maxEventMsgLength is the maximum length of the event shown next to a
+// service's status.
+const maxEventMsgLength = 60
+
 func newStatusPrinter(services []string, disableOutput bool) *statusPrinter {
-	sp := &statusPrinter{services: services, disableOutput: disableOutput}
+	sp := &statusPrinter{
+		services:      services,
+		disableOutput: disableOutput,
+		latestEvents:  map[string]*cluster.Event{},
+		since:         time.Now(),
+	}
 	sort.Strings(sp.services)
 	return sp
 }
@@ -47,6 +61,7 @@ func (sp *statusPrinter) Run(ctx context.Context,
 	defer cancelFn()
 
 	go sp.syncStatus(syncCtx, clusterManager, auth)
+	go sp.syncEvents(syncCtx, clusterManager, auth)
 
 	for {
 		if !sp.disableOutput {
@@ -120,6 +135,43 @@ func (sp *statusPrinter) syncStatus(ctx context.Context,
 	}
 }
 
+// syncEvents tracks the most recent event for each service. Events are only
+// used to add detail to the status, so errors are ignored.
+func (sp *statusPrinter) syncEvents(ctx context.Context,
+	clusterManager manager.Client, auth *auth.BlimpAuth) {
+	for {
+		stream, err := clusterManager.WatchEvents(ctx, &cluster.WatchEventsRequest{
+			Auth:  auth,
+			Since: sp.since.Unix(),
+		})
+		if err == nil {
+			for {
+				msg, err := stream.Recv()
+				if err != nil {
+					break
+				}
+
+				event := msg.GetEvent()
+				switch event.GetType() {
+				// These events don't explain why a service isn't ready yet.
+				case cluster.Event_STARTED, cluster.Event_STOPPING:
+					continue
+				}
+
+				sp.Lock()
+				sp.latestEvents[event.GetService()] = event
+				sp.Unlock()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
 func (sp *statusPrinter) printStatus() {
 	// Reset the cursor so that we'll write over the previous status update.
 	// TODO: Doesn't properly work if the previous print spanned multiple lines.
@@ -155,5 +207,15 @@ func (sp *statusPrinter) getServiceStatus(svc string) (msg string, color int, bo
 		return "Pending", goterm.YELLOW, false
 	}
 
-	return ps.GetStatusString(svcStatus)
+	msg, color, booted = ps.GetStatusString(svcStatus)
+	if event, ok := sp.latestEvents[svc]; ok && !booted && svcStatus.Msg == "" {
+		// Truncate the message so that the status fits on one line, since
+		// printStatus can't overwrite lines that wrapped.
+		eventMsg := event.GetMessage()
+		if len(eventMsg) > maxEventMsgLength {
+			eventMsg = eventMsg[:maxEventMsgLength-3] + "..."
+		}
+		msg += fmt.Sprintf(" (%s)", eventMsg)
+	}
+	return msg, color, booted
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"syscall"
 
@@ -15,12 +16,13 @@ import (
 	composeTypes "github.com/kelda/compose-go/types"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cliConfig "github.com/kelda/blimp/cli/config"
 	"github.com/kelda/blimp/cli/down"
+	"github.com/kelda/blimp/cli/kubeconfig"
 	"github.com/kelda/blimp/cli/logs"
 	"github.com/kelda/blimp/cli/manager"
 	"github.com/kelda/blimp/cli/util"
@@ -78,12 +80,30 @@ func New() *cobra.Command {
 	}
 	cobraCmd.Flags().StringSliceVarP(&composePaths, "file", "f", nil,
 		"Specify an alternate compose file\nDefaults to docker-compose.yml and docker-compose.yaml")
+	cobraCmd.Flags().StringSliceVarP(&cmd.profiles, "profile", "", nil,
+		"Specify a profile to enable")
 	cobraCmd.Flags().BoolVarP(&cmd.alwaysBuild, "build", "", false,
 		"Build images before starting containers")
 	cobraCmd.Flags().BoolVarP(&cmd.detach, "detach", "d", false,
 		"Leave containers running after blimp up exits")
 	cobraCmd.Flags().BoolVarP(&cmd.forceBuildkit, "remote-build", "", false,
 		"Force Docker images to be built in your sandbox instead of locally")
+	cobraCmd.Flags().BoolVarP(&cmd.portFallback, "port-fallback", "", false,
+		"Forward from a free local port if a published port is already in use. "+
+			"Use `blimp port` to look up the port that was used.")
+	cobraCmd.Flags().StringArrayVarP(&cmd.hostPorts, "host-port", "", nil,
+		"Forward connections to `host.docker.internal` on the given port to the local machine. "+
+			"The format is PORT[:[LOCAL_HOST:]LOCAL_PORT]. Can be repeated.")
+	cobraCmd.Flags().BoolVarP(&cmd.watch, "watch", "", false,
+		"Redeploy when the compose files, .env file, or build contexts change")
+	cobraCmd.Flags().BoolVarP(&cmd.dryRun, "dry-run", "", false,
+		"Show which services would be created, recreated, or deleted, without deploying them. "+
+			"Images are still built so that changes to them are detected.")
+	cobraCmd.Flags().StringSliceVarP(&cmd.noRecreate, "no-recreate", "", nil,
+		"Keep the current containers for the given services, even if their configuration changed")
+	cobraCmd.Flags().StringArrayVarP(&cmd.scale, "scale", "", nil,
+		"Run NUM replicas of a service. The format is SERVICE=NUM. "+
+			"Overrides the `deploy.replicas` setting in the Compose file. Can be repeated.")
 
 	cobraCmd.Flags().BoolVarP(&cmd.disableStatusOutput, "disable-status-output", "", false,
 		"Don't print status updates. Used by preview implementation.")
@@ -98,9 +118,16 @@ type up struct {
 	config              cliConfig.Config
 	composePath         string
 	overridePaths       []string
+	profiles            []string
 	alwaysBuild         bool
 	detach              bool
 	forceBuildkit       bool
+	watch               bool
+	portFallback        bool
+	hostPorts           []string
+	dryRun              bool
+	noRecreate          []string
+	scale               []string
 	disableStatusOutput bool
 	dockerConfig        *configfile.ConfigFile
 	regCreds            auth.RegistryCredentials
@@ -131,11 +158,27 @@ func (cmd *up) run(services []string) error {
 	}
 	defer util.ReleaseUpLock()
 
-	parsedCompose, err := dockercompose.Load(cmd.composePath, cmd.overridePaths, services)
+	parsedCompose, err := dockercompose.Load(cmd.composePath, cmd.overridePaths, cmd.profiles, services)
 	if err != nil {
 		return errors.WithContext("load compose file", err)
 	}
 
+	if err := applyScale(&parsedCompose, cmd.scale); err != nil {
+		return err
+	}
+
+	reverseForwards, err := parseHostPorts(cmd.hostPorts)
+	if err != nil {
+		return err
+	}
+
+	// Snapshot the watched files before building so that any changes made
+	// while booting are picked up.
+	var initialSnapshot snapshot
+	if cmd.watch {
+		initialSnapshot = cmd.takeSnapshot(parsedCompose)
+	}
+
 	parsedComposeBytes, err := dockercompose.Marshal(parsedCompose)
 	if err != nil {
 		return err
@@ -154,7 +197,7 @@ func (cmd *up) run(services []string) error {
 
 	// Start creating the sandbox immediately so that the systems services
 	// start booting as soon as possible.
-	if err := cmd.createSandbox(string(parsedComposeBytes), idPathMap); err != nil {
+	if err := cmd.createSandbox(string(parsedComposeBytes), idPathMap, stClient.GetReceiveOnlyFolders()); err != nil {
 		log.WithError(err).Fatal("Failed to create development sandbox")
 	}
 	defer cmd.nodeControllerConn.Close()
@@ -164,19 +207,49 @@ func (cmd *up) run(services []string) error {
 		return err
 	}
 
+	// Show which services will be recreated before deploying, since
+	// recreating a service loses any state that isn't in a volume.
+	planResp, err := cmd.getDeployPlan(string(parsedComposeBytes), builtImages)
+	if err != nil {
+		return errors.WithContext("get deployment plan", err)
+	}
+	if cmd.dryRun {
+		printDeployWarnings(planResp)
+		printDeployPlan(planResp.GetPlan(), false)
+		return nil
+	}
+	printDeployPlan(planResp.GetPlan(), true)
+
 	// Send the boot request to the cluster manager.
 	pp := util.NewProgressPrinter(os.Stdout, "Deploying Docker Compose file to sandbox")
 	go pp.Run()
 
-	_, err = manager.C.DeployToSandbox(context.Background(), &cluster.DeployRequest{
+	deployResp, err := manager.C.DeployToSandbox(context.Background(), &cluster.DeployRequest{
 		Auth:        cmd.config.BlimpAuth(),
 		ComposeFile: string(parsedComposeBytes),
 		BuiltImages: builtImages,
+		NoRecreate:  cmd.noRecreate,
 	})
 	pp.Stop()
 	if err != nil {
 		return err
 	}
+	printDeployWarnings(deployResp)
+
+	return cmd.runLocal(services, stClient, reverseForwards, deployment{
+		project:     parsedCompose,
+		builtImages: builtImages,
+		snapshot:    initialSnapshot,
+	})
+}
+
+// runLocal runs the local processes that connect to the deployed sandbox:
+// Syncthing, the port forwards, and the log and status output. It runs until
+// the user exits, or something crashes.
+func (cmd *up) runLocal(services []string, stClient syncthing.Client,
+	reverseForwards []tunnel.ReverseForward, deployed deployment) error {
+	parsedCompose := deployed.project
+	idPathMap := stClient.GetIDPathMap()
 
 	syncthingError := make(chan error, 1)
 	syncthingCtx, cancelSyncthing := context.WithCancel(context.Background())
@@ -203,90 +276,118 @@ func (cmd *up) run(services []string) error {
 	}
 
 	// Start the tunnels.
-	var tunnelsErrGroup errgroup.Group
-	startedTunnels := false
+	portForwarder := newPortForwarder(cmd.tunnelManager, cmd.portFallback)
+	defer portForwarder.Close()
 	for _, svc := range parsedCompose.Services {
-		svc := svc
 		for _, mapping := range svc.Ports {
-			mapping := mapping
 			if mapping.Protocol == "tcp" {
-				startedTunnels = true
-				tunnelsErrGroup.Go(func() error {
-					return cmd.tunnelManager.Run(mapping.HostIP, mapping.Published, svc.Name, mapping.Target, nil)
-				})
+				err := portForwarder.Publish(mapping.HostIP, mapping.Published, svc.Name, mapping.Target)
+				if err != nil {
+					return err
+				}
 			}
 		}
 	}
-	tunnelsError := make(chan error, 1)
-	if startedTunnels {
-		go func() {
-			tunnelsError <- tunnelsErrGroup.Wait()
-		}()
+
+	portForwardsCtx, cancelPortForwards := context.WithCancel(context.Background())
+	defer cancelPortForwards()
+	go portForwarder.SyncAdHocForwards(portForwardsCtx)
+
+	if len(reverseForwards) != 0 {
+		go cmd.tunnelManager.RunReverse(portForwardsCtx, reverseForwards)
 	}
 
 	// Start the GUI.
-	guiCtx, cancelGui := context.WithCancel(context.Background())
 	guiError := make(chan error, 1)
-	go func() {
-		guiError <- cmd.runGUI(guiCtx, parsedCompose)
-	}()
+	startGUI := func(services []string, since *metav1.Time) context.CancelFunc {
+		guiCtx, cancelGui := context.WithCancel(context.Background())
+		go func() {
+			guiError <- cmd.runGUI(guiCtx, services, since)
+		}()
+		return cancelGui
+	}
+	cancelGui := startGUI(parsedCompose.ServiceNames(), nil)
+
+	redeployed := make(chan composeTypes.Project)
+	if cmd.watch {
+		watchCtx, cancelWatch := context.WithCancel(context.Background())
+		defer cancelWatch()
+		go cmd.watchForChanges(watchCtx, services, deployed, redeployed)
+	}
 
 	// Wait for the user to exit, or for something to error.
 	exit := make(chan os.Signal, 1)
 	signal.Notify(exit, syscall.SIGINT, syscall.SIGTERM)
 
-	select {
-	case err := <-syncthingError:
-		return errors.WithContext("syncthing error", err)
+	for {
+		select {
+		case project := <-redeployed:
+			// Restart the GUI if services were added or removed so that their
+			// logs are shown. Logs that were already printed aren't
+			// repeated.
+			if reflect.DeepEqual(project.ServiceNames(), parsedCompose.ServiceNames()) {
+				continue
+			}
 
-	case err := <-guiError:
-		if err != nil {
-			return errors.WithContext("run gui error", err)
-		}
-		log.Info("All containers have completed. Exiting.")
-		return nil
+			cancelGui()
+			<-guiError
 
-	case err := <-tunnelsError:
-		return errors.WithContext("tunnel crashed", err)
+			parsedCompose = project
+			since := metav1.Now()
+			cancelGui = startGUI(parsedCompose.ServiceNames(), &since)
+			continue
 
-	case <-exit:
-		cancelGui()
+		case err := <-syncthingError:
+			return errors.WithContext("syncthing error", err)
 
-		if cmd.detach {
-			fmt.Println("Cleaning up local processes. The remote containers will continue running.")
-			fmt.Println("Use `blimp down` to clean up your remote sandbox.")
-		}
+		case err := <-guiError:
+			if err != nil {
+				return errors.WithContext("run gui error", err)
+			}
+			log.Info("All containers have completed. Exiting.")
+			return nil
 
-		// If we spawned a child process for Syncthing, terminate it gracefully.
-		if len(idPathMap) != 0 {
-			cancelSyncthing()
-			<-syncthingError
-		}
+		case err := <-portForwarder.errs:
+			return errors.WithContext("tunnel crashed", err)
 
-		if !cmd.detach {
-			fmt.Println("Cleaning up your containers and volumes.")
-			fmt.Println("To keep your sandbox running, use `blimp up -d` instead.")
+		case <-exit:
+			cancelGui()
 
-			downFinished := make(chan error)
-			go func() {
-				downFinished <- down.Run(cmd.config.BlimpAuth(), false)
-			}()
+			if cmd.detach {
+				fmt.Println("Cleaning up local processes. The remote containers will continue running.")
+				fmt.Println("Use `blimp attach` to reconnect, or `blimp down` to clean up your remote sandbox.")
+			}
 
-			select {
-			case err := <-downFinished:
-				return err
-			case <-exit:
-				// This is the second signal, so we exit immediately without
-				// waiting for `blimp down` to finish. We exit naturally without
-				// `os.Exit` so that the lock is released.
+			// If we spawned a child process for Syncthing, terminate it gracefully.
+			if len(idPathMap) != 0 {
+				cancelSyncthing()
+				<-syncthingError
+			}
+
+			if !cmd.detach {
+				fmt.Println("Cleaning up your containers and volumes.")
+				fmt.Println("To keep your sandbox running, use `blimp up -d` instead.")
+
+				downFinished := make(chan error)
+				go func() {
+					downFinished <- down.Run(cmd.config.BlimpAuth(), false)
+				}()
+
+				select {
+				case err := <-downFinished:
+					return err
+				case <-exit:
+					// This is the second signal, so we exit immediately without
+					// waiting for `blimp down` to finish. We exit naturally without
+					// `os.Exit` so that the lock is released.
+				}
 			}
+			return nil
 		}
-		return nil
 	}
-	return nil
 }
 
-func (cmd *up) createSandbox(composeCfg string, idPathMap map[string]string) error {
+func (cmd *up) createSandbox(composeCfg string, idPathMap map[string]string, receiveOnlyFolders []string) error {
 	pp := util.NewProgressPrinter(os.Stdout, "Booting cloud sandbox")
 	go pp.Run()
 	defer pp.Stop()
@@ -297,6 +398,7 @@ func (cmd *up) createSandbox(composeCfg string, idPathMap map[string]string) err
 			ComposeFile:         composeCfg,
 			RegistryCredentials: cmd.regCreds.ToProtobuf(),
 			SyncedFolders:       idPathMap,
+			ReceiveOnlyFolders:  receiveOnlyFolders,
 		})
 	if err != nil {
 		return err
@@ -314,12 +416,9 @@ func (cmd *up) createSandbox(composeCfg string, idPathMap map[string]string) err
 		os.Exit(1)
 	}
 
-	cmd.nodeControllerConn, err = util.Dial(resp.NodeAddress, resp.NodeCert, "")
-	if err != nil {
-		return errors.WithContext("connect to node controller", err)
+	if err := cmd.connect(resp.NodeAddress, resp.NodeCert, resp.GetKubeCredentials()); err != nil {
+		return err
 	}
-	cmd.nodeControllerClient = node.NewControllerClient(cmd.nodeControllerConn)
-	cmd.tunnelManager = tunnel.NewManager(cmd.nodeControllerClient, cmd.config.BlimpAuth())
 
 	cmd.imageNamespace = resp.ImageNamespace
 	// Add the registry credentials for pushing to the blimp registry.
@@ -328,9 +427,20 @@ func (cmd *up) createSandbox(composeCfg string, idPathMap map[string]string) err
 		return errors.WithContext("create Blimp regcred", err)
 	}
 	cmd.regCreds[strings.SplitN(cmd.imageNamespace, "/", 2)[0]] = blimpRegcred.ToDocker()
+	return nil
+}
+
+// connect connects to the sandbox's node controller, and saves the
+// credentials for the sandbox's Kubernetes API.
+func (cmd *up) connect(nodeAddress, nodeCert string, kubeCreds *cluster.KubeCredentials) (err error) {
+	cmd.nodeControllerConn, err = util.Dial(nodeAddress, nodeCert, "")
+	if err != nil {
+		return errors.WithContext("connect to node controller", err)
+	}
+	cmd.nodeControllerClient = node.NewControllerClient(cmd.nodeControllerConn)
+	cmd.tunnelManager = tunnel.NewManager(cmd.nodeControllerClient, cmd.config.BlimpAuth())
 
 	// Save the Kubernetes API credentials for use by other Blimp commands.
-	kubeCreds := resp.GetKubeCredentials()
 	cmd.config.Auth.KubeToken = kubeCreds.Token
 	cmd.config.Auth.KubeHost = kubeCreds.Host
 	cmd.config.Auth.KubeCACrt = kubeCreds.CaCrt
@@ -343,11 +453,17 @@ func (cmd *up) createSandbox(composeCfg string, idPathMap map[string]string) err
 	if err := cmd.config.Auth.Save(); err != nil {
 		return err
 	}
+
+	// Keep the credentials exported by `blimp kubeconfig` up to date.
+	if err := kubeconfig.Refresh(cmd.config.Auth); err != nil {
+		log.WithError(err).Warn("Failed to refresh kubeconfig")
+	}
 	return nil
 }
 
-func (cmd *up) runGUI(ctx context.Context, parsedCompose composeTypes.Project) error {
-	services := parsedCompose.ServiceNames()
+// runGUI prints the services' statuses until they boot, and then streams
+// their logs. If `since` is set, only the logs after that time are shown.
+func (cmd *up) runGUI(ctx context.Context, services []string, since *metav1.Time) error {
 	statusPrinter := newStatusPrinter(services, cmd.disableStatusOutput)
 	if !statusPrinter.Run(ctx, manager.C, cmd.config.BlimpAuth()) {
 		return nil
@@ -355,7 +471,7 @@ func (cmd *up) runGUI(ctx context.Context, parsedCompose composeTypes.Project) e
 
 	return logs.Command{
 		Services: services,
-		Opts:     corev1.PodLogOptions{Follow: true},
+		Opts:     corev1.PodLogOptions{Follow: true, SinceTime: since},
 		Config:   cmd.config,
 	}.Run(ctx)
 }
@@ -374,13 +490,23 @@ func (cmd *up) makeSyncthingClient(dcCfg composeTypes.Project) syncthing.Client
 			}
 			bindVolumes[v.Target] = syncthing.BindVolume{
 				LocalPath: v.Source,
+				ReadOnly:  v.ReadOnly,
+			}
+		}
+
+		// The `tmpfs` field is shorthand for tmpfs volumes. Invalid entries
+		// are ignored here since they're rejected when the pods are created.
+		volumes := append([]composeTypes.ServiceVolumeConfig{}, svc.Volumes...)
+		for _, entry := range svc.Tmpfs {
+			if v, err := dockercompose.ParseTmpfs(entry); err == nil {
+				volumes = append(volumes, v)
 			}
 		}
 
 		// Now, mask off any native volumes that fall under these mounts.
-		for _, v := range svc.Volumes {
-			// Theoretically this could match other types of volumes like tmpfs,
-			// although we don't support these yet.
+		for _, v := range volumes {
+			// This also masks tmpfs volumes, since their contents shouldn't be
+			// synced either.
 			if v.Type == composeTypes.VolumeTypeBind {
 				continue
 			}
@@ -414,3 +540,11 @@ func (cmd *up) makeSyncthingClient(dcCfg composeTypes.Project) syncthing.Client
 
 	return syncthing.NewClient(allVolumes)
 }
+
+// printDeployWarnings prints the problems with the Compose file that the
+// cluster worked around while deploying.
+func printDeployWarnings(resp *cluster.DeployResponse) {
+	for _, warning := range resp.GetWarnings() {
+		log.Warn(warning)
+	}
+}
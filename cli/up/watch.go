@@ -0,0 +1,295 @@
+package up
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/docker/docker/builder/dockerignore"
+	"github.com/docker/docker/pkg/fileutils"
+	composeTypes "github.com/kelda/compose-go/types"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/dockercompose"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// watchInterval is how often `blimp up --watch` checks the watched files for
+// changes.
+const watchInterval = time.Second
+
+// deployment is the state of the most recent deploy. It's used to decide
+// which images need to be rebuilt when the watched files change.
+type deployment struct {
+	project     composeTypes.Project
+	builtImages map[string]string
+	snapshot    snapshot
+}
+
+// snapshot maps each watched file or directory to a hash of its contents'
+// metadata. Build contexts are keyed by service name, since their contents
+// only affect the service's image.
+type snapshot struct {
+	config        map[string]string
+	buildContexts map[string]string
+}
+
+// watchForChanges redeploys the sandbox whenever the compose files, the .env
+// file, or a service's build context changes. After each successful deploy,
+// the new project is sent on `redeployed`. It returns once `ctx` is
+// cancelled.
+func (cmd *up) watchForChanges(ctx context.Context, services []string, curr deployment,
+	redeployed chan<- composeTypes.Project) {
+	var prev snapshot
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchInterval):
+		}
+
+		next := cmd.takeSnapshot(curr.project)
+		if reflect.DeepEqual(next, curr.snapshot) {
+			prev = next
+			continue
+		}
+
+		// Wait for the files to stop changing so that we don't deploy
+		// half-saved files, or redeploy for every file in a `git checkout`.
+		if !reflect.DeepEqual(next, prev) {
+			prev = next
+			continue
+		}
+
+		fmt.Println("Detected changes. Redeploying...")
+		deployed, err := cmd.redeploy(services, curr)
+		if err != nil {
+			log.WithError(err).Warn("Failed to redeploy. Waiting for more changes.")
+
+			// Don't retry until the files change again.
+			curr.snapshot = next
+			continue
+		}
+
+		cmd.warnUnappliedChanges(curr.project, deployed.project)
+		curr = deployed
+		prev = curr.snapshot
+		fmt.Println("Finished redeploying")
+
+		select {
+		case redeployed <- curr.project:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// redeploy reloads the compose file, rebuilds the images whose build context
+// or build config changed, and deploys the result. The pods whose specs
+// didn't change aren't restarted.
+func (cmd *up) redeploy(services []string, curr deployment) (deployment, error) {
+	project, err := dockercompose.Load(cmd.composePath, cmd.overridePaths, cmd.profiles, services)
+	if err != nil {
+		return deployment{}, errors.WithContext("load compose file", err)
+	}
+
+	if err := applyScale(&project, cmd.scale); err != nil {
+		return deployment{}, err
+	}
+
+	// Take the snapshot before building so that changes made during the
+	// build trigger another redeploy.
+	next := cmd.takeSnapshot(project)
+
+	prevServices := map[string]composeTypes.ServiceConfig{}
+	for _, svc := range curr.project.Services {
+		prevServices[svc.Name] = svc
+	}
+
+	builtImages := map[string]string{}
+	var toBuild composeTypes.Services
+	for _, svc := range project.Services {
+		if svc.Build == nil {
+			continue
+		}
+
+		prevSvc, ok := prevServices[svc.Name]
+		image, built := curr.builtImages[svc.Name]
+		contextChanged := next.buildContexts[svc.Name] != curr.snapshot.buildContexts[svc.Name]
+		if ok && built && !contextChanged && reflect.DeepEqual(prevSvc.Build, svc.Build) {
+			builtImages[svc.Name] = image
+			continue
+		}
+		toBuild = append(toBuild, svc)
+	}
+
+	if len(toBuild) != 0 {
+		newBuiltImages, err := cmd.buildServices(project.Name, toBuild, true)
+		if err != nil {
+			return deployment{}, err
+		}
+		for svc, image := range newBuiltImages {
+			builtImages[svc] = image
+		}
+	}
+
+	projectBytes, err := dockercompose.Marshal(project)
+	if err != nil {
+		return deployment{}, err
+	}
+
+	deployResp, err := manager.C.DeployToSandbox(context.Background(), &cluster.DeployRequest{
+		Auth:        cmd.config.BlimpAuth(),
+		ComposeFile: string(projectBytes),
+		BuiltImages: builtImages,
+		NoRecreate:  cmd.noRecreate,
+	})
+	if err != nil {
+		return deployment{}, errors.WithContext("deploy", err)
+	}
+	printDeployWarnings(deployResp)
+
+	return deployment{
+		project:     project,
+		builtImages: builtImages,
+		snapshot:    next,
+	}, nil
+}
+
+// warnUnappliedChanges warns about changes that are only applied when `blimp
+// up` starts, since the tunnels and file sync are only set up once.
+func (cmd *up) warnUnappliedChanges(prev, curr composeTypes.Project) {
+	getPorts := func(project composeTypes.Project) map[string][]composeTypes.ServicePortConfig {
+		ports := map[string][]composeTypes.ServicePortConfig{}
+		for _, svc := range project.Services {
+			if len(svc.Ports) != 0 {
+				ports[svc.Name] = svc.Ports
+			}
+		}
+		return ports
+	}
+
+	if !reflect.DeepEqual(getPorts(prev), getPorts(curr)) {
+		log.Warn("Port changes take effect the next time `blimp up` is run.")
+	}
+
+	prevSynced := cmd.makeSyncthingClient(prev).GetIDPathMap()
+	currSynced := cmd.makeSyncthingClient(curr).GetIDPathMap()
+	if !reflect.DeepEqual(prevSynced, currSynced) {
+		log.Warn("Changes to synced bind volumes take effect the next time `blimp up` is run.")
+	}
+}
+
+// takeSnapshot hashes the files that affect the deployment of `project`.
+func (cmd *up) takeSnapshot(project composeTypes.Project) snapshot {
+	configPaths := append([]string{cmd.composePath}, cmd.overridePaths...)
+	configPaths = append(configPaths, filepath.Join(filepath.Dir(cmd.composePath), ".env"))
+
+	snap := snapshot{
+		config:        map[string]string{},
+		buildContexts: map[string]string{},
+	}
+	for _, path := range configPaths {
+		snap.config[path] = hashPath(path, nil)
+	}
+
+	for _, svc := range project.Services {
+		if svc.Build == nil {
+			continue
+		}
+
+		dockerfile := svc.Build.Dockerfile
+		if dockerfile == "" {
+			dockerfile = "Dockerfile"
+		}
+		if !filepath.IsAbs(dockerfile) {
+			dockerfile = filepath.Join(svc.Build.Context, dockerfile)
+		}
+
+		snap.buildContexts[svc.Name] = hashPath(dockerfile, nil) +
+			hashPath(svc.Build.Context, getDockerignore(svc.Build.Context))
+	}
+	return snap
+}
+
+// getDockerignore returns the patterns in the build context's .dockerignore
+// file, so that changes to ignored files don't trigger rebuilds.
+func getDockerignore(dir string) *fileutils.PatternMatcher {
+	f, err := os.Open(filepath.Join(dir, ".dockerignore"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	patterns, err := dockerignore.ReadAll(f)
+	if err != nil {
+		log.WithError(err).WithField("context", dir).Debug("Failed to parse .dockerignore")
+		return nil
+	}
+
+	pm, err := fileutils.NewPatternMatcher(patterns)
+	if err != nil {
+		log.WithError(err).WithField("context", dir).Debug("Failed to parse .dockerignore")
+		return nil
+	}
+	return pm
+}
+
+// hashPath returns a hash of the names, sizes and modification times of the
+// files in `root`. Files that match `ignore` are skipped. Missing files hash
+// to the empty string so that creating them is detected as a change.
+func hashPath(root string, ignore *fileutils.PatternMatcher) string {
+	if _, err := os.Stat(root); err != nil {
+		return ""
+	}
+
+	var entries []string
+	err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			// The file may have been deleted while we were walking.
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		if fi.IsDir() && fi.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		if ignore != nil && relPath != "." {
+			ignored, err := ignore.Matches(relPath)
+			if err == nil && ignored {
+				// Directories can only be skipped entirely if there aren't
+				// any exceptions that could re-include their children.
+				if fi.IsDir() && !ignore.Exclusions() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+
+		entries = append(entries, fmt.Sprintf("%s %d %d %s",
+			relPath, fi.Size(), fi.ModTime().UnixNano(), fi.Mode()))
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).WithField("path", root).Debug("Failed to walk watched path")
+	}
+
+	sort.Strings(entries)
+	h := sha256.New()
+	for _, entry := range entries {
+		fmt.Fprintln(h, entry)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
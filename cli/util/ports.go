@@ -0,0 +1,115 @@
+package util
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/cfgdir"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// PortBinding is a local port that `blimp up` is forwarding to a service.
+type PortBinding struct {
+	Service     string `json:"service"`
+	PrivatePort uint32 `json:"privatePort"`
+	HostIP      string `json:"hostIP"`
+	HostPort    uint32 `json:"hostPort"`
+
+	// RequestedHostPort is the local port that was asked for. It differs from
+	// HostPort if the requested port was already in use.
+	RequestedHostPort uint32 `json:"requestedHostPort"`
+
+	// AdHoc is true if the forward was added by `blimp forward` rather than
+	// the Compose file.
+	AdHoc bool `json:"adHoc"`
+}
+
+// PortForward is a request for `blimp up` to forward a local port to a
+// service, in addition to the ports published in the Compose file. If
+// HostPort is zero, the private port is used if it's free.
+type PortForward struct {
+	Service     string `json:"service"`
+	PrivatePort uint32 `json:"privatePort"`
+	HostPort    uint32 `json:"hostPort"`
+}
+
+// ReadPortBindings returns the ports currently forwarded by `blimp up`. The
+// bindings are only meaningful if `blimp up` is running.
+func ReadPortBindings() ([]PortBinding, error) {
+	var bindings []PortBinding
+	if err := readJSON(getPortBindingsPath(), &bindings); err != nil {
+		return nil, errors.WithContext("read port bindings", err)
+	}
+	return bindings, nil
+}
+
+func WritePortBindings(bindings []PortBinding) error {
+	if err := writeJSON(getPortBindingsPath(), bindings); err != nil {
+		return errors.WithContext("write port bindings", err)
+	}
+	return nil
+}
+
+// ReadPortForwards returns the ad-hoc port forwards requested by `blimp
+// forward`.
+func ReadPortForwards() ([]PortForward, error) {
+	var forwards []PortForward
+	if err := readJSON(getPortForwardsPath(), &forwards); err != nil {
+		return nil, errors.WithContext("read port forwards", err)
+	}
+	return forwards, nil
+}
+
+func WritePortForwards(forwards []PortForward) error {
+	if err := writeJSON(getPortForwardsPath(), forwards); err != nil {
+		return errors.WithContext("write port forwards", err)
+	}
+	return nil
+}
+
+// ClearPortState removes the port bindings and ad-hoc forwards. It's called
+// when `blimp up` exits, since the forwards stop with it.
+func ClearPortState() {
+	for _, path := range []string{getPortBindingsPath(), getPortForwardsPath()} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.WithError(err).WithField("path", path).Debug("Failed to remove port state")
+		}
+	}
+}
+
+func readJSON(path string, out interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(b, out)
+}
+
+// writeJSON atomically replaces the contents of `path` so that readers never
+// see a partially written file.
+func writeJSON(path string, in interface{}) error {
+	b, err := json.MarshalIndent(in, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func getPortBindingsPath() string {
+	return cfgdir.Expand("up-ports.json")
+}
+
+func getPortForwardsPath() string {
+	return cfgdir.Expand("up-port-forwards.json")
+}
@@ -0,0 +1,159 @@
+package volume
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/ports"
+	"github.com/kelda/blimp/pkg/proto/auth"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+	"github.com/kelda/blimp/pkg/proto/node"
+	"github.com/kelda/blimp/pkg/tunnel"
+	"github.com/kelda/blimp/pkg/vtar"
+)
+
+func newExportCommand(compose *composeFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export VOLUME",
+		Short: "Write a gzipped tarball of a volume's contents to stdout",
+		Long: "Write a gzipped tarball of a volume's contents to stdout.\n\n" +
+			"File ownership, permissions and symlinks are preserved. For example:\n" +
+			"blimp volume export db-data > db-data.tar.gz",
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			run(*compose, func(auth *auth.BlimpAuth, volumes []volumeInfo) error {
+				return exportVolume(auth, volumes, args[0])
+			})
+		},
+	}
+}
+
+func newImportCommand(compose *composeFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import VOLUME",
+		Short: "Replace a volume's contents with a gzipped tarball read from stdin",
+		Long: "Replace a volume's contents with a gzipped tarball read from stdin.\n\n" +
+			"The tarball should be created by `blimp volume export`, or by running " +
+			"`tar -czf` from within the directory to import. For example:\n" +
+			"blimp volume import db-data < db-data.tar.gz\n\n" +
+			"Volumes that are mounted by a running service can't be imported into. " +
+			"This includes bind volumes that contain, or are within, a mounted directory.",
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			run(*compose, func(auth *auth.BlimpAuth, volumes []volumeInfo) error {
+				return importVolume(auth, volumes, args[0])
+			})
+		},
+	}
+}
+
+func exportVolume(blimpAuth *auth.BlimpAuth, volumes []volumeInfo, name string) error {
+	vol, err := findVolume(volumes, name)
+	if err != nil {
+		return err
+	}
+
+	if terminal.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.NewFriendlyError("Refusing to write the archive to a terminal.\n" +
+			"Redirect the output to a file, such as `blimp volume export NAME > volume.tar.gz`.")
+	}
+
+	conn, err := startTransfer(blimpAuth, vol, cluster.StartVolumeTransferRequest_EXPORT)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	if err := vtar.ReadStatus(r); err != nil {
+		return errors.WithContext("export volume", err)
+	}
+
+	// Validate the archive as it's written so that we can tell the user if
+	// the export was interrupted.
+	if err := vtar.Validate(io.TeeReader(r, os.Stdout)); err != nil {
+		return errors.WithContext("export incomplete", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %s\n", vol.name)
+	return nil
+}
+
+func importVolume(blimpAuth *auth.BlimpAuth, volumes []volumeInfo, name string) error {
+	vol, err := findVolume(volumes, name)
+	if err != nil {
+		return err
+	}
+
+	if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.NewFriendlyError("Expected the archive to be piped to stdin, " +
+			"such as `blimp volume import NAME < volume.tar.gz`.")
+	}
+
+	conn, err := startTransfer(blimpAuth, vol, cluster.StartVolumeTransferRequest_IMPORT)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, copyErr := io.Copy(conn, os.Stdin)
+
+	// Read the status even if the copy failed, since the transfer pod
+	// reports why it stopped reading the archive.
+	if err := vtar.ReadStatus(bufio.NewReader(conn)); err != nil {
+		return errors.WithContext("import volume", err)
+	}
+
+	if copyErr != nil {
+		return errors.WithContext("send archive", copyErr)
+	}
+
+	fmt.Fprintf(os.Stderr, "Imported %s\n", vol.name)
+	return nil
+}
+
+// startTransfer boots the volume transfer pod, and returns a connection to
+// it.
+func startTransfer(blimpAuth *auth.BlimpAuth, vol volumeInfo,
+	direction cluster.StartVolumeTransferRequest_Direction) (*tunnel.Conn, error) {
+	// Messages are written to stderr since stdout may be used for the
+	// archive.
+	fmt.Fprintln(os.Stderr, "Starting volume transfer")
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	resp, err := manager.C.StartVolumeTransfer(ctx, &cluster.StartVolumeTransferRequest{
+		Auth:      blimpAuth,
+		Volume:    vol.ref,
+		Direction: direction,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nodeConn, err := util.Dial(resp.NodeAddress, resp.NodeCert, "")
+	if err != nil {
+		return nil, errors.WithContext("connect to node controller", err)
+	}
+
+	conn, err := tunnel.Dial(node.NewControllerClient(nodeConn), blimpAuth,
+		resp.PodName, ports.VolumeTransferPort)
+	if err != nil {
+		return nil, errors.WithContext("connect to transfer pod", err)
+	}
+
+	if _, err := conn.Write([]byte(vtar.Magic)); err != nil {
+		conn.Close()
+		return nil, errors.WithContext("start transfer", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,342 @@
+package volume
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/docker/go-units"
+	composeTypes "github.com/kelda/compose-go/types"
+	"github.com/spf13/cobra"
+
+	"github.com/kelda/blimp/cli/config"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/dockercompose"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/auth"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// volumeInfo is a volume defined in the user's Compose file.
+type volumeInfo struct {
+	// name is how the volume is referred to by the user. It's the name of
+	// the volume for named volumes, and the local path for bind volumes.
+	name string
+	ref  *cluster.VolumeRef
+}
+
+// composeFlags are the flags used by every volume command to load the
+// Compose file.
+type composeFlags struct {
+	paths    []string
+	profiles []string
+}
+
+func New() *cobra.Command {
+	var compose composeFlags
+	cobraCmd := &cobra.Command{
+		Use:   "volume",
+		Short: "Manage the volumes in your cloud sandbox",
+		Long: "Manage the volumes in your cloud sandbox.\n\n" +
+			"Volumes are referred to by their name in the Compose file for named volumes, " +
+			"and by their local path for bind volumes.",
+	}
+	cobraCmd.PersistentFlags().StringSliceVarP(&compose.paths, "file", "f", nil,
+		"Specify an alternate compose file\nDefaults to docker-compose.yml and docker-compose.yaml")
+	cobraCmd.PersistentFlags().StringSliceVarP(&compose.profiles, "profile", "", nil,
+		"Specify a profile to enable")
+
+	cobraCmd.AddCommand(
+		&cobra.Command{
+			Use:   "ls",
+			Short: "List the volumes in the Compose file, and whether they exist in the sandbox",
+			Args:  cobra.NoArgs,
+			Run: func(_ *cobra.Command, _ []string) {
+				run(compose, func(auth *auth.BlimpAuth, volumes []volumeInfo) error {
+					return list(auth, volumes)
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "inspect VOLUME",
+			Short: "Print detailed information about a volume",
+			Args:  cobra.ExactArgs(1),
+			Run: func(_ *cobra.Command, args []string) {
+				run(compose, func(auth *auth.BlimpAuth, volumes []volumeInfo) error {
+					return inspect(auth, volumes, args[0])
+				})
+			},
+		},
+		newRemoveCommand(&compose),
+		newExportCommand(&compose),
+		newImportCommand(&compose),
+		&cobra.Command{
+			Use:   "du",
+			Short: "Print the disk usage of each volume",
+			Args:  cobra.NoArgs,
+			Run: func(_ *cobra.Command, _ []string) {
+				run(compose, func(auth *auth.BlimpAuth, volumes []volumeInfo) error {
+					return diskUsage(auth, volumes)
+				})
+			},
+		},
+	)
+	return cobraCmd
+}
+
+func newRemoveCommand(compose *composeFlags) *cobra.Command {
+	var skipConfirm bool
+	cobraCmd := &cobra.Command{
+		Use:   "rm VOLUME",
+		Short: "Delete the contents of a volume",
+		Long: "Delete the contents of a volume.\n\n" +
+			"Volumes that are mounted by a running service can't be removed. This " +
+			"includes bind volumes that contain, or are within, a mounted directory. " +
+			"Bind volumes that are synced with your machine can't be removed either.",
+		Args: cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			run(*compose, func(auth *auth.BlimpAuth, volumes []volumeInfo) error {
+				return remove(auth, volumes, args[0], skipConfirm)
+			})
+		},
+	}
+	cobraCmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false,
+		"Don't ask for confirmation before removing the volume")
+	return cobraCmd
+}
+
+func run(compose composeFlags, fn func(*auth.BlimpAuth, []volumeInfo) error) {
+	blimpConfig, err := config.GetConfig()
+	if err != nil {
+		errors.HandleFatalError(err)
+	}
+
+	volumes, err := getVolumes(compose)
+	if err != nil {
+		errors.HandleFatalError(err)
+	}
+
+	if err := fn(blimpConfig.BlimpAuth(), volumes); err != nil {
+		errors.HandleFatalError(err)
+	}
+}
+
+// getVolumes returns the volumes referenced by the Compose file. Volumes that
+// are only mounted by services in disabled profiles are skipped.
+func getVolumes(compose composeFlags) ([]volumeInfo, error) {
+	composePath, overridePaths, err := dockercompose.GetPaths(compose.paths)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewFriendlyError("Docker Compose file not found.\n" +
+				"Blimp must be run from the same directory as docker-compose.yml.")
+		}
+		return nil, errors.WithContext("get compose paths", err)
+	}
+
+	cfg, err := dockercompose.Load(composePath, overridePaths, compose.profiles, nil)
+	if err != nil {
+		return nil, errors.WithContext("load compose file", err)
+	}
+
+	seen := map[string]struct{}{}
+	var volumes []volumeInfo
+	add := func(name string, volType cluster.VolumeType, source string) {
+		key := fmt.Sprintf("%s/%s", volType, source)
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		volumes = append(volumes, volumeInfo{
+			name: name,
+			ref:  &cluster.VolumeRef{Name: source, Type: volType},
+		})
+	}
+
+	for name, vol := range cfg.Volumes {
+		if source, ok := dockercompose.ParseNamedBindVolume(vol); ok {
+			add(name, cluster.VolumeType_BIND_VOLUME, source)
+		} else {
+			add(name, cluster.VolumeType_NAMED_VOLUME, name)
+		}
+	}
+
+	for _, svc := range cfg.Services {
+		for _, v := range svc.Volumes {
+			if v.Type == composeTypes.VolumeTypeBind {
+				add(v.Source, cluster.VolumeType_BIND_VOLUME, v.Source)
+			}
+		}
+	}
+
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].name < volumes[j].name
+	})
+	return volumes, nil
+}
+
+// findVolume returns the volume referred to by name. Bind volumes can be
+// referred to by relative paths.
+func findVolume(volumes []volumeInfo, name string) (volumeInfo, error) {
+	absPath, err := filepath.Abs(name)
+	if err != nil {
+		absPath = name
+	}
+
+	for _, vol := range volumes {
+		if vol.name == name {
+			return vol, nil
+		}
+
+		if vol.ref.GetType() == cluster.VolumeType_BIND_VOLUME && vol.ref.GetName() == absPath {
+			return vol, nil
+		}
+	}
+	return volumeInfo{}, errors.NewFriendlyError(
+		"Volume %s isn't defined in the Compose file.\n"+
+			"Run `blimp volume ls` to see the available volumes.", name)
+}
+
+func listVolumes(blimpAuth *auth.BlimpAuth, volumes []volumeInfo, includeSize bool) (
+	*cluster.ListVolumesResponse, error) {
+	var refs []*cluster.VolumeRef
+	for _, vol := range volumes {
+		refs = append(refs, vol.ref)
+	}
+
+	resp, err := manager.C.ListVolumes(context.Background(), &cluster.ListVolumesRequest{
+		Auth:        blimpAuth,
+		Volumes:     refs,
+		IncludeSize: includeSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.GetVolumes()) != len(volumes) {
+		return nil, errors.New("unexpected number of volumes in response")
+	}
+	return resp, nil
+}
+
+func list(blimpAuth *auth.BlimpAuth, volumes []volumeInfo) error {
+	if len(volumes) == 0 {
+		fmt.Println("No volumes found.")
+		return nil
+	}
+
+	resp, err := listVolumes(blimpAuth, volumes, false)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "VOLUME\tTYPE\tCREATED\tMOUNTED BY")
+	for i, status := range resp.GetVolumes() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", volumes[i].name, typeString(status.GetVolume()),
+			yesNo(status.GetExists()), strings.Join(status.GetMountedBy(), ", "))
+	}
+	return nil
+}
+
+func inspect(blimpAuth *auth.BlimpAuth, volumes []volumeInfo, name string) error {
+	vol, err := findVolume(volumes, name)
+	if err != nil {
+		return err
+	}
+
+	resp, err := listVolumes(blimpAuth, []volumeInfo{vol}, true)
+	if err != nil {
+		return err
+	}
+
+	status := resp.GetVolumes()[0]
+	mountedBy := strings.Join(status.GetMountedBy(), ", ")
+	if mountedBy == "" {
+		mountedBy = "-"
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintf(w, "Name:\t%s\n", vol.name)
+	fmt.Fprintf(w, "Type:\t%s\n", typeString(status.GetVolume()))
+	if status.GetVolume().GetType() == cluster.VolumeType_BIND_VOLUME {
+		fmt.Fprintf(w, "Local path:\t%s\n", status.GetVolume().GetName())
+	}
+	fmt.Fprintf(w, "Sandbox path:\t%s\n", status.GetPath())
+	fmt.Fprintf(w, "Created:\t%s\n", yesNo(status.GetExists()))
+	fmt.Fprintf(w, "Size:\t%s\n", units.HumanSize(float64(status.GetSizeBytes())))
+	fmt.Fprintf(w, "Mounted by:\t%s\n", mountedBy)
+	return nil
+}
+
+func remove(blimpAuth *auth.BlimpAuth, volumes []volumeInfo, name string, skipConfirm bool) error {
+	vol, err := findVolume(volumes, name)
+	if err != nil {
+		return err
+	}
+
+	if !skipConfirm {
+		fmt.Printf("This will permanently delete the contents of %s in your sandbox.\n"+
+			"Are you sure you want to continue? (y/N) ", vol.name)
+		var response string
+		num, err := fmt.Scanln(&response)
+		if err != nil || num != 1 ||
+			(strings.ToLower(response) != "y" && strings.ToLower(response) != "yes") {
+			fmt.Println("Aborting.")
+			return nil
+		}
+	}
+
+	_, err = manager.C.RemoveVolume(context.Background(), &cluster.RemoveVolumeRequest{
+		Auth:   blimpAuth,
+		Volume: vol.ref,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed %s\n", vol.name)
+	return nil
+}
+
+func diskUsage(blimpAuth *auth.BlimpAuth, volumes []volumeInfo) error {
+	resp, err := listVolumes(blimpAuth, volumes, true)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "VOLUME\tTYPE\tSIZE")
+	for i, status := range resp.GetVolumes() {
+		size := "-"
+		if status.GetExists() {
+			size = units.HumanSize(float64(status.GetSizeBytes()))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", volumes[i].name, typeString(status.GetVolume()), size)
+	}
+	w.Flush()
+
+	fmt.Printf("\nTotal: %s used of %s\n",
+		units.HumanSize(float64(resp.GetUsedBytes())),
+		units.HumanSize(float64(resp.GetCapacityBytes())))
+	return nil
+}
+
+func typeString(ref *cluster.VolumeRef) string {
+	if ref.GetType() == cluster.VolumeType_BIND_VOLUME {
+		return "bind"
+	}
+	return "named"
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"testing"
+
+	composeTypes "github.com/kelda/compose-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/hostgateway"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func TestPlanCustomerPods(t *testing.T) {
+	makePod := func(svc, image string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "namespace",
+				Name:      svc,
+				Labels: map[string]string{
+					"blimp.customerPod": "true",
+					"blimp.service":     svc,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: svc, Image: image}},
+			},
+		}
+	}
+
+	kubeClient := fakeKube.NewSimpleClientset()
+	s := &server{kubeClient: kubeClient}
+	for _, pod := range []corev1.Pod{
+		makePod("unchanged", "nginx"),
+		makePod("changed", "postgres:11"),
+		makePod("pinned", "redis:5"),
+		makePod("removed", "nginx"),
+		// The reservation pod is a customer pod, but not a service.
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "namespace",
+				Name:      "reservation",
+				Labels:    map[string]string{"blimp.customerPod": "true"},
+			},
+		},
+	} {
+		assert.NoError(t, kube.DeployPod(kubeClient, pod, customerPodDeployOptions))
+	}
+
+	desired := []corev1.Pod{
+		makePod("unchanged", "nginx"),
+		makePod("changed", "postgres:12"),
+		makePod("pinned", "redis:6"),
+		makePod("new", "nginx"),
+	}
+	plan, err := s.planCustomerPods("namespace", desired, map[string]struct{}{"pinned": {}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*cluster.ServiceDeployPlan{
+		{
+			Service: "changed",
+			Action:  cluster.ServiceDeployPlan_RECREATE,
+			Diff:    []string{`spec.containers[0].image: "postgres:11" -> "postgres:12"`},
+		},
+		{Service: "new", Action: cluster.ServiceDeployPlan_CREATE},
+		{
+			Service: "pinned",
+			Action:  cluster.ServiceDeployPlan_SKIP_RECREATE,
+			Diff:    []string{`spec.containers[0].image: "redis:5" -> "redis:6"`},
+		},
+		{Service: "removed", Action: cluster.ServiceDeployPlan_DELETE},
+		{Service: "unchanged", Action: cluster.ServiceDeployPlan_UNCHANGED},
+	}, plan)
+
+	// Deploying should leave the pinned service's pod alone.
+	assert.NoError(t, s.deployCustomerPods("namespace", desired, map[string]struct{}{"pinned": {}}))
+	pinned, err := kubeClient.CoreV1().Pods("namespace").Get("pinned", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "redis:5", pinned.Spec.Containers[0].Image)
+}
+
+func TestPlanCustomerPodsScale(t *testing.T) {
+	toServicePods := func(replicas uint64) []corev1.Pod {
+		cfg := composeTypes.Project{
+			Services: composeTypes.Services{
+				{Name: "web", Image: "nginx", Deploy: &composeTypes.DeployConfig{Replicas: &replicas}},
+			},
+		}
+		pods, _, _, err := toPods(auth.User{Namespace: "namespace"}, "10.0.0.2", "10.0.0.3", cfg, nil)
+		require.NoError(t, err)
+		return pods
+	}
+
+	kubeClient := fakeKube.NewSimpleClientset()
+	s := &server{kubeClient: kubeClient}
+	require.NoError(t, s.deployCustomerPods("namespace", toServicePods(1), nil))
+
+	// Scaling up shouldn't recreate the existing pod.
+	plan, err := s.planCustomerPods("namespace", toServicePods(3), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*cluster.ServiceDeployPlan{
+		{Service: "web.1", Action: cluster.ServiceDeployPlan_UNCHANGED},
+		{Service: "web.2", Action: cluster.ServiceDeployPlan_CREATE},
+		{Service: "web.3", Action: cluster.ServiceDeployPlan_CREATE},
+	}, plan)
+
+	// Neither should scaling back down.
+	require.NoError(t, s.deployCustomerPods("namespace", toServicePods(3), nil))
+	plan, err = s.planCustomerPods("namespace", toServicePods(1), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*cluster.ServiceDeployPlan{
+		{Service: "web", Action: cluster.ServiceDeployPlan_UNCHANGED},
+		{Service: "web.2", Action: cluster.ServiceDeployPlan_DELETE},
+		{Service: "web.3", Action: cluster.ServiceDeployPlan_DELETE},
+	}, plan)
+}
+
+func TestParseNoRecreate(t *testing.T) {
+	dcCfg := composeTypes.Project{
+		Services: composeTypes.Services{{Name: "web"}, {Name: "db"}},
+	}
+
+	noRecreate, err := parseNoRecreate(dcCfg, []string{"db"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"db": {}}, noRecreate)
+
+	_, err = parseNoRecreate(dcCfg, []string{"db", "dbb"})
+	assert.Error(t, err)
+}
+
+func TestDeployDNSKeepsGatewayToken(t *testing.T) {
+	s := &server{kubeClient: fakeKube.NewSimpleClientset()}
+	user := auth.User{Namespace: "namespace"}
+	getToken := func() string {
+		pod, err := s.kubeClient.CoreV1().Pods("namespace").Get("dns", metav1.GetOptions{})
+		require.NoError(t, err)
+		return pod.Annotations[hostgateway.TokenAnnotation]
+	}
+
+	require.NoError(t, s.deployDNS(user))
+	token := getToken()
+	assert.NotEmpty(t, token)
+
+	// Redeploying shouldn't change the token, since that would recreate the
+	// pod.
+	require.NoError(t, s.deployDNS(user))
+	assert.Equal(t, token, getToken())
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kelda/blimp/cli/expose"
+	"github.com/kelda/blimp/cli/logs"
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cli/ps"
+	"github.com/kelda/blimp/cli/run"
+	"github.com/kelda/blimp/cli/up"
+	"github.com/kelda/blimp/cli/util"
+	"github.com/kelda/blimp/cluster-controller/sandbox"
+	"github.com/kelda/blimp/pkg/dockercompose"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+	"github.com/kelda/blimp/pkg/proto/node"
+	"github.com/kelda/blimp/pkg/tunnel"
+	"github.com/kelda/blimp/sandbox/dns/table"
+)
+
+const testComposeFile = `
+version: '3'
+services:
+  web:
+    image: nginx
+    deploy:
+      replicas: 2
+  db:
+    image: postgres
+`
+
+func TestUpDryRun(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composePath := writeComposeFile(t, testComposeFile)
+	defer os.RemoveAll(filepath.Dir(composePath))
+
+	out := tc.runCLI(up.New(), "--dry-run", "-f", composePath)
+	assert.Contains(t, out, "Deployment plan:")
+	assert.NotContains(t, out, "delete")
+	for _, svc := range []string{"db", "web.1", "web.2"} {
+		assert.Contains(t, out, fmt.Sprintf("  %s: ", svc))
+	}
+
+	// Nothing should have been deployed.
+	status, err := manager.C.GetStatus(context.Background(), &cluster.GetStatusRequest{Auth: tc.auth})
+	require.NoError(t, err)
+	assert.Empty(t, status.GetStatus().GetServices())
+}
+
+func TestDeploy(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, testComposeFile)
+	tc.createSandbox(composeFile, nil)
+	tc.deploy(composeFile)
+	tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "web", "db")
+
+	var status struct {
+		Status   string
+		Services []struct {
+			Name     string
+			Status   string
+			Replicas []struct {
+				Name   string
+				Status string
+			}
+		}
+	}
+	out := tc.runCLI(ps.New(), "--format", "json")
+	require.NoError(t, json.Unmarshal([]byte(out), &status), out)
+	assert.Equal(t, "Running", status.Status)
+	require.Len(t, status.Services, 2)
+	for _, svc := range status.Services {
+		assert.Equal(t, "Running", svc.Status)
+		switch svc.Name {
+		case "db":
+			assert.Empty(t, svc.Replicas)
+		case "web":
+			require.Len(t, svc.Replicas, 2)
+			assert.Equal(t, "web.1", svc.Replicas[0].Name)
+			assert.Equal(t, "web.2", svc.Replicas[1].Name)
+		default:
+			t.Errorf("unexpected service %q", svc.Name)
+		}
+	}
+
+	// Services with multiple replicas should resolve to all of them.
+	dnsTable := table.New(tc.namespace, tc.server.statusFetcher.podLister, nil)
+	dnsTable.UpdateTable()
+	assert.Len(t, dnsTable.LookupA("web"), 2)
+	assert.Len(t, dnsTable.LookupA("db"), 1)
+}
+
+func TestServiceReadiness(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, testComposeFile)
+	tc.createSandbox(composeFile, nil)
+
+	// The deploy shouldn't block on services that haven't started.
+	tc.sim.HoldService("db")
+	tc.deploy(composeFile)
+	status := tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "web")
+	assert.Equal(t, cluster.ServicePhase_PENDING, status.Services["db"].Phase)
+
+	out := tc.runCLI(ps.New())
+	assert.Contains(t, out, "Pending")
+
+	require.NoError(t, tc.sim.ReleaseService(tc.namespace, "db"))
+	tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "db")
+}
+
+func TestUpAfterFailedDeploy(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, testComposeFile)
+	tc.createSandbox(composeFile, nil)
+	tc.deploy(composeFile)
+
+	// Fail the last deploy.
+	sb, err := sandbox.Get(tc.server.dynamicClient, tc.namespace)
+	require.NoError(t, err)
+	sb.Status = sandbox.Status{
+		ObservedGeneration: sb.Generation,
+		Phase:              sandbox.PhaseFailed,
+		Message:            "deploy failed",
+	}
+	require.NoError(t, sandbox.UpdateStatus(tc.server.dynamicClient, sb))
+	require.Eventually(t, func() bool {
+		_, err := tc.server.waitForSandbox(context.Background(), tc.namespace, sb.Generation)
+		return err != nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The failure shouldn't prevent `blimp up` from booting the sandbox and
+	// deploying again.
+	tc.createSandbox(composeFile, nil)
+	tc.deploy(composeFile)
+	tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "web", "db")
+}
+
+func TestLogs(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	// `blimp logs` reads the logs directly from the Kubernetes API, using
+	// the credentials saved by `blimp up`.
+	composePath := writeComposeFile(t, testComposeFile)
+	defer os.RemoveAll(filepath.Dir(composePath))
+	tc.runCLI(up.New(), "--dry-run", "-f", composePath)
+
+	tc.deploy(loadComposeFile(t, testComposeFile))
+	status := tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "db")
+
+	_, podName, ok := manager.LookupService(status, "db")
+	require.True(t, ok)
+	tc.sim.WriteLogs(tc.namespace, podName, "database system is ready", "listening on port 5432")
+
+	out := tc.runCLI(logs.New(), "db")
+	assert.Equal(t, "database system is ready\nlistening on port 5432\n", out)
+}
+
+func TestRunExitsImmediately(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, testComposeFile)
+	tc.createSandbox(composeFile, nil)
+	tc.deploy(composeFile)
+	tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "web")
+
+	// The command exits before the CLI can attach, so its output must come
+	// from the logs.
+	tc.sim.ExitImmediately("web", 0, "index.html", "50x.html")
+	out := tc.runCLI(run.New(), "web", "ls")
+	assert.Equal(t, "index.html\n50x.html\n", out)
+
+	// The one-off pod is removed once the command exits.
+	pods, err := tc.kubeClient.CoreV1().Pods(tc.namespace).List(metav1.ListOptions{
+		LabelSelector: kube.OneOffPodLabel + "=true",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, pods.Items)
+}
+
+func TestExpose(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, testComposeFile)
+	sandbox := tc.createSandbox(composeFile, nil)
+	tc.deploy(composeFile)
+	tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "web")
+
+	out := tc.runCLI(expose.New(), "web", "8080")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	link := lines[len(lines)-1]
+	require.True(t, strings.HasPrefix(link, "https://"+tc.namespace), link)
+	require.True(t, strings.HasSuffix(link, "."+LinkProxyBaseHostname+"/"), link)
+
+	// The link proxy resolves links to the node controller that's running
+	// the sandbox.
+	subdomain := strings.SplitN(strings.TrimPrefix(link, "https://"), ".", 2)[0]
+	req := &cluster.ResolveExposedLinkRequest{
+		Namespace: tc.namespace,
+		Token:     strings.TrimPrefix(subdomain, tc.namespace),
+	}
+	var resp *cluster.ResolveExposedLinkResponse
+	require.Eventually(t, func() bool {
+		var err error
+		resp, err = manager.C.ResolveExposedLink(context.Background(), req)
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, sandbox.NodeAddress, resp.NodeAddress)
+	assert.Equal(t, sandbox.NodeCert, resp.NodeCert)
+	assert.Equal(t, "web", resp.Service)
+	assert.Equal(t, uint32(8080), resp.Port)
+}
+
+func TestTunnel(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, testComposeFile)
+	sandbox := tc.createSandbox(composeFile, nil)
+	tc.deploy(composeFile)
+	status := tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "db")
+
+	// Run a server on the pod's IP, which is a loopback address.
+	_, podName, ok := manager.LookupService(status, "db")
+	require.True(t, ok)
+	pod, err := tc.server.statusFetcher.podLister.Pods(tc.namespace).Get(podName)
+	require.NoError(t, err)
+	lis, err := net.Listen("tcp", pod.Status.PodIP+":0")
+	require.NoError(t, err)
+	defer lis.Close()
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprint(conn, "hello from db")
+	}()
+
+	nodeConn, err := util.Dial(sandbox.NodeAddress, sandbox.NodeCert, "")
+	require.NoError(t, err)
+	defer nodeConn.Close()
+
+	port := lis.Addr().(*net.TCPAddr).Port
+	conn, err := tunnel.Dial(node.NewControllerClient(nodeConn), tc.auth, "db", uint32(port))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	msg := make([]byte, len("hello from db"))
+	_, err = conn.Read(msg)
+	require.NoError(t, err)
+	assert.Equal(t, "hello from db", string(msg))
+}
+
+func TestSyncNotifications(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, testComposeFile)
+	sandbox := tc.createSandbox(composeFile, map[string]string{"src": "/src"})
+
+	nodeConn, err := util.Dial(sandbox.NodeAddress, sandbox.NodeCert, "")
+	require.NoError(t, err)
+	defer nodeConn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stream, err := node.NewControllerClient(nodeConn).SyncNotifications(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&node.SyncStatusResponse{
+		Msg: &node.SyncStatusResponse_Auth{Auth: tc.auth},
+	}))
+
+	// Wait for a bind volume in the synced folder, as done by the init
+	// containers of customer pods. The node controller processes the
+	// handshake asynchronously, so retry until it knows about the CLI.
+	waitResult := make(chan error, 1)
+	go func() {
+		for {
+			err := tc.syncTracker.WaitFor(tc.namespace, []string{"/src/app"})(ctx, make(chan string, 8))
+			if err == nil || ctx.Err() != nil || !strings.Contains(err.Error(), "no connection to CLI") {
+				waitResult <- err
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	// The node controller polls the CLI for its sync status.
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&node.SyncStatusResponse{
+		Msg: &node.SyncStatusResponse_Folders{
+			Folders: &node.FolderSyncStatuses{
+				Folders: map[string]*node.FolderSyncStatus{
+					"src": {Path: "/src", Synced: true},
+				},
+			},
+		},
+	}))
+
+	select {
+	case err := <-waitResult:
+		assert.NoError(t, err)
+	case <-ctx.Done():
+		t.Fatal("bind volume never synced")
+	}
+}
+
+// writeComposeFile writes the Compose file to a new temporary directory, and
+// returns its path.
+func writeComposeFile(t *testing.T, contents string) string {
+	dir, err := ioutil.TempDir("", "blimp-compose")
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "docker-compose.yml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	return path
+}
+
+// loadComposeFile parses and normalizes the Compose file, as done by
+// `blimp up` before sending it to the cluster manager.
+func loadComposeFile(t *testing.T, contents string) string {
+	path := writeComposeFile(t, contents)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	project, err := dockercompose.Load(path, nil, nil, nil)
+	require.NoError(t, err)
+
+	composeBytes, err := dockercompose.Marshal(project)
+	require.NoError(t, err)
+	return string(composeBytes)
+}
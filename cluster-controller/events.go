@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kelda/blimp/cluster-controller/events"
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func (s *server) WatchEvents(req *cluster.WatchEventsRequest, stream cluster.Manager_WatchEventsServer) error {
+	user, err := auth.AuthorizeRequest(req.GetAuth())
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkSandboxExists(user.Namespace); err != nil {
+		return err
+	}
+
+	changed := s.statusFetcher.WatchEvents(stream.Context(), user.Namespace)
+	sent := map[string]bool{}
+	for {
+		evs, err := s.statusFetcher.GetEvents(user.Namespace)
+		if err != nil {
+			return err
+		}
+
+		for _, ev := range evs {
+			if sent[ev.Key] || ev.Event.Time < req.GetSince() {
+				continue
+			}
+
+			if err := stream.Send(&cluster.WatchEventsResponse{Event: ev.Event}); err != nil {
+				return err
+			}
+			sent[ev.Key] = true
+		}
+
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return nil
+		case <-s.shutdown:
+			return errShuttingDown
+		}
+	}
+}
+
+// WatchEvents returns a channel that's notified whenever the events for the
+// namespace may have changed.
+func (sf *statusFetcher) WatchEvents(ctx context.Context, namespace string) chan struct{} {
+	notifier := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case notifier <- struct{}{}:
+		default:
+		}
+	}
+
+	// Container exits are only recorded in the pod status, so pod changes
+	// may also create events.
+	podSub := sf.podWatcher.Watch(ctx, kube.Key{Namespace: namespace})
+	eventsSub := sf.eventsWatcher.Watch(ctx, kube.Key{Namespace: namespace})
+	go func() {
+		for {
+			select {
+			case <-podSub:
+				notify()
+			case <-eventsSub:
+				notify()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return notifier
+}
+
+// GetEvents returns the events for the services in the namespace, sorted by
+// time.
+func (sf *statusFetcher) GetEvents(namespace string) ([]events.Event, error) {
+	pods, err := sf.podLister.
+		Pods(namespace).
+		List(labels.Set(
+			map[string]string{"blimp.customerPod": "true"},
+		).AsSelector())
+	if err != nil {
+		return nil, errors.WithContext("list services", err)
+	}
+
+	var evs []events.Event
+	podsByName := map[string]*corev1.Pod{}
+	for _, pod := range pods {
+		if pod.GetName() == "reservation" {
+			continue
+		}
+		podsByName[pod.Name] = pod
+		evs = append(evs, events.FromPod(pod)...)
+	}
+
+	kubeEvents, err := sf.eventsLister.Events(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, errors.WithContext("list events", err)
+	}
+
+	for _, kubeEvent := range kubeEvents {
+		if kubeEvent.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+
+		// Events for pods that no longer exist can't be attributed to a
+		// service, so they're dropped.
+		pod, ok := podsByName[kubeEvent.InvolvedObject.Name]
+		if !ok {
+			continue
+		}
+
+		if ev, ok := events.FromKube(kubeEvent, pod); ok {
+			evs = append(evs, ev)
+		}
+	}
+
+	sort.SliceStable(evs, func(i, j int) bool {
+		return evs[i].Event.Time < evs[j].Event.Time
+	})
+	return evs, nil
+}
@@ -0,0 +1,132 @@
+// Package events converts Kubernetes events and pod statuses into the
+// lifecycle events shown by `blimp events`.
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// Event is a Blimp event, along with a key that uniquely identifies the
+// occurrence. Callers can use the key to avoid sending the same occurrence
+// twice.
+type Event struct {
+	Key   string
+	Event *cluster.Event
+}
+
+// FromKube converts a Kubernetes event about the given pod. It returns false
+// if the event isn't interesting to users.
+func FromKube(event *corev1.Event, pod *corev1.Pod) (Event, bool) {
+	evType, ok := getType(event)
+	if !ok {
+		return Event{}, false
+	}
+
+	// Events created with the newer events API only set EventTime.
+	timestamp := event.LastTimestamp.Time
+	if timestamp.IsZero() {
+		timestamp = event.EventTime.Time
+	}
+
+	count := event.Count
+	if count == 0 {
+		count = 1
+	}
+
+	return Event{
+		Key: fmt.Sprintf("event/%s/%d", event.UID, count),
+		Event: &cluster.Event{
+			Time:    timestamp.Unix(),
+			Service: pod.Labels["blimp.service"],
+			Type:    evType,
+			Message: event.Message,
+			Warning: event.Type == corev1.EventTypeWarning,
+			Count:   count,
+		},
+	}, true
+}
+
+func getType(event *corev1.Event) (cluster.Event_Type, bool) {
+	// These reasons are defined by the kubelet and scheduler.
+	switch event.Reason {
+	case kube.EventReasonWaiting:
+		return cluster.Event_WAITING, true
+	case "FailedScheduling":
+		return cluster.Event_SCHEDULING_FAILED, true
+	case "Pulling":
+		return cluster.Event_PULLING_IMAGE, true
+	case "Pulled":
+		return cluster.Event_PULLED_IMAGE, true
+	case "Failed", "ErrImageNeverPull", "InspectFailed":
+		if strings.Contains(strings.ToLower(event.Message), "image") {
+			return cluster.Event_PULL_FAILED, true
+		}
+		return cluster.Event_OTHER, true
+	case "BackOff":
+		if strings.Contains(event.Message, "pulling image") {
+			return cluster.Event_PULL_FAILED, true
+		}
+		return cluster.Event_CRASH_LOOP, true
+	case "Started":
+		// Don't show the init containers that Blimp uses internally.
+		if strings.HasPrefix(event.InvolvedObject.FieldPath, "spec.initContainers") {
+			return 0, false
+		}
+		return cluster.Event_STARTED, true
+	case "Unhealthy":
+		return cluster.Event_HEALTHCHECK_FAILED, true
+	case "Killing":
+		return cluster.Event_STOPPING, true
+	}
+
+	// Don't drop unknown warnings, since they might explain why a service
+	// isn't working.
+	if event.Type == corev1.EventTypeWarning {
+		return cluster.Event_OTHER, true
+	}
+	return 0, false
+}
+
+// FromPod returns the events for container exits, which Kubernetes only
+// records in the pod's status.
+func FromPod(pod *corev1.Pod) []Event {
+	var events []Event
+	for _, cs := range pod.Status.ContainerStatuses {
+		for _, terminated := range []*corev1.ContainerStateTerminated{
+			cs.LastTerminationState.Terminated, cs.State.Terminated,
+		} {
+			if terminated == nil {
+				continue
+			}
+
+			evType := cluster.Event_EXITED
+			msg := fmt.Sprintf("Exited with code %d", terminated.ExitCode)
+			if terminated.Reason == "OOMKilled" {
+				evType = cluster.Event_OOM_KILLED
+				msg = "Killed because it ran out of memory"
+			}
+			if terminated.Message != "" {
+				msg += ": " + terminated.Message
+			}
+
+			events = append(events, Event{
+				Key: fmt.Sprintf("pod/%s/%s/%d", pod.UID, cs.Name, terminated.FinishedAt.Unix()),
+				Event: &cluster.Event{
+					Time:    terminated.FinishedAt.Unix(),
+					Service: pod.Labels["blimp.service"],
+					Type:    evType,
+					Message: msg,
+					Warning: evType == cluster.Event_OOM_KILLED || terminated.ExitCode != 0,
+					Count:   1,
+				},
+			})
+		}
+	}
+	return events
+}
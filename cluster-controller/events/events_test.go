@@ -0,0 +1,156 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func TestFromKube(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "web",
+			Labels: map[string]string{"blimp.service": "web"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		event   corev1.Event
+		expType cluster.Event_Type
+		expOK   bool
+	}{
+		{
+			name:    "Pulling",
+			event:   corev1.Event{Reason: "Pulling", Message: "Pulling image \"nginx\""},
+			expType: cluster.Event_PULLING_IMAGE,
+			expOK:   true,
+		},
+		{
+			name: "Pull backoff",
+			event: corev1.Event{Reason: "BackOff", Type: corev1.EventTypeWarning,
+				Message: "Back-off pulling image \"nginx\""},
+			expType: cluster.Event_PULL_FAILED,
+			expOK:   true,
+		},
+		{
+			name: "Crash loop",
+			event: corev1.Event{Reason: "BackOff", Type: corev1.EventTypeWarning,
+				Message: "Back-off restarting failed container"},
+			expType: cluster.Event_CRASH_LOOP,
+			expOK:   true,
+		},
+		{
+			name:    "Waiting",
+			event:   corev1.Event{Reason: kube.EventReasonWaiting, Message: "pod db is not ready"},
+			expType: cluster.Event_WAITING,
+			expOK:   true,
+		},
+		{
+			name: "Init container started",
+			event: corev1.Event{Reason: "Started", InvolvedObject: corev1.ObjectReference{
+				FieldPath: "spec.initContainers{wait-depends-on}",
+			}},
+		},
+		{
+			name:  "Uninteresting normal event",
+			event: corev1.Event{Reason: "Scheduled", Type: corev1.EventTypeNormal},
+		},
+		{
+			name:    "Unknown warning",
+			event:   corev1.Event{Reason: "FailedMount", Type: corev1.EventTypeWarning},
+			expType: cluster.Event_OTHER,
+			expOK:   true,
+		},
+	}
+
+	for _, test := range tests {
+		event := test.event
+		event.LastTimestamp = metav1.Unix(100, 0)
+		ev, ok := FromKube(&event, pod)
+		assert.Equal(t, test.expOK, ok, test.name)
+		if !test.expOK {
+			continue
+		}
+
+		assert.Equal(t, test.expType, ev.Event.Type, test.name)
+		assert.Equal(t, "web", ev.Event.Service, test.name)
+		assert.Equal(t, int64(100), ev.Event.Time, test.name)
+		assert.Equal(t, test.event.Type == corev1.EventTypeWarning, ev.Event.Warning, test.name)
+	}
+}
+
+func TestFromKubeCount(t *testing.T) {
+	pod := &corev1.Pod{}
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{UID: "uid"},
+		Reason:     "Unhealthy",
+		Count:      1,
+	}
+
+	first, ok := FromKube(event, pod)
+	assert.True(t, ok)
+
+	// Repeated occurrences must have a different key so that they're sent
+	// again.
+	event.Count = 2
+	second, ok := FromKube(event, pod)
+	assert.True(t, ok)
+	assert.NotEqual(t, first.Key, second.Key)
+	assert.Equal(t, int32(2), second.Event.Count)
+}
+
+func TestFromPod(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "web",
+			UID:    "uid",
+			Labels: map[string]string{"blimp.service": "web"},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "web",
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason:     "OOMKilled",
+							ExitCode:   137,
+							FinishedAt: metav1.Unix(100, 0),
+						},
+					},
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Reason:     "Completed",
+							ExitCode:   0,
+							FinishedAt: metav1.Unix(200, 0),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	evs := FromPod(pod)
+	assert.Len(t, evs, 2)
+	assert.Equal(t, &cluster.Event{
+		Time:    100,
+		Service: "web",
+		Type:    cluster.Event_OOM_KILLED,
+		Message: "Killed because it ran out of memory",
+		Warning: true,
+		Count:   1,
+	}, evs[0].Event)
+	assert.Equal(t, &cluster.Event{
+		Time:    200,
+		Service: "web",
+		Type:    cluster.Event_EXITED,
+		Message: "Exited with code 0",
+		Count:   1,
+	}, evs[1].Event)
+	assert.NotEqual(t, evs[0].Key, evs[1].Key)
+}
@@ -51,6 +51,7 @@ func validateServices(services types.Services) []string {
 		{ID: ".Entrypoint"},
 		{ID: ".Extends"},
 		{ID: ".DependsOn"},
+		{ID: ".Deploy.Replicas"},
 		{ID: ".Environment"},
 		{ID: ".EnvFile"},
 		{ID: ".ExtraHosts"},
@@ -64,12 +65,29 @@ func validateServices(services types.Services) []string {
 		{ID: ".Ports.Published"},
 		{ID: ".Ports.Protocol", AllowedValues: []interface{}{"tcp"}},
 		{ID: ".Ports.Mode", AllowedValues: []interface{}{"ingress"}},
-		{ID: ".Restart", AllowedValues: []interface{}{"no", "always", "unless-stopped", "on-failure"}},
+		{ID: ".Restart", IsSupported: func(intf interface{}) bool {
+			restart, ok := intf.(string)
+			if !ok {
+				return false
+			}
+			switch restart {
+			case "no", "always", "unless-stopped", "on-failure":
+				return true
+			}
+
+			// Check for on-failure with a maximum number of retries.
+			_, maxRestarts, err := parseRestartPolicy(restart)
+			return err == nil && maxRestarts > 0
+		}},
 		{ID: ".StdinOpen"},
+		{ID: ".Tmpfs"},
 		{ID: ".Tty"},
-		{ID: ".Volumes.Type", AllowedValues: []interface{}{types.VolumeTypeBind, types.VolumeTypeVolume}},
+		{ID: ".Volumes.Type", AllowedValues: []interface{}{
+			types.VolumeTypeBind, types.VolumeTypeVolume, types.VolumeTypeTmpfs}},
 		{ID: ".Volumes.Source"},
 		{ID: ".Volumes.Target"},
+		{ID: ".Volumes.ReadOnly"},
+		{ID: ".Volumes.Tmpfs.Size"},
 		{ID: ".WorkingDir"},
 		{ID: ".User"},
 
@@ -172,10 +190,18 @@ type field struct {
 
 	// ALl values are allowed if empty.
 	AllowedValues []interface{}
+
+	// IsSupported is used instead of AllowedValues for fields whose supported
+	// values can't be enumerated.
+	IsSupported func(interface{}) bool
 }
 
 // Supports returns whether the given value for the field is supported by Blimp.
 func (f field) Supports(intf interface{}) bool {
+	if f.IsSupported != nil {
+		return f.IsSupported(intf)
+	}
+
 	if len(f.AllowedValues) == 0 {
 		return true
 	}
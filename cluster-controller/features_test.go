@@ -46,6 +46,23 @@ func TestValidateFeatures(t *testing.T) {
 			exp: []string{"Service.ReadOnly"},
 		},
 
+		// Replicas are supported, but the other deploy options aren't.
+		{
+			cfg: types.Project{
+				Services: types.Services([]types.ServiceConfig{
+					{
+						Name:  "test",
+						Image: "alpine",
+						Deploy: &types.DeployConfig{
+							Mode:     "replicated",
+							Replicas: uint64Ptr(3),
+						},
+					},
+				}),
+			},
+			exp: []string{"Service.Deploy.Mode"},
+		},
+
 		// Using a supported value for ports.
 		{
 			cfg: types.Project{
@@ -78,6 +95,34 @@ func TestValidateFeatures(t *testing.T) {
 			exp: []string{"Service.Ports.Protocol"},
 		},
 
+		// Using on-failure with a maximum number of retries.
+		{
+			cfg: types.Project{
+				Services: types.Services([]types.ServiceConfig{
+					{
+						Name:    "test",
+						Image:   "alpine",
+						Restart: "on-failure:3",
+					},
+				}),
+			},
+			exp: nil,
+		},
+
+		// Using a malformed restart policy.
+		{
+			cfg: types.Project{
+				Services: types.Services([]types.ServiceConfig{
+					{
+						Name:    "test",
+						Image:   "alpine",
+						Restart: "on-failure:never",
+					},
+				}),
+			},
+			exp: []string{"Service.Restart"},
+		},
+
 		// Using a supported field in volumes.
 		{
 			cfg: types.Project{
@@ -160,3 +205,7 @@ func TestValidateFeatures(t *testing.T) {
 		assert.Equal(t, test.exp, main.GetUnsupportedFeatures(test.cfg))
 	}
 }
+
+func uint64Ptr(x uint64) *uint64 {
+	return &x
+}
@@ -0,0 +1,468 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedCoreV1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cluster-controller/node"
+	"github.com/kelda/blimp/cluster-controller/sandbox"
+	nodeController "github.com/kelda/blimp/node/controller"
+	"github.com/kelda/blimp/node/wait"
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/cfgdir"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/kubewait"
+	protoAuth "github.com/kelda/blimp/pkg/proto/auth"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+	protoNode "github.com/kelda/blimp/pkg/proto/node"
+)
+
+const (
+	testNodeName = "node"
+	testUsername = "test-user"
+)
+
+// testCluster runs the cluster manager and node controller in-process against
+// a fake Kubernetes API. The parts of Kubernetes that would normally boot
+// pods are simulated by a clusterSimulator. The CLI's manager client is
+// pointed at the in-process cluster manager, so that CLI commands can be run
+// with runCLI.
+type testCluster struct {
+	t *testing.T
+
+	kubeClient  testKubeClient
+	server      *server
+	sim         *clusterSimulator
+	syncTracker *wait.SyncTracker
+
+	auth      *protoAuth.BlimpAuth
+	namespace string
+
+	stopFuncs []func()
+}
+
+func newTestCluster(t *testing.T) *testCluster {
+	tc := &testCluster{
+		t:    t,
+		auth: &protoAuth.BlimpAuth{Token: testUsername},
+	}
+
+	user, err := auth.ParseIDToken(testUsername)
+	require.NoError(t, err)
+	tc.namespace = user.Namespace
+
+	tc.setupConfigDir()
+
+	// The API server serves the requests that the fake clientset can't, such
+	// as the CLI's requests for logs. Everything else uses the fake
+	// clientset.
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(tc.serveKubeAPI))
+	tc.onStop(apiServer.Close)
+	caCrt := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: apiServer.Certificate().Raw,
+	})
+
+	// Reserve the node controller's port so that it can be assigned to the
+	// node controller's NodePort service.
+	nodeControllerListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	nodePort := nodeControllerListener.Addr().(*net.TCPAddr).Port
+
+	apiServerConfig := &rest.Config{
+		Host:            apiServer.URL,
+		TLSClientConfig: rest.TLSClientConfig{CAData: caCrt},
+	}
+	apiServerClient, err := kubernetes.NewForConfig(apiServerConfig)
+	require.NoError(t, err)
+
+	tc.kubeClient = newTestKubeClient(apiServerClient.CoreV1().RESTClient(), &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testNodeName,
+			Annotations: map[string]string{
+				kube.NodePublicAddressAnnotation: "127.0.0.1",
+			},
+		},
+	})
+	tc.sim = newClusterSimulator(tc.kubeClient, testNodeName, caCrt, int32(nodePort))
+	tc.sim.Start()
+
+	RegistryHostname = "registry.blimp.test"
+	LinkProxyBaseHostname = "blimp.test"
+	tc.server = newServer(tc.kubeClient, newFakeDynamicClient(), apiServerConfig, 100)
+	tc.server.useNodePort = true
+
+	leaderCtx, stopLeading := context.WithCancel(context.Background())
+	leaderElectionDone := make(chan struct{})
+	go func() {
+		runLeaderElection(leaderCtx, tc.kubeClient, "test-manager", tc.server.runSingletons)
+		close(leaderElectionDone)
+	}()
+	tc.onStop(func() {
+		stopLeading()
+		<-leaderElectionDone
+	})
+
+	tc.startNodeController(nodeControllerListener)
+	tc.startManager()
+	return tc
+}
+
+// Stop shuts down the servers started by the test cluster.
+func (tc *testCluster) Stop() {
+	for i := len(tc.stopFuncs) - 1; i >= 0; i-- {
+		tc.stopFuncs[i]()
+	}
+}
+
+func (tc *testCluster) onStop(f func()) {
+	tc.stopFuncs = append(tc.stopFuncs, f)
+}
+
+// setupConfigDir creates a Blimp config directory for the CLI that's logged
+// in as the test user, and an empty Docker config directory.
+func (tc *testCluster) setupConfigDir() {
+	configDir, err := ioutil.TempDir("", "blimp-test")
+	require.NoError(tc.t, err)
+	tc.onStop(func() { os.RemoveAll(configDir) })
+
+	err = ioutil.WriteFile(filepath.Join(configDir, "auth.yaml"),
+		[]byte(fmt.Sprintf("username: %s\n", testUsername)), 0600)
+	require.NoError(tc.t, err)
+
+	oldConfigDir := cfgdir.ConfigDir
+	cfgdir.ConfigDir = configDir
+	tc.onStop(func() { cfgdir.ConfigDir = oldConfigDir })
+
+	oldDockerConfig, hadDockerConfig := os.LookupEnv("DOCKER_CONFIG")
+	require.NoError(tc.t, os.Setenv("DOCKER_CONFIG", filepath.Join(configDir, "docker")))
+	tc.onStop(func() {
+		if hadDockerConfig {
+			os.Setenv("DOCKER_CONFIG", oldDockerConfig)
+		} else {
+			os.Unsetenv("DOCKER_CONFIG")
+		}
+	})
+}
+
+// startNodeController serves the node controller on the given listener once
+// the node controller booter has generated its certificate.
+func (tc *testCluster) startNodeController(lis net.Listener) {
+	secretsClient := tc.kubeClient.CoreV1().Secrets(node.NodeControllerNamespace)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err := kubewait.WaitForObject(ctx,
+		func() (interface{}, error) {
+			return secretsClient.Get(node.CertSecretName(testNodeName), metav1.GetOptions{})
+		},
+		secretsClient.Watch,
+		func(interface{}) bool { return true })
+	require.NoError(tc.t, err, "wait for node controller certificate")
+
+	certSecret, err := secretsClient.Get(node.CertSecretName(testNodeName), metav1.GetOptions{})
+	require.NoError(tc.t, err)
+	cert, err := tls.X509KeyPair(certSecret.Data["cert.pem"], certSecret.Data["key.pem"])
+	require.NoError(tc.t, err)
+
+	// The node controller shares the cluster manager's informers so that
+	// once the cluster manager reports a pod's status, the node controller is
+	// guaranteed to know about it as well.
+	tc.syncTracker = wait.NewSyncTracker()
+	s := nodeController.New(tc.kubeClient, tc.server.restConfig, tc.syncTracker,
+		tc.server.statusFetcher.podLister, tc.server.statusFetcher.namespaceLister)
+
+	grpcServer := grpc.NewServer(
+		grpc.Creds(credentials.NewServerTLSFromCert(&cert)),
+		grpc.UnaryInterceptor(errors.UnaryServerInterceptor))
+	protoNode.RegisterControllerServer(grpcServer, s)
+	go grpcServer.Serve(lis)
+	tc.onStop(grpcServer.Stop)
+}
+
+// startManager serves the cluster manager's gRPC API, and points the CLI's
+// manager client at it.
+func (tc *testCluster) startManager() {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(tc.t, err)
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(errors.UnaryServerInterceptor))
+	cluster.RegisterManagerServer(grpcServer, tc.server)
+	go grpcServer.Serve(lis)
+	tc.onStop(grpcServer.Stop)
+
+	conn, err := grpc.Dial(lis.Addr().String(),
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(errors.UnaryClientInterceptor))
+	require.NoError(tc.t, err)
+	tc.onStop(func() { conn.Close() })
+
+	oldClient := manager.C
+	manager.C = manager.Client{
+		ManagerClient: cluster.NewManagerClient(conn),
+		ClientConn:    conn,
+	}
+	tc.onStop(func() { manager.C = oldClient })
+}
+
+// serveKubeAPI implements the endpoints of the Kubernetes API that can't be
+// faked by the fake clientset: pod logs, and kubelet stats.
+func (tc *testCluster) serveKubeAPI(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	// /api/v1/namespaces/NAMESPACE/pods/POD/log
+	case len(parts) == 7 && parts[2] == "namespaces" && parts[4] == "pods" && parts[6] == "log":
+		timestamps := r.URL.Query().Get("timestamps") == "true"
+		for _, line := range tc.sim.GetLogs(parts[3], parts[5]) {
+			if !timestamps {
+				line = strings.SplitN(line, " ", 2)[1]
+			}
+			fmt.Fprintln(w, line)
+		}
+
+	// /api/v1/nodes/NODE/proxy/stats/summary
+	case len(parts) == 7 && parts[2] == "nodes" && parts[4] == "proxy" && parts[5] == "stats" &&
+		parts[6] == "summary":
+		fmt.Fprintln(w, `{"pods": []}`)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// testKubeClient is a fake clientset whose core REST client sends requests to
+// a real API server. The fake clientset doesn't implement the requests that
+// are made directly through the REST client, or pod logs.
+type testKubeClient struct {
+	*fakeKube.Clientset
+	coreRESTClient rest.Interface
+}
+
+func newTestKubeClient(coreRESTClient rest.Interface, objects ...runtime.Object) testKubeClient {
+	clientset := fakeKube.NewSimpleClientset(objects...)
+
+	// The fake clientset doesn't generate names, which is relied on by
+	// one-off pods.
+	var lock sync.Mutex
+	var lastSuffix int
+	clientset.PrependReactor("create", "pods",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			if pod.Name == "" && pod.GenerateName != "" {
+				lock.Lock()
+				lastSuffix++
+				pod.Name = fmt.Sprintf("%s%05d", pod.GenerateName, lastSuffix)
+				lock.Unlock()
+			}
+			return false, nil, nil
+		})
+	return testKubeClient{Clientset: clientset, coreRESTClient: coreRESTClient}
+}
+
+func (c testKubeClient) CoreV1() typedCoreV1.CoreV1Interface {
+	return testCoreV1Client{c.Clientset.CoreV1(), c.coreRESTClient}
+}
+
+type testCoreV1Client struct {
+	typedCoreV1.CoreV1Interface
+	restClient rest.Interface
+}
+
+func (c testCoreV1Client) RESTClient() rest.Interface {
+	return c.restClient
+}
+
+func (c testCoreV1Client) Pods(namespace string) typedCoreV1.PodInterface {
+	return testPodClient{c.CoreV1Interface.Pods(namespace), c.restClient, namespace}
+}
+
+// testPodClient reads pod logs from the API server, the same way as the real
+// clientset.
+type testPodClient struct {
+	typedCoreV1.PodInterface
+	restClient rest.Interface
+	namespace  string
+}
+
+func (c testPodClient) GetLogs(name string, opts *corev1.PodLogOptions) *rest.Request {
+	return c.restClient.Get().
+		Namespace(c.namespace).
+		Name(name).
+		Resource("pods").
+		SubResource("log").
+		VersionedParams(opts, scheme.ParameterCodec)
+}
+
+// createSandbox boots the sandbox's system pods, as done by `blimp up`.
+func (tc *testCluster) createSandbox(composeFile string, syncedFolders map[string]string) *cluster.CreateSandboxResponse {
+	resp, err := manager.C.CreateSandbox(context.Background(), &cluster.CreateSandboxRequest{
+		Auth:          tc.auth,
+		ComposeFile:   composeFile,
+		SyncedFolders: syncedFolders,
+	})
+	require.NoError(tc.t, err)
+	return resp
+}
+
+// deploy deploys the Compose file, as done by `blimp up`.
+func (tc *testCluster) deploy(composeFile string) {
+	_, err := manager.C.DeployToSandbox(context.Background(), &cluster.DeployRequest{
+		Auth:        tc.auth,
+		ComposeFile: composeFile,
+	})
+	require.NoError(tc.t, err)
+}
+
+// waitForStatus blocks until the sandbox's status satisfies `cond`, and
+// returns the status.
+func (tc *testCluster) waitForStatus(cond func(*cluster.SandboxStatus) bool) *cluster.SandboxStatus {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stream, err := manager.C.WatchStatus(ctx, &cluster.GetStatusRequest{Auth: tc.auth})
+	require.NoError(tc.t, err)
+
+	for {
+		msg, err := stream.Recv()
+		require.NoError(tc.t, err, "wait for status")
+		if cond(msg.GetStatus()) {
+			return msg.GetStatus()
+		}
+	}
+}
+
+// waitForServicePhase blocks until the given services are in `phase`.
+func (tc *testCluster) waitForServicePhase(phase cluster.ServicePhase, services ...string) *cluster.SandboxStatus {
+	return tc.waitForStatus(func(status *cluster.SandboxStatus) bool {
+		for _, svc := range services {
+			if status.GetServices()[svc].GetPhase() != phase {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// cliLock serializes CLI commands, since they write to the global stdout.
+var cliLock sync.Mutex
+
+// runCLI runs the given CLI command, and returns what it printed to stdout.
+func (tc *testCluster) runCLI(cmd *cobra.Command, args ...string) string {
+	cliLock.Lock()
+	defer cliLock.Unlock()
+
+	r, w, err := os.Pipe()
+	require.NoError(tc.t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		output <- buf.String()
+	}()
+
+	cmd.SetArgs(args)
+	execErr := cmd.Execute()
+	w.Close()
+	require.NoError(tc.t, execErr)
+	return <-output
+}
+
+// newFakeDynamicClient returns a fake dynamic client that can be used by the
+// Sandbox controller.
+func newFakeDynamicClient() *fakeDynamic.FakeDynamicClient {
+	// The informers list objects as UnstructuredLists, which the fake client
+	// only returns if the List type is registered.
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{
+		Group:   "fake-dynamic-client-group",
+		Version: "v1",
+		Kind:    "List",
+	}, &unstructured.UnstructuredList{})
+	client := fakeDynamic.NewSimpleDynamicClient(scheme)
+
+	// The API server bumps the Sandbox's generation whenever its spec
+	// changes, and only updates its status through the status subresource.
+	// The fake client does neither, so emulate them by tracking the last
+	// version of each Sandbox.
+	var lock sync.Mutex
+	sandboxes := map[string]*unstructured.Unstructured{}
+	client.PrependReactor("create", sandbox.Plural,
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			obj := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+			obj.SetGeneration(1)
+
+			lock.Lock()
+			sandboxes[obj.GetNamespace()+"/"+obj.GetName()] = obj.DeepCopy()
+			lock.Unlock()
+			return false, nil, nil
+		})
+	client.PrependReactor("update", sandbox.Plural,
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			obj := action.(k8stesting.UpdateAction).GetObject().(*unstructured.Unstructured)
+			key := obj.GetNamespace() + "/" + obj.GetName()
+
+			lock.Lock()
+			defer lock.Unlock()
+			prev, ok := sandboxes[key]
+			if !ok {
+				return false, nil, nil
+			}
+
+			if action.GetSubresource() == "status" {
+				obj.Object["spec"] = prev.Object["spec"]
+				obj.SetGeneration(prev.GetGeneration())
+			} else {
+				generation := prev.GetGeneration()
+				if !equality.Semantic.DeepEqual(prev.Object["spec"], obj.Object["spec"]) {
+					generation++
+				}
+				obj.SetGeneration(generation)
+				if status, ok := prev.Object["status"]; ok {
+					obj.Object["status"] = status
+				} else {
+					delete(obj.Object, "status")
+				}
+			}
+			sandboxes[key] = obj.DeepCopy()
+			return false, nil, nil
+		})
+	return client
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// maxHealthLogLength is the number of healthcheck results reported for each
+// service. It matches the number of results kept by Docker.
+const maxHealthLogLength = 5
+
+// readinessProbeFailedPrefix is the prefix of the message in the events the
+// kubelet creates when a readiness probe fails. The rest of the message is
+// the probe's output.
+const readinessProbeFailedPrefix = "Readiness probe failed: "
+
+// getHealthLog returns the most recent healthcheck results for the pod.
+// Kubernetes only creates events for failed checks, so successful checks are
+// inferred from when the pod last became ready.
+func (sf *statusFetcher) getHealthLog(pod *corev1.Pod) []*cluster.HealthCheckResult {
+	if len(pod.Spec.Containers) == 0 || pod.Spec.Containers[0].ReadinessProbe == nil {
+		return nil
+	}
+
+	events, err := sf.getPodEvents(pod)
+	if err != nil {
+		log.WithError(err).Warn("Failed to get events")
+		return nil
+	}
+
+	var results []*cluster.HealthCheckResult
+	for _, event := range events {
+		if event.Reason != "Unhealthy" ||
+			!strings.HasPrefix(event.Message, readinessProbeFailedPrefix) {
+			continue
+		}
+
+		results = append(results, &cluster.HealthCheckResult{
+			Time:   event.LastTimestamp.Unix(),
+			Output: strings.TrimPrefix(event.Message, readinessProbeFailedPrefix),
+			Count:  event.Count,
+		})
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.ContainersReady && cond.Status == corev1.ConditionTrue {
+			results = append(results, &cluster.HealthCheckResult{
+				Time:    cond.LastTransitionTime.Unix(),
+				Healthy: true,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Time < results[j].Time
+	})
+	if len(results) > maxHealthLogLength {
+		results = results[len(results)-maxHealthLogLength:]
+	}
+	return results
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// imagesInUse returns the images used by all pods, including the saved pods
+// of stopped services. It's used by the registry garbage collector to avoid
+// deleting images that are still needed.
+func (s *server) imagesInUse() ([]string, error) {
+	pods, err := s.statusFetcher.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, errors.WithContext("list pods", err)
+	}
+
+	configMaps, err := s.statusFetcher.stoppedLister.List(labels.Everything())
+	if err != nil {
+		return nil, errors.WithContext("list stopped services", err)
+	}
+
+	for _, configMap := range configMaps {
+		var pod corev1.Pod
+		if err := json.Unmarshal(configMap.BinaryData[stoppedPodKey], &pod); err != nil {
+			return nil, errors.WithContext("parse stopped pod", err)
+		}
+		pods = append(pods, &pod)
+	}
+
+	var images []string
+	for _, pod := range pods {
+		for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			images = append(images, c.Image)
+		}
+	}
+	return images, nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/kelda/blimp/pkg/kube"
+)
+
+const (
+	// leaderLeaseName is the name of the Lease object used to elect the
+	// replica of the cluster manager that runs the singleton controllers.
+	leaderLeaseName = "cluster-manager"
+
+	// leaseDuration is how long other replicas wait before taking over the
+	// lease after the leader stops renewing it.
+	leaseDuration = 15 * time.Second
+
+	// renewDeadline is how long the leader keeps retrying to renew the lease
+	// before it gives up leadership.
+	renewDeadline = 10 * time.Second
+
+	// retryPeriod is how often replicas try to acquire or renew the lease.
+	retryPeriod = 2 * time.Second
+)
+
+// runLeaderElection blocks until `ctx` is cancelled, and calls `lead` whenever
+// this replica becomes the leader. The channel passed to `lead` is closed when
+// the replica stops leading. Once `ctx` is cancelled, the lease is released as
+// soon as `lead` returns so that another replica can take over immediately.
+// `lead` should block until the singletons have finished their in-flight
+// work, so that they never run on two replicas at once.
+//
+// If the replica loses the lease for any other reason, such as failing to
+// renew it in time, the process exits so that the singletons are guaranteed
+// not to be running on two replicas at once.
+func runLeaderElection(ctx context.Context, kubeClient kubernetes.Interface,
+	identity string, lead func(stop <-chan struct{})) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Namespace: kube.BlimpNamespace,
+			Name:      leaderLeaseName,
+		},
+		Client: kubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	// The lease is released when the election's context is cancelled, rather
+	// than when OnStartedLeading returns. So the election gets its own
+	// context, which is only cancelled once `lead` has returned.
+	electionCtx, cancelElection := context.WithCancel(context.Background())
+	defer cancelElection()
+
+	var leadingLock sync.Mutex
+	var leading bool
+	go func() {
+		<-ctx.Done()
+
+		leadingLock.Lock()
+		defer leadingLock.Unlock()
+		if !leading {
+			cancelElection()
+		}
+	}()
+
+	leaderelection.RunOrDie(electionCtx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				leadingLock.Lock()
+				if ctx.Err() != nil {
+					leadingLock.Unlock()
+					return
+				}
+				leading = true
+				leadingLock.Unlock()
+				defer cancelElection()
+
+				stop := make(chan struct{})
+				go func() {
+					select {
+					case <-ctx.Done():
+					case <-leaderCtx.Done():
+					}
+					close(stop)
+				}()
+
+				log.WithField("identity", identity).Info("Became leader. Starting singleton controllers")
+				lead(stop)
+				<-stop
+			},
+			OnStoppedLeading: func() {
+				if ctx.Err() != nil {
+					log.WithField("identity", identity).Info("Stopped leader election")
+					return
+				}
+				log.WithField("identity", identity).Fatal("Lost leadership")
+			},
+			OnNewLeader: func(leader string) {
+				if leader != identity {
+					log.WithField("leader", leader).Info("Following leader")
+				}
+			},
+		},
+	})
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kelda/blimp/pkg/kube"
+)
+
+func TestLeaderElectionFailover(t *testing.T) {
+	kubeClient := fakeKube.NewSimpleClientset()
+
+	type replica struct {
+		cancel  context.CancelFunc
+		done    chan struct{}
+		leading chan (<-chan struct{})
+	}
+	start := func(identity string) replica {
+		ctx, cancel := context.WithCancel(context.Background())
+		r := replica{
+			cancel:  cancel,
+			done:    make(chan struct{}),
+			leading: make(chan (<-chan struct{}), 1),
+		}
+		go func() {
+			runLeaderElection(ctx, kubeClient, identity, func(stop <-chan struct{}) {
+				r.leading <- stop
+			})
+			close(r.done)
+		}()
+		return r
+	}
+
+	a := start("a")
+	defer a.cancel()
+
+	var aStop <-chan struct{}
+	select {
+	case aStop = <-a.leading:
+	case <-time.After(10 * time.Second):
+		t.Fatal("first replica never became leader")
+	}
+
+	// The second replica shouldn't lead while the first holds the lease.
+	b := start("b")
+	defer b.cancel()
+	select {
+	case <-b.leading:
+		t.Fatal("both replicas are leading")
+	case <-time.After(2 * retryPeriod):
+	}
+
+	// Shutting down the leader should stop its singletons, and release the
+	// lease so that the other replica takes over without waiting for the
+	// lease to expire.
+	a.cancel()
+	select {
+	case <-aStop:
+	case <-time.After(10 * time.Second):
+		t.Fatal("singletons weren't stopped")
+	}
+	<-a.done
+
+	select {
+	case <-b.leading:
+	case <-time.After(leaseDuration):
+		t.Fatal("second replica never took over")
+	}
+
+	lease, err := kubeClient.CoordinationV1().Leases(kube.BlimpNamespace).Get(leaderLeaseName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "b", *lease.Spec.HolderIdentity)
+}
+
+func TestLeaseHeldUntilSingletonsStop(t *testing.T) {
+	kubeClient := fakeKube.NewSimpleClientset()
+	getHolder := func() string {
+		lease, err := kubeClient.CoordinationV1().Leases(kube.BlimpNamespace).
+			Get(leaderLeaseName, metav1.GetOptions{})
+		require.NoError(t, err)
+		return *lease.Spec.HolderIdentity
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leading := make(chan struct{})
+	finishWork := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		runLeaderElection(ctx, kubeClient, "a", func(stop <-chan struct{}) {
+			close(leading)
+			<-stop
+			<-finishWork
+		})
+		close(done)
+	}()
+
+	select {
+	case <-leading:
+	case <-time.After(10 * time.Second):
+		t.Fatal("never became leader")
+	}
+
+	// The lease shouldn't be released while the singletons are finishing
+	// their in-flight work.
+	cancel()
+	select {
+	case <-done:
+		t.Fatal("leader election stopped before the singletons")
+	case <-time.After(retryPeriod):
+	}
+	assert.Equal(t, "a", getHolder())
+
+	close(finishWork)
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("leader election never stopped")
+	}
+	assert.Empty(t, getHolder())
+}
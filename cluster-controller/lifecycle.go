@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// drainDelay is how long the cluster manager keeps accepting new requests
+	// after it starts shutting down. This gives Kubernetes time to notice that
+	// the replica is no longer ready, and stop routing traffic to it.
+	drainDelay = 5 * time.Second
+
+	// shutdownTimeout is the maximum amount of time to wait for in-flight
+	// requests to finish before forcibly closing them. Deploys can block until
+	// the sandbox is reconciled, so this matches the reconcile timeout. The
+	// pod's terminationGracePeriodSeconds should be at least
+	// drainDelay + shutdownTimeout.
+	shutdownTimeout = sandboxReconcileTimeout
+)
+
+// errShuttingDown is returned by streaming RPCs when the cluster manager is
+// shutting down. The CLI reconnects when the stream fails, so the stream gets
+// picked up by another replica.
+var errShuttingDown = status.New(codes.Unavailable, "cluster manager is shutting down").Err()
+
+// isShuttingDown returns whether the server has started shutting down.
+func (s *server) isShuttingDown() bool {
+	select {
+	case <-s.shutdown:
+		return true
+	default:
+		return false
+	}
+}
+
+// newHealthServer creates the server for the Kubernetes liveness and
+// readiness probes. The replica stops being ready once it starts shutting
+// down so that it's removed from the Service's endpoints.
+func (s *server) newHealthServer(port int) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if s.isShuttingDown() {
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+
+	return &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: mux,
+	}
+}
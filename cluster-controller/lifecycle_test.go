@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func TestShutdown(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	tc.createSandbox(loadComposeFile(t, testComposeFile), nil)
+
+	healthServer := httptest.NewServer(tc.server.newHealthServer(0).Handler)
+	defer healthServer.Close()
+	probe := func(path string) int {
+		resp, err := http.Get(healthServer.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusOK, probe("/healthz"))
+	assert.Equal(t, http.StatusOK, probe("/readyz"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	stream, err := manager.C.WatchStatus(ctx, &cluster.GetStatusRequest{Auth: tc.auth})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	// Shutting down should end the watch so that the CLI reconnects to
+	// another replica, and take the replica out of rotation.
+	close(tc.server.shutdown)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err), err)
+
+	assert.Equal(t, http.StatusOK, probe("/healthz"))
+	assert.Equal(t, http.StatusServiceUnavailable, probe("/readyz"))
+}
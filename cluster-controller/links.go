@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/cluster-controller/node"
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// exposedLinkCache caches the connection information for the node controllers
+// that serve exposed links. The link proxy resolves links for every new
+// connection, so we avoid hitting the Kubernetes API each time.
+type exposedLinkCache struct {
+	kubeClient kubernetes.Interface
+
+	mutex   sync.Mutex
+	entries map[string]exposedLinkCacheEntry
+}
+
+type exposedLinkCacheEntry struct {
+	// nodeName is the node that the sandbox was scheduled on when the entry
+	// was created. If the sandbox moves to a different node, the entry is
+	// stale.
+	nodeName    string
+	nodeAddress string
+	nodeCert    string
+}
+
+func newExposedLinkCache(kubeClient kubernetes.Interface) *exposedLinkCache {
+	return &exposedLinkCache{
+		kubeClient: kubeClient,
+		entries:    map[string]exposedLinkCacheEntry{},
+	}
+}
+
+// get returns the address and certificate of the node controller running on
+// `nodeName`, which is serving links for `namespace`.
+func (c *exposedLinkCache) get(ctx context.Context, namespace, nodeName string) (
+	addr, cert string, err error) {
+	c.mutex.Lock()
+	entry, ok := c.entries[namespace]
+	c.mutex.Unlock()
+	if ok && entry.nodeName == nodeName {
+		return entry.nodeAddress, entry.nodeCert, nil
+	}
+
+	addr, cert, err = node.GetConnectionInfo(ctx, c.kubeClient, nodeName)
+	if err != nil {
+		return "", "", errors.WithContext("get node connection info", err)
+	}
+
+	c.mutex.Lock()
+	c.entries[namespace] = exposedLinkCacheEntry{
+		nodeName:    nodeName,
+		nodeAddress: addr,
+		nodeCert:    cert,
+	}
+	c.mutex.Unlock()
+	return addr, cert, nil
+}
+
+// invalidate removes any cached information for `namespace`. It should be
+// called whenever the namespace's links are removed.
+//
+// Each replica of the cluster manager has its own cache, so this only affects
+// the replica that handled the request. That's safe since the cache only
+// holds the node controller's connection information. Whether the link still
+// exists is checked against the namespace's annotation on every resolve.
+func (c *exposedLinkCache) invalidate(namespace string) {
+	c.mutex.Lock()
+	delete(c.entries, namespace)
+	c.mutex.Unlock()
+}
@@ -9,8 +9,12 @@ import (
 	"math/big"
 	"net"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/Masterminds/semver"
@@ -20,6 +24,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	composeTypes "github.com/kelda/compose-go/types"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +33,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
@@ -35,16 +41,18 @@ import (
 	"github.com/kelda/blimp/cluster-controller/affinity"
 	"github.com/kelda/blimp/cluster-controller/httpapi"
 	"github.com/kelda/blimp/cluster-controller/node"
+	"github.com/kelda/blimp/cluster-controller/registry"
+	"github.com/kelda/blimp/cluster-controller/sandbox"
 	"github.com/kelda/blimp/cluster-controller/volume"
 	"github.com/kelda/blimp/pkg/auth"
 	clusterAuth "github.com/kelda/blimp/pkg/auth"
 	"github.com/kelda/blimp/pkg/dockercompose"
 	"github.com/kelda/blimp/pkg/errors"
 	"github.com/kelda/blimp/pkg/expose"
+	"github.com/kelda/blimp/pkg/hostgateway"
 	"github.com/kelda/blimp/pkg/kube"
 	"github.com/kelda/blimp/pkg/kubewait"
 	"github.com/kelda/blimp/pkg/metadata"
-	"github.com/kelda/blimp/pkg/names"
 	"github.com/kelda/blimp/pkg/ports"
 	protoAuth "github.com/kelda/blimp/pkg/proto/auth"
 	"github.com/kelda/blimp/pkg/proto/cluster"
@@ -61,10 +69,20 @@ import (
 
 type server struct {
 	kubeClient        kubernetes.Interface
+	dynamicClient     dynamic.Interface
 	restConfig        *rest.Config
+	sandboxes         *sandboxController
 	statusFetcher     *statusFetcher
+	exposedLinks      *exposedLinkCache
 	certPath, keyPath string
 	maxSandboxes      int
+	useNodePort       bool
+
+	// registryGC is nil if the registry admin credentials aren't configured.
+	registryGC *registry.Collector
+
+	// shutdown is closed when the server starts shutting down.
+	shutdown chan struct{}
 }
 
 var (
@@ -75,6 +93,18 @@ var (
 	// LinkProxyBaseHostname is the base hostname for Blimp preview links. It
 	// should match base hostname used in the link proxy.
 	LinkProxyBaseHostname string
+
+	// RegistryMirrorHostname is the hostname of the pull-through mirror for
+	// Docker Hub images. It's set by the environment variable
+	// BLIMP_REGISTRY_MIRROR. Images aren't mirrored if it's empty.
+	//
+	// The mirror isn't provided by the Blimp registry, and must be deployed
+	// separately. For example, it can be a Docker registry with `proxy.remoteurl`
+	// set to https://registry-1.docker.io. A registry in proxy mode doesn't
+	// accept pushes, so it can't share a deployment with RegistryHostname.
+	// Pods don't have credentials for the mirror, so it must allow anonymous
+	// pulls. It should only be reachable from within the cluster.
+	RegistryMirrorHostname string
 )
 
 // MaxServices is the maximum number of service pods allowed in a single
@@ -104,6 +134,10 @@ func main() {
 		LinkProxyBaseHostname = linkProxyBaseHostnameVar
 	}
 
+	if registryMirrorVar, ok := os.LookupEnv("BLIMP_REGISTRY_MIRROR"); ok {
+		RegistryMirrorHostname = registryMirrorVar
+	}
+
 	maxSandboxes := 100
 	if maxSandboxesVar, ok := os.LookupEnv("MAX_SANDBOXES"); ok {
 		parsedVar, err := strconv.Atoi(maxSandboxesVar)
@@ -116,26 +150,126 @@ func main() {
 	}
 	log.Infof("Capping maximum concurrent sandboxes to %d", maxSandboxes)
 
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		log.WithError(err).Error("Failed to create dynamic client")
+		os.Exit(1)
+	}
+
+	// The hostname is the pod name, which is unique across replicas.
+	identity, err := os.Hostname()
+	if err != nil {
+		log.WithError(err).Error("Failed to get hostname")
+		os.Exit(1)
+	}
+
+	s := newServer(kubeClient, dynamicClient, restConfig, maxSandboxes)
+	s.certPath = *certPath
+	s.keyPath = *keyPath
+	s.useNodePort = os.Getenv("USE_NODE_PORT_FOR_NODE_CONTROLLER") == "true"
+
+	if adminPassword := os.Getenv("BLIMP_REGISTRY_ADMIN_PASSWORD"); adminPassword != "" {
+		keepBuilds := registry.DefaultKeepBuilds
+		if keepBuildsVar, ok := os.LookupEnv("REGISTRY_GC_KEEP_BUILDS"); ok {
+			parsedVar, err := strconv.Atoi(keepBuildsVar)
+			if err != nil {
+				log.WithError(err).WithField("REGISTRY_GC_KEEP_BUILDS", keepBuildsVar).
+					Warn("Couldn't parse $REGISTRY_GC_KEEP_BUILDS")
+			} else {
+				keepBuilds = parsedVar
+			}
+		}
+
+		registryAuth := &authn.Basic{
+			Username: auth.RegistryAdminUsername,
+			Password: adminPassword,
+		}
+		s.registryGC = registry.NewCollector(kubeClient, registry.NewRemote(RegistryHostname, registryAuth),
+			RegistryHostname, s.imagesInUse, keepBuilds)
+	} else {
+		log.Warn("$BLIMP_REGISTRY_ADMIN_PASSWORD isn't set. Unused images won't be deleted from the registry.")
+	}
+
+	// Multiple replicas of the cluster manager can serve requests, but only
+	// the leader runs the singleton controllers. The leader lease lives in
+	// the Blimp namespace, so it has to exist before the election starts.
+	node.CreateNamespace(kubeClient)
+	leaderCtx, stopLeading := context.WithCancel(context.Background())
+	leaderElectionDone := make(chan struct{})
+	go func() {
+		runLeaderElection(leaderCtx, kubeClient, identity, s.runSingletons)
+		close(leaderElectionDone)
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	stop := make(chan struct{})
+	go func() {
+		sig := <-signals
+		log.WithField("signal", sig).Info("Shutting down")
+
+		// Stop leading right away so that another replica can take over the
+		// singletons while this replica drains its requests. The lease is
+		// released once the singletons finish their in-flight work.
+		stopLeading()
+		close(stop)
+	}()
+
+	if err := s.listenAndServe(stop); err != nil {
+		log.WithError(err).Error("Unexpected error")
+		os.Exit(1)
+	}
+	<-leaderElectionDone
+}
+
+// newServer creates a server, and starts the controllers that it depends on.
+func newServer(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface,
+	restConfig *rest.Config, maxSandboxes int) *server {
 	s := &server{
 		statusFetcher: newStatusFetcher(kubeClient),
+		exposedLinks:  newExposedLinkCache(kubeClient),
 		kubeClient:    kubeClient,
+		dynamicClient: dynamicClient,
 		restConfig:    restConfig,
-		certPath:      *certPath,
-		keyPath:       *keyPath,
 		maxSandboxes:  maxSandboxes,
+		shutdown:      make(chan struct{}),
 	}
 	s.statusFetcher.Start(nil)
+	s.sandboxes = newSandboxController(s, dynamicClient)
+	return s
+}
 
-	useNodePort := os.Getenv("USE_NODE_PORT_FOR_NODE_CONTROLLER") == "true"
-	node.StartControllerBooter(kubeClient, useNodePort)
-
-	if err := s.listenAndServe(); err != nil {
-		log.WithError(err).Error("Unexpected error")
-		os.Exit(1)
-	}
+// runSingletons runs the controllers that must only run on one replica of
+// the cluster manager at a time. It blocks until `stop` is closed and the
+// controllers have finished their in-flight work.
+func (s *server) runSingletons(stop <-chan struct{}) {
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		node.RunControllerBooter(s.kubeClient, s.useNodePort, stop)
+	}()
+	go func() {
+		defer wg.Done()
+		runRestartLimiter(s.kubeClient, s.statusFetcher, stop)
+	}()
+	go func() {
+		defer wg.Done()
+		s.sandboxes.run(stop)
+	}()
+	if s.registryGC != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.registryGC.Run(stop)
+		}()
+	}
+	wg.Wait()
 }
 
-func (s *server) listenAndServe() error {
+// listenAndServe serves the gRPC and HTTP APIs until `stop` is closed, and
+// then gracefully shuts down the servers.
+func (s *server) listenAndServe(stop <-chan struct{}) error {
 	grpcAddr := fmt.Sprintf(":%d", ports.ClusterManagerGRPCInternalPort)
 	httpAddr := fmt.Sprintf(":%d", ports.ClusterManagerHTTPInternalPort)
 
@@ -186,6 +320,13 @@ func (s *server) listenAndServe() error {
 		serveHTTPErr <- httpServer.ListenAndServe()
 	}()
 
+	// Start the health server.
+	healthServer := s.newHealthServer(ports.ClusterManagerHealthPort)
+	serveHealthErr := make(chan error, 1)
+	go func() {
+		serveHealthErr <- healthServer.ListenAndServe()
+	}()
+
 	log.WithField("address", grpcAddr).Info("Listening for grpc connections..")
 	log.WithField("address", httpAddr).Info("Listening for http connections..")
 	select {
@@ -193,7 +334,43 @@ func (s *server) listenAndServe() error {
 		return errors.WithContext("serve http", err)
 	case err := <-serveGrpcErr:
 		return errors.WithContext("serve grpc", err)
+	case err := <-serveHealthErr:
+		return errors.WithContext("serve health", err)
+	case <-stop:
+	}
+
+	// Fail the readiness probe, and end the long-running watch streams so
+	// that the CLI reconnects to another replica.
+	close(s.shutdown)
+
+	// Keep serving new requests until Kubernetes stops routing traffic to
+	// this replica.
+	time.Sleep(drainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.WithError(err).Warn("Failed to gracefully shut down http server")
 	}
+
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		log.Warn("Timed out waiting for grpc requests to finish")
+		grpcServer.Stop()
+	}
+
+	if err := healthServer.Shutdown(ctx); err != nil {
+		log.WithError(err).Warn("Failed to shut down health server")
+	}
+	return nil
 }
 
 func (s *server) CheckVersion(ctx context.Context, req *cluster.CheckVersionRequest) (
@@ -279,10 +456,20 @@ func (s *server) AttachToSandbox(ctx context.Context, req *cluster.AttachToSandb
 		return &cluster.AttachToSandboxResponse{}, errors.WithContext("get kube credentials", err)
 	}
 
+	var composeFile string
+	sb, err := sandbox.Get(s.dynamicClient, user.Namespace)
+	switch {
+	case err == nil:
+		composeFile = sb.Spec.ComposeFile
+	case !kerrors.IsNotFound(err):
+		return &cluster.AttachToSandboxResponse{}, errors.WithContext("get deployed compose file", err)
+	}
+
 	return &cluster.AttachToSandboxResponse{
 		NodeAddress:     nodeAddress,
 		NodeCert:        nodeCert,
 		KubeCredentials: &cliCreds,
+		ComposeFile:     composeFile,
 	}, nil
 }
 
@@ -409,7 +596,7 @@ func (s *server) CreateSandbox(ctx context.Context, req *cluster.CreateSandboxRe
 		// will ultimately be deployed, to make sure that the namespace is
 		// scheduled on a node that ultimately will be able to handle the
 		// workload.
-		if err := s.createReservation(user, len(dcCfg.Services)); err != nil {
+		if err := s.createReservation(user, countPods(dcCfg.Services)); err != nil {
 			return &cluster.CreateSandboxResponse{}, errors.WithContext("deploy reservation", err)
 		}
 
@@ -427,16 +614,24 @@ func (s *server) CreateSandbox(ctx context.Context, req *cluster.CreateSandboxRe
 		}
 	}
 
-	if err := s.createSyncthing(user, req.GetSyncedFolders()); err != nil {
-		return &cluster.CreateSandboxResponse{}, errors.WithContext("deploy syncthing", err)
-	}
-
-	if err := createBuildkitd(s.kubeClient, namespace); err != nil {
-		return &cluster.CreateSandboxResponse{}, errors.WithContext("deploy buildkitd", err)
+	// The sandbox controller deploys the system pods once the synced folders
+	// are written to the Sandbox.
+	generation, changed, err := sandbox.UpdateSpec(s.dynamicClient, namespace, func(spec *sandbox.Spec) {
+		spec.SyncedFolders = req.GetSyncedFolders()
+		spec.ReceiveOnlyFolders = req.GetReceiveOnlyFolders()
+	})
+	if err != nil {
+		return &cluster.CreateSandboxResponse{}, err
 	}
 
-	if err := s.deployDNS(user); err != nil {
-		return &cluster.CreateSandboxResponse{}, errors.WithContext("deploy dns", err)
+	// If the synced folders didn't change, the Sandbox's status is from the
+	// last update, which may have been a failed deploy. That failure was
+	// already returned by the deploy, so it shouldn't prevent the sandbox
+	// from booting.
+	if changed {
+		if _, err := s.waitForSandbox(ctx, namespace, generation); err != nil {
+			return &cluster.CreateSandboxResponse{}, errors.WithContext("deploy sandbox", err)
+		}
 	}
 
 	// Wait until the DNS pod is scheduled so that we know what node the other
@@ -510,35 +705,92 @@ func (s *server) DeployToSandbox(ctx context.Context, req *cluster.DeployRequest
 	}
 
 	namespace := user.Namespace
-	dnsPod, err := s.getPod(ctx, namespace, "dns", podIsReady)
+	dnsIP, nodeControllerIP, err := s.getSandboxNetwork(ctx, namespace)
 	if err != nil {
-		return &cluster.DeployResponse{}, errors.WithContext("get dns server's IP", err)
+		return &cluster.DeployResponse{}, err
 	}
 
-	nodeControllerIP, err := node.GetNodeControllerInternalIP(s.kubeClient, dnsPod.Spec.NodeName)
+	customerPods, _, warnings, err := toPods(user, dnsIP, nodeControllerIP, dcCfg, req.BuiltImages)
 	if err != nil {
-		return &cluster.DeployResponse{}, errors.WithContext("get node controller's IP", err)
+		return &cluster.DeployResponse{}, errors.WithContext("make pod specs", err)
 	}
 
-	customerPods, configMaps, err := toPods(user, dnsPod.Status.PodIP, nodeControllerIP, dcCfg, req.BuiltImages)
+	noRecreate, err := parseNoRecreate(dcCfg, req.GetNoRecreate())
 	if err != nil {
-		return &cluster.DeployResponse{}, errors.WithContext("make pod specs", err)
+		return &cluster.DeployResponse{}, err
 	}
 
-	// TODO: Garbage collect config maps.
-	for _, configMap := range configMaps {
-		if err := kube.DeployConfigMap(s.kubeClient, configMap); err != nil {
-			return &cluster.DeployResponse{}, errors.WithContext("create configmap", err)
+	if req.GetDryRun() {
+		plan, err := s.planCustomerPods(namespace, s.withoutStoppedServices(namespace, dcCfg, customerPods),
+			noRecreate)
+		if err != nil {
+			return &cluster.DeployResponse{}, errors.WithContext("plan deployment", err)
 		}
+		return &cluster.DeployResponse{Warnings: warnings, Plan: plan}, nil
 	}
 
-	log.WithField("namespace", namespace).
-		WithField("numPods", len(customerPods)).
-		Info("Deploying customer pods")
-	if err := s.deployCustomerPods(namespace, customerPods); err != nil {
+	// The pods are deployed by the sandbox controller. The pod specs are
+	// generated above so that invalid Compose files are rejected before
+	// they're saved.
+	generation, _, err := sandbox.UpdateSpec(s.dynamicClient, namespace, func(spec *sandbox.Spec) {
+		spec.ComposeFile = req.GetComposeFile()
+		spec.BuiltImages = req.GetBuiltImages()
+		spec.NoRecreate = req.GetNoRecreate()
+		spec.Revision++
+	})
+	if err != nil {
+		return &cluster.DeployResponse{}, err
+	}
+
+	sb, err := s.waitForSandbox(ctx, namespace, generation)
+	if err != nil {
 		return &cluster.DeployResponse{}, errors.WithContext("boot customer pods", err)
 	}
-	return &cluster.DeployResponse{}, nil
+	return &cluster.DeployResponse{Warnings: sb.Status.Warnings}, nil
+}
+
+// waitForSandbox waits for the sandbox controller to reconcile the given
+// generation of the namespace's Sandbox. It returns an error if the
+// controller failed to deploy it.
+func (s *server) waitForSandbox(ctx context.Context, namespace string, generation int64) (
+	*sandbox.Sandbox, error) {
+	ctx, cancel := context.WithTimeout(ctx, sandboxReconcileTimeout)
+	defer cancel()
+
+	sb, err := s.sandboxes.waitForReconcile(ctx, namespace, generation)
+	if err != nil {
+		return nil, err
+	}
+
+	if sb.Status.Phase == sandbox.PhaseFailed {
+		return nil, errors.NewFriendlyError("Failed to deploy sandbox: %s", sb.Status.Message)
+	}
+	return sb, nil
+}
+
+// withoutStoppedServices filters out the pods for services that should stay
+// stopped when the sandbox is redeployed. These are services with the
+// `unless-stopped` restart policy that were stopped by `blimp stop`.
+func (s *server) withoutStoppedServices(namespace string, dcCfg composeTypes.Project,
+	pods []corev1.Pod) (filtered []corev1.Pod) {
+	for _, pod := range pods {
+		svcName := pod.Labels["blimp.service"]
+		svc, err := dcCfg.GetService(svcName)
+		if err == nil && svc.Restart == "unless-stopped" && s.isStopped(namespace, svcName) {
+			continue
+		}
+		filtered = append(filtered, pod)
+	}
+	return filtered
+}
+
+func containsPod(pods []corev1.Pod, name string) bool {
+	for _, pod := range pods {
+		if pod.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *server) createNamespace(ctx context.Context, namespace string) error {
@@ -696,7 +948,8 @@ func (s *server) createReservation(user auth.User, numServices int) error {
 	return nil
 }
 
-func (s *server) createSyncthing(user auth.User, syncedFolders map[string]string) error {
+func (s *server) createSyncthing(user auth.User, syncedFolders map[string]string,
+	receiveOnlyFolders []string) error {
 	mount := corev1.VolumeMount{
 		Name:      volume.PersistentVolume.Name,
 		MountPath: "/pv",
@@ -707,6 +960,11 @@ func (s *server) createSyncthing(user auth.User, syncedFolders map[string]string
 		idPathMap[id] = filepath.Join(mount.MountPath, volume.BindVolumeDir(src))
 	}
 
+	receiveOnly := map[string]bool{}
+	for _, id := range receiveOnlyFolders {
+		receiveOnly[id] = true
+	}
+
 	pod := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: user.Namespace,
@@ -720,7 +978,7 @@ func (s *server) createSyncthing(user auth.User, syncedFolders map[string]string
 			Containers: []corev1.Container{{
 				Name:         kube.PodNameSyncthing,
 				Image:        version.SyncthingImage,
-				Args:         syncthing.MapToArgs(idPathMap),
+				Args:         syncthing.MapToArgs(idPathMap, receiveOnly),
 				VolumeMounts: []corev1.VolumeMount{mount},
 				Resources: corev1.ResourceRequirements{
 					Limits: corev1.ResourceList{
@@ -774,6 +1032,23 @@ func (s *server) deployDNS(user auth.User) error {
 		return errors.WithContext("create dns service account", err)
 	}
 
+	// Reuse the current pod's host gateway token so that the pod isn't
+	// recreated every time the sandbox is reconciled.
+	var gatewayToken string
+	currPod, err := s.kubeClient.CoreV1().Pods(namespace).Get("dns", metav1.GetOptions{})
+	switch {
+	case err == nil:
+		gatewayToken = currPod.Annotations[hostgateway.TokenAnnotation]
+	case !kerrors.IsNotFound(err):
+		return errors.WithContext("get current pod", err)
+	}
+	if gatewayToken == "" {
+		gatewayToken, err = hostgateway.NewToken()
+		if err != nil {
+			return errors.WithContext("generate host gateway token", err)
+		}
+	}
+
 	pod := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
@@ -782,6 +1057,9 @@ func (s *server) deployDNS(user auth.User) error {
 				"service":                     "dns",
 				affinity.ColocateNamespaceKey: namespace,
 			},
+			Annotations: map[string]string{
+				hostgateway.TokenAnnotation: gatewayToken,
+			},
 		},
 		Spec: corev1.PodSpec{
 			Containers: []corev1.Container{{
@@ -795,6 +1073,22 @@ func (s *server) deployDNS(user auth.User) error {
 							},
 						},
 					},
+					{
+						Name: "POD_IP",
+						ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{
+								FieldPath: "status.podIP",
+							},
+						},
+					},
+					{
+						Name: "HOST_GATEWAY_TOKEN",
+						ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{
+								FieldPath: fmt.Sprintf("metadata.annotations['%s']", hostgateway.TokenAnnotation),
+							},
+						},
+					},
 				},
 				Image: version.DNSImage,
 				Resources: corev1.ResourceRequirements{
@@ -851,10 +1145,20 @@ func (s *server) createCLICreds(ctx context.Context, namespace string) (cluster.
 			},
 
 			// Get needed for `blimp cp`. Get and watch needed for `blimp logs`.
+			// List needed by tools using `blimp kubeconfig`, such as kubectl
+			// and k9s.
 			{
 				APIGroups: []string{""},
 				Resources: []string{"pods"},
-				Verbs:     []string{"get", "watch"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+
+			// Needed for `kubectl get events` and `kubectl describe` via
+			// `blimp kubeconfig`.
+			{
+				APIGroups: []string{""},
+				Resources: []string{"events"},
+				Verbs:     []string{"get", "list", "watch"},
 			},
 		},
 	}
@@ -965,7 +1269,18 @@ func (s *server) createPodRunnerServiceAccount(namespace string,
 	return kube.DeployServiceAccount(s.kubeClient, serviceAccount)
 }
 
-func (s *server) deployCustomerPods(namespace string, desired []corev1.Pod) error {
+// customerPodDeployOptions are the options used to deploy customer pods.
+var customerPodDeployOptions = kube.DeployPodOptions{
+	Sanitizers: []kube.Sanitizer{
+		kube.SanitizeIgnoreInitContainerImages,
+		kube.SanitizeIgnoreNodeAffinity,
+	},
+}
+
+// deployCustomerPods deploys the desired pods, and deletes any customer pods
+// that are no longer desired. Existing pods for the services in noRecreate are
+// left alone, even if their spec changed.
+func (s *server) deployCustomerPods(namespace string, desired []corev1.Pod, noRecreate map[string]struct{}) error {
 	currPods, err := s.kubeClient.CoreV1().Pods(namespace).List(metav1.ListOptions{
 		LabelSelector: "blimp.customerPod=true",
 	})
@@ -973,19 +1288,31 @@ func (s *server) deployCustomerPods(namespace string, desired []corev1.Pod) erro
 		return errors.WithContext("list", err)
 	}
 
-	// TODO: Parallelize
+	currNames := map[string]struct{}{}
+	for _, pod := range currPods.Items {
+		currNames[pod.Name] = struct{}{}
+	}
+
+	var g errgroup.Group
 	desiredNames := map[string]struct{}{}
 	for _, pod := range desired {
-		opts := kube.DeployPodOptions{
-			Sanitizers: []kube.Sanitizer{
-				kube.SanitizeIgnoreInitContainerImages,
-				kube.SanitizeIgnoreNodeAffinity,
-			},
-		}
-		if err := kube.DeployPod(s.kubeClient, pod, opts); err != nil {
-			return errors.WithContext("create", err)
-		}
 		desiredNames[pod.Name] = struct{}{}
+
+		_, exists := currNames[pod.Name]
+		if _, ok := noRecreate[pod.Labels["blimp.service"]]; ok && exists {
+			continue
+		}
+
+		pod := pod
+		g.Go(func() error {
+			if err := kube.DeployPod(s.kubeClient, pod, customerPodDeployOptions); err != nil {
+				return errors.WithContext(fmt.Sprintf("create %s", pod.Name), err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
 	}
 
 	// Delete any stale pods.
@@ -999,6 +1326,79 @@ func (s *server) deployCustomerPods(namespace string, desired []corev1.Pod) erro
 	return nil
 }
 
+// parseNoRecreate returns the set of services whose pods shouldn't be
+// recreated. Unknown services are rejected so that a typo doesn't cause the
+// service the user meant to pin to be recreated.
+func parseNoRecreate(dcCfg composeTypes.Project, services []string) (map[string]struct{}, error) {
+	noRecreate := map[string]struct{}{}
+	for _, svc := range services {
+		if _, err := dcCfg.GetService(svc); err != nil {
+			return nil, errors.NewFriendlyError("Unknown service %q in --no-recreate", svc)
+		}
+		noRecreate[svc] = struct{}{}
+	}
+	return noRecreate, nil
+}
+
+// planCustomerPods returns what deployCustomerPods would do, without
+// modifying anything.
+func (s *server) planCustomerPods(namespace string, desired []corev1.Pod, noRecreate map[string]struct{}) (
+	[]*cluster.ServiceDeployPlan, error) {
+	currPods, err := s.kubeClient.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: "blimp.customerPod=true",
+	})
+	if err != nil {
+		return nil, errors.WithContext("list", err)
+	}
+
+	var plans []*cluster.ServiceDeployPlan
+	desiredNames := map[string]struct{}{}
+	desiredReplicated := replicatedServices(desired)
+	for _, pod := range desired {
+		desiredNames[pod.Name] = struct{}{}
+
+		svc := pod.Labels["blimp.service"]
+		podPlan, err := kube.PlanPodDeploy(s.kubeClient, pod, customerPodDeployOptions)
+		if err != nil {
+			return nil, errors.WithContext(fmt.Sprintf("plan %s", svc), err)
+		}
+
+		plan := &cluster.ServiceDeployPlan{Service: podDisplayName(pod, desiredReplicated)}
+		switch podPlan.Action {
+		case kube.PodUnchanged:
+			plan.Action = cluster.ServiceDeployPlan_UNCHANGED
+		case kube.PodCreate:
+			plan.Action = cluster.ServiceDeployPlan_CREATE
+		case kube.PodRecreate:
+			plan.Action = cluster.ServiceDeployPlan_RECREATE
+			plan.Diff = podPlan.Diff
+			if _, ok := noRecreate[svc]; ok {
+				plan.Action = cluster.ServiceDeployPlan_SKIP_RECREATE
+			}
+		}
+		plans = append(plans, plan)
+	}
+
+	currReplicated := replicatedServices(currPods.Items)
+	for _, pod := range currPods.Items {
+		// The reservation pod isn't a service, so removing it isn't part of
+		// the plan.
+		if _, ok := pod.Labels["blimp.service"]; !ok {
+			continue
+		}
+
+		if _, ok := desiredNames[pod.Name]; !ok {
+			plans = append(plans, &cluster.ServiceDeployPlan{
+				Service: podDisplayName(pod, currReplicated),
+				Action:  cluster.ServiceDeployPlan_DELETE,
+			})
+		}
+	}
+
+	sort.Slice(plans, func(i, j int) bool { return plans[i].Service < plans[j].Service })
+	return plans, nil
+}
+
 func (s *server) DeleteSandbox(ctx context.Context, req *cluster.DeleteSandboxRequest) (
 	*cluster.DeleteSandboxResponse, error) {
 	user, err := clusterAuth.AuthorizeRequest(clusterAuth.GetAuth(req))
@@ -1014,10 +1414,25 @@ func (s *server) DeleteSandbox(ctx context.Context, req *cluster.DeleteSandboxRe
 		return &cluster.DeleteSandboxResponse{}, errors.WithContext("get sandbox", err)
 	}
 
+	s.exposedLinks.invalidate(user.Namespace)
+
 	if req.DeleteVolumes {
 		if err := volume.PermanentlyDeletePVC(s.kubeClient, user.Namespace); err != nil {
 			return &cluster.DeleteSandboxResponse{}, errors.WithContext("delete persistent volume", err)
 		}
+
+		// Deleting the images can take a while, so it's done in the
+		// background, and failing to delete them doesn't fail the teardown.
+		// The garbage collector doesn't know that the sandbox was deleted, so
+		// it still keeps the newest builds of any images that are left behind.
+		if s.registryGC != nil {
+			go func() {
+				if err := s.registryGC.DeleteNamespace(user.Namespace); err != nil {
+					log.WithError(err).WithField("namespace", user.Namespace).
+						Warn("Failed to delete images during sandbox teardown")
+				}
+			}()
+		}
 	}
 
 	// Give the pods 10 seconds to shut down (rather than the default of 30
@@ -1066,7 +1481,7 @@ func (s *server) WatchStatus(req *cluster.GetStatusRequest, stream cluster.Manag
 		return err
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
 	trig := s.statusFetcher.Watch(ctx, user.Namespace)
 
@@ -1080,7 +1495,13 @@ func (s *server) WatchStatus(req *cluster.GetStatusRequest, stream cluster.Manag
 			return err
 		}
 
-		<-trig
+		select {
+		case <-trig:
+		case <-ctx.Done():
+			return nil
+		case <-s.shutdown:
+			return errShuttingDown
+		}
 	}
 }
 
@@ -1090,17 +1511,34 @@ func (s *server) Restart(ctx context.Context, req *cluster.RestartRequest) (*clu
 		return &cluster.RestartResponse{}, err
 	}
 
-	podName := names.ToDNS1123(req.GetService())
-	currPod, err := s.kubeClient.CoreV1().Pods(user.Namespace).
-		Get(podName, metav1.GetOptions{})
+	pods, err := s.getServicePods(user.Namespace, req.GetService())
 	if err != nil {
-		return &cluster.RestartResponse{}, errors.WithContext("get current pod", err)
+		return &cluster.RestartResponse{}, errors.WithContext("get current pods", err)
+	}
+	if len(pods) == 0 {
+		return &cluster.RestartResponse{}, errors.NewFriendlyError("Service %s does not exist.", req.GetService())
 	}
 
+	for _, currPod := range pods {
+		currPod := currPod
+
+		// Since we are setting ForceRestart, we don't both adding any Sanitizers here.
+		err = kube.DeployPod(s.kubeClient, toRedeployablePod(&currPod), kube.DeployPodOptions{ForceRestart: true})
+		if err != nil {
+			return &cluster.RestartResponse{}, errors.WithContext("deploy new pod", err)
+		}
+	}
+
+	return &cluster.RestartResponse{}, nil
+}
+
+// toRedeployablePod returns a copy of the pod that can be used to recreate
+// it. The runtime state set by Kubernetes is dropped.
+func toRedeployablePod(currPod *corev1.Pod) corev1.Pod {
 	newPod := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      podName,
-			Namespace: user.Namespace,
+			Name:      currPod.Name,
+			Namespace: currPod.Namespace,
 			Labels:    currPod.Labels,
 		},
 		Spec: currPod.Spec,
@@ -1113,14 +1551,7 @@ func (s *server) Restart(ctx context.Context, req *cluster.RestartRequest) (*clu
 			newPod.Annotations[k] = v
 		}
 	}
-
-	// Since we are setting ForceRestart, we don't both adding any Sanitizers here.
-	err = kube.DeployPod(s.kubeClient, newPod, kube.DeployPodOptions{ForceRestart: true})
-	if err != nil {
-		return &cluster.RestartResponse{}, errors.WithContext("deploy new pod", err)
-	}
-
-	return &cluster.RestartResponse{}, nil
+	return newPod
 }
 
 func (s *server) TagImages(req *cluster.TagImagesRequest, stream cluster.Manager_TagImagesServer) error {
@@ -1283,6 +1714,9 @@ func (s *server) Expose(ctx context.Context, req *cluster.ExposeRequest) (
 		Service: req.Service,
 		Port:    int(req.Port),
 	}
+	if req.Protocol == cluster.ExposeProtocol_H2C {
+		exposeInfo.Protocol = expose.ProtocolH2C
+	}
 
 	// Secret should be 8 hex digits, so between 0x00000000 and 0xffffffff
 	secretNum, err := rand.Int(rand.Reader, big.NewInt(0x100000000))
@@ -1364,9 +1798,61 @@ func (s *server) Unexpose(ctx context.Context, req *cluster.UnexposeRequest) (
 		return &cluster.UnexposeResponse{}, errors.WithContext("update sandbox", err)
 	}
 
+	s.exposedLinks.invalidate(user.Namespace)
 	return &cluster.UnexposeResponse{}, nil
 }
 
+func (s *server) ResolveExposedLink(ctx context.Context, req *cluster.ResolveExposedLinkRequest) (
+	*cluster.ResolveExposedLinkResponse, error) {
+	// The link token acts as the authentication for this request. For
+	// security, we return the same error regardless of why the link couldn't
+	// be resolved so that we don't leak whether the namespace exists.
+	unknownLinkErr := errors.NewFriendlyError("unknown link")
+
+	namespace, err := s.statusFetcher.namespaceLister.Get(req.Namespace)
+	if err != nil {
+		return &cluster.ResolveExposedLinkResponse{}, unknownLinkErr
+	}
+
+	annotationJson, ok := namespace.Annotations[kube.ExposeAnnotation]
+	if !ok {
+		return &cluster.ResolveExposedLinkResponse{}, unknownLinkErr
+	}
+
+	annotation, err := expose.ParseJsonAnnotation(annotationJson)
+	if err != nil {
+		return &cluster.ResolveExposedLinkResponse{}, errors.WithContext("parse expose annotation", err)
+	}
+
+	info, ok := annotation[req.Token]
+	if !ok {
+		return &cluster.ResolveExposedLinkResponse{}, unknownLinkErr
+	}
+
+	dnsPod, err := s.statusFetcher.podLister.Pods(req.Namespace).Get("dns")
+	if err != nil || !podIsScheduled(dnsPod) {
+		return &cluster.ResolveExposedLinkResponse{}, errors.New("sandbox is not scheduled")
+	}
+
+	nodeAddress, nodeCert, err := s.exposedLinks.get(ctx, req.Namespace, dnsPod.Spec.NodeName)
+	if err != nil {
+		return &cluster.ResolveExposedLinkResponse{}, err
+	}
+
+	protocol := cluster.ExposeProtocol_HTTP
+	if info.Protocol == expose.ProtocolH2C {
+		protocol = cluster.ExposeProtocol_H2C
+	}
+
+	return &cluster.ResolveExposedLinkResponse{
+		NodeAddress: nodeAddress,
+		NodeCert:    nodeCert,
+		Service:     info.Service,
+		Port:        uint32(info.Port),
+		Protocol:    protocol,
+	}, nil
+}
+
 func toPods(
 	user auth.User,
 	dnsIP,
@@ -1376,30 +1862,104 @@ func toPods(
 ) (
 	pods []corev1.Pod,
 	configMaps []corev1.ConfigMap,
+	warnings []string,
 	err error,
 ) {
-	if len(cfg.Services) > MaxServices {
-		return nil, nil, errors.NewFriendlyError(
-			"Blimp supports a maximum of %d services, but %d are defined.",
-			MaxServices, len(cfg.Services))
+	if numPods := countPods(cfg.Services); numPods > MaxServices {
+		return nil, nil, nil, errors.NewFriendlyError(
+			"Blimp supports a maximum of %d services, but %d are defined (including replicas).",
+			MaxServices, numPods)
 	}
 
 	b, err := newPodBuilder(user, dnsIP, nodeControllerIP, builtImages, cfg.Services, cfg.Volumes)
 	if err != nil {
-		return nil, nil, errors.WithContext("make pod builder", err)
+		return nil, nil, nil, errors.WithContext("make pod builder", err)
 	}
 
 	for _, svc := range cfg.Services {
-		p, cm, err := b.ToPod(svc)
+		p, cm, w, err := b.ToPod(svc)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 
-		pods = append(pods, p)
+		pods = append(pods, replicate(p, numReplicas(svc))...)
 		configMaps = append(configMaps, cm...)
+		warnings = append(warnings, w...)
+	}
+
+	// The names of replicas could collide with other services. For example,
+	// the second replica of `web` has the same name as a service called
+	// `web.2`.
+	podNames := map[string]string{}
+	replicated := replicatedServices(pods)
+	for _, pod := range pods {
+		name := podDisplayName(pod, replicated)
+		if other, ok := podNames[pod.Name]; ok {
+			return nil, nil, nil, errors.NewFriendlyError(
+				"%s and %s can't both be deployed since their names conflict. "+
+					"Please rename one of the services.", other, name)
+		}
+		podNames[pod.Name] = name
 	}
 
-	return pods, configMaps, nil
+	return pods, configMaps, warnings, nil
+}
+
+// numReplicas returns the number of pods that should be deployed for the
+// service.
+func numReplicas(svc composeTypes.ServiceConfig) int {
+	if svc.Deploy == nil || svc.Deploy.Replicas == nil {
+		return 1
+	}
+	return int(*svc.Deploy.Replicas)
+}
+
+// countPods returns the total number of pods that should be deployed for the
+// services.
+func countPods(services composeTypes.Services) (n int) {
+	for _, svc := range services {
+		n += numReplicas(svc)
+	}
+	return n
+}
+
+// replicate returns the pods for each replica of a service. The first replica
+// isn't labeled with its ordinal, so that its pod is the same as if the
+// service weren't replicated. This way, scaling a service up or down doesn't
+// recreate its first pod.
+func replicate(pod corev1.Pod, replicas int) []corev1.Pod {
+	pods := []corev1.Pod{pod}
+	svc := pod.Labels["blimp.service"]
+	for i := 2; i <= replicas; i++ {
+		replica := *pod.DeepCopy()
+		replica.Name = kube.ReplicaPodName(svc, i)
+		replica.Labels[kube.ReplicaLabel] = strconv.Itoa(i)
+		pods = append(pods, replica)
+	}
+	return pods
+}
+
+// replicatedServices returns the services that have more than one replica
+// among the given customer pods.
+func replicatedServices(pods []corev1.Pod) map[string]struct{} {
+	replicated := map[string]struct{}{}
+	for _, pod := range pods {
+		if _, ok := pod.Labels[kube.ReplicaLabel]; ok {
+			replicated[pod.Labels["blimp.service"]] = struct{}{}
+		}
+	}
+	return replicated
+}
+
+// podDisplayName returns the name used to refer to the customer pod in
+// messages to the user. The replicas of the services in `replicated` are
+// referred to by their ordinal, such as `web.2`.
+func podDisplayName(pod corev1.Pod, replicated map[string]struct{}) string {
+	svc := pod.Labels["blimp.service"]
+	if _, ok := replicated[svc]; !ok {
+		return svc
+	}
+	return kube.ReplicaName(svc, kube.GetReplica(pod.Labels))
 }
 
 type podCondition func(*corev1.Pod) bool
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	composeTypes "github.com/kelda/compose-go/types"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kelda/blimp/pkg/dockercompose"
+)
+
+// mirrorImage rewrites references to official Docker Hub images so that
+// they're pulled through the registry mirror. This avoids hitting Docker Hub's
+// rate limits when many sandboxes pull the same images.
+//
+// Only official images are mirrored since the mirror pulls from Docker Hub
+// with its own credentials. Other Docker Hub images may be private, so they're
+// pulled directly with the user's registry credentials. Images in other
+// registries are never rewritten.
+func mirrorImage(image, mirror string) string {
+	if mirror == "" {
+		return image
+	}
+
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return image
+	}
+
+	repo := ref.Context()
+	if repo.RegistryStr() != name.DefaultRegistry || !strings.HasPrefix(repo.RepositoryStr(), "library/") {
+		return image
+	}
+
+	sep := ":"
+	if _, ok := ref.(name.Digest); ok {
+		sep = "@"
+	}
+	return fmt.Sprintf("%s/%s%s%s", mirror, repo.RepositoryStr(), sep, ref.Identifier())
+}
+
+// toPullPolicy converts the service's Compose pull policy into the
+// equivalent Kubernetes policy.
+//
+// Nodes are shared between sandboxes, so images that may be private are
+// always pulled, regardless of the service's pull policy. This makes
+// Kubernetes check the sandbox's registry credentials rather than starting a
+// private image that another sandbox already pulled. The pull policy is only
+// honored for images pulled through the mirror, which are public. If those
+// services don't set a policy, they follow Kubernetes' default: images with
+// the `latest` tag are always pulled, and other images are only pulled if
+// they're not present.
+func toPullPolicy(svc composeTypes.ServiceConfig, image, mirror string) corev1.PullPolicy {
+	ref, err := name.ParseReference(image)
+	if err != nil || mirror == "" || ref.Context().RegistryStr() != mirror {
+		return corev1.PullAlways
+	}
+
+	switch dockercompose.GetPullPolicy(svc) {
+	case dockercompose.PullPolicyAlways:
+		return corev1.PullAlways
+	case dockercompose.PullPolicyNever:
+		return corev1.PullNever
+	case dockercompose.PullPolicyMissing, dockercompose.PullPolicyIfNotPresent,
+		dockercompose.PullPolicyBuild:
+		return corev1.PullIfNotPresent
+	default:
+		if tag, ok := ref.(name.Tag); ok && tag.TagStr() == "latest" {
+			return corev1.PullAlways
+		}
+		return corev1.PullIfNotPresent
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	composeTypes "github.com/kelda/compose-go/types"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMirrorImage(t *testing.T) {
+	digest := "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	tests := []struct {
+		image, mirror, exp string
+	}{
+		{"postgres", "mirror.blimp.test", "mirror.blimp.test/library/postgres:latest"},
+		{"postgres:13", "mirror.blimp.test", "mirror.blimp.test/library/postgres:13"},
+		{"docker.io/library/postgres:13", "mirror.blimp.test", "mirror.blimp.test/library/postgres:13"},
+		{"postgres@" + digest, "mirror.blimp.test", "mirror.blimp.test/library/postgres@" + digest},
+
+		// Non-official images may be private, and images in other registries
+		// aren't cached by the mirror.
+		{"bitnami/redis", "mirror.blimp.test", "bitnami/redis"},
+		{"gcr.io/project/image:tag", "mirror.blimp.test", "gcr.io/project/image:tag"},
+
+		// Images aren't rewritten if there's no mirror.
+		{"postgres", "", "postgres"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.exp, mirrorImage(test.image, test.mirror), test.image)
+	}
+}
+
+func TestToPullPolicy(t *testing.T) {
+	digest := "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	tests := []struct {
+		policy, image string
+		exp           corev1.PullPolicy
+	}{
+		// The pull policy is honored for mirrored images, since they're
+		// public.
+		{"always", "mirror.blimp.test/library/postgres:13", corev1.PullAlways},
+		{"never", "mirror.blimp.test/library/postgres", corev1.PullNever},
+		{"missing", "mirror.blimp.test/library/postgres", corev1.PullIfNotPresent},
+		{"if_not_present", "mirror.blimp.test/library/postgres", corev1.PullIfNotPresent},
+		{"build", "mirror.blimp.test/library/postgres", corev1.PullIfNotPresent},
+
+		// Images that may be private are always pulled, so that the
+		// sandbox's credentials are checked.
+		{"", "postgres:13", corev1.PullAlways},
+		{"", "bitnami/redis:6.0", corev1.PullAlways},
+		{"", "registry.blimp.test/namespace/web:tag", corev1.PullAlways},
+		{"", "gcr.io/project/image@" + digest, corev1.PullAlways},
+		{"never", "bitnami/redis:6.0", corev1.PullAlways},
+		{"never", "registry.blimp.test/namespace/web:tag", corev1.PullAlways},
+		{"missing", "gcr.io/project/image@" + digest, corev1.PullAlways},
+		{"build", "registry.blimp.test/namespace/web@" + digest, corev1.PullAlways},
+
+		// Without a pull policy, only mirrored images with the latest tag are
+		// always pulled.
+		{"", "mirror.blimp.test/library/postgres:latest", corev1.PullAlways},
+		{"", "mirror.blimp.test/library/postgres:13", corev1.PullIfNotPresent},
+		{"", "mirror.blimp.test/library/postgres@" + digest, corev1.PullIfNotPresent},
+	}
+
+	for _, test := range tests {
+		svc := composeTypes.ServiceConfig{Name: "svc"}
+		if test.policy != "" {
+			svc.Extensions = map[string]interface{}{"pull_policy": test.policy}
+		}
+		assert.Equal(t, test.exp, toPullPolicy(svc, test.image, "mirror.blimp.test"), test.policy+" "+test.image)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -58,9 +59,9 @@ type booter struct {
 	workqueue    workqueue.RateLimitingInterface
 }
 
-// StartControllerBooter starts a watcher that watches for new Kubernetes
-// nodes, and deploys a Blimp Node Controller onto them.
-func StartControllerBooter(kubeClient kubernetes.Interface, useNodePort bool) {
+// CreateNamespace creates the namespace that the node controllers run in,
+// retrying until it succeeds.
+func CreateNamespace(kubeClient kubernetes.Interface) {
 	for {
 		_, err := kubeClient.CoreV1().Namespaces().Create(&corev1.Namespace{
 			ObjectMeta: metav1.ObjectMeta{
@@ -73,7 +74,7 @@ func StartControllerBooter(kubeClient kubernetes.Interface, useNodePort bool) {
 			},
 		})
 		if err == nil || kerrors.IsAlreadyExists(err) {
-			break
+			return
 		}
 
 		log.WithError(err).
@@ -81,6 +82,13 @@ func StartControllerBooter(kubeClient kubernetes.Interface, useNodePort bool) {
 			Error("Failed to create namespace. Retrying in 15 seconds.")
 		time.Sleep(15 * time.Second)
 	}
+}
+
+// RunControllerBooter watches for new Kubernetes nodes, and deploys a Blimp
+// Node Controller onto them. It blocks until `stop` is closed and any
+// in-flight deployments have finished.
+func RunControllerBooter(kubeClient kubernetes.Interface, useNodePort bool, stop <-chan struct{}) {
+	CreateNamespace(kubeClient)
 
 	informer := informers.NewSharedInformerFactory(kubeClient, 30*time.Second).
 		Core().V1().Nodes().Informer()
@@ -118,15 +126,22 @@ func StartControllerBooter(kubeClient kubernetes.Interface, useNodePort bool) {
 		},
 	})
 
-	go informer.Run(nil)
-	cache.WaitForCacheSync(nil, informer.HasSynced)
+	go informer.Run(stop)
+	cache.WaitForCacheSync(stop, informer.HasSynced)
 
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
 	for i := 0; i < numWorkers; i++ {
 		go func() {
+			defer wg.Done()
 			for !b.runWorker() {
 			}
 		}()
 	}
+
+	<-stop
+	b.workqueue.ShutDown()
+	wg.Wait()
 }
 
 func (booter *booter) runWorker() (shutdown bool) {
@@ -203,6 +218,28 @@ func (booter *booter) deployNodeController(node *corev1.Node) error {
 				Verbs:     []string{"get", "list", "watch"},
 			},
 
+			// Attach to the pods created by `blimp run`.
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods/attach"},
+				Verbs:     []string{"create"},
+			},
+
+			// Record the progress of boot blocking so that it's shown by
+			// `blimp events`.
+			{
+				APIGroups: []string{""},
+				Resources: []string{"events"},
+				Verbs:     []string{"create", "patch", "update"},
+			},
+
+			// Get the ConfigMaps of stopped services. Used for boot blocking.
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get"},
+			},
+
 			// List all namespaces, and update their finalizers. Used for the
 			// volume deletion finalizer.
 			{
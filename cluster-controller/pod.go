@@ -22,6 +22,7 @@ import (
 	"github.com/kelda/blimp/pkg/dockercompose"
 	"github.com/kelda/blimp/pkg/errors"
 	"github.com/kelda/blimp/pkg/hash"
+	"github.com/kelda/blimp/pkg/hostgateway"
 	"github.com/kelda/blimp/pkg/kube"
 	"github.com/kelda/blimp/pkg/metadata"
 	"github.com/kelda/blimp/pkg/names"
@@ -35,6 +36,10 @@ const (
 	cpuRequestUnits    = "m"
 	memoryRequest      = 50
 	memoryRequestUnits = "Mi"
+
+	// defaultTmpfsSize is the size limit of tmpfs mounts that don't specify
+	// a size.
+	defaultTmpfsSize = "64Mi"
 )
 
 type podBuilder struct {
@@ -60,6 +65,10 @@ type podSpec struct {
 	image      string
 	pod        corev1.Pod
 	configMaps []corev1.ConfigMap
+
+	// warnings are problems with the service's config that were worked
+	// around. They're shown to the user after deploying.
+	warnings []string
 }
 
 func newPodBuilder(user auth.User, dnsIP, nodeControllerIP string, builtImages map[string]string,
@@ -133,7 +142,8 @@ func newPodBuilder(user auth.User, dnsIP, nodeControllerIP string, builtImages m
 	}, nil
 }
 
-func (b podBuilder) ToPod(svc composeTypes.ServiceConfig) (corev1.Pod, []corev1.ConfigMap, error) {
+func (b podBuilder) ToPod(svc composeTypes.ServiceConfig) (
+	pod corev1.Pod, configMaps []corev1.ConfigMap, warnings []string, err error) {
 	spec := podSpec{namespace: b.user.Namespace}
 	spec.pod.Spec.Affinity = affinity.ForUser(b.user)
 
@@ -144,6 +154,8 @@ func (b podBuilder) ToPod(svc composeTypes.ServiceConfig) (corev1.Pod, []corev1.
 		spec.image = svc.Image
 		if builtTag, ok := b.builtTags[spec.image]; ok {
 			spec.image = builtTag
+		} else {
+			spec.image = mirrorImage(spec.image, RegistryMirrorHostname)
 		}
 	}
 
@@ -175,7 +187,7 @@ func (b podBuilder) ToPod(svc composeTypes.ServiceConfig) (corev1.Pod, []corev1.
 			err := spec.addWaiter(b.nodeControllerIP, svc.Name, kube.ContainerNameWaitInitializedVolumes,
 				wait.WaitSpec{FinishedVolumeInit: servicesSharingVolumes})
 			if err != nil {
-				return corev1.Pod{}, nil, err
+				return corev1.Pod{}, nil, nil, err
 			}
 		}
 	}
@@ -184,7 +196,7 @@ func (b podBuilder) ToPod(svc composeTypes.ServiceConfig) (corev1.Pod, []corev1.
 		err := spec.addWaiter(b.nodeControllerIP, svc.Name, kube.ContainerNameWaitDependsOn,
 			wait.WaitSpec{DependsOn: marshalDependencies(svc.DependsOn, svc.Links)})
 		if err != nil {
-			return corev1.Pod{}, nil, err
+			return corev1.Pod{}, nil, nil, err
 		}
 	}
 
@@ -192,15 +204,15 @@ func (b podBuilder) ToPod(svc composeTypes.ServiceConfig) (corev1.Pod, []corev1.
 		err := spec.addWaiter(b.nodeControllerIP, svc.Name, kube.ContainerNameWaitInitialSync,
 			wait.WaitSpec{BindVolumes: bindVolumes})
 		if err != nil {
-			return corev1.Pod{}, nil, err
+			return corev1.Pod{}, nil, nil, err
 		}
 	}
 
 	if err := spec.addRuntimeContainer(svc, b.dnsIP, b.svcAliasesMapping, b.namedBindVolumes); err != nil {
-		return corev1.Pod{}, nil, err
+		return corev1.Pod{}, nil, nil, err
 	}
 	spec.sanitize()
-	return spec.pod, spec.configMaps, nil
+	return spec.pod, spec.configMaps, spec.warnings, nil
 }
 
 func (p *podSpec) addVolumeSeeder(volumes []composeTypes.ServiceVolumeConfig) {
@@ -283,8 +295,25 @@ func (p *podSpec) addRuntimeContainer(svc composeTypes.ServiceConfig, dnsIP stri
 		affinity.ColocateNamespaceKey: p.namespace,
 	}
 
+	tmpfsVolumes, err := getTmpfsVolumes(svc)
+	if err != nil {
+		return err
+	}
+
 	var volumeMounts []corev1.VolumeMount
-	for _, v := range svc.Volumes {
+	var usesPersistentVolume bool
+	allVolumes := append(append([]composeTypes.ServiceVolumeConfig{}, svc.Volumes...), tmpfsVolumes...)
+	for _, v := range allVolumes {
+		if v.Type == composeTypes.VolumeTypeTmpfs {
+			tmpfsVolume := toTmpfsVolume(v)
+			p.addVolume(tmpfsVolume)
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      tmpfsVolume.Name,
+				MountPath: v.Target,
+			})
+			continue
+		}
+
 		var subPath string
 		switch v.Type {
 		case composeTypes.VolumeTypeVolume:
@@ -301,10 +330,12 @@ func (p *podSpec) addRuntimeContainer(svc composeTypes.ServiceConfig, dnsIP stri
 			Name:      volume.PersistentVolume.Name,
 			SubPath:   subPath,
 			MountPath: v.Target,
+			ReadOnly:  v.ReadOnly,
 		})
+		usesPersistentVolume = true
 	}
 
-	if len(volumeMounts) != 0 {
+	if usesPersistentVolume {
 		p.addVolume(volume.PersistentVolume)
 	}
 
@@ -339,20 +370,25 @@ func (p *podSpec) addRuntimeContainer(svc composeTypes.ServiceConfig, dnsIP stri
 		}
 	}
 
+	readinessProbe, warning := toReadinessProbe(svc.HealthCheck)
+	if warning != "" {
+		p.warnings = append(p.warnings, fmt.Sprintf("Ignoring healthcheck for service %s: %s", svc.Name, warning))
+	}
+
 	p.pod.Spec.Containers = []corev1.Container{
 		{
 			Args:            svc.Command,
 			Command:         svc.Entrypoint,
 			Env:             toEnvVars(svc.Environment),
 			Image:           p.image,
-			ImagePullPolicy: "Always",
+			ImagePullPolicy: toPullPolicy(svc, p.image, RegistryMirrorHostname),
 			Name:            names.ToDNS1123(svc.Name),
 			SecurityContext: securityContext,
 			Stdin:           svc.StdinOpen,
 			TTY:             svc.Tty,
 			VolumeMounts:    volumeMounts,
 			WorkingDir:      svc.WorkingDir,
-			ReadinessProbe:  toReadinessProbe(svc.HealthCheck),
+			ReadinessProbe:  readinessProbe,
 			Resources: corev1.ResourceRequirements{
 				Limits: corev1.ResourceList{
 					"cpu":    resource.MustParse("4"),
@@ -411,6 +447,11 @@ func (p *podSpec) addRuntimeContainer(svc composeTypes.ServiceConfig, dnsIP stri
 
 		hostname := aliasParts[0]
 		ip := aliasParts[1]
+
+		// The host gateway runs in the DNS pod.
+		if ip == hostgateway.ExtraHostsGateway {
+			ip = dnsIP
+		}
 		aliasMap[ip] = append(aliasMap[ip], hostname)
 	}
 
@@ -426,15 +467,18 @@ func (p *podSpec) addRuntimeContainer(svc composeTypes.ServiceConfig, dnsIP stri
 	sort.Slice(hostAliases, func(i, j int) bool { return hostAliases[i].IP < hostAliases[j].IP })
 	p.pod.Spec.HostAliases = hostAliases
 
-	// Set the pod's restart policy.
-	p.pod.Spec.RestartPolicy = corev1.RestartPolicyNever
-	switch svc.Restart {
-	case "no":
-		p.pod.Spec.RestartPolicy = corev1.RestartPolicyNever
-	case "always", "unless-stopped":
-		p.pod.Spec.RestartPolicy = corev1.RestartPolicyAlways
-	case "on-failure":
-		p.pod.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	// Set the pod's restart policy. Kubernetes doesn't support limiting the
+	// number of restarts, so the restart limiter stops the pod once it's
+	// exceeded the limit in the annotation.
+	restartPolicy, maxRestarts, err := parseRestartPolicy(svc.Restart)
+	if err != nil {
+		return errors.NewFriendlyError("Invalid restart policy (%s) for service %s.\n"+
+			"The maximum number of retries for on-failure must be a positive integer.",
+			svc.Restart, svc.Name)
+	}
+	p.pod.Spec.RestartPolicy = restartPolicy
+	if maxRestarts > 0 {
+		p.pod.Annotations[metadata.MaxRestartsKey] = strconv.Itoa(maxRestarts)
 	}
 
 	// Setup DNS.
@@ -457,6 +501,37 @@ func (p *podSpec) addRuntimeContainer(svc composeTypes.ServiceConfig, dnsIP stri
 	return nil
 }
 
+// parseRestartPolicy converts a Docker Compose restart policy into a
+// Kubernetes restart policy. If the policy limits the number of restarts (e.g.
+// `on-failure:3`), the limit is returned as well. Otherwise, maxRestarts is
+// zero.
+func parseRestartPolicy(restart string) (policy corev1.RestartPolicy, maxRestarts int, err error) {
+	switch restart {
+	case "", "no":
+		return corev1.RestartPolicyNever, 0, nil
+	case "always", "unless-stopped":
+		return corev1.RestartPolicyAlways, 0, nil
+	case "on-failure":
+		return corev1.RestartPolicyOnFailure, 0, nil
+	}
+
+	maxRestartsStr := strings.TrimPrefix(restart, "on-failure:")
+	if maxRestartsStr == restart {
+		// Docker Compose validates the restart policy, so this shouldn't
+		// happen. Default to not restarting, like Docker.
+		return corev1.RestartPolicyNever, 0, nil
+	}
+
+	maxRestarts, err = strconv.Atoi(maxRestartsStr)
+	if err != nil {
+		return "", 0, err
+	}
+	if maxRestarts <= 0 {
+		return "", 0, errors.New("max restarts must be positive")
+	}
+	return corev1.RestartPolicyOnFailure, maxRestarts, nil
+}
+
 func toEnvVars(vars composeTypes.MappingWithEquals) (kubeVars []corev1.EnvVar) {
 	for k, vPtr := range vars {
 		// vPtr may be nil if only the key is specified.
@@ -483,25 +558,36 @@ func toEnvVars(vars composeTypes.MappingWithEquals) (kubeVars []corev1.EnvVar) {
 	return
 }
 
-func toReadinessProbe(healthCheck *composeTypes.HealthCheckConfig) *corev1.Probe {
-	if healthCheck == nil || len(healthCheck.Test) <= 1 {
-		return nil
+// toReadinessProbe converts the healthcheck into a readiness probe. If the
+// healthcheck can't be converted, it returns a warning explaining why it was
+// ignored.
+func toReadinessProbe(healthCheck *composeTypes.HealthCheckConfig) (*corev1.Probe, string) {
+	if healthCheck == nil || healthCheck.Disable {
+		return nil, ""
+	}
+
+	if len(healthCheck.Test) == 0 {
+		return nil, "healthchecks inherited from the image are not supported, " +
+			"so `test` must be set"
 	}
 
 	var command []string
 	switch healthCheck.Test[0] {
 	case "NONE":
-		return nil
+		return nil, ""
 	case "CMD":
+		if len(healthCheck.Test) < 2 {
+			return nil, "CMD healthchecks must specify a command"
+		}
 		command = healthCheck.Test[1:]
 	case "CMD-SHELL":
+		if len(healthCheck.Test) != 2 {
+			return nil, "CMD-SHELL healthchecks must specify exactly one command string"
+		}
 		command = []string{"sh", "-c", healthCheck.Test[1]}
 	default:
-		// XXX: We should really inform the user that we failed to parse the
-		// healthcheck, but we currently don't have a way of sending warnings
-		// back to the CLI.
-		log.WithField("command", command).Warn("Ignoring healthcheck with unrecognized command")
-		return nil
+		return nil, fmt.Sprintf("unrecognized healthcheck type %q. "+
+			"The test must start with NONE, CMD, or CMD-SHELL", healthCheck.Test[0])
 	}
 
 	probe := &corev1.Probe{
@@ -540,7 +626,7 @@ func toReadinessProbe(healthCheck *composeTypes.HealthCheckConfig) *corev1.Probe
 		probe.FailureThreshold = int32(*healthCheck.Retries)
 	}
 
-	return probe
+	return probe, ""
 }
 
 func marshalDependencies(dependsOn composeTypes.DependsOnConfig, links []string) []*wait.ServiceCondition {
@@ -587,6 +673,40 @@ func marshalDependencies(dependsOn composeTypes.DependsOnConfig, links []string)
 	return pbDeps
 }
 
+// getTmpfsVolumes converts the service's `tmpfs` entries into the equivalent
+// tmpfs volumes.
+func getTmpfsVolumes(svc composeTypes.ServiceConfig) ([]composeTypes.ServiceVolumeConfig, error) {
+	var volumes []composeTypes.ServiceVolumeConfig
+	for _, entry := range svc.Tmpfs {
+		v, err := dockercompose.ParseTmpfs(entry)
+		if err != nil {
+			return nil, errors.WithContext(fmt.Sprintf("service %s", svc.Name), err)
+		}
+		volumes = append(volumes, v)
+	}
+	return volumes, nil
+}
+
+// toTmpfsVolume returns a memory-backed emptyDir for the tmpfs volume. The
+// size limit defaults to defaultTmpfsSize if the volume doesn't specify one,
+// since the memory counts against the node.
+func toTmpfsVolume(v composeTypes.ServiceVolumeConfig) corev1.Volume {
+	size := resource.MustParse(defaultTmpfsSize)
+	if v.Tmpfs != nil && v.Tmpfs.Size > 0 {
+		size = *resource.NewQuantity(v.Tmpfs.Size, resource.BinarySI)
+	}
+
+	return corev1.Volume{
+		Name: "tmpfs-" + hash.DNSCompliant(v.Target),
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium:    corev1.StorageMediumMemory,
+				SizeLimit: &size,
+			},
+		},
+	}
+}
+
 func (p *podSpec) sanitize() {
 	// Retain the same order to avoid unnecessary changes to the pod spec.
 	var volumes []corev1.Volume
@@ -618,6 +738,9 @@ func (p *podSpec) addWaiter(nodeControllerIP, svcName, waitType string, spec wai
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: p.namespace,
 			Name:      names.ToDNS1123(fmt.Sprintf("wait-spec-%s-%s", waitType, svcName)),
+			Labels: map[string]string{
+				kube.WaitSpecLabel: "true",
+			},
 		},
 		BinaryData: map[string][]byte{
 			"wait-spec": waitSpecBytes,
@@ -657,6 +780,16 @@ func (p *podSpec) addWaiter(nodeControllerIP, svcName, waitType string, spec wai
 				},
 			},
 
+			// Used to record the waiter's progress in events on the pod.
+			{
+				Name: "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "metadata.name",
+					},
+				},
+			},
+
 			// Trigger a restart if the wait spec changes.
 			{
 				Name:  "WAIT_SPEC_HASH",
@@ -5,7 +5,14 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	composeTypes "github.com/kelda/compose-go/types"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/kelda/blimp/cluster-controller/volume"
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/names"
 	"github.com/kelda/blimp/pkg/proto/wait"
 	"github.com/kelda/blimp/pkg/hash"
 )
@@ -32,3 +39,256 @@ func TestWaitSpecHash(t *testing.T) {
 		}
 	}
 }
+
+func TestRuntimeContainerVolumes(t *testing.T) {
+	svc := composeTypes.ServiceConfig{
+		Name:  "web",
+		Tmpfs: composeTypes.StringList{"/run:size=1m"},
+		Volumes: []composeTypes.ServiceVolumeConfig{
+			{Type: composeTypes.VolumeTypeBind, Source: "/config", Target: "/etc/app", ReadOnly: true},
+			{Type: composeTypes.VolumeTypeTmpfs, Target: "/tmp"},
+		},
+	}
+
+	var spec podSpec
+	assert.NoError(t, spec.addRuntimeContainer(svc, "", nil, nil))
+
+	mounts := spec.pod.Spec.Containers[0].VolumeMounts
+	assert.Len(t, mounts, 3)
+	assert.Equal(t, volume.PersistentVolume.Name, mounts[0].Name)
+	assert.True(t, mounts[0].ReadOnly)
+
+	sizes := map[string]string{}
+	for _, v := range spec.pod.Spec.Volumes {
+		if v.EmptyDir != nil {
+			assert.Equal(t, corev1.StorageMediumMemory, v.EmptyDir.Medium)
+			sizes[v.Name] = v.EmptyDir.SizeLimit.String()
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"tmpfs-" + hash.DNSCompliant("/tmp"): defaultTmpfsSize,
+		"tmpfs-" + hash.DNSCompliant("/run"): "1Mi",
+	}, sizes)
+
+	svc.Tmpfs = composeTypes.StringList{"/run:size=big"}
+	assert.Error(t, (&podSpec{}).addRuntimeContainer(svc, "", nil, nil))
+}
+
+func TestToReadinessProbe(t *testing.T) {
+	tests := []struct {
+		name        string
+		healthCheck *composeTypes.HealthCheckConfig
+		expCommand  []string
+		expWarning  bool
+	}{
+		{
+			name:        "CMD",
+			healthCheck: &composeTypes.HealthCheckConfig{Test: []string{"CMD", "curl", "localhost"}},
+			expCommand:  []string{"curl", "localhost"},
+		},
+		{
+			name:        "CMD-SHELL",
+			healthCheck: &composeTypes.HealthCheckConfig{Test: []string{"CMD-SHELL", "curl localhost"}},
+			expCommand:  []string{"sh", "-c", "curl localhost"},
+		},
+		{
+			name:        "NONE",
+			healthCheck: &composeTypes.HealthCheckConfig{Test: []string{"NONE"}},
+		},
+		{
+			name: "Disabled",
+			healthCheck: &composeTypes.HealthCheckConfig{
+				Test:    []string{"CMD", "curl", "localhost"},
+				Disable: true,
+			},
+		},
+		{
+			name:        "Unrecognized type",
+			healthCheck: &composeTypes.HealthCheckConfig{Test: []string{"HTTP", "localhost"}},
+			expWarning:  true,
+		},
+		{
+			name:        "CMD without command",
+			healthCheck: &composeTypes.HealthCheckConfig{Test: []string{"CMD"}},
+			expWarning:  true,
+		},
+		{
+			name:        "Inherited from image",
+			healthCheck: &composeTypes.HealthCheckConfig{},
+			expWarning:  true,
+		},
+	}
+
+	for _, test := range tests {
+		probe, warning := toReadinessProbe(test.healthCheck)
+		assert.Equal(t, test.expWarning, warning != "", test.name)
+		if test.expCommand == nil {
+			assert.Nil(t, probe, test.name)
+		} else {
+			assert.Equal(t, test.expCommand, probe.Exec.Command, test.name)
+		}
+	}
+}
+
+func TestParseRestartPolicy(t *testing.T) {
+	tests := []struct {
+		restart        string
+		expPolicy      corev1.RestartPolicy
+		expMaxRestarts int
+		expErr         bool
+	}{
+		{restart: "", expPolicy: corev1.RestartPolicyNever},
+		{restart: "no", expPolicy: corev1.RestartPolicyNever},
+		{restart: "always", expPolicy: corev1.RestartPolicyAlways},
+		{restart: "unless-stopped", expPolicy: corev1.RestartPolicyAlways},
+		{restart: "on-failure", expPolicy: corev1.RestartPolicyOnFailure},
+		{restart: "on-failure:3", expPolicy: corev1.RestartPolicyOnFailure, expMaxRestarts: 3},
+		{restart: "on-failure:0", expErr: true},
+		{restart: "on-failure:three", expErr: true},
+	}
+
+	for _, test := range tests {
+		policy, maxRestarts, err := parseRestartPolicy(test.restart)
+		if test.expErr {
+			assert.Error(t, err, test.restart)
+			continue
+		}
+		assert.NoError(t, err, test.restart)
+		assert.Equal(t, test.expPolicy, policy, test.restart)
+		assert.Equal(t, test.expMaxRestarts, maxRestarts, test.restart)
+	}
+}
+
+func TestExtraHostsGateway(t *testing.T) {
+	svc := composeTypes.ServiceConfig{
+		Name: "web",
+		ExtraHosts: composeTypes.HostsList{
+			"host.docker.internal:host-gateway",
+			"db:10.0.0.5",
+		},
+	}
+
+	var spec podSpec
+	assert.NoError(t, spec.addRuntimeContainer(svc, "10.0.0.2", nil, nil))
+	assert.Equal(t, []corev1.HostAlias{
+		{IP: "10.0.0.2", Hostnames: []string{"host.docker.internal"}},
+		{IP: "10.0.0.5", Hostnames: []string{"db"}},
+	}, spec.pod.Spec.HostAliases)
+}
+
+func TestToOneOffPod(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "namespace",
+			Labels:      map[string]string{"blimp.customerPod": "true"},
+			Annotations: map[string]string{"blimp.dependsOnSpec": "spec"},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: kube.ContainerNameCopyVCP},
+				{Name: kube.ContainerNameWaitInitialSync},
+			},
+			Containers: []corev1.Container{{
+				Name:           "web",
+				ReadinessProbe: &corev1.Probe{},
+			}},
+			RestartPolicy: corev1.RestartPolicyAlways,
+		},
+	}
+
+	oneOff := toOneOffPod(pod, "web", true)
+	assert.Empty(t, oneOff.Name)
+	assert.Equal(t, "web-run-", oneOff.GenerateName)
+	assert.Equal(t, "true", oneOff.Labels[kube.OneOffPodLabel])
+	assert.Empty(t, oneOff.Labels["blimp.customerPod"])
+	assert.Empty(t, oneOff.Annotations)
+	assert.Equal(t, corev1.RestartPolicyNever, oneOff.Spec.RestartPolicy)
+	assert.Len(t, oneOff.Spec.InitContainers, 1)
+
+	container := oneOff.Spec.Containers[0]
+	assert.True(t, container.Stdin)
+	assert.True(t, container.StdinOnce)
+	assert.True(t, container.TTY)
+	assert.Nil(t, container.ReadinessProbe)
+
+	// The original pod shouldn't be modified.
+	assert.NotNil(t, pod.Spec.Containers[0].ReadinessProbe)
+}
+
+func TestToPodsReplicas(t *testing.T) {
+	three := uint64(3)
+	cfg := composeTypes.Project{
+		Services: composeTypes.Services{
+			{Name: "db", Image: "postgres"},
+			{Name: "worker", Image: "worker", Deploy: &composeTypes.DeployConfig{Replicas: &three}},
+		},
+	}
+
+	pods, _, _, err := toPods(auth.User{Namespace: "namespace"}, "10.0.0.2", "10.0.0.3", cfg, nil)
+	assert.NoError(t, err)
+
+	var podNames, displayNames []string
+	replicated := replicatedServices(pods)
+	for _, pod := range pods {
+		podNames = append(podNames, pod.Name)
+		displayNames = append(displayNames, podDisplayName(pod, replicated))
+	}
+	assert.Equal(t, []string{
+		names.ToDNS1123("db"),
+		// The first replica should have the same name as if the service
+		// weren't replicated.
+		names.ToDNS1123("worker"),
+		names.ToDNS1123("worker.2"),
+		names.ToDNS1123("worker.3"),
+	}, podNames)
+	assert.Equal(t, []string{"db", "worker.1", "worker.2", "worker.3"}, displayNames)
+	assert.Equal(t, "3", pods[3].Labels[kube.ReplicaLabel])
+	assert.NotContains(t, pods[0].Labels, kube.ReplicaLabel)
+
+	// The first replica should be the same pod as if the service weren't
+	// replicated.
+	assert.NotContains(t, pods[1].Labels, kube.ReplicaLabel)
+
+	// Replicas can't share a name with another service.
+	cfg.Services = append(cfg.Services, composeTypes.ServiceConfig{Name: "worker.2", Image: "worker"})
+	_, _, _, err = toPods(auth.User{Namespace: "namespace"}, "10.0.0.2", "10.0.0.3", cfg, nil)
+	assert.Error(t, err)
+}
+
+func TestToPodsDefaultPullPolicy(t *testing.T) {
+	oldMirror := RegistryMirrorHostname
+	RegistryMirrorHostname = "mirror.blimp.test"
+	defer func() { RegistryMirrorHostname = oldMirror }()
+
+	cfg := composeTypes.Project{
+		Services: composeTypes.Services{
+			{Name: "db", Image: "postgres:13"},
+			{Name: "web", Image: "nginx"},
+			{Name: "cache", Image: "bitnami/redis:6.0"},
+			{
+				Name:       "private",
+				Image:      "bitnami/redis:6.0",
+				Extensions: map[string]interface{}{"pull_policy": "never"},
+			},
+		},
+	}
+
+	pods, _, _, err := toPods(auth.User{Namespace: "namespace"}, "10.0.0.2", "10.0.0.3", cfg, nil)
+	assert.NoError(t, err)
+	assert.Len(t, pods, 4)
+
+	// Services without a pull policy only skip pulling tagged images from
+	// the mirror. Images that may be private are always pulled, even if the
+	// service sets a pull policy.
+	policies := map[string]corev1.PullPolicy{}
+	for _, pod := range pods {
+		policies[podDisplayName(pod, nil)] = pod.Spec.Containers[0].ImagePullPolicy
+	}
+	assert.Equal(t, map[string]corev1.PullPolicy{
+		"db":      corev1.PullIfNotPresent,
+		"web":     corev1.PullAlways,
+		"cache":   corev1.PullAlways,
+		"private": corev1.PullAlways,
+	}, policies)
+}
@@ -0,0 +1,48 @@
+/*
+Package registry garbage collects the images in the Blimp registry.
+
+BACKGROUND
+
+Images built by `blimp build` and `blimp up` are pushed to
+RegistryHostname/<namespace>/<service>, and base images are pre-pushed to the
+same namespace by TagImages. Each build of a service overwrites the same tag
+(see build.RemoteImageName), so the previous build is left as an untagged
+manifest that can't be listed through the registry API.
+
+BUILD HISTORY
+
+To track previous builds, the cluster manager tags every image that it deploys
+with a tag derived from the image's digest (see BuildTag). The build tags keep
+old builds listable, and also keep the images of running pods tagged even once
+a newer build overwrites the service's tag.
+
+GARBAGE COLLECTION
+
+The collector periodically walks every repository in the registry. For each
+repository, it keeps:
+  - The images referenced by pods, including the saved pods of services
+    stopped by `blimp stop`.
+  - The newest N images, ordered by when they were built.
+
+All other manifests are deleted. `blimp down --volumes` deletes all the images
+in the namespace.
+
+Deleting a manifest through the registry API only removes the reference to its
+layers. The registry must have deletes enabled
+(REGISTRY_STORAGE_DELETE_ENABLED), and the layers are only freed from disk by
+the registry's offline `registry garbage-collect` command.
+
+QUOTAS
+
+After each collection, the collector annotates each namespace with the total
+size of its remaining images (kube.RegistryUsageAnnotation). Namespaces
+without any repositories have their usage reset to zero. The registry's
+authz hook rejects pushes from namespaces whose usage exceeds the quota.
+
+ADMIN CREDENTIALS
+
+The collector needs to read and delete images in every namespace, so it
+authenticates as auth.RegistryAdminUsername, with a password shared between
+the cluster manager and the registry's auth hook.
+*/
+package registry
@@ -0,0 +1,296 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	log "github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+)
+
+const (
+	// DefaultKeepBuilds is the default number of images to keep in each
+	// repository, in addition to the images that are in use.
+	DefaultKeepBuilds = 3
+
+	// gcInterval is how often the collector runs.
+	gcInterval = time.Hour
+
+	// buildTagPrefix is the prefix of the tags used to track previous builds.
+	buildTagPrefix = "build-"
+)
+
+// Collector deletes unused images from the Blimp registry.
+type Collector struct {
+	kubeClient kubernetes.Interface
+	registry   Registry
+	hostname   string
+
+	// inUse returns the references of all the images that are used by pods.
+	inUse func() ([]string, error)
+
+	// keepBuilds is the number of images to keep in each repository, in
+	// addition to the images that are in use.
+	keepBuilds int
+}
+
+// NewCollector creates a collector for the registry at `hostname`.
+func NewCollector(kubeClient kubernetes.Interface, registry Registry, hostname string,
+	inUse func() ([]string, error), keepBuilds int) *Collector {
+	return &Collector{
+		kubeClient: kubeClient,
+		registry:   registry,
+		hostname:   hostname,
+		inUse:      inUse,
+		keepBuilds: keepBuilds,
+	}
+}
+
+// Run garbage collects the registry every gcInterval until `stop` is closed.
+func (c *Collector) Run(stop <-chan struct{}) {
+	for {
+		if err := c.Collect(); err != nil {
+			log.WithError(err).Error("Failed to garbage collect registry")
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(gcInterval):
+		}
+	}
+}
+
+// BuildTag returns the tag used to track the build with the given digest.
+func BuildTag(digest string) string {
+	return buildTagPrefix + strings.TrimPrefix(digest, "sha256:")
+}
+
+// RecordBuilds tags the given images so that they're retained as previous
+// builds, even after a newer build overwrites their tag. Images that aren't
+// referenced by digest, or that aren't within the namespace, are ignored.
+func (c *Collector) RecordBuilds(namespace string, images []string) error {
+	for _, image := range images {
+		ref, err := name.NewDigest(image)
+		if err != nil {
+			continue
+		}
+
+		repo := ref.Context().RepositoryStr()
+		if ref.Context().RegistryStr() != c.hostname || !strings.HasPrefix(repo, namespace+"/") {
+			continue
+		}
+
+		if err := c.registry.Tag(repo, ref.DigestStr(), BuildTag(ref.DigestStr())); err != nil {
+			return errors.WithContext(fmt.Sprintf("tag %s", image), err)
+		}
+	}
+	return nil
+}
+
+// DeleteNamespace deletes all of the namespace's images.
+func (c *Collector) DeleteNamespace(namespace string) error {
+	repos, err := c.registry.Repositories()
+	if err != nil {
+		return errors.WithContext("list repositories", err)
+	}
+
+	for _, repo := range repos {
+		if !strings.HasPrefix(repo, namespace+"/") {
+			continue
+		}
+
+		images, err := c.getImages(repo)
+		if err != nil {
+			return errors.WithContext(fmt.Sprintf("get images in %s", repo), err)
+		}
+
+		for _, image := range images {
+			if err := c.registry.Delete(repo, image.Digest); err != nil {
+				return errors.WithContext(fmt.Sprintf("delete %s@%s", repo, image.Digest), err)
+			}
+		}
+	}
+	return nil
+}
+
+// Collect deletes the images that aren't in use, and aren't one of the newest
+// builds in their repository. It then updates the registry usage of each
+// namespace.
+func (c *Collector) Collect() error {
+	refs, err := c.inUse()
+	if err != nil {
+		return errors.WithContext("get images in use", err)
+	}
+	inUse := c.parseInUse(refs)
+
+	repos, err := c.registry.Repositories()
+	if err != nil {
+		return errors.WithContext("list repositories", err)
+	}
+
+	// The blobs used by each namespace. Blobs are deduplicated since images
+	// in the same namespace often share layers.
+	usage := map[string]map[string]int64{}
+	for _, repo := range repos {
+		namespace := strings.SplitN(repo, "/", 2)[0]
+		if namespace == repo {
+			continue
+		}
+
+		kept, err := c.collectRepo(repo, inUse)
+		if err != nil {
+			log.WithError(err).WithField("repo", repo).Warn("Failed to garbage collect repository")
+		}
+
+		if _, ok := usage[namespace]; !ok {
+			usage[namespace] = map[string]int64{}
+		}
+		for _, image := range kept {
+			for digest, size := range image.Blobs {
+				usage[namespace][digest] = size
+			}
+		}
+	}
+
+	for namespace, blobs := range usage {
+		var total int64
+		for _, size := range blobs {
+			total += size
+		}
+
+		if err := c.updateUsage(namespace, total); err != nil {
+			log.WithError(err).WithField("namespace", namespace).Warn("Failed to update registry usage")
+		}
+	}
+
+	// Namespaces whose repositories were all removed from the registry
+	// (e.g. by `blimp down --volumes`) no longer use any space, so their old
+	// usage shouldn't keep counting against the quota.
+	namespaces, err := c.kubeClient.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return errors.WithContext("list namespaces", err)
+	}
+
+	for _, namespace := range namespaces.Items {
+		if _, ok := usage[namespace.Name]; ok {
+			continue
+		}
+
+		bytes, ok := namespace.Annotations[kube.RegistryUsageAnnotation]
+		if !ok || bytes == "0" {
+			continue
+		}
+
+		if err := c.updateUsage(namespace.Name, 0); err != nil {
+			log.WithError(err).WithField("namespace", namespace.Name).Warn("Failed to reset registry usage")
+		}
+	}
+	return nil
+}
+
+// collectRepo deletes the unused images in the repository, and returns the
+// images that were kept.
+func (c *Collector) collectRepo(repo string, inUse map[string]struct{}) ([]Image, error) {
+	images, err := c.getImages(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the newest builds.
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Created.After(images[j].Created)
+	})
+
+	var kept []Image
+	for i, image := range images {
+		_, used := inUse[repo+"@"+image.Digest]
+		if used || i < c.keepBuilds {
+			kept = append(kept, image)
+			continue
+		}
+
+		log.WithField("repo", repo).WithField("digest", image.Digest).Info("Deleting unused image")
+		if err := c.registry.Delete(repo, image.Digest); err != nil {
+			return kept, errors.WithContext(fmt.Sprintf("delete %s", image.Digest), err)
+		}
+	}
+	return kept, nil
+}
+
+// getImages returns the images referenced by the tags in the repository.
+// Images with multiple tags are only returned once.
+func (c *Collector) getImages(repo string) ([]Image, error) {
+	tags, err := c.registry.Tags(repo)
+	if err != nil {
+		return nil, errors.WithContext("list tags", err)
+	}
+
+	var images []Image
+	seen := map[string]struct{}{}
+	for _, tag := range tags {
+		image, err := c.registry.Image(repo, tag)
+		if err != nil {
+			return nil, errors.WithContext(fmt.Sprintf("get %s", tag), err)
+		}
+
+		if _, ok := seen[image.Digest]; ok {
+			continue
+		}
+		seen[image.Digest] = struct{}{}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// parseInUse converts the given image references into a set of
+// "repo@digest" strings. References to tags are resolved to their current
+// digest.
+func (c *Collector) parseInUse(refs []string) map[string]struct{} {
+	inUse := map[string]struct{}{}
+	for _, refStr := range refs {
+		ref, err := name.ParseReference(refStr)
+		if err != nil || ref.Context().RegistryStr() != c.hostname {
+			continue
+		}
+
+		repo := ref.Context().RepositoryStr()
+		digest := ref.Identifier()
+		if _, isTag := ref.(name.Tag); isTag {
+			image, err := c.registry.Image(repo, digest)
+			if err != nil {
+				// The image may have been deleted, or never pushed. Either
+				// way, there's nothing to keep.
+				continue
+			}
+			digest = image.Digest
+		}
+		inUse[repo+"@"+digest] = struct{}{}
+	}
+	return inUse
+}
+
+// updateUsage records the number of bytes that the namespace's images take up
+// in the registry. The usage is checked by the registry's authz hook to
+// enforce quotas.
+func (c *Collector) updateUsage(namespace string, bytes int64) error {
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`,
+		kube.RegistryUsageAnnotation, strconv.FormatInt(bytes, 10))
+	_, err := c.kubeClient.CoreV1().Namespaces().Patch(namespace, types.MergePatchType, []byte(patch))
+	if kerrors.IsNotFound(err) {
+		// The sandbox was removed by `blimp down`, so there's nothing to
+		// annotate. Its usage is recorded once it's booted again.
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,206 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+)
+
+const testHostname = "registry.blimp.test"
+
+// fakeRegistry is an in-memory registry that tracks the images referenced by
+// each tag.
+type fakeRegistry struct {
+	// tags maps repositories to tags to digests.
+	tags   map[string]map[string]string
+	images map[string]Image
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		tags:   map[string]map[string]string{},
+		images: map[string]Image{},
+	}
+}
+
+// push adds an image to the repository, and tags it.
+func (r *fakeRegistry) push(repo, tag string, created time.Time, blobs map[string]int64) string {
+	digest := fmt.Sprintf("sha256:%064d", len(r.images)+1)
+	r.images[digest] = Image{Digest: digest, Created: created, Blobs: blobs}
+	if _, ok := r.tags[repo]; !ok {
+		r.tags[repo] = map[string]string{}
+	}
+	r.tags[repo][tag] = digest
+	return digest
+}
+
+func (r *fakeRegistry) digests(repo string) (digests []string) {
+	seen := map[string]bool{}
+	for _, digest := range r.tags[repo] {
+		if !seen[digest] {
+			digests = append(digests, digest)
+			seen[digest] = true
+		}
+	}
+	sort.Strings(digests)
+	return digests
+}
+
+func (r *fakeRegistry) Repositories() (repos []string, err error) {
+	for repo := range r.tags {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos, nil
+}
+
+func (r *fakeRegistry) Tags(repo string) (tags []string, err error) {
+	for tag := range r.tags[repo] {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+func (r *fakeRegistry) Image(repo, tagOrDigest string) (Image, error) {
+	digest := tagOrDigest
+	if !strings.Contains(tagOrDigest, ":") {
+		digest = r.tags[repo][tagOrDigest]
+	}
+
+	image, ok := r.images[digest]
+	if !ok {
+		return Image{}, errors.New("not found")
+	}
+	return image, nil
+}
+
+func (r *fakeRegistry) Tag(repo, digest, tag string) error {
+	if _, ok := r.images[digest]; !ok {
+		return errors.New("not found")
+	}
+	r.tags[repo][tag] = digest
+	return nil
+}
+
+func (r *fakeRegistry) Delete(repo, digest string) error {
+	for tag, tagDigest := range r.tags[repo] {
+		if tagDigest == digest {
+			delete(r.tags[repo], tag)
+		}
+	}
+	if len(r.tags[repo]) == 0 {
+		delete(r.tags, repo)
+	}
+	return nil
+}
+
+func TestCollect(t *testing.T) {
+	reg := newFakeRegistry()
+	start := time.Now()
+	build := func(repo string, i int) string {
+		return reg.push(repo, BuildTag(fmt.Sprintf("sha256:%d", i)), start.Add(time.Duration(i)*time.Minute),
+			map[string]int64{"base": 100, fmt.Sprintf("layer-%d", i): 10})
+	}
+
+	// Builds 1 through 5 of the web service. The latest build is also
+	// referenced by the service's tag.
+	var web []string
+	for i := 1; i <= 5; i++ {
+		web = append(web, build("ns/web", i))
+	}
+	reg.tags["ns/web"]["latest"] = web[4]
+
+	// A base image that was pushed by TagImages, and is referenced by tag.
+	postgres := reg.push("ns/postgres", "tag", start, map[string]int64{"postgres": 1000})
+	oldPostgres := reg.push("ns/postgres", "old", start.Add(-time.Hour), map[string]int64{"old-postgres": 1000})
+
+	// The images of another namespace.
+	other := build("other/web", 6)
+
+	kubeClient := fakeKube.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}},
+		// A namespace whose images were all deleted.
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "deleted",
+			Annotations: map[string]string{kube.RegistryUsageAnnotation: "5000"},
+		}},
+	)
+	inUse := func() ([]string, error) {
+		return []string{
+			// An old build that's still running.
+			fmt.Sprintf("%s/ns/web@%s", testHostname, web[0]),
+			fmt.Sprintf("%s/ns/postgres:tag", testHostname),
+			// Images outside the registry should be ignored.
+			"postgres:latest",
+		}, nil
+	}
+
+	c := NewCollector(kubeClient, reg, testHostname, inUse, 2)
+	require.NoError(t, c.Collect())
+
+	// The running build, and the two newest builds should be kept.
+	assert.Equal(t, []string{web[0], web[3], web[4]}, reg.digests("ns/web"))
+	assert.Equal(t, []string{postgres, oldPostgres}, reg.digests("ns/postgres"))
+	assert.Equal(t, []string{other}, reg.digests("other/web"))
+
+	// The usage should count each blob once.
+	ns, err := kubeClient.CoreV1().Namespaces().Get("ns", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "2130", ns.Annotations[kube.RegistryUsageAnnotation])
+
+	// The stale usage of the namespace without any images should be reset.
+	deleted, err := kubeClient.CoreV1().Namespaces().Get("deleted", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "0", deleted.Annotations[kube.RegistryUsageAnnotation])
+
+	// Lowering the limit deletes older builds, but keeps the images in use.
+	c.keepBuilds = 1
+	require.NoError(t, c.Collect())
+	assert.Equal(t, []string{web[0], web[4]}, reg.digests("ns/web"))
+	assert.Equal(t, []string{postgres}, reg.digests("ns/postgres"))
+}
+
+func TestRecordBuilds(t *testing.T) {
+	reg := newFakeRegistry()
+	web := reg.push("ns/web", "latest", time.Now(), nil)
+	other := reg.push("other/web", "latest", time.Now(), nil)
+
+	c := NewCollector(fakeKube.NewSimpleClientset(), reg, testHostname, nil, DefaultKeepBuilds)
+	require.NoError(t, c.RecordBuilds("ns", []string{
+		fmt.Sprintf("%s/ns/web@%s", testHostname, web),
+		// Images in other namespaces, or that aren't referenced by digest
+		// shouldn't be tagged.
+		fmt.Sprintf("%s/other/web@%s", testHostname, other),
+		fmt.Sprintf("%s/ns/web:latest", testHostname),
+	}))
+
+	assert.Equal(t, map[string]string{"latest": web, BuildTag(web): web}, reg.tags["ns/web"])
+	assert.Equal(t, map[string]string{"latest": other}, reg.tags["other/web"])
+}
+
+func TestDeleteNamespace(t *testing.T) {
+	reg := newFakeRegistry()
+	reg.push("ns/web", "latest", time.Now(), nil)
+	reg.push("ns/db", "latest", time.Now(), nil)
+	other := reg.push("ns-other/web", "latest", time.Now(), nil)
+
+	c := NewCollector(fakeKube.NewSimpleClientset(), reg, testHostname, nil, DefaultKeepBuilds)
+	require.NoError(t, c.DeleteNamespace("ns"))
+
+	repos, err := reg.Repositories()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ns-other/web"}, repos)
+	assert.Equal(t, []string{other}, reg.digests("ns-other/web"))
+}
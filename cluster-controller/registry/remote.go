@@ -0,0 +1,142 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// Registry is the subset of the Docker registry API used by the collector.
+// Repositories are referenced without the registry's hostname.
+type Registry interface {
+	// Repositories returns the names of all the repositories in the registry.
+	Repositories() ([]string, error)
+
+	// Tags returns the tags in the repository.
+	Tags(repo string) ([]string, error)
+
+	// Image returns the metadata for the image with the given tag or digest.
+	Image(repo, tagOrDigest string) (Image, error)
+
+	// Tag adds a tag to the image with the given digest.
+	Tag(repo, digest, tag string) error
+
+	// Delete deletes the image with the given digest, along with all the
+	// tags that reference it.
+	Delete(repo, digest string) error
+}
+
+// Image is the metadata for an image in the registry.
+type Image struct {
+	Digest  string
+	Created time.Time
+
+	// Blobs maps the digests of the image's config and layers to their size
+	// in bytes.
+	Blobs map[string]int64
+}
+
+type remoteRegistry struct {
+	hostname string
+	auth     authn.Authenticator
+}
+
+// NewRemote returns a Registry that accesses the registry at the given
+// hostname.
+func NewRemote(hostname string, auth authn.Authenticator) Registry {
+	return remoteRegistry{hostname, auth}
+}
+
+func (r remoteRegistry) Repositories() ([]string, error) {
+	registry, err := name.NewRegistry(r.hostname)
+	if err != nil {
+		return nil, errors.WithContext("parse registry", err)
+	}
+	return remote.Catalog(context.Background(), registry, remote.WithAuth(r.auth))
+}
+
+func (r remoteRegistry) Tags(repo string) ([]string, error) {
+	ref, err := name.NewRepository(fmt.Sprintf("%s/%s", r.hostname, repo))
+	if err != nil {
+		return nil, errors.WithContext("parse repository", err)
+	}
+	return remote.List(ref, remote.WithAuth(r.auth))
+}
+
+func (r remoteRegistry) Image(repo, tagOrDigest string) (Image, error) {
+	// Tags can't contain colons, while digests are prefixed by their
+	// algorithm, e.g. "sha256:".
+	sep := ":"
+	if strings.Contains(tagOrDigest, ":") {
+		sep = "@"
+	}
+	ref, err := name.ParseReference(fmt.Sprintf("%s/%s%s%s", r.hostname, repo, sep, tagOrDigest))
+	if err != nil {
+		return Image{}, errors.WithContext("parse reference", err)
+	}
+
+	desc, err := remote.Get(ref, remote.WithAuth(r.auth))
+	if err != nil {
+		return Image{}, errors.WithContext("get manifest", err)
+	}
+
+	img, err := desc.Image()
+	if err != nil {
+		return Image{}, errors.WithContext("get image", err)
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return Image{}, errors.WithContext("parse manifest", err)
+	}
+
+	config, err := img.ConfigFile()
+	if err != nil {
+		return Image{}, errors.WithContext("get config", err)
+	}
+
+	blobs := map[string]int64{
+		manifest.Config.Digest.String(): manifest.Config.Size,
+	}
+	for _, layer := range manifest.Layers {
+		blobs[layer.Digest.String()] = layer.Size
+	}
+	return Image{
+		Digest:  desc.Digest.String(),
+		Created: config.Created.Time,
+		Blobs:   blobs,
+	}, nil
+}
+
+func (r remoteRegistry) Tag(repo, digest, tag string) error {
+	src, err := name.NewDigest(fmt.Sprintf("%s/%s@%s", r.hostname, repo, digest))
+	if err != nil {
+		return errors.WithContext("parse digest", err)
+	}
+
+	dst, err := name.NewTag(fmt.Sprintf("%s/%s:%s", r.hostname, repo, tag))
+	if err != nil {
+		return errors.WithContext("parse tag", err)
+	}
+
+	desc, err := remote.Get(src, remote.WithAuth(r.auth))
+	if err != nil {
+		return errors.WithContext("get manifest", err)
+	}
+	return remote.Tag(dst, desc, remote.WithAuth(r.auth))
+}
+
+func (r remoteRegistry) Delete(repo, digest string) error {
+	ref, err := name.NewDigest(fmt.Sprintf("%s/%s@%s", r.hostname, repo, digest))
+	if err != nil {
+		return errors.WithContext("parse digest", err)
+	}
+	return remote.Delete(ref, remote.WithAuth(r.auth))
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/metadata"
+)
+
+// maxRestartLimiterRetries is the maximum number of times to retry stopping a
+// pod before giving up.
+const maxRestartLimiterRetries = 4
+
+// restartLimiter emulates Docker's `on-failure:N` restart policy. Kubernetes
+// restarts failed containers indefinitely, so the restart limiter watches the
+// restart counts of pods, and stops them once they've failed more than N
+// times. Stopped pods are saved so that they can be restarted with `blimp
+// start`, just like services stopped by `blimp stop`.
+type restartLimiter struct {
+	kubeClient kubernetes.Interface
+	podLister  listers.PodLister
+	workqueue  workqueue.RateLimitingInterface
+}
+
+// runRestartLimiter stops pods that exceed their restart limit until `stop` is
+// closed. It doesn't return until the pod being stopped, if any, is saved.
+func runRestartLimiter(kubeClient kubernetes.Interface, sf *statusFetcher, stop <-chan struct{}) {
+	rl := restartLimiter{
+		kubeClient: kubeClient,
+		podLister:  sf.podLister,
+		workqueue:  workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	enqueue := func(obj interface{}) {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return
+		}
+
+		if _, stop := shouldStopRestarting(pod); !stop {
+			return
+		}
+
+		key, err := cache.MetaNamespaceKeyFunc(obj)
+		if err == nil {
+			rl.workqueue.Add(key)
+		}
+	}
+	sf.podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    enqueue,
+		UpdateFunc: func(_, cur interface{}) { enqueue(cur) },
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for !rl.runWorker() {
+		}
+	}()
+
+	<-stop
+	rl.workqueue.ShutDown()
+	wg.Wait()
+}
+
+func (rl *restartLimiter) runWorker() (shutdown bool) {
+	key, shutdown := rl.workqueue.Get()
+	if shutdown {
+		return true
+	}
+	defer rl.workqueue.Done(key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key.(string))
+	if err != nil {
+		log.WithError(err).WithField("key", key).Warn("Unexpected pod key")
+		rl.workqueue.Forget(key)
+		return false
+	}
+
+	pod, err := rl.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		// The pod was deleted, so there's nothing to do.
+		if kerrors.IsNotFound(err) {
+			rl.workqueue.Forget(key)
+			return false
+		}
+
+		log.WithError(err).WithField("key", key).Error("Failed to get pod")
+		rl.requeue(key)
+		return false
+	}
+
+	// The pod may have been replaced or already stopped since it was queued.
+	exitReason, stop := shouldStopRestarting(pod)
+	if !stop || pod.DeletionTimestamp != nil {
+		rl.workqueue.Forget(key)
+		return false
+	}
+
+	if err := rl.stop(pod, exitReason); err != nil {
+		log.WithError(err).WithField("key", key).Error("Failed to stop pod that exceeded its restart limit")
+		rl.requeue(key)
+		return false
+	}
+
+	log.WithField("namespace", namespace).
+		WithField("service", pod.Labels["blimp.service"]).
+		WithField("reason", exitReason).
+		Info("Stopped service that exceeded its restart limit")
+	rl.workqueue.Forget(key)
+	return false
+}
+
+func (rl *restartLimiter) stop(pod *corev1.Pod, exitReason string) error {
+	if err := saveStoppedPod(rl.kubeClient, pod, exitReason); err != nil {
+		return errors.WithContext("save pod", err)
+	}
+
+	// The container has already exited, so there's no need to wait for it to
+	// shut down gracefully.
+	zero := int64(0)
+	err := rl.kubeClient.CoreV1().Pods(pod.Namespace).
+		Delete(pod.Name, &metav1.DeleteOptions{GracePeriodSeconds: &zero})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.WithContext("delete pod", err)
+	}
+	return nil
+}
+
+func (rl *restartLimiter) requeue(key interface{}) {
+	if rl.workqueue.NumRequeues(key) < maxRestartLimiterRetries {
+		rl.workqueue.AddRateLimited(key)
+	} else {
+		log.WithField("key", key).Warn("Too many failures stopping pod. Not requeueing.")
+		rl.workqueue.Forget(key)
+	}
+}
+
+// shouldStopRestarting returns whether the pod has failed more times than its
+// restart policy allows. If so, it also returns a description of the final
+// failure.
+func shouldStopRestarting(pod *corev1.Pod) (exitReason string, stop bool) {
+	maxRestartsStr, ok := pod.Annotations[metadata.MaxRestartsKey]
+	if !ok {
+		return "", false
+	}
+
+	maxRestarts, err := strconv.Atoi(maxRestartsStr)
+	if err != nil {
+		return "", false
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		if int(cs.RestartCount) < maxRestarts {
+			continue
+		}
+
+		// After the final restart, the container is only stopped once it
+		// fails again. Kubernetes reports the failure either as the current
+		// state, or as the last state once it starts backing off.
+		lastFailure := cs.State.Terminated
+		isBackingOff := cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff"
+		if lastFailure == nil && (isBackingOff || int(cs.RestartCount) > maxRestarts) {
+			lastFailure = cs.LastTerminationState.Terminated
+		}
+		if int(cs.RestartCount) == maxRestarts && (lastFailure == nil || lastFailure.ExitCode == 0) {
+			continue
+		}
+
+		return fmt.Sprintf("Restarted %d times (maximum retry count %d). Last exit: %s",
+			cs.RestartCount, maxRestarts, describeExit(lastFailure)), true
+	}
+	return "", false
+}
+
+// describeExit returns a human-readable description of why a container
+// exited.
+func describeExit(terminated *corev1.ContainerStateTerminated) string {
+	if terminated == nil {
+		return "unknown"
+	}
+
+	desc := fmt.Sprintf("exit code %d", terminated.ExitCode)
+	if terminated.Reason != "" {
+		desc += fmt.Sprintf(" (%s)", terminated.Reason)
+	}
+	if terminated.Message != "" {
+		desc += ": " + terminated.Message
+	}
+	return desc
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kelda/blimp/pkg/metadata"
+)
+
+func TestShouldStopRestarting(t *testing.T) {
+	failed := &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}
+	succeeded := &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}
+	backingOff := &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+	creating := &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		status        corev1.ContainerStatus
+		expStop       bool
+		expExitReason string
+	}{
+		{
+			name:        "No limit",
+			annotations: nil,
+			status: corev1.ContainerStatus{
+				RestartCount: 10,
+				State:        corev1.ContainerState{Terminated: failed},
+			},
+		},
+		{
+			name:        "Under limit",
+			annotations: map[string]string{metadata.MaxRestartsKey: "3"},
+			status: corev1.ContainerStatus{
+				RestartCount: 2,
+				State:        corev1.ContainerState{Terminated: failed},
+			},
+		},
+		{
+			name:        "Final restart is running",
+			annotations: map[string]string{metadata.MaxRestartsKey: "3"},
+			status: corev1.ContainerStatus{
+				RestartCount:         3,
+				State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				LastTerminationState: corev1.ContainerState{Terminated: failed},
+			},
+		},
+		{
+			name:        "Final restart is being created",
+			annotations: map[string]string{metadata.MaxRestartsKey: "3"},
+			status: corev1.ContainerStatus{
+				RestartCount:         3,
+				State:                corev1.ContainerState{Waiting: creating},
+				LastTerminationState: corev1.ContainerState{Terminated: failed},
+			},
+		},
+		{
+			name:        "Final restart succeeded",
+			annotations: map[string]string{metadata.MaxRestartsKey: "3"},
+			status: corev1.ContainerStatus{
+				RestartCount: 3,
+				State:        corev1.ContainerState{Terminated: succeeded},
+			},
+		},
+		{
+			name:        "Final restart failed",
+			annotations: map[string]string{metadata.MaxRestartsKey: "3"},
+			status: corev1.ContainerStatus{
+				RestartCount: 3,
+				State:        corev1.ContainerState{Terminated: failed},
+			},
+			expStop:       true,
+			expExitReason: "Restarted 3 times (maximum retry count 3). Last exit: exit code 1 (Error)",
+		},
+		{
+			name:        "Final restart is backing off",
+			annotations: map[string]string{metadata.MaxRestartsKey: "3"},
+			status: corev1.ContainerStatus{
+				RestartCount:         3,
+				State:                corev1.ContainerState{Waiting: backingOff},
+				LastTerminationState: corev1.ContainerState{Terminated: failed},
+			},
+			expStop:       true,
+			expExitReason: "Restarted 3 times (maximum retry count 3). Last exit: exit code 1 (Error)",
+		},
+		{
+			name:        "Over limit",
+			annotations: map[string]string{metadata.MaxRestartsKey: "3"},
+			status: corev1.ContainerStatus{
+				RestartCount:         4,
+				State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				LastTerminationState: corev1.ContainerState{Terminated: failed},
+			},
+			expStop:       true,
+			expExitReason: "Restarted 4 times (maximum retry count 3). Last exit: exit code 1 (Error)",
+		},
+	}
+
+	for _, test := range tests {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{test.status},
+			},
+		}
+		exitReason, stop := shouldStopRestarting(pod)
+		assert.Equal(t, test.expStop, stop, test.name)
+		assert.Equal(t, test.expExitReason, exitReason, test.name)
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kelda/blimp/cluster-controller/affinity"
+	"github.com/kelda/blimp/cluster-controller/node"
+	"github.com/kelda/blimp/cluster-controller/sandbox"
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/dockercompose"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/kubewait"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+const (
+	// maxOneOffPodPrefixLength leaves room for the random suffix that
+	// Kubernetes appends to generated names.
+	maxOneOffPodPrefixLength = 57
+
+	// maxOneOffOutputBytes limits how much of the command's output is sent
+	// with the exit message, so that the message stays under gRPC's size
+	// limit.
+	maxOneOffOutputBytes = 1024 * 1024
+)
+
+func (s *server) RunOneOff(req *cluster.RunOneOffRequest, srv cluster.Manager_RunOneOffServer) error {
+	if err := s.runOneOff(req, srv); err != nil {
+		return srv.Send(&cluster.RunOneOffResponse{Error: errors.Marshal(err)})
+	}
+	return nil
+}
+
+func (s *server) runOneOff(req *cluster.RunOneOffRequest, srv cluster.Manager_RunOneOffServer) error {
+	ctx := srv.Context()
+	user, err := auth.AuthorizeRequest(req.GetAuth())
+	if err != nil {
+		return err
+	}
+
+	// The pod is built from the deployed Compose file rather than one sent by
+	// the CLI, so that it's validated the same way as the sandbox's other
+	// pods, and can rely on the wait spec ConfigMaps deployed for them.
+	sb, err := sandbox.Get(s.dynamicClient, user.Namespace)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.WithContext("get sandbox", err)
+	}
+	if err != nil || sb.Spec.ComposeFile == "" {
+		return errors.NewFriendlyError("The sandbox hasn't been deployed yet. Run `blimp up` first.")
+	}
+
+	dcCfg, err := dockercompose.Unmarshal([]byte(sb.Spec.ComposeFile))
+	if err != nil {
+		return errors.WithContext("unmarshal compose file", err)
+	}
+
+	svcIndex := -1
+	for i, svc := range dcCfg.Services {
+		if svc.Name == req.GetService() {
+			svcIndex = i
+			break
+		}
+	}
+	if svcIndex == -1 {
+		return errors.NewFriendlyError("Service %s isn't deployed. "+
+			"Add it to your Compose file and run `blimp up` first.", req.GetService())
+	}
+	if len(req.GetCommand()) != 0 {
+		dcCfg.Services[svcIndex].Command = req.GetCommand()
+	}
+
+	dnsIP, nodeControllerIP, err := s.getSandboxNetwork(ctx, user.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// Warnings are ignored since they were already shown by `blimp up`.
+	pods, _, _, err := toPods(user, dnsIP, nodeControllerIP, dcCfg, sb.Spec.BuiltImages)
+	if err != nil {
+		return errors.WithContext("make pod specs", err)
+	}
+
+	pod, ok := getServicePod(pods, req.GetService())
+	if !ok {
+		return errors.New("no pod for service %s", req.GetService())
+	}
+
+	pod = toOneOffPod(pod, req.GetService(), req.GetTty())
+	podClient := s.kubeClient.CoreV1().Pods(user.Namespace)
+	created, err := podClient.Create(&pod)
+	if err != nil {
+		return errors.WithContext("create pod", err)
+	}
+
+	log.WithField("namespace", user.Namespace).WithField("pod", created.Name).Info("Started one-off pod")
+	if !req.GetKeep() {
+		// Delete the pod even if the CLI disconnects.
+		defer func() {
+			err := podClient.Delete(created.Name, metav1.NewDeleteOptions(0))
+			if err != nil {
+				log.WithError(err).WithField("namespace", user.Namespace).
+					WithField("pod", created.Name).Warn("Failed to delete one-off pod")
+			}
+		}()
+	}
+
+	// Wait for the command to start, and send status updates so that the
+	// user knows what's going on in the meantime.
+	var lastStatus cluster.ServiceStatus
+	err = kubewait.WaitForObject(ctx,
+		kubewait.PodGetter(s.kubeClient, user.Namespace, created.Name),
+		podClient.Watch,
+		func(podIntf interface{}) bool {
+			pod := podIntf.(*corev1.Pod)
+			if oneOffStarted(pod) {
+				return true
+			}
+
+			status := s.statusFetcher.getServiceStatus(pod)
+			if !proto.Equal(&status, &lastStatus) {
+				lastStatus = status
+				//nolint:errcheck // The stream's context is canceled if the CLI disconnects.
+				srv.Send(&cluster.RunOneOffResponse{
+					Msg: &cluster.RunOneOffResponse_Status{Status: &status},
+				})
+			}
+			return false
+		})
+	if err != nil {
+		return errors.WithContext("wait for pod to start", err)
+	}
+
+	startedPod, err := podClient.Get(created.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.WithContext("get pod", err)
+	}
+
+	// Fast commands may exit before the CLI has a chance to attach. Their
+	// output is sent with the exit message instead.
+	if _, ok := getExitCode(startedPod); ok {
+		return s.sendOneOffExit(srv, startedPod)
+	}
+
+	nodeAddress, nodeCert, err := node.GetConnectionInfo(ctx, s.kubeClient, startedPod.Spec.NodeName)
+	if err != nil {
+		return errors.WithContext("get node connection info", err)
+	}
+
+	err = srv.Send(&cluster.RunOneOffResponse{
+		Msg: &cluster.RunOneOffResponse_Attach{Attach: &cluster.RunOneOffAttach{
+			PodName:     created.Name,
+			NodeAddress: nodeAddress,
+			NodeCert:    nodeCert,
+		}},
+	})
+	if err != nil {
+		return errors.WithContext("send attach info", err)
+	}
+
+	var exitedPod *corev1.Pod
+	err = kubewait.WaitForObject(ctx,
+		kubewait.PodGetter(s.kubeClient, user.Namespace, created.Name),
+		podClient.Watch,
+		func(podIntf interface{}) bool {
+			exitedPod = podIntf.(*corev1.Pod)
+			return exitedPod.Status.Phase == corev1.PodSucceeded ||
+				exitedPod.Status.Phase == corev1.PodFailed
+		})
+	if err != nil {
+		return errors.WithContext("wait for command to exit", err)
+	}
+
+	return s.sendOneOffExit(srv, exitedPod)
+}
+
+// sendOneOffExit sends the exit code of the one-off pod's command, along with
+// its output in case the CLI's attach missed some of it. The output must be
+// fetched before the pod is deleted.
+func (s *server) sendOneOffExit(srv cluster.Manager_RunOneOffServer, pod *corev1.Pod) error {
+	code, ok := getExitCode(pod)
+	if !ok {
+		return errors.NewFriendlyError("The command was stopped by Kubernetes: %s", pod.Status.Message)
+	}
+
+	limitBytes := int64(maxOneOffOutputBytes)
+	output, err := s.kubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  pod.Spec.Containers[0].Name,
+		LimitBytes: &limitBytes,
+	}).DoRaw()
+	if err != nil {
+		// The CLI can still show the output it received through the attach.
+		log.WithError(err).WithField("namespace", pod.Namespace).
+			WithField("pod", pod.Name).Warn("Failed to get one-off pod's output")
+		output = nil
+	}
+
+	return srv.Send(&cluster.RunOneOffResponse{
+		Msg: &cluster.RunOneOffResponse_Exit{Exit: &cluster.RunOneOffExit{
+			Code:    code,
+			PodName: pod.Name,
+			Output:  output,
+		}},
+	})
+}
+
+// getSandboxNetwork returns the IPs of the sandbox's DNS server and node
+// controller, which are needed to create the pods for services.
+func (s *server) getSandboxNetwork(ctx context.Context, namespace string) (
+	dnsIP, nodeControllerIP string, err error) {
+	dnsPod, err := s.getPod(ctx, namespace, "dns", podIsReady)
+	if err != nil {
+		return "", "", errors.WithContext("get dns server's IP", err)
+	}
+
+	nodeControllerIP, err = node.GetNodeControllerInternalIP(s.kubeClient, dnsPod.Spec.NodeName)
+	if err != nil {
+		return "", "", errors.WithContext("get node controller's IP", err)
+	}
+	return dnsPod.Status.PodIP, nodeControllerIP, nil
+}
+
+// getServicePod returns the pod for the first replica of the service.
+func getServicePod(pods []corev1.Pod, svcName string) (corev1.Pod, bool) {
+	for _, pod := range pods {
+		_, isReplica := pod.Labels[kube.ReplicaLabel]
+		if pod.Labels["blimp.service"] == svcName && !isReplica {
+			return pod, true
+		}
+	}
+	return corev1.Pod{}, false
+}
+
+// toOneOffPod converts the pod for a service into a pod that runs once and
+// can be attached to. The pod isn't labeled as a customer pod so that it
+// doesn't affect the service's status or DNS, and isn't removed by
+// `blimp up`.
+func toOneOffPod(pod corev1.Pod, svcName string, tty bool) corev1.Pod {
+	prefix := pod.Name + "-run"
+	if len(prefix) > maxOneOffPodPrefixLength {
+		prefix = prefix[:maxOneOffPodPrefixLength]
+	}
+	pod.Name = ""
+	pod.GenerateName = prefix + "-"
+	pod.Labels = map[string]string{
+		kube.OneOffPodLabel:           "true",
+		"blimp.service":               svcName,
+		affinity.ColocateNamespaceKey: pod.Namespace,
+	}
+	pod.Annotations = nil
+	pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+
+	// Don't block on the initial file sync since `blimp up` might not be
+	// running. The files from the last sync are still in the volume.
+	var initContainers []corev1.Container
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name != kube.ContainerNameWaitInitialSync {
+			initContainers = append(initContainers, c)
+		}
+	}
+	pod.Spec.InitContainers = initContainers
+
+	pod.Spec.Containers = append([]corev1.Container{}, pod.Spec.Containers...)
+	container := &pod.Spec.Containers[0]
+	container.Stdin = true
+	container.StdinOnce = true
+	container.TTY = tty
+	container.ReadinessProbe = nil
+	return pod
+}
+
+func oneOffStarted(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return true
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Running != nil || cs.State.Terminated != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func getExitCode(pod *corev1.Pod) (int32, bool) {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Terminated != nil {
+			return cs.State.Terminated.ExitCode, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,106 @@
+package sandbox
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// Get returns the Sandbox in the given namespace.
+func Get(dynamicClient dynamic.Interface, namespace string) (*Sandbox, error) {
+	obj, err := dynamicClient.Resource(GroupVersionResource).Namespace(namespace).
+		Get(Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return FromUnstructured(obj)
+}
+
+// UpdateSpec applies `update` to the spec of the Sandbox in the given
+// namespace, creating the Sandbox if it doesn't exist yet. It returns the
+// generation of the updated spec, which can be compared against
+// Status.ObservedGeneration to tell when the update has been reconciled.
+// `changed` is false if `update` didn't modify the spec, in which case the
+// Sandbox isn't written and its status may describe an earlier update.
+func UpdateSpec(dynamicClient dynamic.Interface, namespace string, update func(*Spec)) (
+	generation int64, changed bool, err error) {
+	client := dynamicClient.Resource(GroupVersionResource).Namespace(namespace)
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sb, err := Get(dynamicClient, namespace)
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return err
+			}
+
+			sb = &Sandbox{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      Name,
+				},
+			}
+			update(&sb.Spec)
+			obj, err := ToUnstructured(sb)
+			if err != nil {
+				return err
+			}
+
+			created, err := client.Create(obj, metav1.CreateOptions{})
+			if err != nil {
+				// If someone else created the Sandbox first, retry with
+				// an update.
+				if kerrors.IsAlreadyExists(err) {
+					return kerrors.NewConflict(GroupVersionResource.GroupResource(), Name, err)
+				}
+				return err
+			}
+			generation = created.GetGeneration()
+			changed = true
+			return nil
+		}
+
+		orig, err := ToUnstructured(sb)
+		if err != nil {
+			return err
+		}
+
+		update(&sb.Spec)
+		obj, err := ToUnstructured(sb)
+		if err != nil {
+			return err
+		}
+
+		if equality.Semantic.DeepEqual(orig.Object["spec"], obj.Object["spec"]) {
+			generation = sb.Generation
+			changed = false
+			return nil
+		}
+
+		updated, err := client.Update(obj, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		generation = updated.GetGeneration()
+		changed = true
+		return nil
+	})
+	if err != nil {
+		return 0, false, errors.WithContext("update sandbox spec", err)
+	}
+	return generation, changed, nil
+}
+
+// UpdateStatus writes the status of the given Sandbox.
+func UpdateStatus(dynamicClient dynamic.Interface, sb *Sandbox) error {
+	obj, err := ToUnstructured(sb)
+	if err != nil {
+		return err
+	}
+
+	_, err = dynamicClient.Resource(GroupVersionResource).Namespace(sb.Namespace).
+		UpdateStatus(obj, metav1.UpdateOptions{})
+	return err
+}
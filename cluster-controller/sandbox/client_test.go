@@ -0,0 +1,97 @@
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestUpdateSpec(t *testing.T) {
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	// The Sandbox should be created if it doesn't exist.
+	_, changed, err := UpdateSpec(client, "namespace", func(spec *Spec) {
+		spec.SyncedFolders = map[string]string{"id": "/src"}
+	})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	sb, err := Get(client, "namespace")
+	require.NoError(t, err)
+	assert.Equal(t, Name, sb.Name)
+	assert.Equal(t, Spec{SyncedFolders: map[string]string{"id": "/src"}}, sb.Spec)
+
+	// Updates should preserve the fields that weren't modified.
+	_, changed, err = UpdateSpec(client, "namespace", func(spec *Spec) {
+		spec.ComposeFile = "services: {}"
+		spec.NoRecreate = []string{"web"}
+	})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	sb, err = Get(client, "namespace")
+	require.NoError(t, err)
+	assert.Equal(t, Spec{
+		SyncedFolders: map[string]string{"id": "/src"},
+		ComposeFile:   "services: {}",
+		NoRecreate:    []string{"web"},
+	}, sb.Spec)
+
+	// Writing the same values shouldn't count as a change.
+	_, changed, err = UpdateSpec(client, "namespace", func(spec *Spec) {
+		spec.SyncedFolders = map[string]string{"id": "/src"}
+	})
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestIsReconciled(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     Status
+		generation int64
+		exp        bool
+	}{
+		{
+			name:       "NeverReconciled",
+			status:     Status{},
+			generation: 1,
+			exp:        false,
+		},
+		{
+			name:       "OldGeneration",
+			status:     Status{ObservedGeneration: 1, Phase: PhaseReady},
+			generation: 2,
+			exp:        false,
+		},
+		{
+			name:       "Ready",
+			status:     Status{ObservedGeneration: 2, Phase: PhaseReady},
+			generation: 2,
+			exp:        true,
+		},
+		{
+			name:       "Failed",
+			status:     Status{ObservedGeneration: 2, Phase: PhaseFailed},
+			generation: 2,
+			exp:        true,
+		},
+		{
+			name:       "Retrying",
+			status:     Status{ObservedGeneration: 2, Phase: PhasePending},
+			generation: 2,
+			exp:        false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			sb := Sandbox{Status: test.status}
+			assert.Equal(t, test.exp, sb.IsReconciled(test.generation))
+		})
+	}
+}
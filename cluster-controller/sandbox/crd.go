@@ -0,0 +1,56 @@
+package sandbox
+
+import (
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+var crdResource = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1beta1",
+	Resource: "customresourcedefinitions",
+}
+
+// EnsureCRD registers the Sandbox custom resource with the Kubernetes API
+// server if it isn't already registered.
+func EnsureCRD(dynamicClient dynamic.Interface) error {
+	// The CRD is built as an unstructured object since the apiextensions
+	// types aren't vendored.
+	crd := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1beta1",
+			"kind":       "CustomResourceDefinition",
+			"metadata": map[string]interface{}{
+				"name": Plural + "." + Group,
+			},
+			"spec": map[string]interface{}{
+				"group":   Group,
+				"version": Version,
+				"scope":   "Namespaced",
+				"names": map[string]interface{}{
+					"kind":     Kind,
+					"listKind": ListKind,
+					"singular": Singular,
+					"plural":   Plural,
+				},
+				// Enabling the status subresource makes the API server only
+				// bump the generation when the spec changes, and prevents
+				// spec updates from overwriting the status.
+				"subresources": map[string]interface{}{
+					"status": map[string]interface{}{},
+				},
+			},
+		},
+	}
+
+	_, err := dynamicClient.Resource(crdResource).Create(crd, metav1.CreateOptions{})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		return errors.WithContext("create CRD", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package sandbox
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+const (
+	Group    = "blimp.kelda.io"
+	Version  = "v1alpha1"
+	Kind     = "Sandbox"
+	ListKind = "SandboxList"
+	Singular = "sandbox"
+	Plural   = "sandboxes"
+
+	// Name is the name of the Sandbox object. Each sandbox namespace contains
+	// exactly one Sandbox.
+	Name = "sandbox"
+)
+
+// GroupVersionResource identifies the Sandbox custom resource.
+var GroupVersionResource = schema.GroupVersionResource{
+	Group:    Group,
+	Version:  Version,
+	Resource: Plural,
+}
+
+// Sandbox declares the desired state of a user's sandbox. The cluster manager's
+// RPCs write the spec, and the sandbox controller reconciles the namespace to
+// match it.
+type Sandbox struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   Spec   `json:"spec"`
+	Status Status `json:"status,omitempty"`
+}
+
+type Spec struct {
+	// SyncedFolders maps Syncthing folder IDs to the paths on the user's
+	// machine that they sync.
+	SyncedFolders map[string]string `json:"syncedFolders,omitempty"`
+
+	// ReceiveOnlyFolders are the IDs of the folders that are only synced
+	// from the sandbox to the user's machine.
+	ReceiveOnlyFolders []string `json:"receiveOnlyFolders,omitempty"`
+
+	// ComposeFile is the marshalled Compose project that should be running
+	// in the sandbox. It's empty until the first deploy.
+	ComposeFile string `json:"composeFile,omitempty"`
+
+	// BuiltImages maps service names to the images that were built for them.
+	BuiltImages map[string]string `json:"builtImages,omitempty"`
+
+	// NoRecreate are the services whose existing pods shouldn't be
+	// recreated, even if their spec changed.
+	NoRecreate []string `json:"noRecreate,omitempty"`
+
+	// Revision is incremented by every deploy, so that deploying an
+	// unchanged Compose file still changes the spec. This restarts services
+	// that were stopped since the last deploy.
+	Revision int64 `json:"revision,omitempty"`
+}
+
+type Phase string
+
+const (
+	// PhasePending means that the controller hasn't finished reconciling the
+	// latest spec.
+	PhasePending Phase = "Pending"
+
+	// PhaseReady means that the latest spec was successfully deployed.
+	PhaseReady Phase = "Ready"
+
+	// PhaseFailed means that the controller gave up deploying the latest
+	// spec.
+	PhaseFailed Phase = "Failed"
+)
+
+type Status struct {
+	// ObservedGeneration is the generation of the spec that the status
+	// describes.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	Phase Phase `json:"phase,omitempty"`
+
+	// Message describes the most recent error, if there was one.
+	Message string `json:"message,omitempty"`
+
+	// Warnings are shown to the user after a deploy.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// IsReconciled returns whether the controller is done reconciling the given
+// generation of the spec, either successfully or not.
+func (sb *Sandbox) IsReconciled(generation int64) bool {
+	return sb.Status.ObservedGeneration >= generation && sb.Status.Phase != PhasePending
+}
+
+// ToUnstructured converts the Sandbox into the representation used by the
+// dynamic client.
+func ToUnstructured(sb *Sandbox) (*unstructured.Unstructured, error) {
+	sb.APIVersion = Group + "/" + Version
+	sb.Kind = Kind
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(sb)
+	if err != nil {
+		return nil, errors.WithContext("convert to unstructured", err)
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// FromUnstructured parses a Sandbox returned by the dynamic client.
+func FromUnstructured(obj runtime.Object) (*Sandbox, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, errors.New("unexpected object type %T", obj)
+	}
+
+	var sb Sandbox
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &sb); err != nil {
+		return nil, errors.WithContext("convert from unstructured", err)
+	}
+	return &sb, nil
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kelda/blimp/cluster-controller/sandbox"
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/dockercompose"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+)
+
+const (
+	// maxSandboxRetries is the maximum number of times to retry reconciling a
+	// sandbox before giving up on its current spec.
+	maxSandboxRetries = 4
+
+	// numSandboxWorkers is the max number of sandboxes to reconcile in
+	// parallel.
+	numSandboxWorkers = 8
+
+	// sandboxResyncPeriod is how often every sandbox is reconciled, even if
+	// it hasn't changed. This redeploys any system pods that were removed.
+	sandboxResyncPeriod = 5 * time.Minute
+
+	// sandboxReconcileTimeout is the maximum amount of time a single attempt
+	// to reconcile a sandbox can take.
+	sandboxReconcileTimeout = 5 * time.Minute
+)
+
+// sandboxController reconciles the namespace of each sandbox to match its
+// Sandbox custom resource.
+//
+// The system pods (DNS, Syncthing, and buildkitd) are reconciled whenever the
+// Sandbox is synced. The customer pods are only reconciled when the spec
+// changes, so that services stopped by `blimp stop`, or by the restart limiter,
+// stay stopped until the next deploy.
+type sandboxController struct {
+	server        *server
+	dynamicClient dynamic.Interface
+	informer      cache.SharedIndexInformer
+	lister        cache.GenericLister
+	watcher       *kube.Watcher
+	workqueue     workqueue.RateLimitingInterface
+}
+
+// newSandboxController creates the Sandbox CRD, and starts the informer used
+// to look up sandboxes. The sandboxes aren't reconciled until `run` is called.
+func newSandboxController(s *server, dynamicClient dynamic.Interface) *sandboxController {
+	for {
+		err := sandbox.EnsureCRD(dynamicClient)
+		if err == nil {
+			break
+		}
+
+		log.WithError(err).Error("Failed to create Sandbox CRD. Retrying in 15 seconds.")
+		time.Sleep(15 * time.Second)
+	}
+
+	informer := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, sandboxResyncPeriod).
+		ForResource(sandbox.GroupVersionResource)
+
+	c := &sandboxController{
+		server:        s,
+		dynamicClient: dynamicClient,
+		informer:      informer.Informer(),
+		lister:        informer.Lister(),
+		watcher:       kube.NewWatcher(informer.Informer()),
+		workqueue:     workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	go c.informer.Run(nil)
+	cache.WaitForCacheSync(nil, c.informer.HasSynced)
+	return c
+}
+
+// run reconciles sandboxes until `stop` is closed. Only one replica of the
+// cluster manager should run the controller at a time, so run doesn't return
+// until the in-flight reconciles have finished.
+func (c *sandboxController) run(stop <-chan struct{}) {
+	enqueue := func(obj interface{}) {
+		key, err := cache.MetaNamespaceKeyFunc(obj)
+		if err == nil {
+			c.workqueue.Add(key)
+		}
+	}
+	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    enqueue,
+		UpdateFunc: func(_, cur interface{}) { enqueue(cur) },
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < numSandboxWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !c.runWorker() {
+			}
+		}()
+	}
+
+	<-stop
+	c.workqueue.ShutDown()
+	wg.Wait()
+}
+
+func (c *sandboxController) runWorker() (shutdown bool) {
+	key, shutdown := c.workqueue.Get()
+	if shutdown {
+		return true
+	}
+	defer c.workqueue.Done(key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key.(string))
+	if err != nil {
+		log.WithError(err).WithField("key", key).Warn("Unexpected sandbox key")
+		c.workqueue.Forget(key)
+		return false
+	}
+
+	obj, err := c.lister.ByNamespace(namespace).Get(name)
+	if err != nil {
+		// The sandbox was deleted, so there's nothing to do.
+		if kerrors.IsNotFound(err) {
+			c.workqueue.Forget(key)
+			return false
+		}
+
+		log.WithError(err).WithField("key", key).Error("Failed to get sandbox")
+		c.requeue(key)
+		return false
+	}
+
+	sb, err := sandbox.FromUnstructured(obj)
+	if err != nil {
+		log.WithError(err).WithField("key", key).Warn("Failed to parse sandbox")
+		c.workqueue.Forget(key)
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sandboxReconcileTimeout)
+	defer cancel()
+
+	status, err := c.reconcile(ctx, sb)
+	if err == nil {
+		c.workqueue.Forget(key)
+		c.updateStatus(sb, status)
+		return false
+	}
+
+	log.WithError(err).WithField("namespace", namespace).Error("Failed to reconcile sandbox")
+
+	// Errors with friendly messages are caused by the user's Compose file, so
+	// retrying won't help.
+	if errors.IsFriendly(err) || c.workqueue.NumRequeues(key) >= maxSandboxRetries {
+		c.workqueue.Forget(key)
+		if sb.Status.ObservedGeneration < sb.Generation {
+			c.updateStatus(sb, sandbox.Status{
+				ObservedGeneration: sb.Generation,
+				Phase:              sandbox.PhaseFailed,
+				Message:            errors.GetPrintableMessage(err),
+			})
+		}
+		return false
+	}
+
+	// Record the error so that it's visible while the deploy is retried.
+	if sb.Status.ObservedGeneration < sb.Generation {
+		pending := sb.Status
+		pending.Phase = sandbox.PhasePending
+		pending.Message = errors.GetPrintableMessage(err)
+		c.updateStatus(sb, pending)
+	}
+	c.workqueue.AddRateLimited(key)
+	return false
+}
+
+func (c *sandboxController) requeue(key interface{}) {
+	if c.workqueue.NumRequeues(key) < maxSandboxRetries {
+		c.workqueue.AddRateLimited(key)
+	} else {
+		log.WithField("key", key).Warn("Too many failures reconciling sandbox. Not requeueing.")
+		c.workqueue.Forget(key)
+	}
+}
+
+func (c *sandboxController) updateStatus(sb *sandbox.Sandbox, status sandbox.Status) {
+	if statusEqual(sb.Status, status) {
+		return
+	}
+
+	sb.Status = status
+	err := sandbox.UpdateStatus(c.dynamicClient, sb)
+	// Conflicts mean that the spec changed since the sandbox was reconciled.
+	// The update will cause the sandbox to be reconciled again, so there's no
+	// need to retry.
+	if err != nil && !kerrors.IsConflict(err) && !kerrors.IsNotFound(err) {
+		log.WithError(err).WithField("namespace", sb.Namespace).Warn("Failed to update sandbox status")
+	}
+}
+
+// reconcile deploys the sandbox's system pods, and, if the spec changed since
+// it was last reconciled, its customer pods. It returns the resulting status.
+func (c *sandboxController) reconcile(ctx context.Context, sb *sandbox.Sandbox) (sandbox.Status, error) {
+	user := auth.User{Namespace: sb.Namespace}
+
+	var g errgroup.Group
+	g.Go(func() error {
+		if err := c.server.createSyncthing(user, sb.Spec.SyncedFolders, sb.Spec.ReceiveOnlyFolders); err != nil {
+			return errors.WithContext("deploy syncthing", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		if err := createBuildkitd(c.server.kubeClient, sb.Namespace); err != nil {
+			return errors.WithContext("deploy buildkitd", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		if err := c.server.deployDNS(user); err != nil {
+			return errors.WithContext("deploy dns", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return sandbox.Status{}, err
+	}
+
+	if sb.IsReconciled(sb.Generation) {
+		return sb.Status, nil
+	}
+
+	var warnings []string
+	if sb.Spec.ComposeFile != "" {
+		var err error
+		warnings, err = c.server.deployServices(ctx, sb)
+		if err != nil {
+			return sandbox.Status{}, err
+		}
+	}
+
+	return sandbox.Status{
+		ObservedGeneration: sb.Generation,
+		Phase:              sandbox.PhaseReady,
+		Warnings:           warnings,
+	}, nil
+}
+
+// waitForReconcile blocks until the controller finishes reconciling the
+// given generation of the namespace's Sandbox.
+func (c *sandboxController) waitForReconcile(ctx context.Context, namespace string, generation int64) (
+	*sandbox.Sandbox, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	updates := c.watcher.Watch(ctx, kube.Key{Namespace: namespace, Name: sandbox.Name})
+	for {
+		obj, err := c.lister.ByNamespace(namespace).Get(sandbox.Name)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return nil, errors.WithContext("get sandbox", err)
+		}
+
+		if err == nil {
+			sb, err := sandbox.FromUnstructured(obj)
+			if err != nil {
+				return nil, err
+			}
+
+			if sb.IsReconciled(generation) {
+				return sb, nil
+			}
+		}
+
+		select {
+		case <-updates:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// deployServices deploys the customer pods for the services in the sandbox's
+// Compose file, and removes the pods and ConfigMaps for services that are no
+// longer in it.
+func (s *server) deployServices(ctx context.Context, sb *sandbox.Sandbox) ([]string, error) {
+	dcCfg, err := dockercompose.Unmarshal([]byte(sb.Spec.ComposeFile))
+	if err != nil {
+		return nil, errors.WithContext("unmarshal compose file", err)
+	}
+
+	namespace := sb.Namespace
+	dnsIP, nodeControllerIP, err := s.getSandboxNetwork(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	customerPods, configMaps, warnings, err := toPods(auth.User{Namespace: namespace},
+		dnsIP, nodeControllerIP, dcCfg, sb.Spec.BuiltImages)
+	if err != nil {
+		return nil, errors.WithContext("make pod specs", err)
+	}
+
+	if err := s.deployWaitSpecs(namespace, configMaps); err != nil {
+		return nil, errors.WithContext("deploy wait specs", err)
+	}
+
+	// Services with the `unless-stopped` restart policy stay stopped across
+	// deploys, just like in Docker. Their saved pods are updated so that
+	// `blimp start` boots the latest version of the service.
+	podsToDeploy := s.withoutStoppedServices(namespace, dcCfg, customerPods)
+	keepStopped := map[string]struct{}{}
+	for _, pod := range customerPods {
+		if containsPod(podsToDeploy, pod.Name) {
+			continue
+		}
+
+		svcName := pod.Labels["blimp.service"]
+		pod := pod
+		if err := saveStoppedPod(s.kubeClient, &pod, ""); err != nil {
+			return nil, errors.WithContext("update stopped service", err)
+		}
+		keepStopped[svcName] = struct{}{}
+		warnings = append(warnings, fmt.Sprintf("Service %s was stopped, and has the unless-stopped restart policy, "+
+			"so it wasn't started. Use `blimp start %s` to start it.", svcName, svcName))
+	}
+
+	noRecreate := map[string]struct{}{}
+	for _, svc := range sb.Spec.NoRecreate {
+		noRecreate[svc] = struct{}{}
+	}
+
+	log.WithField("namespace", namespace).
+		WithField("numPods", len(podsToDeploy)).
+		Info("Deploying customer pods")
+	if err := s.deployCustomerPods(namespace, podsToDeploy, noRecreate); err != nil {
+		return nil, errors.WithContext("boot customer pods", err)
+	}
+
+	// Tag the built images so that the registry garbage collector keeps them
+	// as previous builds.
+	if s.registryGC != nil {
+		var images []string
+		for _, image := range sb.Spec.BuiltImages {
+			images = append(images, image)
+		}
+		if err := s.registryGC.RecordBuilds(namespace, images); err != nil {
+			log.WithError(err).WithField("namespace", namespace).Warn("Failed to record builds")
+		}
+	}
+
+	if err := s.clearStoppedServices(namespace, keepStopped); err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+// deployWaitSpecs deploys the ConfigMaps containing the wait specs of the
+// customer pods, and deletes the wait specs that are no longer used.
+func (s *server) deployWaitSpecs(namespace string, desired []corev1.ConfigMap) error {
+	var g errgroup.Group
+	desiredNames := map[string]struct{}{}
+	for _, configMap := range desired {
+		configMap := configMap
+		desiredNames[configMap.Name] = struct{}{}
+		g.Go(func() error {
+			return kube.DeployConfigMap(s.kubeClient, configMap)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	configMapClient := s.kubeClient.CoreV1().ConfigMaps(namespace)
+	curr, err := configMapClient.List(metav1.ListOptions{
+		LabelSelector: kube.WaitSpecLabel + "=true",
+	})
+	if err != nil {
+		return errors.WithContext("list", err)
+	}
+
+	for _, configMap := range curr.Items {
+		if _, ok := desiredNames[configMap.Name]; ok {
+			continue
+		}
+
+		err := configMapClient.Delete(configMap.Name, &metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.WithContext("delete stale configmap", err)
+		}
+	}
+	return nil
+}
+
+func statusEqual(a, b sandbox.Status) bool {
+	if a.ObservedGeneration != b.ObservedGeneration || a.Phase != b.Phase || a.Message != b.Message ||
+		len(a.Warnings) != len(b.Warnings) {
+		return false
+	}
+
+	for i := range a.Warnings {
+		if a.Warnings[i] != b.Warnings[i] {
+			return false
+		}
+	}
+	return true
+}
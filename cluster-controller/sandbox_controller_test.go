@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/cluster-controller/sandbox"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func TestReconcileSandbox(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, testComposeFile)
+	tc.createSandbox(composeFile, nil)
+	tc.deploy(composeFile)
+
+	sb, err := sandbox.Get(tc.server.dynamicClient, tc.namespace)
+	require.NoError(t, err)
+	assert.Equal(t, sandbox.PhaseReady, sb.Status.Phase)
+	assert.Equal(t, sb.Generation, sb.Status.ObservedGeneration)
+
+	// Reconciling the same generation again should redeploy the system pods,
+	// but leave the customer pods alone.
+	podClient := tc.kubeClient.CoreV1().Pods(tc.namespace)
+	require.NoError(t, podClient.Delete("dns", nil))
+	dbPods, err := tc.server.getServicePods(tc.namespace, "db")
+	require.NoError(t, err)
+	require.Len(t, dbPods, 1)
+	require.NoError(t, podClient.Delete(dbPods[0].Name, nil))
+
+	status, err := tc.server.sandboxes.reconcile(context.Background(), sb)
+	require.NoError(t, err)
+	assert.Equal(t, sb.Status, status)
+
+	_, err = podClient.Get("dns", metav1.GetOptions{})
+	assert.NoError(t, err)
+	dbPods, err = tc.server.getServicePods(tc.namespace, "db")
+	require.NoError(t, err)
+	assert.Empty(t, dbPods)
+}
+
+func TestSandboxControllerFailure(t *testing.T) {
+	t.Run("FriendlyError", func(t *testing.T) {
+		tc := newTestCluster(t)
+		defer tc.Stop()
+
+		c := newIdleSandboxController(tc)
+		createTestSandbox(t, c, tc.namespace, `
+version: '3'
+services:
+  web:
+    image: nginx
+    user: nobody
+`)
+
+		// Errors caused by the Compose file shouldn't be retried.
+		c.runWorker()
+		sb, err := sandbox.Get(c.dynamicClient, tc.namespace)
+		require.NoError(t, err)
+		assert.Equal(t, sandbox.PhaseFailed, sb.Status.Phase)
+		assert.Equal(t, sb.Generation, sb.Status.ObservedGeneration)
+		assert.Contains(t, sb.Status.Message, "Invalid user field")
+		assert.Zero(t, c.workqueue.Len())
+	})
+
+	t.Run("MaxRetries", func(t *testing.T) {
+		tc := newTestCluster(t)
+		defer tc.Stop()
+
+		tc.kubeClient.PrependReactor("create", "pods",
+			func(action k8stesting.Action) (bool, runtime.Object, error) {
+				pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+				if pod.Labels["blimp.customerPod"] == "true" {
+					return true, nil, errors.New("injected error")
+				}
+				return false, nil, nil
+			})
+
+		c := newIdleSandboxController(tc)
+		createTestSandbox(t, c, tc.namespace, testComposeFile)
+
+		// The error should be visible while the deploy is retried.
+		for i := 0; i < maxSandboxRetries; i++ {
+			c.runWorker()
+			sb, err := sandbox.Get(c.dynamicClient, tc.namespace)
+			require.NoError(t, err)
+			assert.Equal(t, sandbox.PhasePending, sb.Status.Phase)
+			assert.Contains(t, sb.Status.Message, "injected error")
+		}
+
+		c.runWorker()
+		sb, err := sandbox.Get(c.dynamicClient, tc.namespace)
+		require.NoError(t, err)
+		assert.Equal(t, sandbox.PhaseFailed, sb.Status.Phase)
+		assert.Equal(t, sb.Generation, sb.Status.ObservedGeneration)
+		assert.Contains(t, sb.Status.Message, "injected error")
+	})
+}
+
+func TestDeployWaitSpecs(t *testing.T) {
+	waitSpec := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "namespace",
+				Name:      name,
+				Labels:    map[string]string{kube.WaitSpecLabel: "true"},
+			},
+		}
+	}
+	unrelated := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "namespace",
+			Name:      "unrelated",
+		},
+	}
+	s := &server{kubeClient: fakeKube.NewSimpleClientset(waitSpec("current"), waitSpec("stale"), unrelated)}
+
+	err := s.deployWaitSpecs("namespace", []corev1.ConfigMap{*waitSpec("current"), *waitSpec("new")})
+	require.NoError(t, err)
+
+	configMaps, err := s.kubeClient.CoreV1().ConfigMaps("namespace").List(metav1.ListOptions{})
+	require.NoError(t, err)
+	var names []string
+	for _, configMap := range configMaps.Items {
+		names = append(names, configMap.Name)
+	}
+	assert.ElementsMatch(t, []string{"current", "new", "unrelated"}, names)
+}
+
+func TestDeployServicesUnlessStopped(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, `
+version: '3'
+services:
+  web:
+    image: nginx
+  db:
+    image: postgres
+    restart: unless-stopped
+`)
+	tc.createSandbox(composeFile, nil)
+	tc.deploy(composeFile)
+	tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "web", "db")
+
+	for _, svc := range []string{"web", "db"} {
+		_, err := manager.C.Stop(context.Background(), &cluster.StopRequest{Auth: tc.auth, Service: svc})
+		require.NoError(t, err)
+	}
+
+	composeFile = loadComposeFile(t, `
+version: '3'
+services:
+  web:
+    image: nginx
+  db:
+    image: postgres:13
+    restart: unless-stopped
+`)
+	resp, err := manager.C.DeployToSandbox(context.Background(), &cluster.DeployRequest{
+		Auth:        tc.auth,
+		ComposeFile: composeFile,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Warnings, 1)
+	assert.Contains(t, resp.Warnings[0], "Service db was stopped")
+
+	// Only the service with the unless-stopped restart policy should stay
+	// stopped.
+	tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "web")
+	assert.False(t, tc.server.isStopped(tc.namespace, "web"))
+	dbPods, err := tc.server.getServicePods(tc.namespace, "db")
+	require.NoError(t, err)
+	assert.Empty(t, dbPods)
+
+	// `blimp start` should boot the latest version of the stopped service.
+	stopped, err := tc.kubeClient.CoreV1().ConfigMaps(tc.namespace).
+		Get(kube.StoppedServiceConfigMapName("db"), metav1.GetOptions{})
+	require.NoError(t, err)
+	var pod corev1.Pod
+	require.NoError(t, json.Unmarshal(stopped.BinaryData[stoppedPodKey], &pod))
+	require.Len(t, pod.Spec.Containers, 1)
+	assert.Contains(t, pod.Spec.Containers[0].Image, "postgres:13")
+}
+
+// newIdleSandboxController returns a sandbox controller that isn't running,
+// so that tests can process its work queue one item at a time. It uses a
+// separate dynamic client from the test cluster so that the test cluster's
+// controller doesn't reconcile the same Sandboxes.
+func newIdleSandboxController(tc *testCluster) *sandboxController {
+	c := newSandboxController(tc.server, newFakeDynamicClient())
+	tc.onStop(c.workqueue.ShutDown)
+	return c
+}
+
+// createTestSandbox creates a Sandbox with the given Compose file, and queues
+// it to be reconciled by `c`.
+func createTestSandbox(t *testing.T, c *sandboxController, namespace, composeFile string) {
+	generation, _, err := sandbox.UpdateSpec(c.dynamicClient, namespace, func(spec *sandbox.Spec) {
+		spec.ComposeFile = composeFile
+	})
+	require.NoError(t, err)
+
+	// Wait for the informer so that the worker sees the Sandbox.
+	require.Eventually(t, func() bool {
+		obj, err := c.lister.ByNamespace(namespace).Get(sandbox.Name)
+		return err == nil && obj.(metav1.Object).GetGeneration() == generation
+	}, 10*time.Second, 10*time.Millisecond)
+	c.workqueue.Add(namespace + "/" + sandbox.Name)
+}
@@ -0,0 +1,397 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kelda/blimp/pkg/kube"
+)
+
+// clusterSimulator stands in for the parts of Kubernetes that aren't
+// implemented by the fake clientset: the scheduler, the kubelet, the service
+// account token controller, the volume provisioner, and the service
+// controller. It reacts to objects as soon as they're created, so tests don't
+// need to sleep while waiting for pods to boot.
+type clusterSimulator struct {
+	kubeClient kubernetes.Interface
+	nodeName   string
+
+	// caCrt is the certificate that's included in service account tokens.
+	caCrt []byte
+
+	// nodePort is the port assigned to NodePort services.
+	nodePort int32
+
+	lock sync.Mutex
+
+	// heldServices are the services whose pods are scheduled, but don't
+	// start running until the service is released.
+	heldServices map[string]struct{}
+
+	// exitingServices maps services to the result of their one-off
+	// commands. One-off pods for these services exit as soon as they're
+	// scheduled.
+	exitingServices map[string]oneOffResult
+
+	// logs maps pods (in the namespace/name format) to their log lines,
+	// prefixed with timestamps.
+	logs map[string][]string
+
+	lastIP int
+}
+
+func newClusterSimulator(kubeClient kubernetes.Interface, nodeName string, caCrt []byte,
+	nodePort int32) *clusterSimulator {
+	return &clusterSimulator{
+		kubeClient:      kubeClient,
+		nodeName:        nodeName,
+		caCrt:           caCrt,
+		nodePort:        nodePort,
+		heldServices:    map[string]struct{}{},
+		exitingServices: map[string]oneOffResult{},
+		logs:            map[string][]string{},
+		lastIP:          1,
+	}
+}
+
+// Start starts reacting to changes to the cluster.
+func (sim *clusterSimulator) Start() {
+	factory := informers.NewSharedInformerFactory(sim.kubeClient, 30*time.Second)
+	informers := []cache.SharedIndexInformer{
+		factory.Core().V1().Namespaces().Informer(),
+		factory.Core().V1().ServiceAccounts().Informer(),
+		factory.Core().V1().Services().Informer(),
+		factory.Core().V1().PersistentVolumeClaims().Informer(),
+		factory.Core().V1().Pods().Informer(),
+	}
+
+	informers[0].AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			sim.createDefaultServiceAccount(obj.(*corev1.Namespace))
+		},
+	})
+	informers[1].AddEventHandler(onAddOrUpdate(func(obj interface{}) {
+		sim.syncServiceAccount(obj.(*corev1.ServiceAccount))
+	}))
+	informers[2].AddEventHandler(onAddOrUpdate(func(obj interface{}) {
+		sim.syncService(obj.(*corev1.Service))
+	}))
+	informers[3].AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			sim.bindPVC(obj.(*corev1.PersistentVolumeClaim))
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			sim.bindPVC(obj.(*corev1.PersistentVolumeClaim))
+		},
+		DeleteFunc: func(obj interface{}) {
+			if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+				sim.releasePV(pvc)
+			}
+		},
+	})
+	informers[4].AddEventHandler(onAddOrUpdate(func(obj interface{}) {
+		sim.syncPod(obj.(*corev1.Pod))
+	}))
+
+	for _, informer := range informers {
+		go informer.Run(nil)
+		cache.WaitForCacheSync(nil, informer.HasSynced)
+	}
+}
+
+// HoldService keeps the pods for the service from booting until
+// ReleaseService is called.
+func (sim *clusterSimulator) HoldService(service string) {
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+	sim.heldServices[service] = struct{}{}
+}
+
+// ReleaseService boots the service's pods that were held by HoldService.
+func (sim *clusterSimulator) ReleaseService(namespace, service string) error {
+	sim.lock.Lock()
+	delete(sim.heldServices, service)
+	sim.lock.Unlock()
+
+	pods, err := sim.kubeClient.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: "blimp.service=" + service,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods.Items {
+		pod := pod
+		sim.syncPod(&pod)
+	}
+	return nil
+}
+
+type oneOffResult struct {
+	exitCode int32
+	output   []string
+}
+
+// ExitImmediately makes the one-off pods for the service exit with the given
+// exit code and output as soon as they're scheduled, before a client has a
+// chance to attach.
+func (sim *clusterSimulator) ExitImmediately(service string, exitCode int32, output ...string) {
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+	sim.exitingServices[service] = oneOffResult{exitCode, output}
+}
+
+// WriteLogs appends log lines for the given pod.
+func (sim *clusterSimulator) WriteLogs(namespace, pod string, lines ...string) {
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+	sim.writeLogsLocked(namespace, pod, lines...)
+}
+
+func (sim *clusterSimulator) writeLogsLocked(namespace, pod string, lines ...string) {
+	key := namespace + "/" + pod
+	for _, line := range lines {
+		timestamp := time.Now().UTC().Format(time.RFC3339Nano)
+		sim.logs[key] = append(sim.logs[key], timestamp+" "+line)
+	}
+}
+
+// GetLogs returns the timestamped log lines for the given pod.
+func (sim *clusterSimulator) GetLogs(namespace, pod string) []string {
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+	return append([]string{}, sim.logs[namespace+"/"+pod]...)
+}
+
+func (sim *clusterSimulator) createDefaultServiceAccount(namespace *corev1.Namespace) {
+	_, err := sim.kubeClient.CoreV1().ServiceAccounts(namespace.Name).Create(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace.Name,
+			Name:      "default",
+		},
+	})
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		log.WithError(err).Error("Simulator failed to create default service account")
+	}
+}
+
+// syncServiceAccount creates a token for the service account if it doesn't
+// already have one.
+func (sim *clusterSimulator) syncServiceAccount(sa *corev1.ServiceAccount) {
+	if len(sa.Secrets) != 0 {
+		return
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: sa.Namespace,
+			Name:      sa.Name + "-token",
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+		Data: map[string][]byte{
+			"token":  []byte(fmt.Sprintf("%s-%s-token", sa.Namespace, sa.Name)),
+			"ca.crt": sim.caCrt,
+		},
+	}
+	_, err := sim.kubeClient.CoreV1().Secrets(sa.Namespace).Create(secret)
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		log.WithError(err).Error("Simulator failed to create service account token")
+		return
+	}
+
+	sa = sa.DeepCopy()
+	sa.Secrets = []corev1.ObjectReference{{Name: secret.Name}}
+	if _, err := sim.kubeClient.CoreV1().ServiceAccounts(sa.Namespace).Update(sa); err != nil {
+		log.WithError(err).Error("Simulator failed to update service account")
+	}
+}
+
+// syncService assigns node ports to NodePort services.
+func (sim *clusterSimulator) syncService(svc *corev1.Service) {
+	if svc.Spec.Type != corev1.ServiceTypeNodePort || len(svc.Spec.Ports) == 0 ||
+		svc.Spec.Ports[0].NodePort != 0 {
+		return
+	}
+
+	svc = svc.DeepCopy()
+	svc.Spec.Ports[0].NodePort = sim.nodePort
+	if _, err := sim.kubeClient.CoreV1().Services(svc.Namespace).Update(svc); err != nil {
+		log.WithError(err).Error("Simulator failed to assign node port")
+	}
+}
+
+// bindPVC binds the PersistentVolumeClaim to a PersistentVolume, creating
+// the volume if the claim doesn't reference one.
+func (sim *clusterSimulator) bindPVC(pvc *corev1.PersistentVolumeClaim) {
+	if pvc.Status.Phase == corev1.ClaimBound {
+		return
+	}
+
+	pvc = pvc.DeepCopy()
+	if pvc.Spec.VolumeName == "" {
+		pv := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("pv-%s-%s", pvc.Namespace, pvc.Name),
+			},
+			Spec: corev1.PersistentVolumeSpec{
+				AccessModes: pvc.Spec.AccessModes,
+				Capacity:    pvc.Spec.Resources.Requests,
+			},
+		}
+		if _, err := sim.kubeClient.CoreV1().PersistentVolumes().Create(pv); err != nil {
+			log.WithError(err).Error("Simulator failed to provision persistent volume")
+			return
+		}
+		pvc.Spec.VolumeName = pv.Name
+	}
+
+	sim.setPVPhase(pvc.Spec.VolumeName, corev1.VolumeBound)
+	pvc.Status.Phase = corev1.ClaimBound
+	if _, err := sim.kubeClient.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(pvc); err != nil {
+		log.WithError(err).Error("Simulator failed to bind persistent volume claim")
+	}
+}
+
+func (sim *clusterSimulator) releasePV(pvc *corev1.PersistentVolumeClaim) {
+	if pvc.Spec.VolumeName != "" {
+		sim.setPVPhase(pvc.Spec.VolumeName, corev1.VolumeReleased)
+	}
+}
+
+func (sim *clusterSimulator) setPVPhase(name string, phase corev1.PersistentVolumePhase) {
+	pvClient := sim.kubeClient.CoreV1().PersistentVolumes()
+	pv, err := pvClient.Get(name, metav1.GetOptions{})
+	if err != nil {
+		log.WithError(err).Error("Simulator failed to get persistent volume")
+		return
+	}
+
+	pv.Status.Phase = phase
+	if _, err := pvClient.UpdateStatus(pv); err != nil {
+		log.WithError(err).Error("Simulator failed to update persistent volume")
+	}
+}
+
+// syncPod schedules the pod, and boots it unless its service is held. Pods
+// boot instantly: their init containers complete, and their containers
+// become ready.
+func (sim *clusterSimulator) syncPod(pod *corev1.Pod) {
+	switch {
+	case pod.DeletionTimestamp != nil,
+		pod.Status.Phase == corev1.PodRunning,
+		pod.Status.Phase == corev1.PodSucceeded,
+		pod.Status.Phase == corev1.PodFailed:
+		return
+	}
+
+	sim.lock.Lock()
+	_, held := sim.heldServices[pod.Labels["blimp.service"]]
+	if held && pod.Spec.NodeName != "" {
+		sim.lock.Unlock()
+		return
+	}
+
+	pod = pod.DeepCopy()
+	pod.Spec.NodeName = sim.nodeName
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+	}
+	if held {
+		pod.Status.Phase = corev1.PodPending
+	} else {
+		// Each pod gets a different loopback address so that tests can
+		// listen on the pod's IP.
+		sim.lastIP++
+		pod.Status.PodIP = fmt.Sprintf("127.0.0.%d", sim.lastIP)
+		setPodRunning(pod)
+
+		result, exiting := sim.exitingServices[pod.Labels["blimp.service"]]
+		if exiting && pod.Labels[kube.OneOffPodLabel] == "true" {
+			sim.writeLogsLocked(pod.Namespace, pod.Name, result.output...)
+			setPodExited(pod, result.exitCode)
+		}
+	}
+	sim.lock.Unlock()
+
+	if _, err := sim.kubeClient.CoreV1().Pods(pod.Namespace).UpdateStatus(pod); err != nil {
+		log.WithError(err).Error("Simulator failed to update pod")
+	}
+}
+
+func setPodRunning(pod *corev1.Pod) {
+	now := metav1.Now()
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.StartTime = &now
+	pod.Status.Conditions = append(pod.Status.Conditions,
+		corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue})
+
+	pod.Status.InitContainerStatuses = nil
+	for _, c := range pod.Spec.InitContainers {
+		pod.Status.InitContainerStatuses = append(pod.Status.InitContainerStatuses, corev1.ContainerStatus{
+			Name:  c.Name,
+			Image: c.Image,
+			Ready: true,
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Reason:     "Completed",
+					StartedAt:  now,
+					FinishedAt: now,
+				},
+			},
+		})
+	}
+
+	pod.Status.ContainerStatuses = nil
+	for _, c := range pod.Spec.Containers {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  c.Name,
+			Image: c.Image,
+			Ready: true,
+			State: corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{StartedAt: now},
+			},
+		})
+	}
+}
+
+// setPodExited marks the running pod's containers as exited. The pod isn't
+// restarted.
+func setPodExited(pod *corev1.Pod, exitCode int32) {
+	pod.Status.Phase = corev1.PodSucceeded
+	reason := "Completed"
+	if exitCode != 0 {
+		pod.Status.Phase = corev1.PodFailed
+		reason = "Error"
+	}
+
+	now := metav1.Now()
+	for i, status := range pod.Status.ContainerStatuses {
+		pod.Status.ContainerStatuses[i].Ready = false
+		pod.Status.ContainerStatuses[i].State = corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{
+				ExitCode:   exitCode,
+				Reason:     reason,
+				StartedAt:  status.State.Running.StartedAt,
+				FinishedAt: now,
+			},
+		}
+	}
+}
+
+func onAddOrUpdate(handler func(obj interface{})) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: handler,
+		UpdateFunc: func(_, obj interface{}) {
+			handler(obj)
+		},
+	}
+}
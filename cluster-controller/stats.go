@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kelda/blimp/cluster-controller/stats"
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// statsRefreshInterval is how often WatchStats sends updated stats. The
+// kubelet only samples usage every ten seconds or so, so there's no point
+// polling it more often.
+const statsRefreshInterval = 5 * time.Second
+
+func (s *server) GetStats(ctx context.Context, req *cluster.GetStatsRequest) (*cluster.GetStatsResponse, error) {
+	user, err := auth.AuthorizeRequest(req.GetAuth())
+	if err != nil {
+		return &cluster.GetStatsResponse{}, err
+	}
+
+	svcStats, err := s.getStats(user.Namespace)
+	if err != nil {
+		return &cluster.GetStatsResponse{}, err
+	}
+	return &cluster.GetStatsResponse{Services: svcStats}, nil
+}
+
+func (s *server) WatchStats(req *cluster.GetStatsRequest, stream cluster.Manager_WatchStatsServer) error {
+	user, err := auth.AuthorizeRequest(req.GetAuth())
+	if err != nil {
+		return err
+	}
+
+	for {
+		svcStats, err := s.getStats(user.Namespace)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&cluster.GetStatsResponse{Services: svcStats}); err != nil {
+			return err
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.shutdown:
+			return errShuttingDown
+		case <-time.After(statsRefreshInterval):
+		}
+	}
+}
+
+func (s *server) getStats(namespace string) (map[string]*cluster.ServiceStats, error) {
+	if err := s.checkSandboxExists(namespace); err != nil {
+		return nil, err
+	}
+
+	pods, err := s.statusFetcher.podLister.
+		Pods(namespace).
+		List(labels.Set(
+			map[string]string{"blimp.customerPod": "true"},
+		).AsSelector())
+	if err != nil {
+		return nil, errors.WithContext("list services", err)
+	}
+
+	svcStats, err := stats.Get(s.kubeClient, pods)
+	if err != nil {
+		return nil, errors.WithContext("get stats", err)
+	}
+	return svcStats, nil
+}
@@ -0,0 +1,183 @@
+package stats
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// summary is the subset of the kubelet's stats summary API that we use. The
+// full types live in k8s.io/kubernetes, which isn't worth importing for a few
+// fields.
+type summary struct {
+	Pods []podStats `json:"pods"`
+}
+
+type podStats struct {
+	PodRef struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"podRef"`
+	CPU        *cpuStats        `json:"cpu"`
+	Memory     *memoryStats     `json:"memory"`
+	Network    *networkStats    `json:"network"`
+	Containers []containerStats `json:"containers"`
+}
+
+type containerStats struct {
+	CPU    *cpuStats    `json:"cpu"`
+	Memory *memoryStats `json:"memory"`
+}
+
+type cpuStats struct {
+	UsageNanoCores *uint64 `json:"usageNanoCores"`
+}
+
+type memoryStats struct {
+	WorkingSetBytes *uint64 `json:"workingSetBytes"`
+}
+
+type networkStats struct {
+	RxBytes *uint64 `json:"rxBytes"`
+	TxBytes *uint64 `json:"txBytes"`
+}
+
+// Get returns the resource usage of the given pods, keyed by the value of
+// their `blimp.service` label. The usage is read from the summary API of the
+// kubelets that the pods are scheduled on. Pods that haven't been scheduled,
+// or that the kubelet doesn't have stats for yet, are omitted.
+func Get(kubeClient kubernetes.Interface, pods []*corev1.Pod) (map[string]*cluster.ServiceStats, error) {
+	podsByNode := map[string][]*corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+		}
+	}
+
+	stats := map[string]*cluster.ServiceStats{}
+	for nodeName, nodePods := range podsByNode {
+		summary, err := getSummary(kubeClient, nodeName)
+		if err != nil {
+			return nil, errors.WithContext("get kubelet stats", err)
+		}
+
+		for svc, svcStats := range fromSummary(summary, nodePods) {
+			stats[svc] = svcStats
+		}
+	}
+	return stats, nil
+}
+
+func getSummary(kubeClient kubernetes.Interface, nodeName string) (summary, error) {
+	// Proxy the request through the API server so that we don't need
+	// credentials for each kubelet.
+	b, err := kubeClient.CoreV1().RESTClient().Get().
+		Resource("nodes").
+		Name(nodeName).
+		SubResource("proxy").
+		Suffix("stats/summary").
+		DoRaw()
+	if err != nil {
+		return summary{}, err
+	}
+
+	var s summary
+	if err := json.Unmarshal(b, &s); err != nil {
+		return summary{}, errors.WithContext("parse summary", err)
+	}
+	return s, nil
+}
+
+func fromSummary(s summary, pods []*corev1.Pod) map[string]*cluster.ServiceStats {
+	type podKey struct{ namespace, name string }
+	podStatsByKey := map[podKey]podStats{}
+	for _, ps := range s.Pods {
+		podStatsByKey[podKey{ps.PodRef.Namespace, ps.PodRef.Name}] = ps
+	}
+
+	stats := map[string]*cluster.ServiceStats{}
+	for _, pod := range pods {
+		ps, ok := podStatsByKey[podKey{pod.Namespace, pod.Name}]
+		if !ok {
+			continue
+		}
+
+		svcStats := &cluster.ServiceStats{
+			MemoryLimitBytes: getMemoryLimit(pod),
+		}
+
+		// Older kubelets don't report pod-level CPU and memory usage, so
+		// fall back to summing the containers.
+		if ps.CPU != nil && ps.CPU.UsageNanoCores != nil {
+			svcStats.CpuNanoCores = *ps.CPU.UsageNanoCores
+		} else {
+			for _, c := range ps.Containers {
+				if c.CPU != nil && c.CPU.UsageNanoCores != nil {
+					svcStats.CpuNanoCores += *c.CPU.UsageNanoCores
+				}
+			}
+		}
+
+		if ps.Memory != nil && ps.Memory.WorkingSetBytes != nil {
+			svcStats.MemoryWorkingSetBytes = *ps.Memory.WorkingSetBytes
+		} else {
+			for _, c := range ps.Containers {
+				if c.Memory != nil && c.Memory.WorkingSetBytes != nil {
+					svcStats.MemoryWorkingSetBytes += *c.Memory.WorkingSetBytes
+				}
+			}
+		}
+
+		if ps.Network != nil {
+			if ps.Network.RxBytes != nil {
+				svcStats.NetworkRxBytes = *ps.Network.RxBytes
+			}
+			if ps.Network.TxBytes != nil {
+				svcStats.NetworkTxBytes = *ps.Network.TxBytes
+			}
+		}
+
+		// The usage of services with multiple replicas is the total usage of
+		// their replicas.
+		svcName := pod.Labels["blimp.service"]
+		if total, ok := stats[svcName]; ok {
+			addStats(total, svcStats)
+		} else {
+			stats[svcName] = svcStats
+		}
+	}
+	return stats
+}
+
+// addStats adds the usage of a replica to the total usage of its service.
+func addStats(total, replica *cluster.ServiceStats) {
+	total.CpuNanoCores += replica.CpuNanoCores
+	total.MemoryWorkingSetBytes += replica.MemoryWorkingSetBytes
+	total.NetworkRxBytes += replica.NetworkRxBytes
+	total.NetworkTxBytes += replica.NetworkTxBytes
+
+	// A limit of zero means that the memory is unlimited.
+	if total.MemoryLimitBytes == 0 || replica.MemoryLimitBytes == 0 {
+		total.MemoryLimitBytes = 0
+	} else {
+		total.MemoryLimitBytes += replica.MemoryLimitBytes
+	}
+}
+
+// getMemoryLimit returns the total memory limit of the pod's containers, or
+// zero if any container is unlimited.
+func getMemoryLimit(pod *corev1.Pod) uint64 {
+	var total int64
+	for _, c := range pod.Spec.Containers {
+		limit, ok := c.Resources.Limits[corev1.ResourceMemory]
+		if !ok {
+			return 0
+		}
+		total += limit.Value()
+	}
+	return uint64(total)
+}
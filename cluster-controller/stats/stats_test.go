@@ -0,0 +1,95 @@
+package stats
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func TestFromSummary(t *testing.T) {
+	summaryJSON := `{
+  "pods": [
+    {
+      "podRef": {"name": "web", "namespace": "user"},
+      "cpu": {"usageNanoCores": 250000000},
+      "memory": {"workingSetBytes": 1024},
+      "network": {"rxBytes": 10, "txBytes": 20}
+    },
+    {
+      "podRef": {"name": "db", "namespace": "user"},
+      "containers": [
+        {"cpu": {"usageNanoCores": 1}, "memory": {"workingSetBytes": 2}},
+        {"cpu": {"usageNanoCores": 3}, "memory": {"workingSetBytes": 4}}
+      ]
+    },
+    {
+      "podRef": {"name": "worker-1", "namespace": "user"},
+      "cpu": {"usageNanoCores": 5},
+      "memory": {"workingSetBytes": 10}
+    },
+    {
+      "podRef": {"name": "worker-2", "namespace": "user"},
+      "cpu": {"usageNanoCores": 7},
+      "memory": {"workingSetBytes": 20}
+    },
+    {
+      "podRef": {"name": "web", "namespace": "other-user"},
+      "cpu": {"usageNanoCores": 1}
+    }
+  ]
+}`
+	var s summary
+	assert.NoError(t, json.Unmarshal([]byte(summaryJSON), &s))
+
+	makePod := func(name, svc string, limits ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "user",
+				Labels:    map[string]string{"blimp.service": svc},
+			},
+		}
+		for _, limit := range limits {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(limit)},
+				},
+			})
+		}
+		return pod
+	}
+
+	pods := []*corev1.Pod{
+		makePod("web", "web", "16Gi"),
+		makePod("db", "db", "1Gi", "1Gi"),
+		makePod("unscheduled", "unscheduled", "1Gi"),
+		makePod("worker-1", "worker", "1Gi"),
+		makePod("worker-2", "worker", "1Gi"),
+	}
+	assert.Equal(t, map[string]*cluster.ServiceStats{
+		"web": {
+			CpuNanoCores:          250000000,
+			MemoryWorkingSetBytes: 1024,
+			MemoryLimitBytes:      16 * 1024 * 1024 * 1024,
+			NetworkRxBytes:        10,
+			NetworkTxBytes:        20,
+		},
+		"db": {
+			CpuNanoCores:          4,
+			MemoryWorkingSetBytes: 6,
+			MemoryLimitBytes:      2 * 1024 * 1024 * 1024,
+		},
+		// The usage of replicas is combined.
+		"worker": {
+			CpuNanoCores:          12,
+			MemoryWorkingSetBytes: 30,
+			MemoryLimitBytes:      2 * 1024 * 1024 * 1024,
+		},
+	}, fromSummary(s, pods))
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -40,16 +41,31 @@ type statusFetcher struct {
 	namespaceInformer cache.SharedIndexInformer
 	namespaceLister   listers.NamespaceLister
 
+	// The ConfigMaps used to track services stopped by `blimp stop`.
+	stoppedInformer cache.SharedIndexInformer
+	stoppedLister   listers.ConfigMapLister
+
 	podWatcher       *kube.Watcher
+	eventsWatcher    *kube.Watcher
 	namespaceWatcher *kube.Watcher
+	stoppedWatcher   *kube.Watcher
 }
 
 func newStatusFetcher(kubeClient kubernetes.Interface) *statusFetcher {
 	factory := informers.NewSharedInformerFactory(kubeClient, 30*time.Second)
 	podInformer := factory.Core().V1().Pods()
 	eventsInformer := factory.Core().V1().Events()
+	// AddIndexers only fails if the informer is already running.
+	//nolint:errcheck
+	eventsInformer.Informer().AddIndexers(cache.Indexers{eventsByPodUIDIndex: indexEventsByPodUID})
 	namespaceInformer := factory.Core().V1().Namespaces()
 
+	stoppedFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 30*time.Second,
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = kube.StoppedServiceLabel + "=true"
+		}))
+	stoppedInformer := stoppedFactory.Core().V1().ConfigMaps()
+
 	return &statusFetcher{
 		podInformer:       podInformer.Informer(),
 		podLister:         podInformer.Lister(),
@@ -57,8 +73,12 @@ func newStatusFetcher(kubeClient kubernetes.Interface) *statusFetcher {
 		eventsLister:      eventsInformer.Lister(),
 		namespaceInformer: namespaceInformer.Informer(),
 		namespaceLister:   namespaceInformer.Lister(),
+		stoppedInformer:   stoppedInformer.Informer(),
+		stoppedLister:     stoppedInformer.Lister(),
 		podWatcher:        kube.NewWatcher(podInformer.Informer()),
+		eventsWatcher:     kube.NewWatcher(eventsInformer.Informer()),
 		namespaceWatcher:  kube.NewWatcher(namespaceInformer.Informer()),
+		stoppedWatcher:    kube.NewWatcher(stoppedInformer.Informer()),
 	}
 }
 
@@ -66,9 +86,11 @@ func (sf *statusFetcher) Start(stop chan struct{}) {
 	go sf.podInformer.Run(stop)
 	go sf.eventsInformer.Run(stop)
 	go sf.namespaceInformer.Run(stop)
+	go sf.stoppedInformer.Run(stop)
 	cache.WaitForCacheSync(stop, sf.podInformer.HasSynced)
 	cache.WaitForCacheSync(stop, sf.eventsInformer.HasSynced)
 	cache.WaitForCacheSync(stop, sf.namespaceInformer.HasSynced)
+	cache.WaitForCacheSync(stop, sf.stoppedInformer.HasSynced)
 }
 
 func (sf *statusFetcher) Watch(ctx context.Context, namespace string) chan struct{} {
@@ -80,15 +102,18 @@ func (sf *statusFetcher) Watch(ctx context.Context, namespace string) chan struc
 		}
 	}
 
-	// Send notifications whenever a pod within the namespace changes, or the
-	// namespace itself changes.
+	// Send notifications whenever a pod within the namespace changes, a
+	// service is stopped or started, or the namespace itself changes.
 	podSub := sf.podWatcher.Watch(ctx, kube.Key{Namespace: namespace})
+	stoppedSub := sf.stoppedWatcher.Watch(ctx, kube.Key{Namespace: namespace})
 	namespaceSub := sf.namespaceWatcher.Watch(ctx, kube.Key{Name: namespace})
 	go func() {
 		for {
 			select {
 			case <-podSub:
 				notify()
+			case <-stoppedSub:
+				notify()
 			case <-namespaceSub:
 				notify()
 			case <-ctx.Done():
@@ -124,7 +149,21 @@ func (sf *statusFetcher) Get(namespace string) (cluster.SandboxStatus, error) {
 
 	sandboxPhase := cluster.SandboxStatus_RUNNING
 
-	services := map[string]*cluster.ServiceStatus{}
+	// The status of each replica, keyed by service and then replica ordinal.
+	// Services that aren't replicated are tracked as a single replica.
+	replicas := map[string]map[int32]*cluster.ServiceStatus{}
+	isReplicated := map[string]bool{}
+	setStatus := func(labels map[string]string, status *cluster.ServiceStatus) {
+		svcName := labels["blimp.service"]
+		if _, ok := labels[kube.ReplicaLabel]; ok {
+			isReplicated[svcName] = true
+		}
+		if replicas[svcName] == nil {
+			replicas[svcName] = map[int32]*cluster.ServiceStatus{}
+		}
+		replicas[svcName][int32(kube.GetReplica(labels))] = status
+	}
+
 	for _, pod := range pods {
 		if pod.GetName() == "reservation" {
 			// Don't include its status, and also mark the sandbox as preparing.
@@ -133,9 +172,60 @@ func (sf *statusFetcher) Get(namespace string) (cluster.SandboxStatus, error) {
 			sandboxPhase = cluster.SandboxStatus_PREPARING
 			continue
 		}
-		svcName := pod.GetLabels()["blimp.service"]
 		serviceStatus := sf.getServiceStatus(pod)
-		services[svcName] = &serviceStatus
+		serviceStatus.HealthLog = sf.getHealthLog(pod)
+		serviceStatus.RestartCount, serviceStatus.LastExitReason = getRestartInfo(pod)
+
+		// Explain why the service is unhealthy with the output of the most
+		// recent check.
+		if n := len(serviceStatus.HealthLog); serviceStatus.Phase == cluster.ServicePhase_UNHEALTHY &&
+			serviceStatus.Msg == "" && n != 0 && !serviceStatus.HealthLog[n-1].Healthy {
+			serviceStatus.Msg = serviceStatus.HealthLog[n-1].Output
+		}
+		setStatus(pod.GetLabels(), &serviceStatus)
+	}
+
+	// Services that were stopped by `blimp stop` don't have pods, or their
+	// pods are in the process of shutting down.
+	stopped, err := sf.stoppedLister.ConfigMaps(namespace).List(labels.Everything())
+	if err != nil {
+		return cluster.SandboxStatus{}, errors.WithContext("get stopped services", err)
+	}
+
+	for _, configMap := range stopped {
+		svcName := configMap.GetLabels()["blimp.service"]
+		podName := kube.ReplicaPodName(svcName, kube.GetReplica(configMap.GetLabels()))
+		pod, err := sf.podLister.Pods(namespace).Get(podName)
+		if err == nil && pod.DeletionTimestamp == nil {
+			// The service is being started.
+			continue
+		}
+
+		// Services that exceeded their maximum number of restarts are
+		// reported as having exited, rather than being stopped by the user.
+		if exitReason, ok := configMap.GetAnnotations()[kube.StoppedExitReasonAnnotation]; ok {
+			setStatus(configMap.GetLabels(), &cluster.ServiceStatus{
+				Phase:          cluster.ServicePhase_EXITED,
+				Msg:            exitReason,
+				HasStarted:     true,
+				LastExitReason: exitReason,
+			})
+			continue
+		}
+
+		setStatus(configMap.GetLabels(), &cluster.ServiceStatus{
+			Phase:      cluster.ServicePhase_STOPPED,
+			HasStarted: true,
+		})
+	}
+
+	services := map[string]*cluster.ServiceStatus{}
+	for svcName, svcReplicas := range replicas {
+		if isReplicated[svcName] {
+			services[svcName] = summarizeReplicas(svcReplicas)
+		} else {
+			services[svcName] = svcReplicas[1]
+		}
 	}
 	return cluster.SandboxStatus{
 		Phase:    sandboxPhase,
@@ -143,8 +233,85 @@ func (sf *statusFetcher) Get(namespace string) (cluster.SandboxStatus, error) {
 	}, nil
 }
 
-func (sf *statusFetcher) isPulling(namespace, pod, fieldPath string) bool {
-	events, err := sf.eventsLister.Events(namespace).List(labels.Everything())
+// summarizeReplicas returns the status of a service with multiple replicas.
+// The service is only reported as running once all of its replicas are
+// running. Otherwise, it's reported with the status of the first replica that
+// isn't running.
+func summarizeReplicas(replicas map[int32]*cluster.ServiceStatus) *cluster.ServiceStatus {
+	var ordinals []int
+	for i := range replicas {
+		ordinals = append(ordinals, int(i))
+	}
+	sort.Ints(ordinals)
+
+	summary := &cluster.ServiceStatus{
+		Phase:    cluster.ServicePhase_RUNNING,
+		Replicas: replicas,
+	}
+	var numRunning int
+	var notRunning *cluster.ServiceStatus
+	var notRunningOrdinal int
+	for _, i := range ordinals {
+		replica := replicas[int32(i)]
+		summary.HasStarted = summary.HasStarted || replica.HasStarted
+		summary.RestartCount += replica.RestartCount
+		if replica.LastExitReason != "" {
+			summary.LastExitReason = replica.LastExitReason
+		}
+
+		if replica.Phase == cluster.ServicePhase_RUNNING {
+			numRunning++
+		} else if notRunning == nil {
+			notRunning = replica
+			notRunningOrdinal = i
+		}
+	}
+
+	if notRunning == nil {
+		return summary
+	}
+
+	summary.Phase = notRunning.Phase
+	summary.HealthLog = notRunning.HealthLog
+	summary.Msg = fmt.Sprintf("%d/%d replicas running", numRunning, len(replicas))
+	if notRunning.Msg != "" {
+		summary.Msg += fmt.Sprintf(" (replica %d: %s)", notRunningOrdinal, notRunning.Msg)
+	}
+	return summary
+}
+
+// eventsByPodUIDIndex is the name of the index of events by the UID of the pod
+// they're about. It's used so that computing a sandbox's status doesn't list
+// every event in the namespace for each pod.
+const eventsByPodUIDIndex = "podUID"
+
+func indexEventsByPodUID(obj interface{}) ([]string, error) {
+	event, ok := obj.(*corev1.Event)
+	if !ok || event.InvolvedObject.Kind != "Pod" {
+		return nil, nil
+	}
+	return []string{string(event.InvolvedObject.UID)}, nil
+}
+
+// getPodEvents returns the events about the given pod. Events about previous
+// pods with the same name aren't included.
+func (sf *statusFetcher) getPodEvents(pod *corev1.Pod) ([]*corev1.Event, error) {
+	objs, err := sf.eventsInformer.GetIndexer().ByIndex(eventsByPodUIDIndex, string(pod.UID))
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*corev1.Event
+	for _, obj := range objs {
+		if event, ok := obj.(*corev1.Event); ok {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (sf *statusFetcher) isPulling(pod *corev1.Pod, fieldPath string) bool {
+	events, err := sf.getPodEvents(pod)
 	if err != nil {
 		log.WithError(err).Warn("Failed to get events")
 		return false
@@ -154,10 +321,7 @@ func (sf *statusFetcher) isPulling(namespace, pod, fieldPath string) bool {
 	var pullStarted metav1.Time
 	var pullCompleted metav1.Time
 	for _, event := range events {
-		if event.InvolvedObject.Kind != "Pod" ||
-			event.InvolvedObject.Namespace != namespace ||
-			event.InvolvedObject.Name != pod ||
-			event.InvolvedObject.FieldPath != fieldPath {
+		if event.InvolvedObject.FieldPath != fieldPath {
 			continue
 		}
 
@@ -220,7 +384,7 @@ func (sf *statusFetcher) getServiceStatus(pod *corev1.Pod) cluster.ServiceStatus
 				}
 			}
 
-			isPulling := sf.isPulling(pod.Namespace, pod.Name,
+			isPulling := sf.isPulling(pod,
 				fmt.Sprintf("spec.initContainers{%s}", kube.ContainerNameInitializeVolumeFromImage))
 			if isPulling {
 				return cluster.ServiceStatus{
@@ -266,8 +430,7 @@ func (sf *statusFetcher) getServiceStatus(pod *corev1.Pod) cluster.ServiceStatus
 				}
 			}
 
-			isPulling := sf.isPulling(pod.Namespace, pod.Name,
-				fmt.Sprintf("spec.containers{%s}", cs.Name))
+			isPulling := sf.isPulling(pod, fmt.Sprintf("spec.containers{%s}", cs.Name))
 			if isPulling {
 				return cluster.ServiceStatus{
 					Phase:      cluster.ServicePhase_PENDING,
@@ -315,6 +478,23 @@ func (sf *statusFetcher) getServiceStatus(pod *corev1.Pod) cluster.ServiceStatus
 	}
 }
 
+// getRestartInfo returns the number of times the pod's container has
+// restarted, and why it most recently exited.
+func getRestartInfo(pod *corev1.Pod) (restartCount int32, lastExitReason string) {
+	for _, cs := range pod.Status.ContainerStatuses {
+		restartCount += cs.RestartCount
+
+		lastExit := cs.State.Terminated
+		if lastExit == nil {
+			lastExit = cs.LastTerminationState.Terminated
+		}
+		if lastExit != nil {
+			lastExitReason = describeExit(lastExit)
+		}
+	}
+	return restartCount, lastExitReason
+}
+
 func isUnschedulable(pod *corev1.Pod) bool {
 	if pod.Status.Phase != corev1.PodPending {
 		return false
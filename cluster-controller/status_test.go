@@ -146,6 +146,376 @@ func TestGetStatus(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "Unhealthy",
+			namespace: "namespace",
+			mockObjects: []runtime.Object{
+				&corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "namespace",
+					},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "web",
+						UID:       "uid",
+						Labels: map[string]string{
+							"blimp.customerPod": "true",
+							"blimp.service":     "web",
+						},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{ReadinessProbe: &corev1.Probe{}},
+						},
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+						Conditions: []corev1.PodCondition{
+							{
+								Type:               corev1.ContainersReady,
+								Status:             corev1.ConditionFalse,
+								LastTransitionTime: metav1.Unix(200, 0),
+							},
+						},
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								Ready: false,
+								State: corev1.ContainerState{
+									Running: &corev1.ContainerStateRunning{},
+								},
+							},
+						},
+					},
+				},
+				&corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "web.1",
+					},
+					InvolvedObject: corev1.ObjectReference{
+						Kind: "Pod",
+						Name: "web",
+						UID:  "uid",
+					},
+					Reason:        "Unhealthy",
+					Message:       "Readiness probe failed: connection refused",
+					Count:         3,
+					LastTimestamp: metav1.Unix(300, 0),
+				},
+				// Events for previous pods should be ignored.
+				&corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "web.2",
+					},
+					InvolvedObject: corev1.ObjectReference{
+						Kind: "Pod",
+						Name: "web",
+						UID:  "old-uid",
+					},
+					Reason:        "Unhealthy",
+					Message:       "Readiness probe failed: stale",
+					LastTimestamp: metav1.Unix(100, 0),
+				},
+			},
+			exp: cluster.SandboxStatus{
+				Phase: cluster.SandboxStatus_RUNNING,
+				Services: map[string]*cluster.ServiceStatus{
+					"web": {
+						Phase:      cluster.ServicePhase_UNHEALTHY,
+						Msg:        "connection refused",
+						HasStarted: true,
+						HealthLog: []*cluster.HealthCheckResult{
+							{Time: 300, Output: "connection refused", Count: 3},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "Pulling",
+			namespace: "namespace",
+			mockObjects: []runtime.Object{
+				&corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "namespace",
+					},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "web",
+						UID:       "uid",
+						Labels: map[string]string{
+							"blimp.customerPod": "true",
+							"blimp.service":     "web",
+						},
+					},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								Name: "web",
+								State: corev1.ContainerState{
+									Waiting: &corev1.ContainerStateWaiting{
+										Reason: "ContainerCreating",
+									},
+								},
+							},
+						},
+					},
+				},
+				&corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "web.1",
+					},
+					InvolvedObject: corev1.ObjectReference{
+						Kind:      "Pod",
+						Name:      "web",
+						UID:       "uid",
+						FieldPath: "spec.containers{web}",
+					},
+					Reason:        "Pulling",
+					LastTimestamp: metav1.Unix(200, 0),
+				},
+				// The pull by a previous pod shouldn't count as completing
+				// the current pull.
+				&corev1.Event{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "web.2",
+					},
+					InvolvedObject: corev1.ObjectReference{
+						Kind:      "Pod",
+						Name:      "web",
+						UID:       "old-uid",
+						FieldPath: "spec.containers{web}",
+					},
+					Reason:        "Pulled",
+					LastTimestamp: metav1.Unix(300, 0),
+				},
+			},
+			exp: cluster.SandboxStatus{
+				Phase: cluster.SandboxStatus_RUNNING,
+				Services: map[string]*cluster.ServiceStatus{
+					"web": {
+						Phase: cluster.ServicePhase_PENDING,
+						Msg:   imagePullingMsg,
+					},
+				},
+			},
+		},
+		{
+			name:      "Stopped",
+			namespace: "namespace",
+			mockObjects: []runtime.Object{
+				&corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "namespace",
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      kube.StoppedServiceConfigMapName("web"),
+						Labels: map[string]string{
+							kube.StoppedServiceLabel: "true",
+							"blimp.service":          "web",
+						},
+					},
+				},
+				// Other ConfigMaps should be ignored.
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "wait-spec",
+						Labels: map[string]string{
+							"blimp.service": "db",
+						},
+					},
+				},
+			},
+			exp: cluster.SandboxStatus{
+				Phase: cluster.SandboxStatus_RUNNING,
+				Services: map[string]*cluster.ServiceStatus{
+					"web": {
+						Phase:      cluster.ServicePhase_STOPPED,
+						HasStarted: true,
+					},
+				},
+			},
+		},
+		{
+			name:      "Restarted",
+			namespace: "namespace",
+			mockObjects: []runtime.Object{
+				&corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "namespace",
+					},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      "web",
+						Labels: map[string]string{
+							"blimp.customerPod": "true",
+							"blimp.service":     "web",
+						},
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								Ready:        true,
+								RestartCount: 2,
+								State: corev1.ContainerState{
+									Running: &corev1.ContainerStateRunning{},
+								},
+								LastTerminationState: corev1.ContainerState{
+									Terminated: &corev1.ContainerStateTerminated{
+										ExitCode: 137,
+										Reason:   "OOMKilled",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			exp: cluster.SandboxStatus{
+				Phase: cluster.SandboxStatus_RUNNING,
+				Services: map[string]*cluster.ServiceStatus{
+					"web": {
+						Phase:          cluster.ServicePhase_RUNNING,
+						HasStarted:     true,
+						RestartCount:   2,
+						LastExitReason: "exit code 137 (OOMKilled)",
+					},
+				},
+			},
+		},
+		{
+			name:      "ExceededMaxRestarts",
+			namespace: "namespace",
+			mockObjects: []runtime.Object{
+				&corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "namespace",
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      kube.StoppedServiceConfigMapName("web"),
+						Labels: map[string]string{
+							kube.StoppedServiceLabel: "true",
+							"blimp.service":          "web",
+						},
+						Annotations: map[string]string{
+							kube.StoppedExitReasonAnnotation: "exit code 1 (Error)",
+						},
+					},
+				},
+			},
+			exp: cluster.SandboxStatus{
+				Phase: cluster.SandboxStatus_RUNNING,
+				Services: map[string]*cluster.ServiceStatus{
+					"web": {
+						Phase:          cluster.ServicePhase_EXITED,
+						Msg:            "exit code 1 (Error)",
+						HasStarted:     true,
+						LastExitReason: "exit code 1 (Error)",
+					},
+				},
+			},
+		},
+		{
+			name:      "Replicas",
+			namespace: "namespace",
+			mockObjects: []runtime.Object{
+				&corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "namespace",
+					},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      kube.ReplicaPodName("web", 1),
+						Labels: map[string]string{
+							"blimp.customerPod": "true",
+							"blimp.service":     "web",
+							kube.ReplicaLabel:   "1",
+						},
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								Ready:        true,
+								RestartCount: 1,
+								State: corev1.ContainerState{
+									Running: &corev1.ContainerStateRunning{},
+								},
+							},
+						},
+					},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      kube.ReplicaPodName("web", 2),
+						Labels: map[string]string{
+							"blimp.customerPod": "true",
+							"blimp.service":     "web",
+							kube.ReplicaLabel:   "2",
+						},
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodPending,
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "namespace",
+						Name:      kube.StoppedReplicaConfigMapName("web", 3),
+						Labels: map[string]string{
+							kube.StoppedServiceLabel: "true",
+							"blimp.service":          "web",
+							kube.ReplicaLabel:        "3",
+						},
+					},
+				},
+			},
+			exp: cluster.SandboxStatus{
+				Phase: cluster.SandboxStatus_RUNNING,
+				Services: map[string]*cluster.ServiceStatus{
+					"web": {
+						Phase:        cluster.ServicePhase_PENDING,
+						Msg:          "1/3 replicas running",
+						HasStarted:   true,
+						RestartCount: 1,
+						Replicas: map[int32]*cluster.ServiceStatus{
+							1: {
+								Phase:        cluster.ServicePhase_RUNNING,
+								HasStarted:   true,
+								RestartCount: 1,
+							},
+							2: {
+								Phase: cluster.ServicePhase_PENDING,
+							},
+							3: {
+								Phase:      cluster.ServicePhase_STOPPED,
+								HasStarted: true,
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
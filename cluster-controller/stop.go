@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// stoppedPodKey is the key in the stopped service's ConfigMap that contains
+// the pod to recreate when the service is started.
+const stoppedPodKey = "pod"
+
+func (s *server) Stop(ctx context.Context, req *cluster.StopRequest) (*cluster.StopResponse, error) {
+	user, err := auth.AuthorizeRequest(req.GetAuth())
+	if err != nil {
+		return &cluster.StopResponse{}, err
+	}
+
+	svc := req.GetService()
+	pods, err := s.getServicePods(user.Namespace, svc)
+	if err != nil {
+		return &cluster.StopResponse{}, errors.WithContext("get current pods", err)
+	}
+
+	if len(pods) == 0 {
+		if s.isStopped(user.Namespace, svc) {
+			return &cluster.StopResponse{}, errors.NewFriendlyError("Service %s is already stopped.", svc)
+		}
+		return &cluster.StopResponse{}, errors.NewFriendlyError("Service %s does not exist.", svc)
+	}
+
+	podClient := s.kubeClient.CoreV1().Pods(user.Namespace)
+	for _, pod := range pods {
+		pod := pod
+		if err := saveStoppedPod(s.kubeClient, &pod, ""); err != nil {
+			return &cluster.StopResponse{}, errors.WithContext("save pod", err)
+		}
+
+		// Give the pod 10 seconds to shut down, just like `blimp down`.
+		ten := int64(10)
+		err = podClient.Delete(pod.Name, &metav1.DeleteOptions{GracePeriodSeconds: &ten})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return &cluster.StopResponse{}, errors.WithContext("delete pod", err)
+		}
+	}
+
+	log.WithField("namespace", user.Namespace).WithField("service", svc).Info("Stopped service")
+	return &cluster.StopResponse{}, nil
+}
+
+// saveStoppedPod saves the pod so that it can be recreated by Start. If the
+// service was stopped by Blimp rather than the user, exitReason explains why.
+func saveStoppedPod(kubeClient kubernetes.Interface, currPod *corev1.Pod, exitReason string) error {
+	// The node is cleared since the service may be scheduled elsewhere when
+	// it's started.
+	stoppedPod := toRedeployablePod(currPod)
+	stoppedPod.Spec.NodeName = ""
+	podBytes, err := json.Marshal(stoppedPod)
+	if err != nil {
+		return errors.WithContext("marshal pod", err)
+	}
+
+	svc := currPod.Labels["blimp.service"]
+	configMap := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: currPod.Namespace,
+			Name:      kube.StoppedReplicaConfigMapName(svc, kube.GetReplica(currPod.Labels)),
+			Labels: map[string]string{
+				kube.StoppedServiceLabel: "true",
+				"blimp.service":          svc,
+			},
+		},
+		BinaryData: map[string][]byte{
+			stoppedPodKey: podBytes,
+		},
+	}
+	if replica, ok := currPod.Labels[kube.ReplicaLabel]; ok {
+		configMap.Labels[kube.ReplicaLabel] = replica
+	}
+	if exitReason != "" {
+		configMap.Annotations = map[string]string{
+			kube.StoppedExitReasonAnnotation: exitReason,
+		}
+	}
+	return kube.DeployConfigMap(kubeClient, configMap)
+}
+
+func (s *server) Start(ctx context.Context, req *cluster.StartRequest) (*cluster.StartResponse, error) {
+	user, err := auth.AuthorizeRequest(req.GetAuth())
+	if err != nil {
+		return &cluster.StartResponse{}, err
+	}
+
+	svc := req.GetService()
+	configMapClient := s.kubeClient.CoreV1().ConfigMaps(user.Namespace)
+	stopped, err := configMapClient.List(metav1.ListOptions{
+		LabelSelector: kube.StoppedServiceLabel + "=true",
+	})
+	if err != nil {
+		return &cluster.StartResponse{}, errors.WithContext("list stopped services", err)
+	}
+
+	var toStart []corev1.ConfigMap
+	for _, configMap := range stopped.Items {
+		if matchesServiceName(configMap.Labels, svc) {
+			toStart = append(toStart, configMap)
+		}
+	}
+
+	if len(toStart) == 0 {
+		pods, err := s.getServicePods(user.Namespace, svc)
+		if err == nil && len(pods) != 0 {
+			return &cluster.StartResponse{}, errors.NewFriendlyError(
+				"Service %s isn't stopped. Use `blimp restart %s` to restart it.", svc, svc)
+		}
+		return &cluster.StartResponse{}, errors.NewFriendlyError("Service %s does not exist.", svc)
+	}
+
+	for _, configMap := range toStart {
+		var pod corev1.Pod
+		if err := json.Unmarshal(configMap.BinaryData[stoppedPodKey], &pod); err != nil {
+			return &cluster.StartResponse{}, errors.WithContext("unmarshal pod", err)
+		}
+
+		// ForceRestart waits for the old pod to finish terminating if the
+		// service was just stopped.
+		err = kube.DeployPod(s.kubeClient, pod, kube.DeployPodOptions{ForceRestart: true})
+		if err != nil {
+			return &cluster.StartResponse{}, errors.WithContext("deploy pod", err)
+		}
+
+		if err := configMapClient.Delete(configMap.Name, nil); err != nil && !kerrors.IsNotFound(err) {
+			return &cluster.StartResponse{}, errors.WithContext("delete stopped service", err)
+		}
+	}
+
+	log.WithField("namespace", user.Namespace).WithField("service", svc).Info("Started service")
+	return &cluster.StartResponse{}, nil
+}
+
+// getServicePods returns the customer pods for the service, or for a single
+// replica if the name is of the form SERVICE.N.
+func (s *server) getServicePods(namespace, name string) ([]corev1.Pod, error) {
+	pods, err := s.kubeClient.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: "blimp.customerPod=true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []corev1.Pod
+	for _, pod := range pods.Items {
+		if matchesServiceName(pod.Labels, name) {
+			matching = append(matching, pod)
+		}
+	}
+	return matching, nil
+}
+
+// matchesServiceName returns whether the object with the given labels
+// belongs to the service referred to by name. The name may either refer to
+// all the replicas of a service, or a single replica, such as `web.2`.
+func matchesServiceName(labels map[string]string, name string) bool {
+	if labels["blimp.service"] == name {
+		return true
+	}
+
+	svc, replica, ok := kube.ParseReplicaName(name)
+	return ok && labels["blimp.service"] == svc && kube.GetReplica(labels) == replica
+}
+
+// isStopped returns whether the service was stopped by `blimp stop`. For
+// services with multiple replicas, it checks the first replica.
+func (s *server) isStopped(namespace, svc string) bool {
+	_, err := s.kubeClient.CoreV1().ConfigMaps(namespace).
+		Get(kube.StoppedServiceConfigMapName(svc), metav1.GetOptions{})
+	return err == nil
+}
+
+// clearStoppedServices forgets about all stopped services, except for those
+// in keep. It's called after the sandbox is redeployed, since `blimp up` boots
+// all services other than those with the `unless-stopped` restart policy.
+func (s *server) clearStoppedServices(namespace string, keep map[string]struct{}) error {
+	configMapClient := s.kubeClient.CoreV1().ConfigMaps(namespace)
+	stopped, err := configMapClient.List(metav1.ListOptions{
+		LabelSelector: kube.StoppedServiceLabel + "=true",
+	})
+	if err != nil {
+		return errors.WithContext("list stopped services", err)
+	}
+
+	for _, configMap := range stopped.Items {
+		if _, ok := keep[configMap.Labels["blimp.service"]]; ok {
+			continue
+		}
+
+		err := configMapClient.Delete(configMap.Name, nil)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.WithContext("delete stopped service", err)
+		}
+	}
+	return nil
+}
@@ -55,5 +55,16 @@ PersistentVolume according to the Delete reclaim policy.
 
 Note that this controller _is not_ a Blimp component. It's just part of the
 abstraction Kubernetes provides for PersistentVolumes.
+
+INSPECTING AND TRANSFERRING VOLUMES
+
+Individual volumes are inspected and removed by short-lived helper pods that
+mount the PersistentVolume, and operate on the volume's subdirectory. The
+helper pods are scheduled onto the same node as the rest of the namespace
+since the PersistentVolume can only be attached to one node at a time.
+
+Volumes are exported and imported by the volume transfer pod, which runs
+blimp-vtar from the init image. The CLI streams the volume's archive to and
+from the pod over a node controller tunnel.
 */
 package volume
@@ -0,0 +1,215 @@
+package volume
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kelda/blimp/cluster-controller/affinity"
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kubewait"
+	"github.com/kelda/blimp/pkg/version"
+)
+
+// helperMountPath is where the helper pod mounts the namespace's
+// PersistentVolume.
+const helperMountPath = "/pv"
+
+// DirUsage describes a single volume directory within the PersistentVolume.
+type DirUsage struct {
+	Exists    bool
+	SizeBytes int64
+}
+
+// Usage describes the state of the directories requested via Inspect, as well
+// as the overall usage of the PersistentVolume.
+type Usage struct {
+	Dirs          map[string]DirUsage
+	UsedBytes     int64
+	CapacityBytes int64
+}
+
+// Inspect checks whether each of the given volume directories exists in the
+// namespace's PersistentVolume. If includeSize is true, it also calculates
+// how much disk space each directory uses. The directories should be
+// generated with NamedVolumeDir or BindVolumeDir.
+func Inspect(ctx context.Context, kubeClient kubernetes.Interface, user auth.User,
+	dirs []string, includeSize bool) (Usage, error) {
+	for _, dir := range dirs {
+		if err := validateDir(dir); err != nil {
+			return Usage{}, err
+		}
+	}
+
+	// The script prints one line for each directory, in the same order as
+	// the arguments. The line is "-" if the directory doesn't exist, and the
+	// size in kilobytes otherwise. The final line contains the total size and
+	// usage of the filesystem in kilobytes.
+	script := `for d in "$@"; do
+  if [ ! -d "/pv/$d" ]; then
+    echo "-"
+  elif [ -n "$INCLUDE_SIZE" ]; then
+    du -sk "/pv/$d" | cut -f1
+  else
+    echo 0
+  fi
+done
+df -Pk /pv | tail -n 1 | awk '{print $2, $3}'`
+	env := []corev1.EnvVar{}
+	if includeSize {
+		env = append(env, corev1.EnvVar{Name: "INCLUDE_SIZE", Value: "true"})
+	}
+
+	out, err := runHelper(ctx, kubeClient, user, script, dirs, env)
+	if err != nil {
+		return Usage{}, err
+	}
+	return parseInspectOutput(dirs, out)
+}
+
+// Remove deletes the given volume directory from the namespace's
+// PersistentVolume. It's the caller's responsibility to make sure that the
+// volume isn't in use.
+func Remove(ctx context.Context, kubeClient kubernetes.Interface, user auth.User, dir string) error {
+	if err := validateDir(dir); err != nil {
+		return err
+	}
+
+	_, err := runHelper(ctx, kubeClient, user, `rm -rf "/pv/$1"`, []string{dir}, nil)
+	return err
+}
+
+// validateDir makes sure that the given directory refers to a volume within
+// the PersistentVolume, so that helpers can't be used to modify other files.
+func validateDir(dir string) error {
+	if filepath.IsAbs(dir) || filepath.Clean(dir) != dir {
+		return errors.New("invalid volume path %q", dir)
+	}
+
+	for _, parent := range []string{"volume", "bind"} {
+		rel, err := filepath.Rel(parent, dir)
+		if err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return nil
+		}
+	}
+	return errors.New("invalid volume path %q", dir)
+}
+
+func parseInspectOutput(dirs []string, out string) (Usage, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != len(dirs)+1 {
+		return Usage{}, errors.New("unexpected helper output: %q", out)
+	}
+
+	usage := Usage{Dirs: map[string]DirUsage{}}
+	for i, dir := range dirs {
+		line := strings.TrimSpace(lines[i])
+		if line == "-" {
+			usage.Dirs[dir] = DirUsage{}
+			continue
+		}
+
+		sizeKB, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			return Usage{}, errors.WithContext("parse volume size", err)
+		}
+		usage.Dirs[dir] = DirUsage{Exists: true, SizeBytes: sizeKB * 1024}
+	}
+
+	fsFields := strings.Fields(lines[len(dirs)])
+	if len(fsFields) != 2 {
+		return Usage{}, errors.New("unexpected filesystem usage: %q", lines[len(dirs)])
+	}
+
+	capacityKB, err := strconv.ParseInt(fsFields[0], 10, 64)
+	if err != nil {
+		return Usage{}, errors.WithContext("parse filesystem capacity", err)
+	}
+
+	usedKB, err := strconv.ParseInt(fsFields[1], 10, 64)
+	if err != nil {
+		return Usage{}, errors.WithContext("parse filesystem usage", err)
+	}
+
+	usage.CapacityBytes = capacityKB * 1024
+	usage.UsedBytes = usedKB * 1024
+	return usage, nil
+}
+
+// runHelper runs the given shell script in a short-lived pod that has the
+// namespace's PersistentVolume mounted at /pv, and returns its output. The
+// pod is scheduled onto the same node as the rest of the namespace since the
+// PersistentVolume can only be mounted by a single node at a time.
+func runHelper(ctx context.Context, kubeClient kubernetes.Interface, user auth.User,
+	script string, args []string, env []corev1.EnvVar) (string, error) {
+	podClient := kubeClient.CoreV1().Pods(user.Namespace)
+	pod, err := podClient.Create(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "volume-helper-",
+			Namespace:    user.Namespace,
+			Labels: map[string]string{
+				"service":                     "volume-helper",
+				affinity.ColocateNamespaceKey: user.Namespace,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:    "helper",
+				Image:   version.InitImage,
+				Command: append([]string{"sh", "-c", script, "sh"}, args...),
+				Env:     env,
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      PersistentVolume.Name,
+					MountPath: helperMountPath,
+				}},
+			}},
+			Volumes:       []corev1.Volume{PersistentVolume},
+			Affinity:      affinity.ForUser(user),
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	})
+	if err != nil {
+		return "", errors.WithContext("create helper pod", err)
+	}
+
+	defer func() {
+		if err := podClient.Delete(pod.Name, &metav1.DeleteOptions{}); err != nil {
+			log.WithError(err).WithField("namespace", user.Namespace).
+				WithField("pod", pod.Name).
+				Warn("Failed to delete volume helper pod")
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	var phase corev1.PodPhase
+	err = kubewait.WaitForObject(ctx,
+		kubewait.PodGetter(kubeClient, user.Namespace, pod.Name),
+		podClient.Watch,
+		func(podIntf interface{}) bool {
+			phase = podIntf.(*corev1.Pod).Status.Phase
+			return phase == corev1.PodSucceeded || phase == corev1.PodFailed
+		})
+	if err != nil {
+		return "", errors.WithContext("wait for helper pod", err)
+	}
+
+	out, err := podClient.GetLogs(pod.Name, &corev1.PodLogOptions{}).DoRaw()
+	if err != nil {
+		return "", errors.WithContext("get helper output", err)
+	}
+
+	if phase != corev1.PodSucceeded {
+		return "", errors.New("volume helper failed: %s", strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
@@ -0,0 +1,53 @@
+package volume
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDir(t *testing.T) {
+	tests := []struct {
+		dir   string
+		valid bool
+	}{
+		{NamedVolumeDir("db-data"), true},
+		{BindVolumeDir("/Users/kevin/src"), true},
+		{BindVolumeDir("/../../etc"), false},
+		{"volume", false},
+		{"bind", false},
+		{"/volume/foo", false},
+		{"volume/../bind", false},
+		{"other/foo", false},
+	}
+
+	for _, test := range tests {
+		err := validateDir(test.dir)
+		if test.valid {
+			assert.NoError(t, err, test.dir)
+		} else {
+			assert.Error(t, err, test.dir)
+		}
+	}
+}
+
+func TestParseInspectOutput(t *testing.T) {
+	dirs := []string{"volume/a", "bind/b"}
+
+	usage, err := parseInspectOutput(dirs, "12\n-\n26214400 1024\n")
+	assert.NoError(t, err)
+	assert.Equal(t, Usage{
+		Dirs: map[string]DirUsage{
+			"volume/a": {Exists: true, SizeBytes: 12 * 1024},
+			"bind/b":   {},
+		},
+		CapacityBytes: 26214400 * 1024,
+		UsedBytes:     1024 * 1024,
+	}, usage)
+
+	_, err = parseInspectOutput(dirs, "12\n26214400 1024\n")
+	assert.Error(t, err)
+
+	_, err = parseInspectOutput(dirs, "12\nfoo\n26214400 1024\n")
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package volume
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+
+	"github.com/kelda/blimp/cluster-controller/affinity"
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/ports"
+	"github.com/kelda/blimp/pkg/version"
+)
+
+// TransferMode is the direction of a volume transfer.
+type TransferMode string
+
+const (
+	TransferExport TransferMode = "export"
+	TransferImport TransferMode = "import"
+
+	// transferTimeout is the maximum amount of time that the transfer pod
+	// runs for. It protects against the pod running forever if the CLI never
+	// connects.
+	transferTimeout = int64(60 * 60)
+)
+
+// TransferPod returns the pod that serves an export or import of the given
+// volume directory. The CLI connects to the pod over a node controller
+// tunnel to stream the volume's contents. The pod exits once a single
+// transfer completes.
+//
+// Each call returns a pod with a unique name so that starting a transfer
+// doesn't interrupt transfers that are already running.
+func TransferPod(user auth.User, dir string, mode TransferMode) (corev1.Pod, error) {
+	if err := validateDir(dir); err != nil {
+		return corev1.Pod{}, err
+	}
+
+	timeout := transferTimeout
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", kube.PodNameVolumeTransfer, utilrand.String(5)),
+			Namespace: user.Namespace,
+			Labels: map[string]string{
+				"service":                     kube.PodNameVolumeTransfer,
+				affinity.ColocateNamespaceKey: user.Namespace,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  kube.PodNameVolumeTransfer,
+				Image: version.InitImage,
+				Command: []string{"/bin/blimp-vtar",
+					"-mode", string(mode),
+					"-dir", filepath.Join(helperMountPath, dir),
+					"-port", strconv.Itoa(ports.VolumeTransferPort),
+				},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      PersistentVolume.Name,
+					MountPath: helperMountPath,
+				}},
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						TCPSocket: &corev1.TCPSocketAction{
+							Port: intstr.FromInt(ports.VolumeTransferPort),
+						},
+					},
+					PeriodSeconds: 1,
+				},
+			}},
+			Volumes:               []corev1.Volume{PersistentVolume},
+			Affinity:              affinity.ForUser(user),
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: &timeout,
+		},
+	}, nil
+}
@@ -0,0 +1,30 @@
+package volume
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/kube"
+)
+
+func TestTransferPod(t *testing.T) {
+	user := auth.User{Namespace: "namespace"}
+	first, err := TransferPod(user, NamedVolumeDir("db-data"), TransferExport)
+	require.NoError(t, err)
+	second, err := TransferPod(user, NamedVolumeDir("db-data"), TransferImport)
+	require.NoError(t, err)
+
+	// Concurrent transfers shouldn't replace each other's pods.
+	assert.NotEqual(t, first.Name, second.Name)
+	for _, pod := range []string{first.Name, second.Name} {
+		assert.True(t, strings.HasPrefix(pod, kube.PodNameVolumeTransfer+"-"), pod)
+	}
+	assert.Equal(t, kube.PodNameVolumeTransfer, first.Labels["service"])
+
+	_, err = TransferPod(user, "volume/../bind", TransferExport)
+	assert.Error(t, err)
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kelda/blimp/cluster-controller/node"
+	"github.com/kelda/blimp/cluster-controller/sandbox"
+	"github.com/kelda/blimp/cluster-controller/volume"
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func (s *server) ListVolumes(ctx context.Context, req *cluster.ListVolumesRequest) (
+	*cluster.ListVolumesResponse, error) {
+	user, err := auth.AuthorizeRequest(req.GetAuth())
+	if err != nil {
+		return &cluster.ListVolumesResponse{}, err
+	}
+
+	if err := s.checkSandboxExists(user.Namespace); err != nil {
+		return &cluster.ListVolumesResponse{}, err
+	}
+
+	mounts, err := s.getVolumeMounts(user.Namespace)
+	if err != nil {
+		return &cluster.ListVolumesResponse{}, err
+	}
+
+	var dirs []string
+	for _, ref := range req.GetVolumes() {
+		dirs = append(dirs, toVolumeDir(ref))
+	}
+
+	usage, err := volume.Inspect(ctx, s.kubeClient, user, dirs, req.GetIncludeSize())
+	if err != nil {
+		return &cluster.ListVolumesResponse{}, errors.WithContext("inspect volumes", err)
+	}
+
+	var statuses []*cluster.VolumeStatus
+	for _, ref := range req.GetVolumes() {
+		dir := toVolumeDir(ref)
+		dirUsage := usage.Dirs[dir]
+		statuses = append(statuses, &cluster.VolumeStatus{
+			Volume:    ref,
+			Path:      dir,
+			Exists:    dirUsage.Exists,
+			SizeBytes: dirUsage.SizeBytes,
+			MountedBy: servicesUsing(mounts, dir),
+		})
+	}
+
+	resp := &cluster.ListVolumesResponse{Volumes: statuses}
+	if req.GetIncludeSize() {
+		resp.UsedBytes = usage.UsedBytes
+		resp.CapacityBytes = usage.CapacityBytes
+	}
+	return resp, nil
+}
+
+func (s *server) RemoveVolume(ctx context.Context, req *cluster.RemoveVolumeRequest) (
+	*cluster.RemoveVolumeResponse, error) {
+	user, err := auth.AuthorizeRequest(req.GetAuth())
+	if err != nil {
+		return &cluster.RemoveVolumeResponse{}, err
+	}
+
+	if err := s.checkSandboxExists(user.Namespace); err != nil {
+		return &cluster.RemoveVolumeResponse{}, err
+	}
+
+	mounts, err := s.getVolumeMounts(user.Namespace)
+	if err != nil {
+		return &cluster.RemoveVolumeResponse{}, err
+	}
+
+	dir := toVolumeDir(req.GetVolume())
+	if services := servicesUsing(mounts, dir); len(services) != 0 {
+		return &cluster.RemoveVolumeResponse{}, errors.NewFriendlyError(
+			"Volume %s is in use by %v. Stop the services using it before removing it.",
+			req.GetVolume().GetName(), services)
+	}
+
+	// Syncthing is still syncing the folder with the user's machine, so the
+	// removal would race with the sync.
+	syncedDirs, err := s.getSyncedDirs(user.Namespace)
+	if err != nil {
+		return &cluster.RemoveVolumeResponse{}, err
+	}
+
+	for _, syncedDir := range syncedDirs {
+		if dirsOverlap(syncedDir, dir) {
+			return &cluster.RemoveVolumeResponse{}, errors.NewFriendlyError(
+				"Volume %s is synced with your machine by `blimp up`. Remove it from your "+
+					"Compose file and rerun `blimp up` before removing it.",
+				req.GetVolume().GetName())
+		}
+	}
+
+	if err := volume.Remove(ctx, s.kubeClient, user, dir); err != nil {
+		return &cluster.RemoveVolumeResponse{}, errors.WithContext("remove volume", err)
+	}
+	return &cluster.RemoveVolumeResponse{}, nil
+}
+
+func (s *server) checkSandboxExists(namespace string) error {
+	ns, err := s.statusFetcher.namespaceLister.Get(namespace)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return errors.NewFriendlyError("Sandbox does not exist")
+		}
+		return errors.WithContext("get sandbox", err)
+	}
+
+	if ns.Status.Phase == corev1.NamespaceTerminating {
+		return errors.NewFriendlyError("Sandbox is being deleted")
+	}
+	return nil
+}
+
+// getVolumeMounts returns a map from volume directories to the names of the
+// running services that mount them. Pods started by `blimp run` are referred
+// to by their pod name, since they aren't part of their service.
+func (s *server) getVolumeMounts(namespace string) (map[string][]string, error) {
+	pods, err := s.statusFetcher.podLister.Pods(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, errors.WithContext("list services", err)
+	}
+
+	mounts := map[string][]string{}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		var svcName string
+		switch {
+		case pod.Labels["blimp.customerPod"] == "true":
+			svcName = pod.Labels["blimp.service"]
+		case pod.Labels[kube.OneOffPodLabel] == "true":
+			svcName = pod.Name
+		default:
+			continue
+		}
+
+		for _, c := range pod.Spec.Containers {
+			for _, mount := range c.VolumeMounts {
+				if mount.Name != volume.PersistentVolume.Name {
+					continue
+				}
+
+				if !contains(mounts[mount.SubPath], svcName) {
+					mounts[mount.SubPath] = append(mounts[mount.SubPath], svcName)
+				}
+			}
+		}
+	}
+
+	for _, services := range mounts {
+		sort.Strings(services)
+	}
+	return mounts, nil
+}
+
+// getSyncedDirs returns the volume directories that Syncthing syncs with the
+// user's machine.
+func (s *server) getSyncedDirs(namespace string) ([]string, error) {
+	sb, err := sandbox.Get(s.dynamicClient, namespace)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.WithContext("get sandbox", err)
+	}
+
+	var dirs []string
+	for _, src := range sb.Spec.SyncedFolders {
+		dirs = append(dirs, volume.BindVolumeDir(src))
+	}
+	return dirs, nil
+}
+
+// servicesUsing returns the services that mount `dir`. Bind volumes can be
+// nested, so services that mount a directory within `dir`, or a directory
+// containing it, are also using it.
+func servicesUsing(mounts map[string][]string, dir string) []string {
+	var services []string
+	for mountedDir, mountedBy := range mounts {
+		if !dirsOverlap(mountedDir, dir) {
+			continue
+		}
+
+		for _, svc := range mountedBy {
+			if !contains(services, svc) {
+				services = append(services, svc)
+			}
+		}
+	}
+	sort.Strings(services)
+	return services
+}
+
+// dirsOverlap returns whether `a` and `b` are the same directory, or one of
+// them contains the other. The paths are compared by component, so
+// `bind/src` doesn't contain `bind/src2`.
+func dirsOverlap(a, b string) bool {
+	return isWithinDir(a, b) || isWithinDir(b, a)
+}
+
+func isWithinDir(parent, path string) bool {
+	rel, err := filepath.Rel(parent, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func toVolumeDir(ref *cluster.VolumeRef) string {
+	if ref.GetType() == cluster.VolumeType_BIND_VOLUME {
+		return volume.BindVolumeDir(ref.GetName())
+	}
+	return volume.NamedVolumeDir(ref.GetName())
+}
+
+func (s *server) StartVolumeTransfer(ctx context.Context, req *cluster.StartVolumeTransferRequest) (
+	*cluster.StartVolumeTransferResponse, error) {
+	user, err := auth.AuthorizeRequest(req.GetAuth())
+	if err != nil {
+		return &cluster.StartVolumeTransferResponse{}, err
+	}
+
+	if err := s.checkSandboxExists(user.Namespace); err != nil {
+		return &cluster.StartVolumeTransferResponse{}, err
+	}
+
+	dir := toVolumeDir(req.GetVolume())
+	mode := volume.TransferExport
+	if req.GetDirection() == cluster.StartVolumeTransferRequest_IMPORT {
+		mode = volume.TransferImport
+
+		// Importing replaces the volume's contents, so it isn't safe while
+		// services are using the volume.
+		mounts, err := s.getVolumeMounts(user.Namespace)
+		if err != nil {
+			return &cluster.StartVolumeTransferResponse{}, err
+		}
+
+		if services := servicesUsing(mounts, dir); len(services) != 0 {
+			return &cluster.StartVolumeTransferResponse{}, errors.NewFriendlyError(
+				"Volume %s is in use by %v. Stop the services using it before importing into it.",
+				req.GetVolume().GetName(), services)
+		}
+	}
+
+	if err := s.deleteFinishedTransferPods(user.Namespace); err != nil {
+		log.WithError(err).WithField("namespace", user.Namespace).Warn("Failed to delete old volume transfer pods")
+	}
+
+	pod, err := volume.TransferPod(user, dir, mode)
+	if err != nil {
+		return &cluster.StartVolumeTransferResponse{}, err
+	}
+
+	err = kube.DeployPod(s.kubeClient, pod, kube.DeployPodOptions{})
+	if err != nil {
+		return &cluster.StartVolumeTransferResponse{}, errors.WithContext("deploy transfer pod", err)
+	}
+
+	transferPod, err := s.getPod(ctx, user.Namespace, pod.Name, podIsReady)
+	if err != nil {
+		return &cluster.StartVolumeTransferResponse{}, errors.WithContext("transfer pod never started", err)
+	}
+
+	nodeAddress, nodeCert, err := node.GetConnectionInfo(ctx, s.kubeClient, transferPod.Spec.NodeName)
+	if err != nil {
+		return &cluster.StartVolumeTransferResponse{}, errors.WithContext("get node connection info", err)
+	}
+
+	return &cluster.StartVolumeTransferResponse{
+		NodeAddress: nodeAddress,
+		NodeCert:    nodeCert,
+		PodName:     pod.Name,
+	}, nil
+}
+
+// deleteFinishedTransferPods deletes the pods of volume transfers that have
+// completed. Transfer pods that are still running are left alone, and are
+// killed by their deadline if the CLI never connects.
+func (s *server) deleteFinishedTransferPods(namespace string) error {
+	pods, err := s.statusFetcher.podLister.
+		Pods(namespace).
+		List(labels.Set(
+			map[string]string{"service": kube.PodNameVolumeTransfer},
+		).AsSelector())
+	if err != nil {
+		return errors.WithContext("list transfer pods", err)
+	}
+
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			continue
+		}
+
+		if err := kube.DeletePod(s.kubeClient, namespace, pod.Name); err != nil {
+			return errors.WithContext(fmt.Sprintf("delete %s", pod.Name), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kelda/blimp/cli/manager"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+func TestServicesUsing(t *testing.T) {
+	mounts := map[string][]string{
+		"bind/src/app":  {"web"},
+		"bind/src/data": {"db", "web"},
+		"volume/cache":  {"worker"},
+	}
+
+	tests := []struct {
+		dir string
+		exp []string
+	}{
+		{"bind/src/app", []string{"web"}},
+		{"bind/src/app/static", []string{"web"}},
+		{"bind/src", []string{"db", "web"}},
+		{"bind", []string{"db", "web"}},
+		{"volume/cache", []string{"worker"}},
+
+		// Directories are compared by path component rather than by
+		// string prefix.
+		{"bind/src/ap", nil},
+		{"bind/src/app2", nil},
+		{"volume/cache2", nil},
+		{"bind/other", nil},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.exp, servicesUsing(mounts, test.dir), test.dir)
+	}
+}
+
+func TestRemoveVolumeInUse(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, `
+version: '3'
+services:
+  web:
+    image: nginx
+    volumes:
+      - /src/app:/app
+`)
+	tc.createSandbox(composeFile, map[string]string{"data": "/data"})
+	tc.deploy(composeFile)
+	tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "web")
+
+	removeBindVolume := func(path string) error {
+		_, err := manager.C.RemoveVolume(context.Background(), &cluster.RemoveVolumeRequest{
+			Auth: tc.auth,
+			Volume: &cluster.VolumeRef{
+				Type: cluster.VolumeType_BIND_VOLUME,
+				Name: path,
+			},
+		})
+		return err
+	}
+
+	// Removing the mounted directory, or a directory that contains it or is
+	// within it, would delete files out from under the service.
+	for _, path := range []string{"/src/app", "/src", "/src/app/static"} {
+		err := removeBindVolume(path)
+		require.Error(t, err, path)
+		assert.Contains(t, err.Error(), "in use by [web]", path)
+	}
+
+	for _, path := range []string{"/data", "/data/db"} {
+		err := removeBindVolume(path)
+		require.Error(t, err, path)
+		assert.Contains(t, err.Error(), "synced with your machine", path)
+	}
+}
+
+func TestImportVolumeInUse(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, `
+version: '3'
+services:
+  web:
+    image: nginx
+    volumes:
+      - /src/app:/app
+`)
+	tc.createSandbox(composeFile, nil)
+	tc.deploy(composeFile)
+	tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "web")
+
+	for _, path := range []string{"/src/app", "/src", "/src/app/static"} {
+		_, err := manager.C.StartVolumeTransfer(context.Background(), &cluster.StartVolumeTransferRequest{
+			Auth: tc.auth,
+			Volume: &cluster.VolumeRef{
+				Type: cluster.VolumeType_BIND_VOLUME,
+				Name: path,
+			},
+			Direction: cluster.StartVolumeTransferRequest_IMPORT,
+		})
+		require.Error(t, err, path)
+		assert.Contains(t, err.Error(), "in use by [web]", path)
+	}
+}
+
+func TestRemoveVolumeInUseByOneOffPod(t *testing.T) {
+	tc := newTestCluster(t)
+	defer tc.Stop()
+
+	composeFile := loadComposeFile(t, `
+version: '3'
+services:
+  web:
+    image: nginx
+    volumes:
+      - /src/app:/app
+`)
+	tc.createSandbox(composeFile, nil)
+	tc.deploy(composeFile)
+	status := tc.waitForServicePhase(cluster.ServicePhase_RUNNING, "web")
+
+	// Simulate a pod started by `blimp run` that's still running after the
+	// service stops mounting the volume.
+	_, podName, ok := manager.LookupService(status, "web")
+	require.True(t, ok)
+	webPod, err := tc.kubeClient.CoreV1().Pods(tc.namespace).Get(podName, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	oneOff := toOneOffPod(*webPod, "web", false)
+	oneOff.Name = "web-run-test"
+	oneOff.Status.Phase = corev1.PodRunning
+	_, err = tc.kubeClient.CoreV1().Pods(tc.namespace).Create(&oneOff)
+	require.NoError(t, err)
+
+	tc.deploy(loadComposeFile(t, `
+version: '3'
+services:
+  web:
+    image: nginx
+`))
+
+	require.Eventually(t, func() bool {
+		_, err := manager.C.RemoveVolume(context.Background(), &cluster.RemoveVolumeRequest{
+			Auth: tc.auth,
+			Volume: &cluster.VolumeRef{
+				Type: cluster.VolumeType_BIND_VOLUME,
+				Name: "/src/app",
+			},
+		})
+		return err != nil && strings.Contains(err.Error(), "in use by [web-run-test]")
+	}, 10*time.Second, 10*time.Millisecond)
+}
@@ -26,6 +26,7 @@ require (
 	github.com/daaku/go.zipexe v1.0.1 // indirect
 	github.com/docker/cli v0.0.0-20200320120634-22acbbcc4b3f
 	github.com/docker/docker v1.14.0-0.20190319215453-e7b5f7dbe98c
+	github.com/docker/go-units v0.4.0
 	github.com/ghodss/yaml v1.0.1-0.20190212211648-25d852aebe32
 	github.com/golang/protobuf v1.4.2
 	github.com/google/go-containerregistry v0.1.0
@@ -44,6 +45,7 @@ require (
 	github.com/stretchr/testify v1.5.1
 	github.com/syncthing/syncthing v1.6.1
 	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
+	golang.org/x/net v0.0.0-20200625001655-4c5254603344
 	golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d
 	golang.org/x/sync v0.0.0-20200625203802-6e8e738ad208
 	google.golang.org/grpc v1.29.1
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// linkRecorderKey is the context key for a request's linkRecorder.
+type linkRecorderKey struct{}
+
+// linkRecorder holds the link that a request was proxied to. The link is
+// recorded by RoundTrip so that the access log doesn't resolve it again,
+// which would query the cluster manager for every request to an unknown
+// link.
+type linkRecorder struct {
+	link *cluster.ResolveExposedLinkResponse
+}
+
+// recordLink saves the link that the request was proxied to for the access
+// log.
+func recordLink(req *http.Request, link *cluster.ResolveExposedLinkResponse) {
+	if recorder, ok := req.Context().Value(linkRecorderKey{}).(*linkRecorder); ok {
+		recorder.link = link
+	}
+}
+
+// accessLogger wraps `next` so that a structured log line is written for
+// every request to an exposed link.
+func (s *server) accessLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		lw := &loggingResponseWriter{ResponseWriter: w}
+		recorder := &linkRecorder{}
+		next.ServeHTTP(lw, req.WithContext(context.WithValue(req.Context(), linkRecorderKey{}, recorder)))
+
+		fields := log.Fields{
+			"method":    req.Method,
+			"path":      req.URL.Path,
+			"proto":     req.Proto,
+			"status":    lw.statusCode(),
+			"bytes":     lw.bytes,
+			"duration":  time.Since(start),
+			"userAgent": req.UserAgent(),
+		}
+
+		// Identify the link by its namespace, service and port rather than the
+		// full hostname, since the hostname contains the link's secret token.
+		if linkHost := getLinkHost(req); linkHost != "" {
+			if namespace, _, err := parseLinkHost(linkHost); err == nil {
+				fields["namespace"] = namespace
+			}
+			if recorder.link != nil {
+				fields["service"] = recorder.link.GetService()
+				fields["port"] = recorder.link.GetPort()
+			}
+		}
+
+		if lw.hijacked {
+			fields["upgraded"] = true
+		}
+		log.WithFields(fields).Info("Access")
+	})
+}
+
+// loggingResponseWriter records the status code and number of bytes written
+// for a response. It passes through the optional interfaces that are needed
+// to proxy streaming and upgraded connections.
+type loggingResponseWriter struct {
+	http.ResponseWriter
+	status   int
+	bytes    int
+	hijacked bool
+}
+
+func (w *loggingResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *loggingResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+func (w *loggingResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *loggingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer doesn't support hijacking")
+	}
+	w.hijacked = true
+	if w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
+
+func (w *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *loggingResponseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
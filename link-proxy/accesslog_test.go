@@ -0,0 +1,56 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLogger(t *testing.T) {
+	hook := logTest.NewGlobal()
+	defer log.StandardLogger().ReplaceHooks(log.LevelHooks{})
+
+	manager := &mockManagerClient{}
+	s := &server{links: newLinkCache(manager)}
+	host := "kevinkeldaio-e8cbfe34030f2170a6." + LinkProxyBaseHostname
+
+	// The link resolved while proxying the request should be logged without
+	// querying the manager again.
+	handler := s.accessLogger(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		link, err := s.links.Resolve(req.Context(), getLinkHost(req))
+		require.NoError(t, err)
+		recordLink(req, link)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest("GET", "http://"+host+"/path", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Len(t, manager.requests, 1)
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, "kevinkeldaio-e8cbfe3403", entry.Data["namespace"])
+	assert.Equal(t, "web", entry.Data["service"])
+	assert.Equal(t, uint32(8080), entry.Data["port"])
+	assert.Equal(t, http.StatusNoContent, entry.Data["status"])
+
+	// Requests that fail before the link is resolved shouldn't cause the
+	// access log to query the manager.
+	handler = s.accessLogger(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	req = httptest.NewRequest("GET", "http://unknown0f2170a6."+LinkProxyBaseHostname+"/", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Len(t, manager.requests, 1)
+
+	entry = hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, "unknown", entry.Data["namespace"])
+	assert.NotContains(t, entry.Data, "service")
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+// linkCacheTTL is how long resolved links are cached before the cluster
+// manager is queried again, so changes to a link's service or port can take
+// up to this long to apply.
+//
+// Removing a link with `blimp expose --rm` isn't delayed by the cache: the
+// node controller checks the link whenever a tunnel is opened, and rejecting
+// it evicts the link from the cache. However, requests on connections that
+// were already open continue to be proxied until the connections close.
+const linkCacheTTL = 10 * time.Second
+
+// linkCache resolves exposed links via the cluster manager, and caches the
+// results so that the manager isn't queried for every connection.
+type linkCache struct {
+	manager cluster.ManagerClient
+
+	mutex sync.Mutex
+	links map[string]cachedLink
+}
+
+type cachedLink struct {
+	link   *cluster.ResolveExposedLinkResponse
+	expiry time.Time
+}
+
+func newLinkCache(manager cluster.ManagerClient) *linkCache {
+	return &linkCache{
+		manager: manager,
+		links:   map[string]cachedLink{},
+	}
+}
+
+// Resolve returns the information needed to connect to the link with the
+// given subdomain.
+func (c *linkCache) Resolve(ctx context.Context, host string) (*cluster.ResolveExposedLinkResponse, error) {
+	c.mutex.Lock()
+	cached, ok := c.links[host]
+	c.mutex.Unlock()
+	if ok && time.Now().Before(cached.expiry) {
+		return cached.link, nil
+	}
+
+	namespace, token, err := parseLinkHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := c.manager.ResolveExposedLink(ctx, &cluster.ResolveExposedLinkRequest{
+		Namespace: namespace,
+		Token:     token,
+	})
+	if err != nil {
+		return nil, errors.WithContext("resolve link", err)
+	}
+
+	c.mutex.Lock()
+	c.links[host] = cachedLink{
+		link:   link,
+		expiry: time.Now().Add(linkCacheTTL),
+	}
+	c.mutex.Unlock()
+	return link, nil
+}
+
+// Invalidate evicts the link with the given subdomain from the cache.
+func (c *linkCache) Invalidate(host string) {
+	c.mutex.Lock()
+	delete(c.links, host)
+	c.mutex.Unlock()
+}
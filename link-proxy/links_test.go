@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/kelda/blimp/pkg/proto/cluster"
+)
+
+type mockManagerClient struct {
+	cluster.ManagerClient
+	requests []cluster.ResolveExposedLinkRequest
+}
+
+func (m *mockManagerClient) ResolveExposedLink(_ context.Context, req *cluster.ResolveExposedLinkRequest,
+	_ ...grpc.CallOption) (*cluster.ResolveExposedLinkResponse, error) {
+	m.requests = append(m.requests, *req)
+	return &cluster.ResolveExposedLinkResponse{
+		NodeAddress: "node:443",
+		Service:     "web",
+		Port:        8080,
+	}, nil
+}
+
+func TestLinkCache(t *testing.T) {
+	manager := &mockManagerClient{}
+	links := newLinkCache(manager)
+
+	// The first lookup should query the manager.
+	link, err := links.Resolve(context.Background(), "kevinkeldaio-e8cbfe34030f2170a6")
+	assert.NoError(t, err)
+	assert.Equal(t, "web", link.Service)
+	assert.Equal(t, []cluster.ResolveExposedLinkRequest{
+		{Namespace: "kevinkeldaio-e8cbfe3403", Token: "0f2170a6"},
+	}, manager.requests)
+
+	// The second lookup should be served from the cache.
+	_, err = links.Resolve(context.Background(), "kevinkeldaio-e8cbfe34030f2170a6")
+	assert.NoError(t, err)
+	assert.Len(t, manager.requests, 1)
+
+	// Invalidating the link should force another query.
+	links.Invalidate("kevinkeldaio-e8cbfe34030f2170a6")
+	_, err = links.Resolve(context.Background(), "kevinkeldaio-e8cbfe34030f2170a6")
+	assert.NoError(t, err)
+	assert.Len(t, manager.requests, 2)
+
+	// Malformed hosts should be rejected without querying the manager.
+	_, err = links.Resolve(context.Background(), "not_a_link")
+	assert.Error(t, err)
+	assert.Len(t, manager.requests, 2)
+}
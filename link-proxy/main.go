@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"crypto/x509"
+	"flag"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"os"
@@ -12,22 +16,26 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/keepalive"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
 
-	// Importing cluster-controller/node seems bad...
-	"github.com/kelda/blimp/cluster-controller/node"
 	"github.com/kelda/blimp/pkg/errors"
-	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/proto/cluster"
 	nodeGRPC "github.com/kelda/blimp/pkg/proto/node"
 )
 
 type server struct {
-	kubeClient kubernetes.Interface
+	links *linkCache
+
+	// httpTransport is used for services that speak HTTP/1.1, including
+	// WebSocket upgrades.
+	httpTransport http.RoundTripper
+	// h2cTransport is used for services that speak cleartext HTTP/2.
+	h2cTransport http.RoundTripper
 
 	nodeConns      map[string]nodeGRPC.ControllerClient
 	nodeConnsMutex sync.Mutex
@@ -35,36 +43,70 @@ type server struct {
 
 var LinkProxyBaseHostname string
 
+// defaultManagerCertPath is where the link proxy's image contains the
+// certificate generated by `make certs`.
+const defaultManagerCertPath = "/etc/blimp/manager-cert.pem"
+
 func main() {
+	managerHost := flag.String("manager-host", "blimp-manager:9000",
+		"The address of the cluster manager's gRPC server")
+	managerCertPath := flag.String("manager-cert", defaultManagerCertPath,
+		"The path to the PEM-encoded certificate used by the cluster manager. "+
+			"It must include localhost in its subject alternative names.")
+	flag.Parse()
+
 	if linkProxyBaseHostnameVar, ok := os.LookupEnv("BLIMP_LINK_PROXY_BASE_HOSTNAME"); ok {
 		LinkProxyBaseHostname = linkProxyBaseHostnameVar
 	}
 
-	kubeClient, _, err := kube.GetClient()
+	managerCert, err := ioutil.ReadFile(*managerCertPath)
 	if err != nil {
-		log.WithError(err).Fatal("Failed to get kubernetes client")
+		log.WithError(err).Fatal("Failed to read cluster manager certificate")
+	}
+
+	// Like the CLI, verify the certificate against localhost rather than
+	// managerHost, since the manager's in-cluster address isn't in the
+	// certificate.
+	managerConn, err := dial(*managerHost, string(managerCert), "localhost")
+	if err != nil {
+		log.WithError(err).Fatal("Failed to connect to cluster manager")
 	}
 
 	s := &server{
-		kubeClient: kubeClient,
-		nodeConns:  map[string]nodeGRPC.ControllerClient{},
-	}
-
-	handler := httputil.ReverseProxy{
-		Director: director,
-		Transport: &http.Transport{
-			DialContext: s.dialTunnelContext,
-			// These are taken from http.DefaultTransport
-			MaxIdleConns:          100,
-			IdleConnTimeout:       90 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
+		links:     newLinkCache(cluster.NewManagerClient(managerConn)),
+		nodeConns: map[string]nodeGRPC.ControllerClient{},
+	}
+	s.httpTransport = &http.Transport{
+		DialContext: s.dialTunnelContext,
+		// These are taken from http.DefaultTransport
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	s.h2cTransport = &http2.Transport{
+		// Allow the http:// scheme, and dial the tunnel directly rather than
+		// doing a TLS handshake.
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			return s.dialTunnelContext(context.Background(), network, addr)
 		},
 	}
 
+	proxy := &httputil.ReverseProxy{
+		Director:     director,
+		Transport:    s,
+		ErrorHandler: proxyErrorHandler,
+		// Flush immediately so that streaming responses, such as server-sent
+		// events and gRPC streams, aren't buffered.
+		FlushInterval: -1,
+	}
+
+	// Accept cleartext HTTP/2 from the load balancer so that HTTP/2 requests
+	// can be proxied end-to-end to H2C services.
 	httpServer := http.Server{
 		Addr:    ":8000",
-		Handler: &handler,
+		Handler: h2c.NewHandler(s.accessLogger(proxy), &http2.Server{}),
 	}
 
 	err = httpServer.ListenAndServe()
@@ -73,45 +115,74 @@ func main() {
 	}
 }
 
-// Adjust requests by adding namespace info to req.URL.Host, where it will be
-// used by our custom transport.
-func director(req *http.Request) {
+// RoundTrip implements http.RoundTripper by picking the transport that
+// matches the protocol spoken by the exposed service.
+func (s *server) RoundTrip(req *http.Request) (*http.Response, error) {
+	link, err := s.links.Resolve(req.Context(), req.URL.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	recordLink(req, link)
+
+	if link.GetProtocol() == cluster.ExposeProtocol_H2C {
+		return s.h2cTransport.RoundTrip(req)
+	}
+	return s.httpTransport.RoundTrip(req)
+}
+
+func proxyErrorHandler(w http.ResponseWriter, req *http.Request, err error) {
+	log.WithError(err).WithField("host", req.Host).Info("Failed to proxy request")
+	w.WriteHeader(http.StatusBadGateway)
+}
+
+// getLinkHost returns the subdomain of the link being requested. It returns
+// an empty string if the request wasn't for an exposed link.
+func getLinkHost(req *http.Request) string {
 	// Make sure we don't get bamboozled into doing weird things. We expect
 	// "<namespace><token>.blimp.dev". The token is 8 hex characters, and
 	// everything before it is the namespace.
 	hostRegexp := regexp.MustCompile(`^([0-9a-z\-]+)\.` + regexp.QuoteMeta(LinkProxyBaseHostname) + `$`)
 	matches := hostRegexp.FindAllStringSubmatch(strings.ToLower(req.Host), 1)
 	if len(matches) != 1 {
+		return ""
+	}
+	// Return the regexp subgroup for the namespace.
+	return matches[0][1]
+}
+
+// parseLinkHost splits a link subdomain into the namespace and token.
+func parseLinkHost(host string) (namespace, token string, err error) {
+	hostRegexp := regexp.MustCompile(`^([a-z0-9\-]+)([a-f0-9]{8})$`)
+	matches := hostRegexp.FindStringSubmatch(host)
+	if len(matches) != 3 {
+		return "", "", errors.New("unexpected namespace format: %q", host)
+	}
+
+	// matches[0] contains the entire match, matches[1] and matches[2] are the
+	// submatches.
+	return matches[1], matches[2], nil
+}
+
+// Adjust requests by adding namespace info to req.URL.Host, where it will be
+// used by our custom transport.
+func director(req *http.Request) {
+	linkHost := getLinkHost(req)
+	if linkHost == "" {
 		// Host header did not match what we were expecting, abort.
 		req.URL.Host = ""
 		log.WithField("Host", req.Host).Info("Unexpected host")
 		return
 	}
-	// Get the regexp subgroup for the namespace, and place it in req.URL.Host
-	// for our transport to use.
-	req.URL.Host = matches[0][1]
+	req.URL.Host = linkHost
 	req.URL.Scheme = "http"
 
 	// Clear RemoteAddr from request so that it is not added to X-Forwarded-For.
 	req.RemoteAddr = ""
 }
 
-func (s *server) getNodeControllerConn(ctx context.Context, namespace string) (
+func (s *server) getNodeControllerConn(ctx context.Context, link *cluster.ResolveExposedLinkResponse) (
 	conn nodeGRPC.ControllerClient, err error) {
-	// XXX: This should happen via a gRPC to the cluster-controller, to avoid
-	// having to interact directly with kubernetes.
-	dnsPod, err := s.kubeClient.CoreV1().Pods(namespace).Get("dns", metav1.GetOptions{})
-	if err != nil {
-		return nil, errors.WithContext("get sandbox node", err)
-	}
-
-	certSecret, err := s.kubeClient.CoreV1().Secrets(node.NodeControllerNamespace).Get(
-		node.CertSecretName(dnsPod.Spec.NodeName), metav1.GetOptions{})
-	if err != nil {
-		return nil, errors.WithContext("get node controller cert", err)
-	}
-
-	nodeAddr := certSecret.Annotations["host"]
+	nodeAddr := link.GetNodeAddress()
 
 	s.nodeConnsMutex.Lock()
 	defer s.nodeConnsMutex.Unlock()
@@ -121,17 +192,7 @@ func (s *server) getNodeControllerConn(ctx context.Context, namespace string) (
 	}
 
 	// We need to create a new connection for this node.
-	certPool := x509.NewCertPool()
-	if !certPool.AppendCertsFromPEM(certSecret.Data["cert.pem"]) {
-		return nil, errors.New("failed to parse node controller cert")
-	}
-	nodeConn, err := grpc.DialContext(ctx, nodeAddr,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(certPool, "")),
-		// AWS ELBs close connections that are inactive for 60s, so we set a
-		// keepalive interval lower than this.
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{Time: 30 * time.Second}),
-		grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)),
-		grpc.WithUnaryInterceptor(errors.UnaryClientInterceptor))
+	nodeConn, err := dial(nodeAddr, link.GetNodeCert(), "")
 	if err != nil {
 		return nil, errors.WithContext("dial node controller", err)
 	}
@@ -141,3 +202,18 @@ func (s *server) getNodeControllerConn(ctx context.Context, namespace string) (
 
 	return conn, nil
 }
+
+func dial(addr, certPEM, serverNameOverride string) (*grpc.ClientConn, error) {
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM([]byte(certPEM)) {
+		return nil, errors.New("failed to parse cert")
+	}
+
+	return grpc.Dial(addr,
+		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(certPool, serverNameOverride)),
+		// AWS ELBs close connections that are inactive for 60s, so we set a
+		// keepalive interval lower than this.
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{Time: 30 * time.Second}),
+		grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)),
+		grpc.WithUnaryInterceptor(errors.UnaryClientInterceptor))
+}
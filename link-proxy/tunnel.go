@@ -4,7 +4,7 @@ import (
 	"context"
 	"io"
 	"net"
-	"regexp"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -21,6 +21,15 @@ type tunnelConn struct {
 	// pendingData contains any extra data that we receieved but was not read
 	// because the buffer was too small.
 	pendingData []byte
+	// onUnknownDestination is called if the node controller rejects the
+	// tunnel because the link no longer exists.
+	onUnknownDestination func()
+
+	// sendLock protects writes to the tunnel. Connections that have been
+	// upgraded (e.g. WebSockets) may be written and closed from different
+	// goroutines, but gRPC streams don't support concurrent sends.
+	sendLock sync.Mutex
+	closed   bool
 }
 
 // dialTunnelContext dials a network connection over a tunnel, and expects addr
@@ -40,18 +49,18 @@ func (s *server) dialTunnelContext(ctx context.Context, network, addr string) (n
 	if err != nil {
 		return nil, errors.WithContext("tunnel dial parse address", err)
 	}
-	hostRegexp := regexp.MustCompile(`^([a-z0-9\-]+)([a-f0-9]{8})$`)
-	matches := hostRegexp.FindStringSubmatch(host)
-	if len(matches) != 3 {
-		return nil, errors.New("unexpected namespace format: %q", host)
+
+	namespace, token, err := parseLinkHost(host)
+	if err != nil {
+		return nil, err
 	}
 
-	// matches[0] contains the entire match, matches[1] and matches[2] are the
-	// submatches.
-	namespace := matches[1]
-	token := matches[2]
+	link, err := s.links.Resolve(ctx, host)
+	if err != nil {
+		return nil, errors.WithContext("resolve link", err)
+	}
 
-	nodeController, err := s.getNodeControllerConn(ctx, namespace)
+	nodeController, err := s.getNodeControllerConn(ctx, link)
 	if err != nil {
 		return nil, errors.WithContext("get node controller connection", err)
 	}
@@ -77,7 +86,8 @@ func (s *server) dialTunnelContext(ctx context.Context, network, addr string) (n
 	}
 
 	return &tunnelConn{
-		tunnel: tunnel,
+		tunnel:               tunnel,
+		onUnknownDestination: func() { s.links.Invalidate(host) },
 	}, nil
 }
 
@@ -100,6 +110,10 @@ func (tc *tunnelConn) Read(b []byte) (n int, err error) {
 		tc.Close()
 		return 0, err
 	}
+	if status.Code(err) == codes.OutOfRange && tc.onUnknownDestination != nil {
+		// The link was removed since we last resolved it.
+		tc.onUnknownDestination()
+	}
 	if err != nil {
 		return 0, errors.WithContext("recv on tunnel", err)
 	}
@@ -116,12 +130,18 @@ func (tc *tunnelConn) Read(b []byte) (n int, err error) {
 }
 
 func (tc *tunnelConn) Write(b []byte) (n int, err error) {
+	tc.sendLock.Lock()
+	defer tc.sendLock.Unlock()
+	if tc.closed {
+		return 0, io.ErrClosedPipe
+	}
+
 	err = tc.tunnel.Send(&node.TunnelMsg{Msg: &node.TunnelMsg_Buf{Buf: b}})
 	if err == io.EOF || status.Code(err) == codes.Canceled {
 		// We attempt to send EOF and close the stream if possible, but it
 		// probably won't work and that's ok.
 		//nolint:errcheck
-		tc.Close()
+		tc.closeLocked()
 		return 0, err
 	}
 	if err != nil {
@@ -132,6 +152,20 @@ func (tc *tunnelConn) Write(b []byte) (n int, err error) {
 }
 
 func (tc *tunnelConn) Close() error {
+	tc.sendLock.Lock()
+	defer tc.sendLock.Unlock()
+	return tc.closeLocked()
+}
+
+// closeLocked closes the sending side of the tunnel. The receiving side stays
+// open until the other end sends an EOF so that half-closed connections work.
+// The caller must hold sendLock.
+func (tc *tunnelConn) closeLocked() error {
+	if tc.closed {
+		return nil
+	}
+	tc.closed = true
+
 	eofErr := tc.tunnel.Send(&node.TunnelMsg{Msg: &node.TunnelMsg_Eof{Eof: &node.EOF{}}})
 	closeErr := tc.tunnel.CloseSend()
 	if eofErr != nil {
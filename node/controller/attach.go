@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"io"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/proto/node"
+)
+
+// maxReplayBytes limits how much of the output written before the client
+// attached is replayed.
+const maxReplayBytes = 1024 * 1024
+
+func (s *Server) Attach(nsrv node.Controller_AttachServer) error {
+	msg, err := nsrv.Recv()
+	if err != nil {
+		return err
+	}
+
+	header := msg.GetHeader()
+	if header == nil {
+		return status.New(codes.Internal, "first message must be a header").Err()
+	}
+
+	user, err := auth.AuthorizeRequest(header.GetAuth())
+	if err != nil {
+		return errors.WithContext("bad token", err)
+	}
+
+	// Only allow attaching to pods created by `blimp run`. Attaching to
+	// regular services would steal their stdin.
+	pod, err := s.podLister.Pods(user.Namespace).Get(header.GetPodName())
+	if err != nil || pod.Labels[kube.OneOffPodLabel] != "true" {
+		return status.New(codes.OutOfRange, "unknown destination").Err()
+	}
+
+	attachOpts := corev1.PodAttachOptions{
+		Container: pod.Spec.Containers[0].Name,
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    !header.GetTty(),
+		TTY:       header.GetTty(),
+	}
+	req := s.kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		SubResource("attach").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		VersionedParams(&attachOpts, scheme.ParameterCodec)
+	exec, err := remotecommand.NewSPDYExecutor(s.restConfig, "POST", req.URL())
+	if err != nil {
+		return errors.WithContext("setup attach", err)
+	}
+
+	stdinReader, stdinWriter := io.Pipe()
+	sizeQueue := &terminalSizeQueue{sizes: make(chan remotecommand.TerminalSize, 1)}
+	go func() {
+		defer sizeQueue.close()
+		for {
+			msg, err := nsrv.Recv()
+			if err != nil {
+				stdinWriter.CloseWithError(err)
+				return
+			}
+
+			switch {
+			case msg.GetStdin() != nil:
+				if _, err := stdinWriter.Write(msg.GetStdin()); err != nil {
+					return
+				}
+			case msg.GetStdinEof() != nil:
+				stdinWriter.Close()
+			case msg.GetResize() != nil:
+				sizeQueue.push(remotecommand.TerminalSize{
+					Width:  uint16(msg.GetResize().GetWidth()),
+					Height: uint16(msg.GetResize().GetHeight()),
+				})
+			}
+		}
+	}()
+
+	// gRPC streams don't support concurrent sends, so stdout and stderr
+	// share a lock.
+	var sendLock sync.Mutex
+	streamOpts := remotecommand.StreamOptions{
+		Stdin: stdinReader,
+		Stdout: attachWriter{lock: &sendLock, send: func(b []byte) error {
+			return nsrv.Send(&node.AttachMsg{Msg: &node.AttachMsg_Stdout{Stdout: b}})
+		}},
+		Tty: header.GetTty(),
+	}
+	if header.GetTty() {
+		streamOpts.TerminalSizeQueue = sizeQueue
+	} else {
+		streamOpts.Stderr = attachWriter{lock: &sendLock, send: func(b []byte) error {
+			return nsrv.Send(&node.AttachMsg{Msg: &node.AttachMsg_Stderr{Stderr: b}})
+		}}
+	}
+
+	// The command starts running before the client attaches, so replay
+	// anything that it has already written. The output written between
+	// fetching the logs and the attach connecting is lost, but that window
+	// is much shorter than the time it takes the client to connect. The logs
+	// don't distinguish stdout from stderr, so it's all sent as stdout.
+	limitBytes := int64(maxReplayBytes)
+	replay, err := s.kubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  attachOpts.Container,
+		LimitBytes: &limitBytes,
+	}).DoRaw()
+	if err != nil {
+		log.WithError(err).WithField("pod", pod.Name).Warn("Failed to get output written before attach")
+	} else if len(replay) != 0 {
+		if _, err := streamOpts.Stdout.Write(replay); err != nil {
+			return errors.WithContext("send output", err)
+		}
+	}
+
+	// Stream returns once the process exits, or the client disconnects.
+	if err := exec.Stream(streamOpts); err != nil {
+		log.WithError(err).WithField("pod", pod.Name).Info("Attach ended with an error")
+		return errors.WithContext("attach", err)
+	}
+	return nil
+}
+
+// attachWriter forwards output from the attached process to the client.
+type attachWriter struct {
+	lock *sync.Mutex
+	send func([]byte) error
+}
+
+func (w attachWriter) Write(b []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	// Copy the buffer since the caller may reuse it after Write returns.
+	if err := w.send(append([]byte{}, b...)); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// terminalSizeQueue passes the resize events sent by the client to the
+// attach stream.
+type terminalSizeQueue struct {
+	sizes     chan remotecommand.TerminalSize
+	closeOnce sync.Once
+}
+
+func (q *terminalSizeQueue) push(size remotecommand.TerminalSize) {
+	// Drop stale sizes if the attach stream hasn't consumed them yet.
+	select {
+	case <-q.sizes:
+	default:
+	}
+
+	select {
+	case q.sizes <- size:
+	default:
+	}
+}
+
+func (q *terminalSizeQueue) close() {
+	q.closeOnce.Do(func() { close(q.sizes) })
+}
+
+// Next blocks until the terminal is resized. It returns nil once the client
+// disconnects.
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q.sizes
+	if !ok {
+		return nil
+	}
+	return &size
+}
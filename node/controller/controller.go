@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/kubernetes"
+	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/kelda/blimp/node/wait"
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/expose"
+	"github.com/kelda/blimp/pkg/kube"
+	"github.com/kelda/blimp/pkg/names"
+	"github.com/kelda/blimp/pkg/proto/node"
+	"github.com/kelda/blimp/pkg/tunnel"
+)
+
+// Server implements the Node Controller's gRPC API. It proxies connections
+// from the CLI to the pods on its node, and tracks the file sync status of the
+// CLIs connected to it.
+type Server struct {
+	kubeClient  kubernetes.Interface
+	restConfig  *rest.Config
+	syncTracker *wait.SyncTracker
+	podLister   listers.PodLister
+	nsLister    listers.NamespaceLister
+}
+
+func New(kubeClient kubernetes.Interface, restConfig *rest.Config, syncTracker *wait.SyncTracker,
+	podLister listers.PodLister, nsLister listers.NamespaceLister) *Server {
+	return &Server{
+		kubeClient:  kubeClient,
+		restConfig:  restConfig,
+		syncTracker: syncTracker,
+		podLister:   podLister,
+		nsLister:    nsLister,
+	}
+}
+
+func (s *Server) Tunnel(nsrv node.Controller_TunnelServer) error {
+	msg, err := nsrv.Recv()
+	if err != nil {
+		return err
+	}
+
+	header := msg.GetHeader()
+	if header == nil {
+		return status.New(codes.Internal, "first message must be a header").Err()
+	}
+
+	user, err := auth.AuthorizeRequest(auth.GetAuth(header))
+	if err != nil {
+		return errors.WithContext("bad token", err)
+	}
+
+	// XXX: We don't hash the name of the syncthing pod when deploying it.
+	// This weird special case is a sign that the API between the CLI and the
+	// Node Controller is poorly designed. We should revisit this when we
+	// redesign the other APIs that refer to service names, such as logs and
+	// SSH.
+	podName := header.Name
+	if header.Name != kube.PodNameSyncthing && header.Name != kube.PodNameBuildkitd &&
+		!strings.HasPrefix(header.Name, kube.PodNameVolumeTransfer+"-") {
+		podName = names.ToDNS1123(header.Name)
+	}
+
+	dstPod, err := s.podLister.Pods(user.Namespace).Get(podName)
+	if err != nil {
+		return status.New(codes.OutOfRange, "unknown destination").Err()
+	}
+
+	dialAddr := fmt.Sprintf("%s:%d", dstPod.Status.PodIP, header.Port)
+	stream, err := net.Dial("tcp", dialAddr)
+	if err != nil {
+		return status.New(codes.Internal, err.Error()).Err()
+	}
+
+	tunnel.ServerStream(nsrv, stream)
+	return nil
+}
+
+func (s *Server) ExposedTunnel(nsrv node.Controller_ExposedTunnelServer) error {
+	msg, err := nsrv.Recv()
+	if err != nil {
+		return status.New(codes.Internal, err.Error()).Err()
+	}
+
+	header := msg.GetExposedHeader()
+	if header == nil {
+		return status.New(codes.Internal, "first message must be a header").Err()
+	}
+
+	namespace, err := s.nsLister.Get(header.Namespace)
+	if err != nil {
+		return status.New(codes.OutOfRange, "unknown destination").Err()
+	}
+
+	annotationJson, ok := namespace.Annotations[kube.ExposeAnnotation]
+	if !ok {
+		// For security, if nothing is exposed, don't leak that the namespace exists.
+		return status.New(codes.OutOfRange, "unknown destination").Err()
+	}
+
+	annotation, err := expose.ParseJsonAnnotation(annotationJson)
+	if err != nil {
+		log.WithError(err).Error("Failed to parse expose annotation")
+		return status.New(codes.Internal, "failed to parse expose annotation").Err()
+	}
+
+	info, ok := annotation[header.Token]
+	if !ok {
+		return status.New(codes.OutOfRange, "unknown destination").Err()
+	}
+
+	podName := names.ToDNS1123(info.Service)
+
+	dstPod, err := s.podLister.Pods(header.Namespace).Get(podName)
+	if err != nil {
+		return status.New(codes.OutOfRange, "unknown destination").Err()
+	}
+
+	dialAddr := fmt.Sprintf("%s:%d", dstPod.Status.PodIP, info.Port)
+	stream, err := net.Dial("tcp", dialAddr)
+	if err != nil {
+		return status.New(codes.Internal, err.Error()).Err()
+	}
+
+	tunnel.ServerStream(nsrv, stream)
+	return nil
+}
+
+func (s *Server) SyncNotifications(srv node.Controller_SyncNotificationsServer) error {
+	handshake, err := srv.Recv()
+	if err != nil {
+		return err
+	}
+
+	user, err := auth.AuthorizeRequest(auth.GetAuth(handshake))
+	if err != nil {
+		return errors.WithContext("validate token", err)
+	}
+
+	return s.syncTracker.RunServer(user.Namespace, srv)
+}
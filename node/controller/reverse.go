@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kelda/blimp/pkg/auth"
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/hostgateway"
+	"github.com/kelda/blimp/pkg/proto/node"
+	"github.com/kelda/blimp/pkg/tunnel"
+)
+
+// ReverseTunnel forwards connections to `host.docker.internal` to the CLI.
+// The host gateway runs in the sandbox's DNS pod.
+func (s *Server) ReverseTunnel(req *node.ReverseTunnelRequest, nsrv node.Controller_ReverseTunnelServer) error {
+	user, err := auth.AuthorizeRequest(req.GetAuth())
+	if err != nil {
+		return errors.WithContext("bad token", err)
+	}
+
+	gatewayIP, token, err := s.getHostGateway(user.Namespace)
+	if err != nil {
+		return err
+	}
+
+	session, err := hostgateway.Dial(gatewayIP, token, req.GetPorts())
+	if err != nil {
+		return status.New(codes.Internal, err.Error()).Err()
+	}
+
+	// Stop forwarding once the CLI disconnects.
+	go func() {
+		<-nsrv.Context().Done()
+		session.Close()
+	}()
+
+	for {
+		conn, err := session.Next()
+		if err != nil {
+			if nsrv.Context().Err() != nil {
+				return nil
+			}
+			return status.New(codes.Unavailable, err.Error()).Err()
+		}
+
+		err = nsrv.Send(&node.ReverseTunnelConn{Id: conn.ID, Port: conn.Port})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// AcceptReverseTunnel proxies a connection announced by ReverseTunnel.
+func (s *Server) AcceptReverseTunnel(nsrv node.Controller_AcceptReverseTunnelServer) error {
+	msg, err := nsrv.Recv()
+	if err != nil {
+		return err
+	}
+
+	header := msg.GetReverseHeader()
+	if header == nil {
+		return status.New(codes.Internal, "first message must be a header").Err()
+	}
+
+	user, err := auth.AuthorizeRequest(header.GetAuth())
+	if err != nil {
+		return errors.WithContext("bad token", err)
+	}
+
+	gatewayIP, token, err := s.getHostGateway(user.Namespace)
+	if err != nil {
+		return err
+	}
+
+	stream, err := hostgateway.Accept(gatewayIP, token, header.GetId())
+	if err != nil {
+		return status.New(codes.Internal, err.Error()).Err()
+	}
+
+	tunnel.ServerStream(nsrv, stream)
+	return nil
+}
+
+// getHostGateway returns the IP of the namespace's host gateway, and the token
+// for connecting to it.
+func (s *Server) getHostGateway(namespace string) (ip, token string, err error) {
+	dnsPod, err := s.podLister.Pods(namespace).Get("dns")
+	if err != nil || dnsPod.Status.PodIP == "" || dnsPod.Annotations[hostgateway.TokenAnnotation] == "" {
+		return "", "", status.New(codes.Unavailable, "host gateway not ready").Err()
+	}
+	return dnsPod.Status.PodIP, dnsPod.Annotations[hostgateway.TokenAnnotation], nil
+}
@@ -3,7 +3,9 @@ package wait
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -35,8 +37,11 @@ type cliConn struct {
 	shutdown chan error
 
 	// `waiters` is the set of waiters that should be notified when the sync
-	// completes. It's protected by `waitersLock`.
+	// completes. It's protected by `waitersLock`, along with the most recent
+	// sync status reported by the CLI.
 	waiters     map[int]syncWaiter
+	folders     map[string]*node.FolderSyncStatus
+	allSynced   bool
 	waitersLock sync.Mutex
 
 	// `idCtr` is used for generating unique IDs for the `waiters` map.  It's
@@ -46,11 +51,52 @@ type cliConn struct {
 }
 
 type syncWaiter struct {
+	// `paths` are the bind volumes that the waiter is waiting for. The
+	// waiter only waits for the folders that contain these paths.
+	paths []string
+
 	// `result` sends a message when the sync has completed, or there is
 	// unrecoverable error that terminates the wait.
 	result chan error
 }
 
+// isSynced returns whether all the folders containing the waiter's paths have
+// synced. If the CLI reported that all folders have synced, then the waiter is
+// always done, even if its paths aren't in any of the folders.
+func (w syncWaiter) isSynced(folders map[string]*node.FolderSyncStatus, allSynced bool) bool {
+	if allSynced {
+		return true
+	}
+
+	for _, path := range w.paths {
+		var synced bool
+		for _, folder := range folders {
+			if folder.GetSynced() && folderContains(folder.GetPath(), path) {
+				synced = true
+				break
+			}
+		}
+
+		if !synced {
+			return false
+		}
+	}
+	return true
+}
+
+// folderContains returns whether the path is within the folder. The paths are
+// from the user's machine, so they may use either forward slashes or
+// backslashes as separators.
+func folderContains(folder, path string) bool {
+	if !strings.HasPrefix(path, folder) {
+		return false
+	}
+
+	rest := path[len(folder):]
+	return rest == "" || rest[0] == '/' || rest[0] == '\\' ||
+		strings.HasSuffix(folder, "/") || strings.HasSuffix(folder, "\\")
+}
+
 func NewSyncTracker() *SyncTracker {
 	return &SyncTracker{conns: map[string]*cliConn{}}
 }
@@ -90,39 +136,56 @@ func (st *SyncTracker) RunServer(namespace string, srv node.Controller_SyncNotif
 }
 
 // WaitFor creates a waiter function that blocks until the initial sync is
-// completed for the given namespace.
-func (st *SyncTracker) WaitFor(namespace string) Waiter {
+// completed for the folders containing the given bind volumes.
+func (st *SyncTracker) WaitFor(namespace string, bindVolumes []string) Waiter {
 	return func(ctx context.Context, updates chan<- string) error {
-		log.WithField("namespace", namespace).Info("Started waiting for bind volumes to sync")
+		log.WithField("namespace", namespace).
+			WithField("bindVolumes", bindVolumes).
+			Info("Started waiting for bind volumes to sync")
 
-		waiter, err := st.newWaiter(ctx, namespace)
+		waiter, err := st.newWaiter(ctx, namespace, bindVolumes)
 		if err != nil {
 			return err
 		}
 
-		select {
-		case err := <-waiter:
-			var status string
-			if err == nil {
-				status = fmt.Sprintf("%s's bind volumes are synced", namespace)
-			} else {
-				status = fmt.Sprintf("unexpected error waiting for %s's volumes to sync: %s",
-					namespace, err)
-			}
+		// Periodically report that we're still waiting so that stalled syncs
+		// show up in `blimp events`. The message doesn't change so that the
+		// events are aggregated.
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
 
+		for {
 			select {
-			case updates <- status:
-			default:
-				log.WithField("status", status).Info("Updates channel is full, dropping.")
+			case <-ticker.C:
+				status := "still waiting for bind volumes to sync"
+				select {
+				case updates <- status:
+				default:
+					log.WithField("status", status).Info("Updates channel is full, dropping.")
+				}
+			case err := <-waiter:
+				var status string
+				if err == nil {
+					status = fmt.Sprintf("%s's bind volumes are synced", namespace)
+				} else {
+					status = fmt.Sprintf("unexpected error waiting for %s's volumes to sync: %s",
+						namespace, err)
+				}
+
+				select {
+				case updates <- status:
+				default:
+					log.WithField("status", status).Info("Updates channel is full, dropping.")
+				}
+				return err
+			case <-ctx.Done():
+				return ctx.Err()
 			}
-			return err
-		case <-ctx.Done():
-			return ctx.Err()
 		}
 	}
 }
 
-func (st *SyncTracker) newWaiter(ctx context.Context, namespace string) (chan error, error) {
+func (st *SyncTracker) newWaiter(ctx context.Context, namespace string, bindVolumes []string) (chan error, error) {
 	st.lock.Lock()
 	cc, ok := st.conns[namespace]
 	st.lock.Unlock()
@@ -131,7 +194,7 @@ func (st *SyncTracker) newWaiter(ctx context.Context, namespace string) (chan er
 		return nil, errors.New("no connection to CLI")
 	}
 
-	return cc.NewWaiter(ctx)
+	return cc.NewWaiter(ctx, bindVolumes)
 }
 
 func newClientConn(srv node.Controller_SyncNotificationsServer) *cliConn {
@@ -139,6 +202,7 @@ func newClientConn(srv node.Controller_SyncNotificationsServer) *cliConn {
 		srv:      srv,
 		shutdown: make(chan error, 1),
 		waiters:  map[int]syncWaiter{},
+		folders:  map[string]*node.FolderSyncStatus{},
 	}
 }
 
@@ -170,16 +234,22 @@ func (cc *cliConn) Run() error {
 				return errors.WithContext("receive", recvResult.err)
 			}
 
+			// Older CLIs only report whether all the folders have synced.
 			resp := recvResult.msg
-			if !resp.GetSynced() {
-				break
+			cc.waitersLock.Lock()
+			if resp.GetSynced() {
+				cc.allSynced = true
+			}
+			for id, folder := range resp.GetFolders().GetFolders() {
+				cc.folders[id] = folder
 			}
 
-			// Notify all the waiters.
-			cc.waitersLock.Lock()
+			// Notify the waiters whose folders have synced.
 			for id, waiter := range cc.waiters {
-				close(waiter.result)
-				delete(cc.waiters, id)
+				if waiter.isSynced(cc.folders, cc.allSynced) {
+					close(waiter.result)
+					delete(cc.waiters, id)
+				}
 			}
 			cc.waitersLock.Unlock()
 
@@ -189,8 +259,8 @@ func (cc *cliConn) Run() error {
 	}
 }
 
-func (cc *cliConn) NewWaiter(ctx context.Context) (chan error, error) {
-	w := syncWaiter{make(chan error, 1)}
+func (cc *cliConn) NewWaiter(ctx context.Context, bindVolumes []string) (chan error, error) {
+	w := syncWaiter{paths: bindVolumes, result: make(chan error, 1)}
 
 	// Get an ID to identify the waiter in `cc.waiters`. This is used to remove
 	// the waiter if it's cancelled.
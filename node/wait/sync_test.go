@@ -0,0 +1,61 @@
+package wait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kelda/blimp/pkg/proto/node"
+)
+
+func TestWaiterIsSynced(t *testing.T) {
+	folders := map[string]*node.FolderSyncStatus{
+		"config": {Path: "/home/user/app/config", Synced: true},
+		"src":    {Path: "/home/user/app/src", Synced: false},
+		"win":    {Path: `C:\Users\user\app`, Synced: true},
+	}
+
+	tests := []struct {
+		name      string
+		paths     []string
+		allSynced bool
+		exp       bool
+	}{
+		{
+			name:  "Synced folder",
+			paths: []string{"/home/user/app/config"},
+			exp:   true,
+		},
+		{
+			name:  "File in synced folder",
+			paths: []string{"/home/user/app/config/nginx.conf"},
+			exp:   true,
+		},
+		{
+			name:  "Unsynced folder",
+			paths: []string{"/home/user/app/config", "/home/user/app/src"},
+			exp:   false,
+		},
+		{
+			name:  "Folder with shared prefix",
+			paths: []string{"/home/user/app/config-old"},
+			exp:   false,
+		},
+		{
+			name:  "Windows path",
+			paths: []string{`C:\Users\user\app\src`},
+			exp:   true,
+		},
+		{
+			name:      "Everything synced",
+			paths:     []string{"/home/user/app/src", "/unknown"},
+			allSynced: true,
+			exp:       true,
+		},
+	}
+
+	for _, test := range tests {
+		w := syncWaiter{paths: test.paths}
+		assert.Equal(t, test.exp, w.isSynced(folders, test.allSynced), test.name)
+	}
+}
@@ -10,11 +10,17 @@ import (
 	composeTypes "github.com/kelda/compose-go/types"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/kelda/blimp/pkg/errors"
 	"github.com/kelda/blimp/pkg/kube"
@@ -26,10 +32,15 @@ import (
 )
 
 type server struct {
+	kubeClient  kubernetes.Interface
 	podInformer cache.SharedIndexInformer
 	podLister   listers.PodLister
 	podWatcher  *kube.Watcher
 	syncTracker *SyncTracker
+
+	// recorder records the waiters' progress in events on the waiting pods
+	// so that they're shown by `blimp events`.
+	recorder record.EventRecorder
 }
 
 // Implementations of waiters should block until they have finished waiting.
@@ -42,10 +53,11 @@ type podCondition func(corev1.Pod) (status string, satisfied bool)
 
 // podWaiter orchestrates waiting for a pod to satisfy a condition.
 type podWaiter struct {
-	namespace, name string
-	condition       podCondition
-	watcher         *kube.Watcher
-	lister          listers.PodLister
+	namespace, service string
+	condition          podCondition
+	watcher            *kube.Watcher
+	lister             listers.PodLister
+	kubeClient         kubernetes.Interface
 }
 
 const Port = 9002
@@ -53,11 +65,19 @@ const Port = 9002
 func Run(kubeClient kubernetes.Interface, syncTracker *SyncTracker) {
 	podInformer := informers.NewSharedInformerFactory(kubeClient, 30*time.Second).
 		Core().V1().Pods()
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: kubeClient.CoreV1().Events(""),
+	})
+
 	s := &server{
+		kubeClient:  kubeClient,
 		podInformer: podInformer.Informer(),
 		podLister:   podInformer.Lister(),
 		podWatcher:  kube.NewWatcher(podInformer.Informer()),
 		syncTracker: syncTracker,
+		recorder: broadcaster.NewRecorder(scheme.Scheme,
+			corev1.EventSource{Component: "blimp-node-controller"}),
 	}
 
 	go s.podInformer.Run(nil)
@@ -102,34 +122,82 @@ func (s *server) CheckReady(req *wait.CheckReadyRequest, srv wait.BootWaiter_Che
 		}
 
 		waiters = append(waiters, podWaiter{
-			namespace: req.GetNamespace(),
-			name:      names.ToDNS1123(condition.Service),
-			condition: pc,
-			watcher:   s.podWatcher,
-			lister:    s.podLister,
+			namespace:  req.GetNamespace(),
+			service:    condition.Service,
+			condition:  pc,
+			watcher:    s.podWatcher,
+			lister:     s.podLister,
+			kubeClient: s.kubeClient,
 		}.wait)
 	}
 
 	for _, service := range req.GetWaitSpec().GetFinishedVolumeInit() {
 		waiters = append(waiters, podWaiter{
-			namespace: req.GetNamespace(),
-			name:      names.ToDNS1123(service),
-			condition: conditionFinishedVolumeInit,
-			watcher:   s.podWatcher,
-			lister:    s.podLister,
+			namespace:  req.GetNamespace(),
+			service:    service,
+			condition:  conditionFinishedVolumeInit,
+			watcher:    s.podWatcher,
+			lister:     s.podLister,
+			kubeClient: s.kubeClient,
 		}.wait)
 	}
 
-	// We block boot until all the files are synced, even if the sync is
-	// waiting on files in another volume.
-	if len(req.GetWaitSpec().GetBindVolumes()) != 0 {
-		waiters = append(waiters, s.syncTracker.WaitFor(req.GetNamespace()))
+	// We only block boot on the folders that contain the service's bind
+	// volumes, so that services don't wait on unrelated large folders.
+	if bindVolumes := req.GetWaitSpec().GetBindVolumes(); len(bindVolumes) != 0 {
+		waiters = append(waiters, s.syncTracker.WaitFor(req.GetNamespace(), bindVolumes))
+	}
+
+	// Old versions of the init container don't send their pod name, so their
+	// progress isn't recorded.
+	recordUpdate := func(string) {}
+	if req.GetPodName() != "" {
+		recordUpdate = func(update string) {
+			pod, err := s.getWaitingPod(srv.Context(), req.GetNamespace(), req.GetPodName())
+			if err != nil {
+				log.WithError(err).Debug("Failed to get waiting pod")
+				return
+			}
+			s.recorder.Event(pod, corev1.EventTypeNormal, kube.EventReasonWaiting, update)
+		}
+	}
+
+	return s.waitForAll(srv, waiters, recordUpdate)
+}
+
+// getWaitingPod returns the pod that sent a CheckReady request. Requests
+// aren't authenticated, so the pod is only returned if the request came from
+// the pod's IP. Otherwise, any pod could record events on the pods of other
+// sandboxes.
+func (s *server) getWaitingPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, errors.New("unknown peer")
+	}
+
+	peerIP, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return nil, errors.WithContext("parse peer address", err)
+	}
+
+	pod, err := s.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		return nil, errors.WithContext("get pod", err)
 	}
 
-	return s.waitForAll(srv, waiters)
+	if pod.Labels["blimp.customerPod"] != "true" {
+		return nil, errors.New("pod %s/%s isn't a customer pod", namespace, name)
+	}
+
+	if pod.Status.PodIP == "" || pod.Status.PodIP != peerIP {
+		return nil, errors.New("request from %s doesn't match the IP of pod %s/%s (%s)",
+			peerIP, namespace, name, pod.Status.PodIP)
+	}
+	return pod, nil
 }
 
-func (s *server) waitForAll(srv wait.BootWaiter_CheckReadyServer, waiters []Waiter) error {
+func (s *server) waitForAll(srv wait.BootWaiter_CheckReadyServer, waiters []Waiter,
+	recordUpdate func(string)) error {
 	waitCtx, cancelWaiters := context.WithCancel(context.Background())
 	defer cancelWaiters()
 
@@ -154,6 +222,7 @@ func (s *server) waitForAll(srv wait.BootWaiter_CheckReadyServer, waiters []Wait
 
 		select {
 		case update := <-updates:
+			recordUpdate(update)
 			if err := srv.Send(&wait.CheckReadyResponse{Reason: update}); err != nil {
 				return errors.WithContext("send update", err)
 			}
@@ -173,19 +242,24 @@ func (s *server) waitForAll(srv wait.BootWaiter_CheckReadyServer, waiters []Wait
 }
 
 func (w podWaiter) wait(ctx context.Context, updates chan<- string) error {
+	name := names.ToDNS1123(w.service)
 	checkOnce := func() (msg string, done bool) {
-		pod, err := w.lister.Pods(w.namespace).Get(w.name)
+		pod, err := w.lister.Pods(w.namespace).Get(name)
 		if err != nil {
-			return fmt.Sprintf("failed to get pod %s: %s", w.name, err), false
+			if kerrors.IsNotFound(err) && w.isStopped() {
+				return fmt.Sprintf("service %s is stopped. Run `blimp start %s` to start it",
+					w.service, w.service), false
+			}
+			return fmt.Sprintf("failed to get pod %s: %s", name, err), false
 		}
 
 		status, ready := w.condition(*pod)
-		return fmt.Sprintf("pod %s is %s", w.name, status), ready
+		return fmt.Sprintf("pod %s is %s", name, status), ready
 	}
 
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
-	podChanged := w.watcher.Watch(ctx, kube.Key{Namespace: w.namespace, Name: w.name})
+	podChanged := w.watcher.Watch(ctx, kube.Key{Namespace: w.namespace, Name: name})
 
 	for {
 		status, done := checkOnce()
@@ -208,6 +282,13 @@ func (w podWaiter) wait(ctx context.Context, updates chan<- string) error {
 	}
 }
 
+// isStopped returns whether the service was stopped by `blimp stop`.
+func (w podWaiter) isStopped() bool {
+	_, err := w.kubeClient.CoreV1().ConfigMaps(w.namespace).
+		Get(kube.StoppedServiceConfigMapName(w.service), metav1.GetOptions{})
+	return err == nil
+}
+
 func conditionPodHealthy(pod corev1.Pod) (string, bool) {
 	// Make sure that all the pod's containers have passed their
 	// healthchecks. The healthchecks are configured at pod creation by the
@@ -0,0 +1,71 @@
+package wait
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestGetWaitingPod(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pod := range []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "namespace",
+				Name:      "web",
+				Labels:    map[string]string{"blimp.customerPod": "true"},
+			},
+			Status: corev1.PodStatus{PodIP: "10.0.0.2"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "other",
+				Name:      "web",
+				Labels:    map[string]string{"blimp.customerPod": "true"},
+			},
+			Status: corev1.PodStatus{PodIP: "10.0.0.3"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "blimp-system",
+				Name:      "manager",
+			},
+			Status: corev1.PodStatus{PodIP: "10.0.0.4"},
+		},
+	} {
+		assert.NoError(t, indexer.Add(pod))
+	}
+	s := &server{podLister: listers.NewPodLister(indexer)}
+
+	fromIP := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000},
+		})
+	}
+
+	pod, err := s.getWaitingPod(fromIP("10.0.0.2"), "namespace", "web")
+	assert.NoError(t, err)
+	assert.Equal(t, "namespace", pod.Namespace)
+
+	// Pods can't record events on other sandboxes' pods.
+	_, err = s.getWaitingPod(fromIP("10.0.0.2"), "other", "web")
+	assert.Error(t, err)
+
+	// Or on pods that aren't customer pods.
+	_, err = s.getWaitingPod(fromIP("10.0.0.4"), "blimp-system", "manager")
+	assert.Error(t, err)
+
+	_, err = s.getWaitingPod(fromIP("10.0.0.2"), "namespace", "missing")
+	assert.Error(t, err)
+
+	_, err = s.getWaitingPod(context.Background(), "namespace", "web")
+	assert.Error(t, err)
+}
@@ -24,6 +24,12 @@ import (
 // auth.BlimpAuth, as opposed to a plain ID token.
 const JSONCredUsername = "_json"
 
+// RegistryAdminUsername is the username used by the cluster manager to manage
+// the images in every namespace of the Blimp registry. The password is shared
+// between the cluster manager and the registry through the
+// BLIMP_REGISTRY_ADMIN_PASSWORD environment variable.
+const RegistryAdminUsername = "_admin"
+
 type RegistryCredentials map[string]types.AuthConfig
 
 // GetLocalRegistryCredentials reads the user's registry credentials from their
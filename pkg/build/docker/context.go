@@ -0,0 +1,220 @@
+package docker
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	dockerBuild "github.com/docker/cli/cli/command/image/build"
+	"github.com/docker/docker/pkg/fileutils"
+	units "github.com/docker/go-units"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+const (
+	// progressInterval is how often the size of the build context is updated
+	// while it's being sent to the Docker daemon.
+	progressInterval = 100 * time.Millisecond
+
+	// contextHashLabel is set on the images built by Blimp to the hash of
+	// their build context, so that later builds can tell whether the image
+	// is up to date.
+	contextHashLabel = "blimp.contextHash"
+)
+
+// contextExcludes returns the patterns in the build context's .dockerignore.
+// Like `docker build`, the Dockerfile and .dockerignore are always sent to
+// the daemon, even if they're ignored.
+func contextExcludes(dir, dockerfile string) ([]string, error) {
+	excludes, err := dockerBuild.ReadDockerignore(dir)
+	if err != nil {
+		return nil, errors.WithContext("read .dockerignore", err)
+	}
+
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+	return dockerBuild.TrimBuildFilesFromExcludes(excludes, filepath.ToSlash(dockerfile), false), nil
+}
+
+// walkContext calls walkFn for each file in the build context that isn't
+// excluded. relPath is the file's path relative to the build context.
+func walkContext(dir string, excludes []string,
+	walkFn func(path, relPath string, fi os.FileInfo) error) error {
+	pm, err := fileutils.NewPatternMatcher(excludes)
+	if err != nil {
+		return errors.WithContext("parse .dockerignore", err)
+	}
+
+	return filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return errors.WithContext(fmt.Sprintf("get normalized path %q", path), err)
+		}
+
+		if relPath != "." {
+			ignored, err := pm.Matches(relPath)
+			if err != nil {
+				return errors.WithContext(fmt.Sprintf("match %q", relPath), err)
+			}
+
+			if ignored {
+				// Directories can only be skipped entirely if there aren't
+				// any exceptions that could re-include their children.
+				if fi.IsDir() && !pm.Exclusions() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		return walkFn(path, relPath, fi)
+	})
+}
+
+// contextHash returns a hash of the files in the build context that are sent
+// to the Docker daemon. Only the files' paths, modes, and contents are hashed,
+// so neither touching a file nor editing an ignored file changes the hash.
+func contextHash(dir string, excludes []string) (string, error) {
+	h := sha256.New()
+	err := walkContext(dir, excludes, func(path, relPath string, fi os.FileInfo) error {
+		fmt.Fprintf(h, "%s\x00%o\x00", filepath.ToSlash(relPath), fi.Mode())
+		switch {
+		case fi.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return errors.WithContext(fmt.Sprintf("read link %q", relPath), err)
+			}
+			fmt.Fprintf(h, "%s\x00", link)
+		case fi.Mode().IsRegular():
+			f, err := os.Open(path)
+			if err != nil {
+				return errors.WithContext(fmt.Sprintf("open file %q", relPath), err)
+			}
+			defer f.Close()
+
+			fmt.Fprintf(h, "%d\x00", fi.Size())
+			if _, err := io.Copy(h, f); err != nil {
+				return errors.WithContext(fmt.Sprintf("read file %q", relPath), err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// makeTar streams a tarball of the build context. The tarball is written as
+// it's read, so the context is never held in memory. The returned reader must
+// be closed so that the writer exits if the tarball isn't fully read.
+func makeTar(dir string, excludes []string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := walkContext(dir, excludes, func(path, relPath string, fi os.FileInfo) error {
+			header, err := getHeader(fi, path)
+			if err != nil {
+				return errors.WithContext("get header", err)
+			}
+
+			// Set the file's path within the archive to be relative to the
+			// build context. On Windows, relPath will use backslashes.
+			// ToSlash normalizes to use forward slashes.
+			header.Name = filepath.ToSlash(relPath)
+
+			if err := tw.WriteHeader(header); err != nil {
+				return errors.WithContext(fmt.Sprintf("write header %q", header.Name), err)
+			}
+
+			if !fi.Mode().IsRegular() {
+				return nil
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				return errors.WithContext(fmt.Sprintf("open file %q", header.Name), err)
+			}
+			defer f.Close()
+
+			if _, err := io.Copy(tw, f); err != nil {
+				return errors.WithContext(fmt.Sprintf("write file %q", header.Name), err)
+			}
+			return nil
+		})
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// progressReader prints the number of bytes of the build context that have
+// been sent to the Docker daemon. When the output isn't a terminal, the size
+// is only printed once the entire context has been sent.
+type progressReader struct {
+	io.ReadCloser
+	out        io.Writer
+	isTerminal bool
+
+	sent      int64
+	lastPrint time.Time
+	printed   bool
+	done      bool
+}
+
+func newProgressReader(r io.ReadCloser, out io.Writer, isTerminal bool) *progressReader {
+	return &progressReader{ReadCloser: r, out: out, isTerminal: isTerminal}
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.sent += int64(n)
+
+	switch {
+	case err == io.EOF:
+		r.finish()
+	case r.isTerminal && time.Since(r.lastPrint) > progressInterval:
+		r.print()
+	}
+	return n, err
+}
+
+func (r *progressReader) Close() error {
+	// Terminate the progress line if the context wasn't fully sent.
+	if r.printed && !r.done {
+		r.done = true
+		fmt.Fprintln(r.out)
+	}
+	return r.ReadCloser.Close()
+}
+
+func (r *progressReader) finish() {
+	if r.done {
+		return
+	}
+	r.done = true
+	r.print()
+	fmt.Fprintln(r.out)
+}
+
+func (r *progressReader) print() {
+	prefix := ""
+	if r.isTerminal {
+		prefix = "\r"
+	}
+	fmt.Fprintf(r.out, "%sSending build context to Docker daemon  %s",
+		prefix, units.HumanSize(float64(r.sent)))
+	r.lastPrint = time.Now()
+	r.printed = true
+}
@@ -0,0 +1,116 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeTar(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blimp-build-context")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		".dockerignore":             "node_modules\n.git\n*.log\n!keep.log\nDockerfile\n",
+		"Dockerfile":                "FROM scratch",
+		"main.go":                   "package main",
+		"debug.log":                 "ignored",
+		"keep.log":                  "re-included",
+		"node_modules/dep/index.js": "ignored",
+		".git/HEAD":                 "ignored",
+		"src/app.js":                "app",
+	}
+	for path, contents := range files {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	excludes, err := contextExcludes(dir, "")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	tarReader := newProgressReader(makeTar(dir, excludes), &out, false)
+	defer tarReader.Close()
+
+	var tarred []string
+	tr := tar.NewReader(tarReader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		tarred = append(tarred, header.Name)
+	}
+
+	// The Dockerfile and .dockerignore are always sent, even if they're
+	// ignored.
+	assert.ElementsMatch(t, []string{".", ".dockerignore", "Dockerfile", "main.go", "keep.log", "src", "src/app.js"}, tarred)
+
+	// Drain the tarball's padding so that the total size is printed.
+	_, err = io.Copy(ioutil.Discard, tarReader)
+	require.NoError(t, err)
+	assert.Regexp(t, `^Sending build context to Docker daemon  \d+(\.\d+)?kB\n$`, out.String())
+}
+
+func TestContextHash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blimp-build-context")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile := func(path, contents string) {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+	writeFile(".dockerignore", "node_modules\n*.log\n")
+	writeFile("Dockerfile", "FROM scratch")
+	writeFile("main.go", "package main")
+	writeFile("debug.log", "ignored")
+	writeFile("node_modules/dep/index.js", "ignored")
+
+	getHash := func() string {
+		excludes, err := contextExcludes(dir, "")
+		require.NoError(t, err)
+
+		hash, err := contextHash(dir, excludes)
+		require.NoError(t, err)
+		return hash
+	}
+	orig := getHash()
+
+	// Editing ignored files doesn't change what's sent to the builder, so
+	// the cached image should still be used.
+	writeFile("debug.log", "changed")
+	writeFile("node_modules/dep/index.js", "changed")
+	writeFile("node_modules/new/index.js", "new")
+	assert.Equal(t, orig, getHash())
+
+	// Neither should only changing a file's modification time.
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "main.go"), future, future))
+	assert.Equal(t, orig, getHash())
+
+	// Editing, adding, or ignoring a file that's sent to the builder should
+	// invalidate the cached image.
+	writeFile("main.go", "package main // changed")
+	edited := getHash()
+	assert.NotEqual(t, orig, edited)
+
+	writeFile("src/app.go", "package src")
+	added := getHash()
+	assert.NotEqual(t, edited, added)
+
+	writeFile(".dockerignore", "node_modules\n*.log\nsrc\n")
+	assert.NotEqual(t, added, getHash())
+}
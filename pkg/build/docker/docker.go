@@ -2,14 +2,11 @@ package docker
 
 import (
 	"archive/tar"
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -91,20 +88,30 @@ func (c client) BuildAndPush(images map[string]build.BuildPushConfig) (pushedIma
 
 	// Build all the services.
 	for serviceName, opts := range images {
+		excludes, err := contextExcludes(opts.Context, opts.Dockerfile)
+		if err != nil {
+			return nil, err
+		}
+
+		ctxHash, err := contextHash(opts.Context, excludes)
+		if err != nil {
+			return nil, errors.WithContext("hash build context", err)
+		}
+
 		// If the image is in the docker cache, then just tag it to be imageName
 		// rather than doing a full build.
 		if !opts.ForceBuild {
-			cached, ok := c.getCachedImage(serviceName)
+			cachedID, ok := c.getCachedImage(serviceName, opts.ImageName, ctxHash)
 			if ok {
 				log.WithField("service", serviceName).Info("Using cached image")
-				if err := c.client.ImageTag(context.Background(), cached.ID, opts.ImageName); err != nil {
+				if err := c.client.ImageTag(context.Background(), cachedID, opts.ImageName); err != nil {
 					return nil, errors.WithContext("tag", err)
 				}
 				continue
 			}
 		}
 
-		if err := c.build(serviceName, opts.ImageName, opts); err != nil {
+		if err := c.build(serviceName, opts, excludes, ctxHash); err != nil {
 			return nil, errors.WithContext("build", err)
 		}
 	}
@@ -161,20 +168,24 @@ func (c client) BuildAndPush(images map[string]build.BuildPushConfig) (pushedIma
 	return pushedImages, nil
 }
 
-func (c *client) build(serviceName, imageName string, opts build.BuildPushConfig) error {
-	fmt.Printf("Building image for %s...\n", serviceName)
-	buildContextTar, err := makeTar(opts.Context)
-	if err != nil {
-		return errors.WithContext("tar context", err)
+func (c *client) build(serviceName string, opts build.BuildPushConfig, excludes []string, ctxHash string) error {
+	labels := map[string]string{contextHashLabel: ctxHash}
+	for k, v := range opts.Labels {
+		labels[k] = v
 	}
 
+	fmt.Printf("Building image for %s...\n", serviceName)
+	isTerminal := terminal.IsTerminal(int(os.Stdout.Fd()))
+	buildContextTar := newProgressReader(makeTar(opts.Context, excludes), os.Stdout, isTerminal)
+	defer buildContextTar.Close()
+
 	buildResp, err := c.client.ImageBuild(context.TODO(), buildContextTar, types.ImageBuildOptions{
-		Tags:        []string{imageName},
+		Tags:        []string{opts.ImageName},
 		Dockerfile:  opts.Dockerfile,
 		AuthConfigs: c.regCreds,
 		BuildArgs:   c.dockerConfig.ParseProxyConfig(c.client.DaemonHost(), opts.Args),
 		Target:      opts.Target,
-		Labels:      opts.Labels,
+		Labels:      labels,
 		CacheFrom:   opts.CacheFrom,
 		PullParent:  opts.PullParent,
 		NoCache:     opts.NoCache,
@@ -186,7 +197,6 @@ func (c *client) build(serviceName, imageName string, opts build.BuildPushConfig
 
 	// Block until the build completes, and return any errors that happen
 	// during the build.
-	isTerminal := terminal.IsTerminal(int(os.Stdout.Fd()))
 	err = jsonmessage.DisplayJSONMessagesStream(buildResp.Body, os.Stdout, os.Stdout.Fd(), isTerminal, nil)
 	if err != nil {
 		return errors.NewFriendlyError(
@@ -294,54 +304,6 @@ func getHeader(fi os.FileInfo, path string) (*tar.Header, error) {
 	return tar.FileInfoHeader(fi, link)
 }
 
-func makeTar(dir string) (io.Reader, error) {
-	var out bytes.Buffer
-	tw := tar.NewWriter(&out)
-	defer tw.Close()
-
-	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		header, err := getHeader(fi, path)
-		if err != nil {
-			return errors.WithContext("get header", err)
-		}
-
-		// Set the file's path within the archive to be relative to the build
-		// context.
-		relPath, err := filepath.Rel(dir, path)
-		if err != nil {
-			return errors.WithContext(fmt.Sprintf("get normalized path %q", path), err)
-		}
-		// On Windows, relPath will use backslashes. ToSlash normalizes to use
-		// forward slashes.
-		header.Name = filepath.ToSlash(relPath)
-
-		if err := tw.WriteHeader(header); err != nil {
-			return errors.WithContext(fmt.Sprintf("write header %q", header.Name), err)
-		}
-
-		fileMode := fi.Mode()
-		if !fileMode.IsRegular() {
-			return nil
-		}
-
-		f, err := os.Open(path)
-		if err != nil {
-			return errors.WithContext(fmt.Sprintf("open file %q", header.Name), err)
-		}
-		defer f.Close()
-
-		if _, err := io.Copy(tw, f); err != nil {
-			return errors.WithContext(fmt.Sprintf("write file %q", header.Name), err)
-		}
-		return nil
-	})
-	return &out, err
-}
-
 func getImageCaches(c *docker.Client, project string) (map[string]types.ImageSummary, map[string]types.ImageSummary, error) {
 	// See https://github.com/docker/compose/blob/854c14a5bcf566792ee8a972325c37590521656b/compose/service.py#L379
 	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
@@ -384,15 +346,28 @@ func getImageCaches(c *docker.Client, project string) (map[string]types.ImageSum
 	return oldBlimpCache, composeCache, nil
 }
 
-func (c *client) getCachedImage(service string) (types.ImageSummary, bool) {
+// getCachedImage returns the ID of a local image that can be used for the
+// service instead of building it. Blimp's previous build of the service is
+// only reused if the hash of its build context still matches. Images built
+// by Docker Compose or older versions of Blimp don't have a context hash, so
+// they're reused whenever they exist.
+func (c *client) getCachedImage(service, imageName, ctxHash string) (string, bool) {
+	image, _, err := c.client.ImageInspectWithRaw(context.Background(), imageName)
+	if err == nil && image.Config != nil {
+		if builtHash, ok := image.Config.Labels[contextHashLabel]; ok {
+			return image.ID, builtHash == ctxHash
+		}
+	}
+
 	// Try the old Blimp cache first.
 	tag := build.BlimpServiceTag(c.composePath, service)
-	image, ok := c.oldBlimpImageCache[tag]
-	if ok {
-		return image, true
+	if cached, ok := c.oldBlimpImageCache[tag]; ok {
+		return cached.ID, true
 	}
 
 	// Try Docker Compose's cache.
-	image, ok = c.composeImageCache[service]
-	return image, ok
+	if cached, ok := c.composeImageCache[service]; ok {
+		return cached.ID, true
+	}
+	return "", false
 }
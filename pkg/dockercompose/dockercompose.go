@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/buger/goterm"
+	"github.com/docker/go-units"
 	"github.com/ghodss/yaml"
 	"github.com/kelda/compose-go/envfile"
 	"github.com/kelda/compose-go/loader"
@@ -22,9 +23,12 @@ import (
 
 var fs = afero.NewOsFs()
 
-// Load loads and merges the given compose files. If `services` is non-empty,
-// the return config only includes the services specified in `services`.
-func Load(composePath string, overridePaths, services []string) (types.Project, error) {
+// Load loads and merges the given compose files. Services with profiles are
+// only included if one of their profiles is enabled by `profiles` or the
+// COMPOSE_PROFILES environment variable. If `services` is non-empty, the
+// return config only includes the services specified in `services`, and their
+// dependencies.
+func Load(composePath string, overridePaths, profiles, services []string) (types.Project, error) {
 	var configFiles []types.ConfigFile
 	for _, path := range append([]string{composePath}, overridePaths...) {
 		b, err := afero.ReadFile(fs, path)
@@ -195,20 +199,18 @@ func Load(composePath string, overridePaths, services []string) (types.Project,
 		}
 	}
 
-	// If the user specified specific services to boot, modify the config file
-	// to only contain those services, and their dependencies.
-	if len(services) != 0 {
-		// cfg.WithServices also walks all dependencies of the services.
-		var filtered []types.ServiceConfig
-		err := cfgPtr.WithServices(services, func(service types.ServiceConfig) error {
-			filtered = append(filtered, service)
-			return nil
-		})
-		if err != nil {
-			return types.Project{}, errors.WithContext("lookup services", err)
-		}
+	// Remove the services that are disabled by profiles. If the user
+	// specified specific services to boot, only those services and their
+	// dependencies are kept.
+	filtered, err := filterServices(cfgPtr, getServiceProfiles(configFiles),
+		getActiveProfiles(profiles, env), services)
+	if err != nil {
+		return types.Project{}, err
+	}
+	cfgPtr.Services = filtered
 
-		cfgPtr.Services = filtered
+	if err := setPullPolicies(cfgPtr, getPullPolicies(configFiles)); err != nil {
+		return types.Project{}, err
 	}
 
 	cfgPtr.Name = getProjectName(composePath)
@@ -239,17 +241,22 @@ func Unmarshal(b []byte) (parsed types.Project, err error) {
 		return types.Project{}, errors.WithContext("parse", err)
 	}
 
-	cfgPtr, err := load(types.ConfigDetails{
-		ConfigFiles: []types.ConfigFile{
-			{
-				Config: configIntf,
-			},
+	configFiles := []types.ConfigFile{
+		{
+			Config: configIntf,
 		},
+	}
+	cfgPtr, err := load(types.ConfigDetails{
+		ConfigFiles: configFiles,
 	}, withSkipValidation, withSkipConsistency, withSkipInterpolation, withSkipExtends)
 	if err != nil {
 		return types.Project{}, errors.WithContext("load", err)
 	}
 
+	if err := setPullPolicies(cfgPtr, getPullPolicies(configFiles)); err != nil {
+		return types.Project{}, err
+	}
+
 	return *cfgPtr, nil
 }
 
@@ -260,7 +267,11 @@ func Unmarshal(b []byte) (parsed types.Project, err error) {
 // `types.Project` and `types.Config` both have the same field names and types
 // for `Services`, `Networks`, and `Volumes`.
 func Marshal(cfg types.Project) ([]byte, error) {
-	return yaml.Marshal(cfg)
+	marshalled, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return marshalPullPolicies(cfg, marshalled)
 }
 
 func withSkipValidation(opts *loader.Options) {
@@ -364,3 +375,32 @@ func ParseNamedBindVolume(vol types.VolumeConfig) (source string, ok bool) {
 
 	return "", false
 }
+
+// ParseTmpfs parses an entry in a service's `tmpfs` field into the
+// equivalent tmpfs volume. Entries are of the form `/path[:options]`, where
+// options is a comma separated list of mount options. Only the size option is
+// used -- the other options are ignored.
+func ParseTmpfs(entry string) (types.ServiceVolumeConfig, error) {
+	parts := strings.SplitN(entry, ":", 2)
+	vol := types.ServiceVolumeConfig{
+		Type:   types.VolumeTypeTmpfs,
+		Target: parts[0],
+	}
+	if len(parts) == 1 {
+		return vol, nil
+	}
+
+	for _, opt := range strings.Split(parts[1], ",") {
+		if !strings.HasPrefix(opt, "size=") {
+			continue
+		}
+
+		size, err := units.RAMInBytes(strings.TrimPrefix(opt, "size="))
+		if err != nil {
+			return types.ServiceVolumeConfig{}, errors.NewFriendlyError(
+				"Invalid tmpfs size for %s: %s", parts[0], err)
+		}
+		vol.Tmpfs = &types.ServiceVolumeTmpfs{Size: size}
+	}
+	return vol, nil
+}
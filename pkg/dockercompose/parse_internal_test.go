@@ -38,9 +38,54 @@ ignoreme:
 		t.Run(test.name, func(t *testing.T) {
 			fs = afero.NewMemMapFs()
 			assert.NoError(t, afero.WriteFile(fs, "docker-compose.yml", []byte(test.composeFile), 0644))
-			config, err := Load("docker-compose.yml", nil, nil)
+			config, err := Load("docker-compose.yml", nil, nil, nil)
 			assert.Equal(t, test.expError, err)
 			assert.Equal(t, test.expConfig, config)
 		})
 	}
 }
+
+func TestParseTmpfs(t *testing.T) {
+	tests := []struct {
+		name     string
+		entry    string
+		expVol   types.ServiceVolumeConfig
+		expError bool
+	}{
+		{
+			name:  "no options",
+			entry: "/run",
+			expVol: types.ServiceVolumeConfig{
+				Type:   types.VolumeTypeTmpfs,
+				Target: "/run",
+			},
+		},
+		{
+			name:  "size",
+			entry: "/run:rw,noexec,size=64m",
+			expVol: types.ServiceVolumeConfig{
+				Type:   types.VolumeTypeTmpfs,
+				Target: "/run",
+				Tmpfs:  &types.ServiceVolumeTmpfs{Size: 64 * 1024 * 1024},
+			},
+		},
+		{
+			name:     "invalid size",
+			entry:    "/run:size=big",
+			expError: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			vol, err := ParseTmpfs(test.entry)
+			if test.expError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expVol, vol)
+		})
+	}
+}
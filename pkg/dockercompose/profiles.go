@@ -0,0 +1,164 @@
+package dockercompose
+
+import (
+	"strings"
+
+	"github.com/kelda/compose-go/types"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// profilesEnvVar is the environment variable that Docker Compose reads the
+// active profiles from, in addition to the --profile flags.
+const profilesEnvVar = "COMPOSE_PROFILES"
+
+// getServiceProfiles returns the profiles assigned to each service. The
+// compose-go loader drops the `profiles` field, so it's read directly from
+// the parsed YAML. If multiple files set a service's profiles, the last file
+// takes precedence.
+func getServiceProfiles(configFiles []types.ConfigFile) map[string][]string {
+	serviceProfiles := map[string][]string{}
+	for _, file := range configFiles {
+		services, ok := file.Config["services"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for name, svcIntf := range services {
+			svc, ok := svcIntf.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			profilesIntf, ok := svc["profiles"].([]interface{})
+			if !ok {
+				continue
+			}
+
+			var profiles []string
+			for _, profile := range profilesIntf {
+				if profileStr, ok := profile.(string); ok {
+					profiles = append(profiles, profileStr)
+				}
+			}
+			serviceProfiles[name] = profiles
+		}
+	}
+	return serviceProfiles
+}
+
+// getActiveProfiles combines the profiles enabled by flags with the profiles
+// enabled by the COMPOSE_PROFILES environment variable.
+func getActiveProfiles(flagProfiles []string, env map[string]string) []string {
+	profiles := append([]string{}, flagProfiles...)
+	for _, profile := range strings.Split(env[profilesEnvVar], ",") {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// filterServices returns the services that should be deployed, following
+// Docker Compose's semantics for profiles:
+// - Services without any profiles are always enabled.
+// - Services with profiles are enabled if any of their profiles are active.
+// - Explicitly requested services activate their own profiles, so that the
+//   services they depend on in the same profile are also enabled.
+// - A service that depends on a disabled service is an error.
+// If `services` is non-empty, only those services and their dependencies are
+// returned.
+func filterServices(project *types.Project, serviceProfiles map[string][]string,
+	activeProfiles, services []string) ([]types.ServiceConfig, error) {
+
+	active := map[string]bool{}
+	for _, profile := range activeProfiles {
+		active[profile] = true
+	}
+	for _, name := range services {
+		for _, profile := range serviceProfiles[name] {
+			active[profile] = true
+		}
+	}
+
+	isEnabled := func(name string) bool {
+		profiles := serviceProfiles[name]
+		if len(profiles) == 0 || active["*"] {
+			return true
+		}
+
+		for _, profile := range profiles {
+			if active[profile] {
+				return true
+			}
+		}
+		return false
+	}
+
+	checkDependencies := func(svc types.ServiceConfig) error {
+		for _, dep := range svc.GetDependencies() {
+			if !isEnabled(dep) {
+				return errors.NewFriendlyError(
+					"Service %q was pulled in as a dependency of service %q, "+
+						"but is not enabled by the active profiles.\n"+
+						"You may fix this by adding a common profile to %q and %q.",
+					dep, svc.Name, dep, svc.Name)
+			}
+		}
+		return nil
+	}
+
+	if len(services) == 0 {
+		var filtered []types.ServiceConfig
+		for _, svc := range project.Services {
+			if !isEnabled(svc.Name) {
+				continue
+			}
+
+			if err := checkDependencies(svc); err != nil {
+				return nil, err
+			}
+			filtered = append(filtered, svc)
+		}
+		return filtered, nil
+	}
+
+	// Walk the dependencies of the requested services. Dependencies are
+	// added before the services that depend on them.
+	var filtered []types.ServiceConfig
+	visited := map[string]bool{}
+	var visit func(names []string) error
+	visit = func(names []string) error {
+		// GetServices returns all the services when no names are given.
+		if len(names) == 0 {
+			return nil
+		}
+
+		svcs, err := project.GetServices(names)
+		if err != nil {
+			return errors.WithContext("lookup services", err)
+		}
+
+		for _, svc := range svcs {
+			if visited[svc.Name] {
+				continue
+			}
+			visited[svc.Name] = true
+
+			if err := checkDependencies(svc); err != nil {
+				return err
+			}
+
+			if err := visit(svc.GetDependencies()); err != nil {
+				return err
+			}
+			filtered = append(filtered, svc)
+		}
+		return nil
+	}
+
+	if err := visit(services); err != nil {
+		return nil, err
+	}
+	return filtered, nil
+}
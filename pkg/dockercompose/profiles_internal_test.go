@@ -0,0 +1,130 @@
+package dockercompose
+
+import (
+	"testing"
+
+	"github.com/kelda/compose-go/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterServices(t *testing.T) {
+	project := types.Project{
+		Services: types.Services{
+			{Name: "web", DependsOn: types.DependsOnConfig{"db": {}}},
+			{Name: "db"},
+			{Name: "debugger", DependsOn: types.DependsOnConfig{"web": {}}},
+			{Name: "mock", DependsOn: types.DependsOnConfig{"mock-db": {}}},
+			{Name: "mock-db"},
+			{Name: "admin", DependsOn: types.DependsOnConfig{"mock-db": {}}},
+		},
+	}
+	serviceProfiles := map[string][]string{
+		"debugger": {"debug"},
+		"mock":     {"dev"},
+		"mock-db":  {"dev"},
+		"admin":    {"admin"},
+	}
+
+	tests := []struct {
+		name        string
+		profiles    []string
+		services    []string
+		expServices []string
+		expError    bool
+	}{
+		{
+			name:        "no profiles",
+			expServices: []string{"web", "db"},
+		},
+		{
+			name:        "profile",
+			profiles:    []string{"debug"},
+			expServices: []string{"web", "db", "debugger"},
+		},
+		{
+			name:        "all profiles",
+			profiles:    []string{"*"},
+			expServices: []string{"web", "db", "debugger", "mock", "mock-db", "admin"},
+		},
+		{
+			name:     "disabled dependency",
+			profiles: []string{"admin"},
+			expError: true,
+		},
+		{
+			name:        "explicit service",
+			services:    []string{"debugger"},
+			expServices: []string{"db", "web", "debugger"},
+		},
+		{
+			name:        "explicit service enables its profiles",
+			services:    []string{"mock"},
+			expServices: []string{"mock-db", "mock"},
+		},
+		{
+			name:     "explicit service with disabled dependency",
+			services: []string{"admin"},
+			expError: true,
+		},
+		{
+			name:        "explicit service with enabled dependency",
+			profiles:    []string{"dev"},
+			services:    []string{"admin"},
+			expServices: []string{"mock-db", "admin"},
+		},
+		{
+			name:     "unknown service",
+			services: []string{"missing"},
+			expError: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			services, err := filterServices(&project, serviceProfiles, test.profiles, test.services)
+			if test.expError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var names []string
+			for _, svc := range services {
+				names = append(names, svc.Name)
+			}
+			assert.Equal(t, test.expServices, names)
+		})
+	}
+}
+
+func TestGetServiceProfiles(t *testing.T) {
+	configFiles := []types.ConfigFile{
+		{Config: map[string]interface{}{
+			"services": map[string]interface{}{
+				"web":      map[string]interface{}{"image": "web"},
+				"debugger": map[string]interface{}{"profiles": []interface{}{"debug"}},
+			},
+		}},
+		{Config: map[string]interface{}{
+			"services": map[string]interface{}{
+				"debugger": map[string]interface{}{"profiles": []interface{}{"debug", "tools"}},
+			},
+		}},
+	}
+	assert.Equal(t, map[string][]string{"debugger": {"debug", "tools"}},
+		getServiceProfiles(configFiles))
+}
+
+func TestGetActiveProfiles(t *testing.T) {
+	assert.Equal(t, []string{"debug", "dev", "tools"},
+		getActiveProfiles([]string{"debug"}, map[string]string{profilesEnvVar: "dev, tools"}))
+	assert.Empty(t, getActiveProfiles(nil, map[string]string{}))
+
+	// The caller's slice shouldn't be modified, even if it has spare
+	// capacity.
+	flagProfiles := make([]string, 1, 2)
+	flagProfiles[0] = "debug"
+	getActiveProfiles(flagProfiles, map[string]string{profilesEnvVar: "dev"})
+	assert.Equal(t, []string{"debug", ""}, flagProfiles[:2])
+}
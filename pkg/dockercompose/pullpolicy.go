@@ -0,0 +1,110 @@
+package dockercompose
+
+import (
+	"github.com/ghodss/yaml"
+	"github.com/kelda/compose-go/types"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// pullPolicyKey is the Compose field that controls when a service's image is
+// pulled.
+const pullPolicyKey = "pull_policy"
+
+// The pull policies supported by Docker Compose.
+const (
+	PullPolicyAlways       = "always"
+	PullPolicyNever        = "never"
+	PullPolicyMissing      = "missing"
+	PullPolicyIfNotPresent = "if_not_present"
+	PullPolicyBuild        = "build"
+)
+
+// GetPullPolicy returns the service's pull policy, or an empty string if it's
+// not set.
+func GetPullPolicy(svc types.ServiceConfig) string {
+	policy, _ := svc.Extensions[pullPolicyKey].(string)
+	return policy
+}
+
+// getPullPolicies returns the pull policy of each service. The compose-go
+// loader drops the `pull_policy` field, so it's read directly from the parsed
+// YAML. If multiple files set a service's pull policy, the last file takes
+// precedence.
+func getPullPolicies(configFiles []types.ConfigFile) map[string]string {
+	pullPolicies := map[string]string{}
+	for _, file := range configFiles {
+		services, ok := file.Config["services"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for name, svcIntf := range services {
+			svc, ok := svcIntf.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if policy, ok := svc[pullPolicyKey].(string); ok {
+				pullPolicies[name] = policy
+			}
+		}
+	}
+	return pullPolicies
+}
+
+// setPullPolicies stores the pull policies in the services' extensions so
+// that they can be read by GetPullPolicy.
+func setPullPolicies(project *types.Project, pullPolicies map[string]string) error {
+	for i, svc := range project.Services {
+		policy, ok := pullPolicies[svc.Name]
+		if !ok {
+			continue
+		}
+
+		switch policy {
+		case PullPolicyAlways, PullPolicyNever, PullPolicyMissing, PullPolicyIfNotPresent, PullPolicyBuild:
+		default:
+			return errors.NewFriendlyError("Invalid pull_policy (%s) for service %s.\n"+
+				"Expected one of always, never, missing, if_not_present, or build.", policy, svc.Name)
+		}
+
+		if project.Services[i].Extensions == nil {
+			project.Services[i].Extensions = map[string]interface{}{}
+		}
+		project.Services[i].Extensions[pullPolicyKey] = policy
+	}
+	return nil
+}
+
+// marshalPullPolicies adds the services' pull policies to the marshalled
+// project. The extensions aren't included when the project is marshalled
+// since they're excluded from the JSON encoding used by the YAML library.
+func marshalPullPolicies(project types.Project, marshalled []byte) ([]byte, error) {
+	pullPolicies := map[string]string{}
+	for _, svc := range project.Services {
+		if policy := GetPullPolicy(svc); policy != "" {
+			pullPolicies[svc.Name] = policy
+		}
+	}
+	if len(pullPolicies) == 0 {
+		return marshalled, nil
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(marshalled, &raw); err != nil {
+		return nil, err
+	}
+
+	services, ok := raw["services"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("missing services")
+	}
+
+	for name, policy := range pullPolicies {
+		if svc, ok := services[name].(map[string]interface{}); ok {
+			svc[pullPolicyKey] = policy
+		}
+	}
+	return yaml.Marshal(raw)
+}
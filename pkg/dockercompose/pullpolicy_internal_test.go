@@ -0,0 +1,58 @@
+package dockercompose
+
+import (
+	"testing"
+
+	"github.com/kelda/compose-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPullPolicies(t *testing.T) {
+	configFiles := []types.ConfigFile{
+		{
+			Filename: "docker-compose.yml",
+			Config: map[string]interface{}{
+				"services": map[string]interface{}{
+					"web":   map[string]interface{}{"pull_policy": "always"},
+					"db":    map[string]interface{}{"pull_policy": "missing"},
+					"cache": map[string]interface{}{"image": "redis"},
+				},
+			},
+		},
+		{
+			Filename: "docker-compose.override.yml",
+			Config: map[string]interface{}{
+				"services": map[string]interface{}{
+					"web": map[string]interface{}{"pull_policy": "never"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]string{"web": "never", "db": "missing"}, getPullPolicies(configFiles))
+}
+
+func TestSetPullPolicies(t *testing.T) {
+	project := types.Project{
+		Services: types.Services{
+			{Name: "web", Image: "web"},
+			{Name: "db", Image: "postgres"},
+		},
+	}
+	require.NoError(t, setPullPolicies(&project, map[string]string{"web": "never"}))
+	assert.Equal(t, "never", GetPullPolicy(project.Services[0]))
+	assert.Equal(t, "", GetPullPolicy(project.Services[1]))
+
+	// The pull policy should survive being sent to the cluster manager.
+	marshalled, err := Marshal(project)
+	require.NoError(t, err)
+	unmarshalled, err := Unmarshal(marshalled)
+	require.NoError(t, err)
+	web, err := unmarshalled.GetService("web")
+	require.NoError(t, err)
+	assert.Equal(t, "never", GetPullPolicy(web))
+
+	err = setPullPolicies(&project, map[string]string{"db": "sometimes"})
+	assert.Error(t, err)
+}
@@ -104,6 +104,13 @@ func GetPrintableMessage(err error) string {
 	return err.Error()
 }
 
+// IsFriendly returns whether any error in the error chain has a user friendly
+// error message.
+func IsFriendly(err error) bool {
+	_, ok := getFriendlyMessage(err)
+	return ok
+}
+
 func getFriendlyMessage(err error) (string, bool) {
 	friendlyError, ok := err.(FriendlyError)
 	if ok {
@@ -65,6 +65,16 @@ func TestGetPrintableMessage(t *testing.T) {
 	assert.Equal(t, "context: regular error", errors.GetPrintableMessage(wrappedRegularError))
 }
 
+func TestIsFriendly(t *testing.T) {
+	friendlyError := errors.NewFriendlyError("friendly error")
+	assert.True(t, errors.IsFriendly(friendlyError))
+	assert.True(t, errors.IsFriendly(errors.WithContext("context", friendlyError)))
+
+	regularError := errors.New("regular error")
+	assert.False(t, errors.IsFriendly(regularError))
+	assert.False(t, errors.IsFriendly(errors.WithContext("context", regularError)))
+}
+
 func TestNewFriendlyErrorFmt(t *testing.T) {
 	err := errors.NewFriendlyError("%d fish, %d fish, %s fish, %s fish",
 		1, 2, "red", "blue")
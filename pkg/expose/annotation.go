@@ -9,8 +9,18 @@ import (
 type ExposeInfo struct {
 	Service string
 	Port    int
+
+	// Protocol is the protocol spoken by the service. If it's empty, the
+	// service is assumed to speak HTTP/1.1.
+	Protocol string `json:",omitempty"`
 }
 
+const (
+	// ProtocolH2C is used for services that speak cleartext HTTP/2, such as
+	// gRPC servers.
+	ProtocolH2C = "h2c"
+)
+
 // ExposeAnnotation maps secret tokens to their underlying ExposeInfos.
 type ExposeAnnotation map[string]ExposeInfo
 
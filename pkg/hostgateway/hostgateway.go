@@ -0,0 +1,351 @@
+// Package hostgateway implements the listener in the sandbox that forwards
+// connections to `host.docker.internal` back to the user's machine.
+//
+// The gateway runs in the sandbox's DNS pod. The node controller connects to
+// the gateway's control port and sends the ports that should be forwarded.
+// The gateway then listens on those ports, and notifies the node controller of
+// each new connection over the control connection. The node controller
+// accepts the connection by opening a new connection to the control port, and
+// proxies it to the CLI.
+//
+// The control port is reachable by the containers in the sandbox, so every
+// control connection must present the sandbox's token. The token is set by
+// the cluster manager in the DNS pod's TokenAnnotation, which the node
+// controller reads from the pod.
+package hostgateway
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/ports"
+)
+
+// Hostname is the hostname that containers use to connect to the user's
+// machine. It matches the hostname used by Docker Desktop.
+const Hostname = "host.docker.internal"
+
+// ExtraHostsGateway is the special `extra_hosts` IP that's replaced with the
+// gateway's IP, like Docker's `host-gateway`.
+const ExtraHostsGateway = "host-gateway"
+
+// TokenAnnotation is the annotation on the DNS pod that contains the token
+// for the host gateway's control port.
+const TokenAnnotation = "blimp.hostGatewayToken"
+
+// acceptTimeout is how long the gateway waits for the node controller to
+// accept a connection before dropping it.
+const acceptTimeout = 30 * time.Second
+
+// header is the first line sent on every connection to the control port.
+// Exactly one of Ports and Accept is set.
+type header struct {
+	// Token authenticates the connection.
+	Token string `json:"token"`
+
+	// Ports starts a new session that forwards the given ports. Any previous
+	// session is stopped.
+	Ports []uint32 `json:"ports,omitempty"`
+
+	// Accept accepts the pending connection with the given ID.
+	Accept string `json:"accept,omitempty"`
+}
+
+// Conn describes a connection to the gateway that's waiting to be accepted.
+type Conn struct {
+	ID   string `json:"id"`
+	Port uint32 `json:"port"`
+}
+
+// Server is the gateway listener that runs in the sandbox.
+type Server struct {
+	token string
+
+	lock    sync.Mutex
+	session *serverSession
+	pending map[string]net.Conn
+}
+
+type serverSession struct {
+	control   net.Conn
+	listeners []net.Listener
+	sendLock  sync.Mutex
+}
+
+// NewServer creates a gateway that only accepts control connections that
+// present `token`.
+func NewServer(token string) *Server {
+	return &Server{token: token, pending: map[string]net.Conn{}}
+}
+
+// NewToken generates a random token for authenticating control connections.
+func NewToken() (string, error) {
+	return newConnID()
+}
+
+// ListenAndServe accepts control connections from the node controller.
+func (s *Server) ListenAndServe() error {
+	ln, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", ports.HostGatewayControlPort))
+	if err != nil {
+		return errors.WithContext("listen", err)
+	}
+	return s.Serve(ln)
+}
+
+func (s *Server) Serve(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return errors.WithContext("accept", err)
+		}
+		go s.handleControlConn(conn)
+	}
+}
+
+func (s *Server) handleControlConn(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		log.WithError(err).Debug("Failed to read host gateway header")
+		conn.Close()
+		return
+	}
+
+	var hdr header
+	if err := json.Unmarshal(line, &hdr); err != nil {
+		log.WithError(err).Warn("Failed to parse host gateway header")
+		conn.Close()
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hdr.Token), []byte(s.token)) != 1 {
+		log.WithField("remoteAddr", conn.RemoteAddr()).Warn("Rejected host gateway connection with bad token")
+		conn.Close()
+		return
+	}
+
+	if hdr.Accept != "" {
+		s.accept(hdr.Accept, conn, reader)
+		return
+	}
+	s.runSession(conn, reader, hdr.Ports)
+}
+
+func (s *Server) runSession(control net.Conn, reader *bufio.Reader, portsToForward []uint32) {
+	session := &serverSession{control: control}
+
+	// Only one CLI can forward ports at a time, so the latest session wins.
+	s.lock.Lock()
+	if s.session != nil {
+		s.session.close()
+	}
+	s.session = session
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		if s.session == session {
+			s.session = nil
+		}
+		s.lock.Unlock()
+		session.close()
+	}()
+
+	for _, port := range portsToForward {
+		ln, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+		if err != nil {
+			log.WithError(err).WithField("port", port).Warn("Failed to listen for host gateway connections")
+			continue
+		}
+		session.listeners = append(session.listeners, ln)
+		go s.acceptLoop(session, ln, port)
+	}
+
+	// The session ends when the node controller closes the control
+	// connection.
+	//nolint:errcheck // The error just means that the session ended.
+	io.Copy(ioutil.Discard, reader)
+}
+
+func (s *Server) acceptLoop(session *serverSession, ln net.Listener, port uint32) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+
+		id, err := newConnID()
+		if err != nil {
+			log.WithError(err).Warn("Failed to generate connection ID")
+			conn.Close()
+			continue
+		}
+
+		s.lock.Lock()
+		s.pending[id] = conn
+		s.lock.Unlock()
+
+		if err := session.send(Conn{ID: id, Port: port}); err != nil {
+			s.dropPending(id)
+			session.close()
+			return
+		}
+
+		time.AfterFunc(acceptTimeout, func() { s.dropPending(id) })
+	}
+}
+
+func (s *Server) accept(id string, conn net.Conn, reader *bufio.Reader) {
+	s.lock.Lock()
+	pending, ok := s.pending[id]
+	delete(s.pending, id)
+	s.lock.Unlock()
+
+	if !ok {
+		conn.Close()
+		return
+	}
+
+	splice(pending, conn, reader)
+}
+
+func (s *Server) dropPending(id string) {
+	s.lock.Lock()
+	conn, ok := s.pending[id]
+	delete(s.pending, id)
+	s.lock.Unlock()
+
+	if ok {
+		conn.Close()
+	}
+}
+
+func (session *serverSession) send(conn Conn) error {
+	b, err := json.Marshal(conn)
+	if err != nil {
+		return err
+	}
+
+	session.sendLock.Lock()
+	defer session.sendLock.Unlock()
+	_, err = session.control.Write(append(b, '\n'))
+	return err
+}
+
+func (session *serverSession) close() {
+	session.control.Close()
+	for _, ln := range session.listeners {
+		ln.Close()
+	}
+}
+
+// Session is the node controller's side of a control connection.
+type Session struct {
+	gatewayIP string
+	token     string
+	conn      net.Conn
+	reader    *bufio.Reader
+}
+
+// Dial starts forwarding the given ports from the gateway at `gatewayIP`.
+func Dial(gatewayIP, token string, portsToForward []uint32) (*Session, error) {
+	conn, err := dialControl(gatewayIP, header{Token: token, Ports: portsToForward})
+	if err != nil {
+		return nil, err
+	}
+	return &Session{gatewayIP: gatewayIP, token: token, conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+// Next blocks until a container connects to one of the forwarded ports.
+func (session *Session) Next() (Conn, error) {
+	line, err := session.reader.ReadBytes('\n')
+	if err != nil {
+		return Conn{}, err
+	}
+
+	var conn Conn
+	if err := json.Unmarshal(line, &conn); err != nil {
+		return Conn{}, errors.WithContext("parse connection", err)
+	}
+	return conn, nil
+}
+
+// Accept returns the connection with the given ID.
+func (session *Session) Accept(id string) (net.Conn, error) {
+	return Accept(session.gatewayIP, session.token, id)
+}
+
+// Close stops forwarding the ports.
+func (session *Session) Close() error {
+	return session.conn.Close()
+}
+
+// Accept returns the pending connection with the given ID from the gateway
+// at `gatewayIP`. It doesn't require a reference to the session that received
+// the connection, so that connections can be accepted by a different request
+// than the one that's forwarding the ports.
+func Accept(gatewayIP, token, id string) (net.Conn, error) {
+	return dialControl(gatewayIP, header{Token: token, Accept: id})
+}
+
+func dialControl(gatewayIP string, hdr header) (net.Conn, error) {
+	b, err := json.Marshal(hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.DialTimeout("tcp", getControlAddr(gatewayIP), 10*time.Second)
+	if err != nil {
+		return nil, errors.WithContext("connect to host gateway", err)
+	}
+
+	if _, err := conn.Write(append(b, '\n')); err != nil {
+		conn.Close()
+		return nil, errors.WithContext("send header", err)
+	}
+	return conn, nil
+}
+
+// splice copies data between the two connections until either side closes.
+// `bReader` is used to read from `b` since it may have buffered data.
+func splice(a, b net.Conn, bReader io.Reader) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		//nolint:errcheck // Errors just mean that the connection closed.
+		io.Copy(a, bReader)
+		a.Close()
+		wg.Done()
+	}()
+	go func() {
+		//nolint:errcheck // Errors just mean that the connection closed.
+		io.Copy(b, a)
+		b.Close()
+		wg.Done()
+	}()
+	wg.Wait()
+}
+
+func newConnID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+var getControlAddr = func(gatewayIP string) string {
+	return fmt.Sprintf("%s:%d", gatewayIP, ports.HostGatewayControlPort)
+}
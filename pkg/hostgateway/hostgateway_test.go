@@ -0,0 +1,121 @@
+package hostgateway
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForward(t *testing.T) {
+	controlLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer controlLn.Close()
+	go NewServer("token").Serve(controlLn)
+
+	getControlAddr = func(string) string { return controlLn.Addr().String() }
+
+	// Find a free port for the gateway to forward.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := uint32(ln.Addr().(*net.TCPAddr).Port)
+	ln.Close()
+
+	session, err := Dial("127.0.0.1", "token", []uint32{port})
+	require.NoError(t, err)
+	defer session.Close()
+
+	// The gateway listens asynchronously, so retry until it's ready.
+	var container net.Conn
+	assert.Eventually(t, func() bool {
+		container, err = net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NotNil(t, container)
+	defer container.Close()
+
+	conn, err := session.Next()
+	require.NoError(t, err)
+	assert.Equal(t, port, conn.Port)
+
+	host, err := session.Accept(conn.ID)
+	require.NoError(t, err)
+	defer host.Close()
+
+	_, err = container.Write([]byte("ping"))
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(host, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+
+	_, err = host.Write([]byte("pong"))
+	require.NoError(t, err)
+	_, err = io.ReadFull(container, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(buf))
+
+	// Accepting the same connection twice fails.
+	dup, err := session.Accept(conn.ID)
+	require.NoError(t, err)
+	_, err = dup.Read(buf)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestBadToken(t *testing.T) {
+	controlLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer controlLn.Close()
+	go NewServer("token").Serve(controlLn)
+
+	getControlAddr = func(string) string { return controlLn.Addr().String() }
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := uint32(ln.Addr().(*net.TCPAddr).Port)
+	ln.Close()
+
+	session, err := Dial("127.0.0.1", "token", []uint32{port})
+	require.NoError(t, err)
+	defer session.Close()
+
+	var container net.Conn
+	assert.Eventually(t, func() bool {
+		container, err = net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NotNil(t, container)
+	defer container.Close()
+
+	conn, err := session.Next()
+	require.NoError(t, err)
+
+	// Control connections without the token should be closed without
+	// replacing the session or accepting its connections.
+	buf := make([]byte, 4)
+	for _, token := range []string{"", "wrong"} {
+		evil, err := Dial("127.0.0.1", token, []uint32{port})
+		require.NoError(t, err)
+		_, err = evil.Next()
+		assert.Equal(t, io.EOF, err)
+
+		stolen, err := Accept("127.0.0.1", token, conn.ID)
+		require.NoError(t, err)
+		_, err = stolen.Read(buf)
+		assert.Equal(t, io.EOF, err)
+	}
+
+	host, err := session.Accept(conn.ID)
+	require.NoError(t, err)
+	defer host.Close()
+
+	_, err = container.Write([]byte("ping"))
+	require.NoError(t, err)
+	_, err = io.ReadFull(host, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+}
@@ -69,35 +69,74 @@ type DeployPodOptions struct {
 	Sanitizers   []Sanitizer
 }
 
-func DeployPod(kubeClient kubernetes.Interface, pod corev1.Pod, opts DeployPodOptions) error {
-	// Get the current pod, if it exists.
-	podClient := kubeClient.CoreV1().Pods(pod.Namespace)
-	curr, err := podClient.Get(pod.Name, metav1.GetOptions{})
-	if err != nil && !kerrors.IsNotFound(err) {
-		return errors.WithContext("get pod", err)
+// PodDeployAction describes what DeployPod does to an existing pod.
+type PodDeployAction int
+
+const (
+	// PodUnchanged means that the deployed pod is already up to date.
+	PodUnchanged PodDeployAction = iota
+
+	// PodCreate means that the pod doesn't exist yet.
+	PodCreate
+
+	// PodRecreate means that the pod exists, but its spec changed, so it
+	// must be deleted and created again.
+	PodRecreate
+)
+
+// PodDeployPlan is the result of PlanPodDeploy.
+type PodDeployPlan struct {
+	Action PodDeployAction
+
+	// Diff describes the fields that changed when the pod is recreated.
+	Diff []string
+}
+
+// PlanPodDeploy returns what DeployPod would do to deploy the given pod,
+// without modifying anything.
+func PlanPodDeploy(kubeClient kubernetes.Interface, pod corev1.Pod, opts DeployPodOptions) (PodDeployPlan, error) {
+	curr, err := kubeClient.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return PodDeployPlan{Action: PodCreate}, nil
+	}
+	if err != nil {
+		return PodDeployPlan{}, errors.WithContext("get pod", err)
 	}
 
-	// If the pod already exists.
-	if err == nil {
-		// If the currently deployed pod is already up to date, we don't have
-		// to do anything.
-		if !opts.ForceRestart {
-			// Make a copy to avoid modifying the desired pod, since that pod is used
-			// to deploy.
-			sanitized := (&pod).DeepCopy()
-			for _, sanitize := range opts.Sanitizers {
-				sanitized = sanitize(sanitized, curr)
-			}
-			annot, err := runtime.Encode(unstructured.UnstructuredJSONScheme, sanitized)
-			if err != nil {
-				return errors.WithContext("make apply annotation", err)
-			}
-
-			if string(annot) == curr.Annotations["blimp.appliedObject"] {
-				return nil
-			}
-		}
+	if opts.ForceRestart {
+		return PodDeployPlan{Action: PodRecreate}, nil
+	}
+
+	// Make a copy to avoid modifying the desired pod, since that pod is used
+	// to deploy.
+	sanitized := (&pod).DeepCopy()
+	for _, sanitize := range opts.Sanitizers {
+		sanitized = sanitize(sanitized, curr)
+	}
+	annot, err := runtime.Encode(unstructured.UnstructuredJSONScheme, sanitized)
+	if err != nil {
+		return PodDeployPlan{}, errors.WithContext("make apply annotation", err)
+	}
+
+	// If the currently deployed pod is already up to date, we don't have to
+	// do anything.
+	applied := curr.Annotations["blimp.appliedObject"]
+	if string(annot) == applied {
+		return PodDeployPlan{Action: PodUnchanged}, nil
+	}
+	return PodDeployPlan{Action: PodRecreate, Diff: DiffJSON([]byte(applied), annot)}, nil
+}
+
+func DeployPod(kubeClient kubernetes.Interface, pod corev1.Pod, opts DeployPodOptions) error {
+	plan, err := PlanPodDeploy(kubeClient, pod, opts)
+	if err != nil {
+		return err
+	}
 
+	switch plan.Action {
+	case PodUnchanged:
+		return nil
+	case PodRecreate:
 		// Delete the existing pod before we recreate it.
 		if err := DeletePod(kubeClient, pod.Namespace, pod.Name); err != nil {
 			return errors.WithContext("delete pod", err)
@@ -119,7 +158,7 @@ func DeployPod(kubeClient kubernetes.Interface, pod corev1.Pod, opts DeployPodOp
 	}
 	pod.Annotations["blimp.appliedObject"] = string(applyAnnotation)
 
-	if _, err := podClient.Create(&pod); err != nil {
+	if _, err := kubeClient.CoreV1().Pods(pod.Namespace).Create(&pod); err != nil {
 		return errors.WithContext("create pod", err)
 	}
 	return nil
@@ -174,6 +213,8 @@ func deployServiceAccount(kubeClient kubernetes.Interface, serviceAccount corev1
 		}
 
 		meta, subjects, roleRef := roleBindingForRole(serviceAccount, "ClusterRole", role.Name)
+		// ClusterRoleBindings aren't namespaced.
+		meta.Namespace = ""
 		binding := rbacv1.ClusterRoleBinding{
 			ObjectMeta: meta,
 			Subjects:   subjects,
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -161,3 +163,30 @@ func withAnnotation(pod *corev1.Pod) *corev1.Pod {
 	pod.Annotations["blimp.appliedObject"] = string(annot)
 	return pod
 }
+
+func TestDeployClusterServiceAccount(t *testing.T) {
+	kubeClient := fakeKube.NewSimpleClientset()
+	sa := corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "node-controller",
+			Namespace: "kube-system",
+		},
+	}
+	role := rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "role"},
+	}
+
+	// Deploying again should update the existing objects.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, DeployClusterServiceAccount(kubeClient, sa, role))
+	}
+
+	binding, err := kubeClient.RbacV1().ClusterRoleBindings().Get("node-controller-role", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, binding.Namespace)
+	assert.Equal(t, []rbacv1.Subject{{
+		Kind:      "ServiceAccount",
+		Name:      "node-controller",
+		Namespace: "kube-system",
+	}}, binding.Subjects)
+}
@@ -0,0 +1,97 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// maxDiffValueLength is the maximum length of the values shown by DiffJSON.
+const maxDiffValueLength = 60
+
+// DiffJSON returns a human-readable description of the fields that differ
+// between two JSON objects. Each changed field is described on its own line,
+// such as `spec.containers[0].image: "old" -> "new"`.
+func DiffJSON(oldJSON, newJSON []byte) []string {
+	// Invalid JSON is treated as an empty object so that all the fields in
+	// the other object show up in the diff.
+	var oldObj, newObj interface{}
+	if err := json.Unmarshal(oldJSON, &oldObj); err != nil {
+		oldObj = map[string]interface{}{}
+	}
+	if err := json.Unmarshal(newJSON, &newObj); err != nil {
+		newObj = map[string]interface{}{}
+	}
+
+	var diff []string
+	diffValues("", oldObj, newObj, &diff)
+	return diff
+}
+
+func diffValues(path string, oldVal, newVal interface{}, diff *[]string) {
+	if reflect.DeepEqual(oldVal, newVal) {
+		return
+	}
+
+	switch oldVal := oldVal.(type) {
+	case map[string]interface{}:
+		newVal, ok := newVal.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := map[string]struct{}{}
+		for key := range oldVal {
+			keys[key] = struct{}{}
+		}
+		for key := range newVal {
+			keys[key] = struct{}{}
+		}
+
+		var sortedKeys []string
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+
+		for _, key := range sortedKeys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			diffValues(childPath, oldVal[key], newVal[key], diff)
+		}
+		return
+	case []interface{}:
+		// Lists with different lengths are shown in full, since it's
+		// ambiguous which elements were added or removed.
+		newVal, ok := newVal.([]interface{})
+		if !ok || len(oldVal) != len(newVal) {
+			break
+		}
+
+		for i := range oldVal {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), oldVal[i], newVal[i], diff)
+		}
+		return
+	}
+
+	*diff = append(*diff, fmt.Sprintf("%s: %s -> %s", path, formatDiffValue(oldVal), formatDiffValue(newVal)))
+}
+
+func formatDiffValue(val interface{}) string {
+	if val == nil {
+		return "<unset>"
+	}
+
+	str, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+
+	if len(str) > maxDiffValueLength {
+		return string(str[:maxDiffValueLength-3]) + "..."
+	}
+	return string(str)
+}
@@ -0,0 +1,65 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		exp  []string
+	}{
+		{
+			name: "Unchanged",
+			old:  `{"spec": {"containers": [{"image": "nginx"}]}}`,
+			new:  `{"spec": {"containers": [{"image": "nginx"}]}}`,
+			exp:  nil,
+		},
+		{
+			name: "ChangedField",
+			old:  `{"spec": {"containers": [{"image": "nginx:1"}]}}`,
+			new:  `{"spec": {"containers": [{"image": "nginx:2"}]}}`,
+			exp:  []string{`spec.containers[0].image: "nginx:1" -> "nginx:2"`},
+		},
+		{
+			name: "AddedAndRemovedFields",
+			old:  `{"spec": {"hostname": "web"}}`,
+			new:  `{"spec": {"restartPolicy": "Always"}}`,
+			exp: []string{
+				`spec.hostname: "web" -> <unset>`,
+				`spec.restartPolicy: <unset> -> "Always"`,
+			},
+		},
+		{
+			name: "ChangedListLength",
+			old:  `{"args": ["a"]}`,
+			new:  `{"args": ["a", "b"]}`,
+			exp:  []string{`args: ["a"] -> ["a","b"]`},
+		},
+		{
+			name: "LongValue",
+			old:  `{"command": "short"}`,
+			new:  `{"command": "a very long command that goes on and on and on and on and on"}`,
+			exp: []string{
+				`command: "short" -> "a very long command that goes on and on and on and on an...`,
+			},
+		},
+		{
+			name: "InvalidOld",
+			old:  ``,
+			new:  `{"image": "nginx"}`,
+			exp:  []string{`image: <unset> -> "nginx"`},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.exp, DiffJSON([]byte(test.old), []byte(test.new)))
+		})
+	}
+}
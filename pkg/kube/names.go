@@ -1,5 +1,13 @@
 package kube
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kelda/blimp/pkg/names"
+)
+
 const (
 	ContainerNameCopyVCP                   = "copy-vcp"
 	ContainerNameInitializeVolumeFromImage = "vcp"
@@ -13,6 +21,99 @@ const (
 	ExposeAnnotation            = "blimp.exposed"
 	NodePublicAddressAnnotation = "blimp.public-address"
 
+	// RegistryUsageAnnotation is set on customer namespaces by the registry
+	// garbage collector. It contains the number of bytes used by the
+	// namespace's images in the Blimp registry.
+	RegistryUsageAnnotation = "blimp.registryUsage"
+
 	PodNameSyncthing = "syncthing"
 	PodNameBuildkitd = "buildkitd"
+
+	// PodNameVolumeTransfer is the prefix of the names of the pods used to
+	// export and import volumes. Each transfer gets its own pod.
+	PodNameVolumeTransfer = "volume-transfer"
+
+	// StoppedServiceLabel marks the ConfigMaps that store the pods of
+	// services stopped by `blimp stop`.
+	StoppedServiceLabel = "blimp.stoppedService"
+
+	// StoppedExitReasonAnnotation is set on the ConfigMaps of services that
+	// were stopped because they exceeded their maximum number of restarts.
+	// It explains why the service was stopped.
+	StoppedExitReasonAnnotation = "blimp.exitReason"
+
+	// WaitSpecLabel marks the ConfigMaps that store the wait specs of
+	// customer pods. It's used to garbage collect ConfigMaps for services
+	// that are no longer deployed.
+	WaitSpecLabel = "blimp.waitSpec"
+
+	// ReplicaLabel is set on the pods of services with multiple replicas. It
+	// contains the replica's ordinal. The first replica isn't labeled, so
+	// that its pod is the same as when the service isn't replicated.
+	ReplicaLabel = "blimp.replica"
+
+	// OneOffPodLabel marks the pods created by `blimp run`.
+	OneOffPodLabel = "blimp.oneOffPod"
+
+	// EventReasonWaiting is the reason of the events the node controller
+	// records while a pod is blocked from booting.
+	EventReasonWaiting = "BlimpWaiting"
 )
+
+// ReplicaName returns the name used to refer to a single replica of a
+// service, such as `web.2`.
+func ReplicaName(service string, replica int) string {
+	return fmt.Sprintf("%s.%d", service, replica)
+}
+
+// ParseReplicaName splits a name returned by ReplicaName into the service and
+// replica ordinal.
+func ParseReplicaName(name string) (service string, replica int, ok bool) {
+	i := strings.LastIndex(name, ".")
+	if i <= 0 {
+		return "", 0, false
+	}
+
+	replica, err := strconv.Atoi(name[i+1:])
+	if err != nil || replica < 1 {
+		return "", 0, false
+	}
+	return name[:i], replica, true
+}
+
+// ReplicaPodName returns the name of the pod for the given replica of a
+// service. The first replica uses the same pod name as services that aren't
+// replicated. Along with leaving the first replica's ReplicaLabel unset, this
+// keeps scaling a service up from recreating its existing pod.
+func ReplicaPodName(service string, replica int) string {
+	if replica <= 1 {
+		return names.ToDNS1123(service)
+	}
+	return names.ToDNS1123(ReplicaName(service, replica))
+}
+
+// StoppedServiceConfigMapName returns the name of the ConfigMap that stores
+// the pod of the given stopped service.
+func StoppedServiceConfigMapName(service string) string {
+	return names.ToDNS1123("stopped-" + service)
+}
+
+// StoppedReplicaConfigMapName returns the name of the ConfigMap that stores
+// the pod of the given replica of a stopped service.
+func StoppedReplicaConfigMapName(service string, replica int) string {
+	if replica <= 1 {
+		return StoppedServiceConfigMapName(service)
+	}
+	return StoppedServiceConfigMapName(ReplicaName(service, replica))
+}
+
+// GetReplica returns the replica ordinal of the given customer pod or stopped
+// service ConfigMap. Objects for services that aren't replicated are treated
+// as the first replica.
+func GetReplica(labels map[string]string) int {
+	replica, err := strconv.Atoi(labels[ReplicaLabel])
+	if err != nil || replica < 1 {
+		return 1
+	}
+	return replica
+}
@@ -0,0 +1,43 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kelda/blimp/pkg/names"
+)
+
+func TestParseReplicaName(t *testing.T) {
+	tests := []struct {
+		name       string
+		expService string
+		expReplica int
+		expOK      bool
+	}{
+		{name: "web.2", expService: "web", expReplica: 2, expOK: true},
+		{name: "my.web.10", expService: "my.web", expReplica: 10, expOK: true},
+		{name: "web"},
+		{name: "web.0"},
+		{name: "web.abc"},
+		{name: ".2"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			service, replica, ok := ParseReplicaName(test.name)
+			assert.Equal(t, test.expService, service)
+			assert.Equal(t, test.expReplica, replica)
+			assert.Equal(t, test.expOK, ok)
+		})
+	}
+}
+
+func TestReplicaPodName(t *testing.T) {
+	// The first replica should keep the pod name used for services without
+	// replicas.
+	assert.Equal(t, names.ToDNS1123("web"), ReplicaPodName("web", 1))
+	assert.Equal(t, names.ToDNS1123("web.2"), ReplicaPodName("web", 2))
+	assert.NotEqual(t, ReplicaPodName("web", 2), ReplicaPodName("web", 3))
+}
@@ -6,10 +6,15 @@ import (
 
 const AliasesKey = "io.kelda.blimp/aliases"
 
+// MaxRestartsKey is the maximum number of times a pod with the `on-failure:N`
+// restart policy may be restarted before it's stopped.
+const MaxRestartsKey = "io.kelda.blimp/max-restarts"
+
 // CustomPodAnnotations contains all annotations that Blimp could apply to pods
 // that should persist across restarts, except blimp.appliedObject.
 var CustomPodAnnotations = []string{
 	AliasesKey,
+	MaxRestartsKey,
 }
 
 func ParseAliases(aliases string) []string {
@@ -6,4 +6,14 @@ const (
 
 	ClusterManagerGRPCInternalPort = 9000
 	ClusterManagerHTTPInternalPort = 9002
+
+	// ClusterManagerHealthPort serves the cluster manager's liveness and
+	// readiness probes.
+	ClusterManagerHealthPort = 9005
+
+	VolumeTransferPort = 9003
+
+	// HostGatewayControlPort is the port that the node controller uses to
+	// control the host gateway in the sandbox's DNS pod.
+	HostGatewayControlPort = 9004
 )
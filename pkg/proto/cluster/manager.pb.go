@@ -63,6 +63,7 @@ const (
 	ServicePhase_EXITED               ServicePhase = 6
 	ServicePhase_UNHEALTHY            ServicePhase = 7
 	ServicePhase_UNSCHEDULABLE        ServicePhase = 8
+	ServicePhase_STOPPED              ServicePhase = 9
 )
 
 var ServicePhase_name = map[int32]string{
@@ -75,6 +76,7 @@ var ServicePhase_name = map[int32]string{
 	6: "EXITED",
 	7: "UNHEALTHY",
 	8: "UNSCHEDULABLE",
+	9: "STOPPED",
 }
 
 var ServicePhase_value = map[string]int32{
@@ -87,6 +89,7 @@ var ServicePhase_value = map[string]int32{
 	"EXITED":               6,
 	"UNHEALTHY":            7,
 	"UNSCHEDULABLE":        8,
+	"STOPPED":              9,
 }
 
 func (x ServicePhase) String() string {
@@ -97,6 +100,92 @@ func (ServicePhase) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_d156d5389f4d1cd6, []int{1}
 }
 
+type ExposeProtocol int32
+
+const (
+	ExposeProtocol_HTTP ExposeProtocol = 0
+	// H2C is cleartext HTTP/2, e.g. for gRPC servers.
+	ExposeProtocol_H2C ExposeProtocol = 1
+)
+
+var ExposeProtocol_name = map[int32]string{
+	0: "HTTP",
+	1: "H2C",
+}
+
+var ExposeProtocol_value = map[string]int32{
+	"HTTP": 0,
+	"H2C":  1,
+}
+
+func (x ExposeProtocol) String() string {
+	return proto.EnumName(ExposeProtocol_name, int32(x))
+}
+
+func (ExposeProtocol) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{2}
+}
+
+type VolumeType int32
+
+const (
+	VolumeType_NAMED_VOLUME VolumeType = 0
+	VolumeType_BIND_VOLUME  VolumeType = 1
+)
+
+var VolumeType_name = map[int32]string{
+	0: "NAMED_VOLUME",
+	1: "BIND_VOLUME",
+}
+
+var VolumeType_value = map[string]int32{
+	"NAMED_VOLUME": 0,
+	"BIND_VOLUME":  1,
+}
+
+func (x VolumeType) String() string {
+	return proto.EnumName(VolumeType_name, int32(x))
+}
+
+func (VolumeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{3}
+}
+
+type ServiceDeployPlan_Action int32
+
+const (
+	ServiceDeployPlan_UNCHANGED ServiceDeployPlan_Action = 0
+	ServiceDeployPlan_CREATE    ServiceDeployPlan_Action = 1
+	ServiceDeployPlan_RECREATE  ServiceDeployPlan_Action = 2
+	ServiceDeployPlan_DELETE    ServiceDeployPlan_Action = 3
+	// The service would be recreated, but it was excluded by no_recreate.
+	ServiceDeployPlan_SKIP_RECREATE ServiceDeployPlan_Action = 4
+)
+
+var ServiceDeployPlan_Action_name = map[int32]string{
+	0: "UNCHANGED",
+	1: "CREATE",
+	2: "RECREATE",
+	3: "DELETE",
+	4: "SKIP_RECREATE",
+}
+
+var ServiceDeployPlan_Action_value = map[string]int32{
+	"UNCHANGED":     0,
+	"CREATE":        1,
+	"RECREATE":      2,
+	"DELETE":        3,
+	"SKIP_RECREATE": 4,
+}
+
+func (x ServiceDeployPlan_Action) String() string {
+	return proto.EnumName(ServiceDeployPlan_Action_name, int32(x))
+}
+
+func (ServiceDeployPlan_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{9, 0}
+}
+
 type SandboxStatus_SandboxPhase int32
 
 const (
@@ -128,7 +217,87 @@ func (x SandboxStatus_SandboxPhase) String() string {
 }
 
 func (SandboxStatus_SandboxPhase) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{14, 0}
+	return fileDescriptor_d156d5389f4d1cd6, []int{15, 0}
+}
+
+type StartVolumeTransferRequest_Direction int32
+
+const (
+	StartVolumeTransferRequest_EXPORT StartVolumeTransferRequest_Direction = 0
+	StartVolumeTransferRequest_IMPORT StartVolumeTransferRequest_Direction = 1
+)
+
+var StartVolumeTransferRequest_Direction_name = map[int32]string{
+	0: "EXPORT",
+	1: "IMPORT",
+}
+
+var StartVolumeTransferRequest_Direction_value = map[string]int32{
+	"EXPORT": 0,
+	"IMPORT": 1,
+}
+
+func (x StartVolumeTransferRequest_Direction) String() string {
+	return proto.EnumName(StartVolumeTransferRequest_Direction_name, int32(x))
+}
+
+func (StartVolumeTransferRequest_Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{43, 0}
+}
+
+type Event_Type int32
+
+const (
+	Event_OTHER              Event_Type = 0
+	Event_SCHEDULING_FAILED  Event_Type = 1
+	Event_WAITING            Event_Type = 2
+	Event_PULLING_IMAGE      Event_Type = 3
+	Event_PULLED_IMAGE       Event_Type = 4
+	Event_PULL_FAILED        Event_Type = 5
+	Event_STARTED            Event_Type = 6
+	Event_HEALTHCHECK_FAILED Event_Type = 7
+	Event_EXITED             Event_Type = 8
+	Event_OOM_KILLED         Event_Type = 9
+	Event_CRASH_LOOP         Event_Type = 10
+	Event_STOPPING           Event_Type = 11
+)
+
+var Event_Type_name = map[int32]string{
+	0:  "OTHER",
+	1:  "SCHEDULING_FAILED",
+	2:  "WAITING",
+	3:  "PULLING_IMAGE",
+	4:  "PULLED_IMAGE",
+	5:  "PULL_FAILED",
+	6:  "STARTED",
+	7:  "HEALTHCHECK_FAILED",
+	8:  "EXITED",
+	9:  "OOM_KILLED",
+	10: "CRASH_LOOP",
+	11: "STOPPING",
+}
+
+var Event_Type_value = map[string]int32{
+	"OTHER":              0,
+	"SCHEDULING_FAILED":  1,
+	"WAITING":            2,
+	"PULLING_IMAGE":      3,
+	"PULLED_IMAGE":       4,
+	"PULL_FAILED":        5,
+	"STARTED":            6,
+	"HEALTHCHECK_FAILED": 7,
+	"EXITED":             8,
+	"OOM_KILLED":         9,
+	"CRASH_LOOP":         10,
+	"STOPPING":           11,
+}
+
+func (x Event_Type) String() string {
+	return proto.EnumName(Event_Type_name, int32(x))
+}
+
+func (Event_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{56, 0}
 }
 
 type CheckVersionRequest struct {
@@ -234,14 +403,18 @@ func (m *CheckVersionResponse) GetError() *errors.Error {
 }
 
 type CreateSandboxRequest struct {
-	OldToken             string                         `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
-	Auth                 *auth.BlimpAuth                `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
-	ComposeFile          string                         `protobuf:"bytes,2,opt,name=composeFile,proto3" json:"composeFile,omitempty"`
-	RegistryCredentials  map[string]*RegistryCredential `protobuf:"bytes,3,rep,name=registryCredentials,proto3" json:"registryCredentials,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	SyncedFolders        map[string]string              `protobuf:"bytes,4,rep,name=syncedFolders,proto3" json:"syncedFolders,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
-	XXX_unrecognized     []byte                         `json:"-"`
-	XXX_sizecache        int32                          `json:"-"`
+	OldToken            string                         `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
+	Auth                *auth.BlimpAuth                `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
+	ComposeFile         string                         `protobuf:"bytes,2,opt,name=composeFile,proto3" json:"composeFile,omitempty"`
+	RegistryCredentials map[string]*RegistryCredential `protobuf:"bytes,3,rep,name=registryCredentials,proto3" json:"registryCredentials,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SyncedFolders       map[string]string              `protobuf:"bytes,4,rep,name=syncedFolders,proto3" json:"syncedFolders,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// receiveOnlyFolders are the IDs of the synced folders that are only
+	// mounted as read-only. The sandbox never sends changes in these folders
+	// back to the CLI.
+	ReceiveOnlyFolders   []string `protobuf:"bytes,6,rep,name=receiveOnlyFolders,proto3" json:"receiveOnlyFolders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CreateSandboxRequest) Reset()         { *m = CreateSandboxRequest{} }
@@ -304,6 +477,13 @@ func (m *CreateSandboxRequest) GetSyncedFolders() map[string]string {
 	return nil
 }
 
+func (m *CreateSandboxRequest) GetReceiveOnlyFolders() []string {
+	if m != nil {
+		return m.ReceiveOnlyFolders
+	}
+	return nil
+}
+
 type RegistryCredential struct {
 	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
@@ -399,13 +579,16 @@ func (m *AttachToSandboxRequest) GetAuth() *auth.BlimpAuth {
 }
 
 type AttachToSandboxResponse struct {
-	Error                *errors.Error    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	NodeAddress          string           `protobuf:"bytes,2,opt,name=NodeAddress,proto3" json:"NodeAddress,omitempty"`
-	NodeCert             string           `protobuf:"bytes,3,opt,name=NodeCert,proto3" json:"NodeCert,omitempty"`
-	KubeCredentials      *KubeCredentials `protobuf:"bytes,4,opt,name=kubeCredentials,proto3" json:"kubeCredentials,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	Error           *errors.Error    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	NodeAddress     string           `protobuf:"bytes,2,opt,name=NodeAddress,proto3" json:"NodeAddress,omitempty"`
+	NodeCert        string           `protobuf:"bytes,3,opt,name=NodeCert,proto3" json:"NodeCert,omitempty"`
+	KubeCredentials *KubeCredentials `protobuf:"bytes,4,opt,name=kubeCredentials,proto3" json:"kubeCredentials,omitempty"`
+	// The Compose file that was most recently deployed to the sandbox. It's
+	// empty if nothing has been deployed.
+	ComposeFile          string   `protobuf:"bytes,5,opt,name=compose_file,json=composeFile,proto3" json:"compose_file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *AttachToSandboxResponse) Reset()         { *m = AttachToSandboxResponse{} }
@@ -461,6 +644,13 @@ func (m *AttachToSandboxResponse) GetKubeCredentials() *KubeCredentials {
 	return nil
 }
 
+func (m *AttachToSandboxResponse) GetComposeFile() string {
+	if m != nil {
+		return m.ComposeFile
+	}
+	return ""
+}
+
 type CreateSandboxResponse struct {
 	Error                *errors.Error    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
 	NodeAddress          string           `protobuf:"bytes,2,opt,name=NodeAddress,proto3" json:"NodeAddress,omitempty"`
@@ -549,13 +739,18 @@ func (m *CreateSandboxResponse) GetAction() CLIAction {
 }
 
 type DeployRequest struct {
-	OldToken             string            `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
-	Auth                 *auth.BlimpAuth   `protobuf:"bytes,4,opt,name=auth,proto3" json:"auth,omitempty"`
-	ComposeFile          string            `protobuf:"bytes,2,opt,name=composeFile,proto3" json:"composeFile,omitempty"`
-	BuiltImages          map[string]string `protobuf:"bytes,3,rep,name=builtImages,proto3" json:"builtImages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	OldToken    string            `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
+	Auth        *auth.BlimpAuth   `protobuf:"bytes,4,opt,name=auth,proto3" json:"auth,omitempty"`
+	ComposeFile string            `protobuf:"bytes,2,opt,name=composeFile,proto3" json:"composeFile,omitempty"`
+	BuiltImages map[string]string `protobuf:"bytes,3,rep,name=builtImages,proto3" json:"builtImages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// If dry_run is set, nothing is deployed. Instead, the response describes
+	// what would change.
+	DryRun bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Services whose pods shouldn't be recreated, even if their spec changed.
+	NoRecreate           []string `protobuf:"bytes,6,rep,name=no_recreate,json=noRecreate,proto3" json:"no_recreate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DeployRequest) Reset()         { *m = DeployRequest{} }
@@ -611,11 +806,30 @@ func (m *DeployRequest) GetBuiltImages() map[string]string {
 	return nil
 }
 
+func (m *DeployRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *DeployRequest) GetNoRecreate() []string {
+	if m != nil {
+		return m.NoRecreate
+	}
+	return nil
+}
+
 type DeployResponse struct {
-	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// Warnings are problems with the Compose file that didn't prevent it from
+	// being deployed, such as healthchecks that couldn't be parsed.
+	Warnings []string `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	// What happens to each service. Only set for dry runs.
+	Plan                 []*ServiceDeployPlan `protobuf:"bytes,3,rep,name=plan,proto3" json:"plan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *DeployResponse) Reset()         { *m = DeployResponse{} }
@@ -650,6 +864,78 @@ func (m *DeployResponse) GetError() *errors.Error {
 	return nil
 }
 
+func (m *DeployResponse) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+func (m *DeployResponse) GetPlan() []*ServiceDeployPlan {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+type ServiceDeployPlan struct {
+	// The name of the service, or SERVICE.N for the replicas of services with
+	// multiple replicas.
+	Service string                   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Action  ServiceDeployPlan_Action `protobuf:"varint,2,opt,name=action,proto3,enum=blimp.cluster.v0.ServiceDeployPlan_Action" json:"action,omitempty"`
+	// The fields that changed, if the service is recreated.
+	Diff                 []string `protobuf:"bytes,3,rep,name=diff,proto3" json:"diff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceDeployPlan) Reset()         { *m = ServiceDeployPlan{} }
+func (m *ServiceDeployPlan) String() string { return proto.CompactTextString(m) }
+func (*ServiceDeployPlan) ProtoMessage()    {}
+func (*ServiceDeployPlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{9}
+}
+
+func (m *ServiceDeployPlan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceDeployPlan.Unmarshal(m, b)
+}
+func (m *ServiceDeployPlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceDeployPlan.Marshal(b, m, deterministic)
+}
+func (m *ServiceDeployPlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceDeployPlan.Merge(m, src)
+}
+func (m *ServiceDeployPlan) XXX_Size() int {
+	return xxx_messageInfo_ServiceDeployPlan.Size(m)
+}
+func (m *ServiceDeployPlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceDeployPlan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceDeployPlan proto.InternalMessageInfo
+
+func (m *ServiceDeployPlan) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ServiceDeployPlan) GetAction() ServiceDeployPlan_Action {
+	if m != nil {
+		return m.Action
+	}
+	return ServiceDeployPlan_UNCHANGED
+}
+
+func (m *ServiceDeployPlan) GetDiff() []string {
+	if m != nil {
+		return m.Diff
+	}
+	return nil
+}
+
 type KubeCredentials struct {
 	Host                 string   `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
 	CaCrt                string   `protobuf:"bytes,2,opt,name=caCrt,proto3" json:"caCrt,omitempty"`
@@ -664,7 +950,7 @@ func (m *KubeCredentials) Reset()         { *m = KubeCredentials{} }
 func (m *KubeCredentials) String() string { return proto.CompactTextString(m) }
 func (*KubeCredentials) ProtoMessage()    {}
 func (*KubeCredentials) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{9}
+	return fileDescriptor_d156d5389f4d1cd6, []int{10}
 }
 
 func (m *KubeCredentials) XXX_Unmarshal(b []byte) error {
@@ -726,7 +1012,7 @@ func (m *DeleteSandboxRequest) Reset()         { *m = DeleteSandboxRequest{} }
 func (m *DeleteSandboxRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteSandboxRequest) ProtoMessage()    {}
 func (*DeleteSandboxRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{10}
+	return fileDescriptor_d156d5389f4d1cd6, []int{11}
 }
 
 func (m *DeleteSandboxRequest) XXX_Unmarshal(b []byte) error {
@@ -779,7 +1065,7 @@ func (m *DeleteSandboxResponse) Reset()         { *m = DeleteSandboxResponse{} }
 func (m *DeleteSandboxResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteSandboxResponse) ProtoMessage()    {}
 func (*DeleteSandboxResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{11}
+	return fileDescriptor_d156d5389f4d1cd6, []int{12}
 }
 
 func (m *DeleteSandboxResponse) XXX_Unmarshal(b []byte) error {
@@ -819,7 +1105,7 @@ func (m *GetStatusRequest) Reset()         { *m = GetStatusRequest{} }
 func (m *GetStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*GetStatusRequest) ProtoMessage()    {}
 func (*GetStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{12}
+	return fileDescriptor_d156d5389f4d1cd6, []int{13}
 }
 
 func (m *GetStatusRequest) XXX_Unmarshal(b []byte) error {
@@ -866,7 +1152,7 @@ func (m *GetStatusResponse) Reset()         { *m = GetStatusResponse{} }
 func (m *GetStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*GetStatusResponse) ProtoMessage()    {}
 func (*GetStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{13}
+	return fileDescriptor_d156d5389f4d1cd6, []int{14}
 }
 
 func (m *GetStatusResponse) XXX_Unmarshal(b []byte) error {
@@ -913,7 +1199,7 @@ func (m *SandboxStatus) Reset()         { *m = SandboxStatus{} }
 func (m *SandboxStatus) String() string { return proto.CompactTextString(m) }
 func (*SandboxStatus) ProtoMessage()    {}
 func (*SandboxStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{14}
+	return fileDescriptor_d156d5389f4d1cd6, []int{15}
 }
 
 func (m *SandboxStatus) XXX_Unmarshal(b []byte) error {
@@ -949,19 +1235,30 @@ func (m *SandboxStatus) GetPhase() SandboxStatus_SandboxPhase {
 }
 
 type ServiceStatus struct {
-	Phase                ServicePhase `protobuf:"varint,1,opt,name=phase,proto3,enum=blimp.cluster.v0.ServicePhase" json:"phase,omitempty"`
-	Msg                  string       `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
-	HasStarted           bool         `protobuf:"varint,3,opt,name=has_started,json=hasStarted,proto3" json:"has_started,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Phase      ServicePhase `protobuf:"varint,1,opt,name=phase,proto3,enum=blimp.cluster.v0.ServicePhase" json:"phase,omitempty"`
+	Msg        string       `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	HasStarted bool         `protobuf:"varint,3,opt,name=has_started,json=hasStarted,proto3" json:"has_started,omitempty"`
+	// The most recent healthcheck results, oldest first. Unset if the service
+	// doesn't have a healthcheck.
+	HealthLog []*HealthCheckResult `protobuf:"bytes,4,rep,name=health_log,json=healthLog,proto3" json:"health_log,omitempty"`
+	// The number of times the service's container has restarted, and why it
+	// last exited.
+	RestartCount   int32  `protobuf:"varint,5,opt,name=restart_count,json=restartCount,proto3" json:"restart_count,omitempty"`
+	LastExitReason string `protobuf:"bytes,6,opt,name=last_exit_reason,json=lastExitReason,proto3" json:"last_exit_reason,omitempty"`
+	// The status of each replica, keyed by the replica's ordinal. Only set for
+	// services with multiple replicas, in which case the other fields summarize
+	// the replicas.
+	Replicas             map[int32]*ServiceStatus `protobuf:"bytes,7,rep,name=replicas,proto3" json:"replicas,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
 func (m *ServiceStatus) Reset()         { *m = ServiceStatus{} }
 func (m *ServiceStatus) String() string { return proto.CompactTextString(m) }
 func (*ServiceStatus) ProtoMessage()    {}
 func (*ServiceStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{15}
+	return fileDescriptor_d156d5389f4d1cd6, []int{16}
 }
 
 func (m *ServiceStatus) XXX_Unmarshal(b []byte) error {
@@ -1003,6 +1300,102 @@ func (m *ServiceStatus) GetHasStarted() bool {
 	return false
 }
 
+func (m *ServiceStatus) GetHealthLog() []*HealthCheckResult {
+	if m != nil {
+		return m.HealthLog
+	}
+	return nil
+}
+
+func (m *ServiceStatus) GetRestartCount() int32 {
+	if m != nil {
+		return m.RestartCount
+	}
+	return 0
+}
+
+func (m *ServiceStatus) GetLastExitReason() string {
+	if m != nil {
+		return m.LastExitReason
+	}
+	return ""
+}
+
+func (m *ServiceStatus) GetReplicas() map[int32]*ServiceStatus {
+	if m != nil {
+		return m.Replicas
+	}
+	return nil
+}
+
+type HealthCheckResult struct {
+	// The Unix time of the most recent check with this result.
+	Time    int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Healthy bool  `protobuf:"varint,2,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// The output of the check. Only set for failed checks, since Kubernetes
+	// doesn't record the output of successful checks.
+	Output string `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	// The number of times the check failed with this output. Unset for
+	// successful checks.
+	Count                int32    `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthCheckResult) Reset()         { *m = HealthCheckResult{} }
+func (m *HealthCheckResult) String() string { return proto.CompactTextString(m) }
+func (*HealthCheckResult) ProtoMessage()    {}
+func (*HealthCheckResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{17}
+}
+
+func (m *HealthCheckResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthCheckResult.Unmarshal(m, b)
+}
+func (m *HealthCheckResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthCheckResult.Marshal(b, m, deterministic)
+}
+func (m *HealthCheckResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthCheckResult.Merge(m, src)
+}
+func (m *HealthCheckResult) XXX_Size() int {
+	return xxx_messageInfo_HealthCheckResult.Size(m)
+}
+func (m *HealthCheckResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthCheckResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthCheckResult proto.InternalMessageInfo
+
+func (m *HealthCheckResult) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *HealthCheckResult) GetHealthy() bool {
+	if m != nil {
+		return m.Healthy
+	}
+	return false
+}
+
+func (m *HealthCheckResult) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *HealthCheckResult) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 type RestartRequest struct {
 	OldToken             string          `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
 	Auth                 *auth.BlimpAuth `protobuf:"bytes,3,opt,name=auth,proto3" json:"auth,omitempty"`
@@ -1016,7 +1409,7 @@ func (m *RestartRequest) Reset()         { *m = RestartRequest{} }
 func (m *RestartRequest) String() string { return proto.CompactTextString(m) }
 func (*RestartRequest) ProtoMessage()    {}
 func (*RestartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{16}
+	return fileDescriptor_d156d5389f4d1cd6, []int{18}
 }
 
 func (m *RestartRequest) XXX_Unmarshal(b []byte) error {
@@ -1069,7 +1462,7 @@ func (m *RestartResponse) Reset()         { *m = RestartResponse{} }
 func (m *RestartResponse) String() string { return proto.CompactTextString(m) }
 func (*RestartResponse) ProtoMessage()    {}
 func (*RestartResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{17}
+	return fileDescriptor_d156d5389f4d1cd6, []int{19}
 }
 
 func (m *RestartResponse) XXX_Unmarshal(b []byte) error {
@@ -1097,365 +1490,1570 @@ func (m *RestartResponse) GetError() *errors.Error {
 	return nil
 }
 
-type TagImageRequest struct {
-	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
-	Image                string   `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
-	Tag                  string   `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type StopRequest struct {
+	Auth                 *auth.BlimpAuth `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Service              string          `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *TagImageRequest) Reset()         { *m = TagImageRequest{} }
-func (m *TagImageRequest) String() string { return proto.CompactTextString(m) }
-func (*TagImageRequest) ProtoMessage()    {}
-func (*TagImageRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{18}
+func (m *StopRequest) Reset()         { *m = StopRequest{} }
+func (m *StopRequest) String() string { return proto.CompactTextString(m) }
+func (*StopRequest) ProtoMessage()    {}
+func (*StopRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{20}
 }
 
-func (m *TagImageRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TagImageRequest.Unmarshal(m, b)
+func (m *StopRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopRequest.Unmarshal(m, b)
 }
-func (m *TagImageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TagImageRequest.Marshal(b, m, deterministic)
+func (m *StopRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopRequest.Marshal(b, m, deterministic)
 }
-func (m *TagImageRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TagImageRequest.Merge(m, src)
+func (m *StopRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopRequest.Merge(m, src)
 }
-func (m *TagImageRequest) XXX_Size() int {
-	return xxx_messageInfo_TagImageRequest.Size(m)
+func (m *StopRequest) XXX_Size() int {
+	return xxx_messageInfo_StopRequest.Size(m)
 }
-func (m *TagImageRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TagImageRequest.DiscardUnknown(m)
+func (m *StopRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TagImageRequest proto.InternalMessageInfo
+var xxx_messageInfo_StopRequest proto.InternalMessageInfo
 
-func (m *TagImageRequest) GetService() string {
+func (m *StopRequest) GetAuth() *auth.BlimpAuth {
 	if m != nil {
-		return m.Service
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *StopRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+type StopResponse struct {
+	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *StopResponse) Reset()         { *m = StopResponse{} }
+func (m *StopResponse) String() string { return proto.CompactTextString(m) }
+func (*StopResponse) ProtoMessage()    {}
+func (*StopResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{21}
+}
+
+func (m *StopResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopResponse.Unmarshal(m, b)
+}
+func (m *StopResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopResponse.Marshal(b, m, deterministic)
+}
+func (m *StopResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopResponse.Merge(m, src)
+}
+func (m *StopResponse) XXX_Size() int {
+	return xxx_messageInfo_StopResponse.Size(m)
+}
+func (m *StopResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopResponse proto.InternalMessageInfo
+
+func (m *StopResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type StartRequest struct {
+	Auth                 *auth.BlimpAuth `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Service              string          `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *StartRequest) Reset()         { *m = StartRequest{} }
+func (m *StartRequest) String() string { return proto.CompactTextString(m) }
+func (*StartRequest) ProtoMessage()    {}
+func (*StartRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{22}
+}
+
+func (m *StartRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartRequest.Unmarshal(m, b)
+}
+func (m *StartRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartRequest.Marshal(b, m, deterministic)
+}
+func (m *StartRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartRequest.Merge(m, src)
+}
+func (m *StartRequest) XXX_Size() int {
+	return xxx_messageInfo_StartRequest.Size(m)
+}
+func (m *StartRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartRequest proto.InternalMessageInfo
+
+func (m *StartRequest) GetAuth() *auth.BlimpAuth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *StartRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+type StartResponse struct {
+	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *StartResponse) Reset()         { *m = StartResponse{} }
+func (m *StartResponse) String() string { return proto.CompactTextString(m) }
+func (*StartResponse) ProtoMessage()    {}
+func (*StartResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{23}
+}
+
+func (m *StartResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartResponse.Unmarshal(m, b)
+}
+func (m *StartResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartResponse.Marshal(b, m, deterministic)
+}
+func (m *StartResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartResponse.Merge(m, src)
+}
+func (m *StartResponse) XXX_Size() int {
+	return xxx_messageInfo_StartResponse.Size(m)
+}
+func (m *StartResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartResponse proto.InternalMessageInfo
+
+func (m *StartResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type RunOneOffRequest struct {
+	Auth    *auth.BlimpAuth `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Service string          `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
+	// If set, overrides the service's command.
+	Command []string `protobuf:"bytes,5,rep,name=command,proto3" json:"command,omitempty"`
+	Tty     bool     `protobuf:"varint,6,opt,name=tty,proto3" json:"tty,omitempty"`
+	// If true, the pod isn't deleted after the command exits.
+	Keep                 bool     `protobuf:"varint,7,opt,name=keep,proto3" json:"keep,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunOneOffRequest) Reset()         { *m = RunOneOffRequest{} }
+func (m *RunOneOffRequest) String() string { return proto.CompactTextString(m) }
+func (*RunOneOffRequest) ProtoMessage()    {}
+func (*RunOneOffRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{24}
+}
+
+func (m *RunOneOffRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunOneOffRequest.Unmarshal(m, b)
+}
+func (m *RunOneOffRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunOneOffRequest.Marshal(b, m, deterministic)
+}
+func (m *RunOneOffRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunOneOffRequest.Merge(m, src)
+}
+func (m *RunOneOffRequest) XXX_Size() int {
+	return xxx_messageInfo_RunOneOffRequest.Size(m)
+}
+func (m *RunOneOffRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunOneOffRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunOneOffRequest proto.InternalMessageInfo
+
+func (m *RunOneOffRequest) GetAuth() *auth.BlimpAuth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *RunOneOffRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *RunOneOffRequest) GetCommand() []string {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+func (m *RunOneOffRequest) GetTty() bool {
+	if m != nil {
+		return m.Tty
+	}
+	return false
+}
+
+func (m *RunOneOffRequest) GetKeep() bool {
+	if m != nil {
+		return m.Keep
+	}
+	return false
+}
+
+type RunOneOffResponse struct {
+	Error *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// Types that are valid to be assigned to Msg:
+	//	*RunOneOffResponse_Status
+	//	*RunOneOffResponse_Attach
+	//	*RunOneOffResponse_Exit
+	Msg                  isRunOneOffResponse_Msg `protobuf_oneof:"msg"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *RunOneOffResponse) Reset()         { *m = RunOneOffResponse{} }
+func (m *RunOneOffResponse) String() string { return proto.CompactTextString(m) }
+func (*RunOneOffResponse) ProtoMessage()    {}
+func (*RunOneOffResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{25}
+}
+
+func (m *RunOneOffResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunOneOffResponse.Unmarshal(m, b)
+}
+func (m *RunOneOffResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunOneOffResponse.Marshal(b, m, deterministic)
+}
+func (m *RunOneOffResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunOneOffResponse.Merge(m, src)
+}
+func (m *RunOneOffResponse) XXX_Size() int {
+	return xxx_messageInfo_RunOneOffResponse.Size(m)
+}
+func (m *RunOneOffResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunOneOffResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunOneOffResponse proto.InternalMessageInfo
+
+func (m *RunOneOffResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type isRunOneOffResponse_Msg interface {
+	isRunOneOffResponse_Msg()
+}
+
+type RunOneOffResponse_Status struct {
+	Status *ServiceStatus `protobuf:"bytes,2,opt,name=status,proto3,oneof"`
+}
+
+type RunOneOffResponse_Attach struct {
+	Attach *RunOneOffAttach `protobuf:"bytes,3,opt,name=attach,proto3,oneof"`
+}
+
+type RunOneOffResponse_Exit struct {
+	Exit *RunOneOffExit `protobuf:"bytes,4,opt,name=exit,proto3,oneof"`
+}
+
+func (*RunOneOffResponse_Status) isRunOneOffResponse_Msg() {}
+
+func (*RunOneOffResponse_Attach) isRunOneOffResponse_Msg() {}
+
+func (*RunOneOffResponse_Exit) isRunOneOffResponse_Msg() {}
+
+func (m *RunOneOffResponse) GetMsg() isRunOneOffResponse_Msg {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *RunOneOffResponse) GetStatus() *ServiceStatus {
+	if x, ok := m.GetMsg().(*RunOneOffResponse_Status); ok {
+		return x.Status
+	}
+	return nil
+}
+
+func (m *RunOneOffResponse) GetAttach() *RunOneOffAttach {
+	if x, ok := m.GetMsg().(*RunOneOffResponse_Attach); ok {
+		return x.Attach
+	}
+	return nil
+}
+
+func (m *RunOneOffResponse) GetExit() *RunOneOffExit {
+	if x, ok := m.GetMsg().(*RunOneOffResponse_Exit); ok {
+		return x.Exit
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*RunOneOffResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*RunOneOffResponse_Status)(nil),
+		(*RunOneOffResponse_Attach)(nil),
+		(*RunOneOffResponse_Exit)(nil),
+	}
+}
+
+type RunOneOffAttach struct {
+	PodName              string   `protobuf:"bytes,1,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	NodeAddress          string   `protobuf:"bytes,2,opt,name=node_address,json=nodeAddress,proto3" json:"node_address,omitempty"`
+	NodeCert             string   `protobuf:"bytes,3,opt,name=node_cert,json=nodeCert,proto3" json:"node_cert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunOneOffAttach) Reset()         { *m = RunOneOffAttach{} }
+func (m *RunOneOffAttach) String() string { return proto.CompactTextString(m) }
+func (*RunOneOffAttach) ProtoMessage()    {}
+func (*RunOneOffAttach) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{26}
+}
+
+func (m *RunOneOffAttach) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunOneOffAttach.Unmarshal(m, b)
+}
+func (m *RunOneOffAttach) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunOneOffAttach.Marshal(b, m, deterministic)
+}
+func (m *RunOneOffAttach) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunOneOffAttach.Merge(m, src)
+}
+func (m *RunOneOffAttach) XXX_Size() int {
+	return xxx_messageInfo_RunOneOffAttach.Size(m)
+}
+func (m *RunOneOffAttach) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunOneOffAttach.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunOneOffAttach proto.InternalMessageInfo
+
+func (m *RunOneOffAttach) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+func (m *RunOneOffAttach) GetNodeAddress() string {
+	if m != nil {
+		return m.NodeAddress
+	}
+	return ""
+}
+
+func (m *RunOneOffAttach) GetNodeCert() string {
+	if m != nil {
+		return m.NodeCert
+	}
+	return ""
+}
+
+type RunOneOffExit struct {
+	Code    int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	PodName string `protobuf:"bytes,2,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	// The beginning of the command's output, as recorded in the container's
+	// logs. The CLI prints the output that it didn't receive through the
+	// attach, such as when the command exits before the attach connects.
+	Output               []byte   `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunOneOffExit) Reset()         { *m = RunOneOffExit{} }
+func (m *RunOneOffExit) String() string { return proto.CompactTextString(m) }
+func (*RunOneOffExit) ProtoMessage()    {}
+func (*RunOneOffExit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{27}
+}
+
+func (m *RunOneOffExit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunOneOffExit.Unmarshal(m, b)
+}
+func (m *RunOneOffExit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunOneOffExit.Marshal(b, m, deterministic)
+}
+func (m *RunOneOffExit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunOneOffExit.Merge(m, src)
+}
+func (m *RunOneOffExit) XXX_Size() int {
+	return xxx_messageInfo_RunOneOffExit.Size(m)
+}
+func (m *RunOneOffExit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunOneOffExit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunOneOffExit proto.InternalMessageInfo
+
+func (m *RunOneOffExit) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *RunOneOffExit) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+func (m *RunOneOffExit) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+type TagImageRequest struct {
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Image                string   `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Tag                  string   `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TagImageRequest) Reset()         { *m = TagImageRequest{} }
+func (m *TagImageRequest) String() string { return proto.CompactTextString(m) }
+func (*TagImageRequest) ProtoMessage()    {}
+func (*TagImageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{28}
+}
+
+func (m *TagImageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TagImageRequest.Unmarshal(m, b)
+}
+func (m *TagImageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TagImageRequest.Marshal(b, m, deterministic)
+}
+func (m *TagImageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagImageRequest.Merge(m, src)
+}
+func (m *TagImageRequest) XXX_Size() int {
+	return xxx_messageInfo_TagImageRequest.Size(m)
+}
+func (m *TagImageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagImageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TagImageRequest proto.InternalMessageInfo
+
+func (m *TagImageRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *TagImageRequest) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *TagImageRequest) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+type TagImagesRequest struct {
+	OldToken             string                         `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
+	Auth                 *auth.BlimpAuth                `protobuf:"bytes,4,opt,name=auth,proto3" json:"auth,omitempty"`
+	TagRequests          []*TagImageRequest             `protobuf:"bytes,2,rep,name=tagRequests,proto3" json:"tagRequests,omitempty"`
+	RegistryCredentials  map[string]*RegistryCredential `protobuf:"bytes,3,rep,name=registryCredentials,proto3" json:"registryCredentials,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *TagImagesRequest) Reset()         { *m = TagImagesRequest{} }
+func (m *TagImagesRequest) String() string { return proto.CompactTextString(m) }
+func (*TagImagesRequest) ProtoMessage()    {}
+func (*TagImagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{29}
+}
+
+func (m *TagImagesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TagImagesRequest.Unmarshal(m, b)
+}
+func (m *TagImagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TagImagesRequest.Marshal(b, m, deterministic)
+}
+func (m *TagImagesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagImagesRequest.Merge(m, src)
+}
+func (m *TagImagesRequest) XXX_Size() int {
+	return xxx_messageInfo_TagImagesRequest.Size(m)
+}
+func (m *TagImagesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagImagesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TagImagesRequest proto.InternalMessageInfo
+
+func (m *TagImagesRequest) GetOldToken() string {
+	if m != nil {
+		return m.OldToken
+	}
+	return ""
+}
+
+func (m *TagImagesRequest) GetAuth() *auth.BlimpAuth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *TagImagesRequest) GetTagRequests() []*TagImageRequest {
+	if m != nil {
+		return m.TagRequests
+	}
+	return nil
+}
+
+func (m *TagImagesRequest) GetRegistryCredentials() map[string]*RegistryCredential {
+	if m != nil {
+		return m.RegistryCredentials
+	}
+	return nil
+}
+
+type TagImagesResponse struct {
+	Service              string        `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Error                *errors.Error `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *TagImagesResponse) Reset()         { *m = TagImagesResponse{} }
+func (m *TagImagesResponse) String() string { return proto.CompactTextString(m) }
+func (*TagImagesResponse) ProtoMessage()    {}
+func (*TagImagesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{30}
+}
+
+func (m *TagImagesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TagImagesResponse.Unmarshal(m, b)
+}
+func (m *TagImagesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TagImagesResponse.Marshal(b, m, deterministic)
+}
+func (m *TagImagesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TagImagesResponse.Merge(m, src)
+}
+func (m *TagImagesResponse) XXX_Size() int {
+	return xxx_messageInfo_TagImagesResponse.Size(m)
+}
+func (m *TagImagesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TagImagesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TagImagesResponse proto.InternalMessageInfo
+
+func (m *TagImagesResponse) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *TagImagesResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type ExposeRequest struct {
+	OldToken             string          `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
+	Auth                 *auth.BlimpAuth `protobuf:"bytes,4,opt,name=auth,proto3" json:"auth,omitempty"`
+	Service              string          `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Port                 uint32          `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Protocol             ExposeProtocol  `protobuf:"varint,5,opt,name=protocol,proto3,enum=blimp.cluster.v0.ExposeProtocol" json:"protocol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ExposeRequest) Reset()         { *m = ExposeRequest{} }
+func (m *ExposeRequest) String() string { return proto.CompactTextString(m) }
+func (*ExposeRequest) ProtoMessage()    {}
+func (*ExposeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{31}
+}
+
+func (m *ExposeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExposeRequest.Unmarshal(m, b)
+}
+func (m *ExposeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExposeRequest.Marshal(b, m, deterministic)
+}
+func (m *ExposeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExposeRequest.Merge(m, src)
+}
+func (m *ExposeRequest) XXX_Size() int {
+	return xxx_messageInfo_ExposeRequest.Size(m)
+}
+func (m *ExposeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExposeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExposeRequest proto.InternalMessageInfo
+
+func (m *ExposeRequest) GetOldToken() string {
+	if m != nil {
+		return m.OldToken
+	}
+	return ""
+}
+
+func (m *ExposeRequest) GetAuth() *auth.BlimpAuth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *ExposeRequest) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *ExposeRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *ExposeRequest) GetProtocol() ExposeProtocol {
+	if m != nil {
+		return m.Protocol
+	}
+	return ExposeProtocol_HTTP
+}
+
+type ExposeResponse struct {
+	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Link                 string        `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ExposeResponse) Reset()         { *m = ExposeResponse{} }
+func (m *ExposeResponse) String() string { return proto.CompactTextString(m) }
+func (*ExposeResponse) ProtoMessage()    {}
+func (*ExposeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{32}
+}
+
+func (m *ExposeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExposeResponse.Unmarshal(m, b)
+}
+func (m *ExposeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExposeResponse.Marshal(b, m, deterministic)
+}
+func (m *ExposeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExposeResponse.Merge(m, src)
+}
+func (m *ExposeResponse) XXX_Size() int {
+	return xxx_messageInfo_ExposeResponse.Size(m)
+}
+func (m *ExposeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExposeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExposeResponse proto.InternalMessageInfo
+
+func (m *ExposeResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ExposeResponse) GetLink() string {
+	if m != nil {
+		return m.Link
+	}
+	return ""
+}
+
+type UnexposeRequest struct {
+	OldToken             string          `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
+	Auth                 *auth.BlimpAuth `protobuf:"bytes,2,opt,name=auth,proto3" json:"auth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *UnexposeRequest) Reset()         { *m = UnexposeRequest{} }
+func (m *UnexposeRequest) String() string { return proto.CompactTextString(m) }
+func (*UnexposeRequest) ProtoMessage()    {}
+func (*UnexposeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{33}
+}
+
+func (m *UnexposeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnexposeRequest.Unmarshal(m, b)
+}
+func (m *UnexposeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnexposeRequest.Marshal(b, m, deterministic)
+}
+func (m *UnexposeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnexposeRequest.Merge(m, src)
+}
+func (m *UnexposeRequest) XXX_Size() int {
+	return xxx_messageInfo_UnexposeRequest.Size(m)
+}
+func (m *UnexposeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnexposeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnexposeRequest proto.InternalMessageInfo
+
+func (m *UnexposeRequest) GetOldToken() string {
+	if m != nil {
+		return m.OldToken
+	}
+	return ""
+}
+
+func (m *UnexposeRequest) GetAuth() *auth.BlimpAuth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+type UnexposeResponse struct {
+	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *UnexposeResponse) Reset()         { *m = UnexposeResponse{} }
+func (m *UnexposeResponse) String() string { return proto.CompactTextString(m) }
+func (*UnexposeResponse) ProtoMessage()    {}
+func (*UnexposeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{34}
+}
+
+func (m *UnexposeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnexposeResponse.Unmarshal(m, b)
+}
+func (m *UnexposeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnexposeResponse.Marshal(b, m, deterministic)
+}
+func (m *UnexposeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnexposeResponse.Merge(m, src)
+}
+func (m *UnexposeResponse) XXX_Size() int {
+	return xxx_messageInfo_UnexposeResponse.Size(m)
+}
+func (m *UnexposeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnexposeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnexposeResponse proto.InternalMessageInfo
+
+func (m *UnexposeResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type ResolveExposedLinkRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResolveExposedLinkRequest) Reset()         { *m = ResolveExposedLinkRequest{} }
+func (m *ResolveExposedLinkRequest) String() string { return proto.CompactTextString(m) }
+func (*ResolveExposedLinkRequest) ProtoMessage()    {}
+func (*ResolveExposedLinkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{35}
+}
+
+func (m *ResolveExposedLinkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveExposedLinkRequest.Unmarshal(m, b)
+}
+func (m *ResolveExposedLinkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveExposedLinkRequest.Marshal(b, m, deterministic)
+}
+func (m *ResolveExposedLinkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveExposedLinkRequest.Merge(m, src)
+}
+func (m *ResolveExposedLinkRequest) XXX_Size() int {
+	return xxx_messageInfo_ResolveExposedLinkRequest.Size(m)
+}
+func (m *ResolveExposedLinkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveExposedLinkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveExposedLinkRequest proto.InternalMessageInfo
+
+func (m *ResolveExposedLinkRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ResolveExposedLinkRequest) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
 	return ""
 }
 
-func (m *TagImageRequest) GetImage() string {
+type ResolveExposedLinkResponse struct {
+	Error                *errors.Error  `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	NodeAddress          string         `protobuf:"bytes,2,opt,name=NodeAddress,proto3" json:"NodeAddress,omitempty"`
+	NodeCert             string         `protobuf:"bytes,3,opt,name=NodeCert,proto3" json:"NodeCert,omitempty"`
+	Service              string         `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"`
+	Port                 uint32         `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"`
+	Protocol             ExposeProtocol `protobuf:"varint,6,opt,name=protocol,proto3,enum=blimp.cluster.v0.ExposeProtocol" json:"protocol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ResolveExposedLinkResponse) Reset()         { *m = ResolveExposedLinkResponse{} }
+func (m *ResolveExposedLinkResponse) String() string { return proto.CompactTextString(m) }
+func (*ResolveExposedLinkResponse) ProtoMessage()    {}
+func (*ResolveExposedLinkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{36}
+}
+
+func (m *ResolveExposedLinkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveExposedLinkResponse.Unmarshal(m, b)
+}
+func (m *ResolveExposedLinkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveExposedLinkResponse.Marshal(b, m, deterministic)
+}
+func (m *ResolveExposedLinkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveExposedLinkResponse.Merge(m, src)
+}
+func (m *ResolveExposedLinkResponse) XXX_Size() int {
+	return xxx_messageInfo_ResolveExposedLinkResponse.Size(m)
+}
+func (m *ResolveExposedLinkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveExposedLinkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResolveExposedLinkResponse proto.InternalMessageInfo
+
+func (m *ResolveExposedLinkResponse) GetError() *errors.Error {
 	if m != nil {
-		return m.Image
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ResolveExposedLinkResponse) GetNodeAddress() string {
+	if m != nil {
+		return m.NodeAddress
 	}
 	return ""
 }
 
-func (m *TagImageRequest) GetTag() string {
+func (m *ResolveExposedLinkResponse) GetNodeCert() string {
 	if m != nil {
-		return m.Tag
+		return m.NodeCert
 	}
 	return ""
 }
 
-type TagImagesRequest struct {
-	OldToken             string                         `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
-	Auth                 *auth.BlimpAuth                `protobuf:"bytes,4,opt,name=auth,proto3" json:"auth,omitempty"`
-	TagRequests          []*TagImageRequest             `protobuf:"bytes,2,rep,name=tagRequests,proto3" json:"tagRequests,omitempty"`
-	RegistryCredentials  map[string]*RegistryCredential `protobuf:"bytes,3,rep,name=registryCredentials,proto3" json:"registryCredentials,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
-	XXX_unrecognized     []byte                         `json:"-"`
-	XXX_sizecache        int32                          `json:"-"`
+func (m *ResolveExposedLinkResponse) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
 }
 
-func (m *TagImagesRequest) Reset()         { *m = TagImagesRequest{} }
-func (m *TagImagesRequest) String() string { return proto.CompactTextString(m) }
-func (*TagImagesRequest) ProtoMessage()    {}
-func (*TagImagesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{19}
+func (m *ResolveExposedLinkResponse) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
 }
 
-func (m *TagImagesRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TagImagesRequest.Unmarshal(m, b)
+func (m *ResolveExposedLinkResponse) GetProtocol() ExposeProtocol {
+	if m != nil {
+		return m.Protocol
+	}
+	return ExposeProtocol_HTTP
 }
-func (m *TagImagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TagImagesRequest.Marshal(b, m, deterministic)
+
+type VolumeRef struct {
+	// For named volumes, name is the name of the volume in the Compose file.
+	// For bind volumes, it's the path on the user's machine.
+	Name                 string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 VolumeType `protobuf:"varint,2,opt,name=type,proto3,enum=blimp.cluster.v0.VolumeType" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
-func (m *TagImagesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TagImagesRequest.Merge(m, src)
+
+func (m *VolumeRef) Reset()         { *m = VolumeRef{} }
+func (m *VolumeRef) String() string { return proto.CompactTextString(m) }
+func (*VolumeRef) ProtoMessage()    {}
+func (*VolumeRef) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{37}
 }
-func (m *TagImagesRequest) XXX_Size() int {
-	return xxx_messageInfo_TagImagesRequest.Size(m)
+
+func (m *VolumeRef) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeRef.Unmarshal(m, b)
 }
-func (m *TagImagesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TagImagesRequest.DiscardUnknown(m)
+func (m *VolumeRef) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeRef.Marshal(b, m, deterministic)
+}
+func (m *VolumeRef) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeRef.Merge(m, src)
+}
+func (m *VolumeRef) XXX_Size() int {
+	return xxx_messageInfo_VolumeRef.Size(m)
+}
+func (m *VolumeRef) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeRef.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TagImagesRequest proto.InternalMessageInfo
+var xxx_messageInfo_VolumeRef proto.InternalMessageInfo
 
-func (m *TagImagesRequest) GetOldToken() string {
+func (m *VolumeRef) GetName() string {
 	if m != nil {
-		return m.OldToken
+		return m.Name
 	}
 	return ""
 }
 
-func (m *TagImagesRequest) GetAuth() *auth.BlimpAuth {
+func (m *VolumeRef) GetType() VolumeType {
+	if m != nil {
+		return m.Type
+	}
+	return VolumeType_NAMED_VOLUME
+}
+
+type VolumeStatus struct {
+	Volume *VolumeRef `protobuf:"bytes,1,opt,name=volume,proto3" json:"volume,omitempty"`
+	// path is the location of the volume within the sandbox's persistent
+	// volume.
+	Path      string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Exists    bool   `protobuf:"varint,3,opt,name=exists,proto3" json:"exists,omitempty"`
+	SizeBytes int64  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// mounted_by is the list of services that currently mount the volume.
+	MountedBy            []string `protobuf:"bytes,5,rep,name=mounted_by,json=mountedBy,proto3" json:"mounted_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VolumeStatus) Reset()         { *m = VolumeStatus{} }
+func (m *VolumeStatus) String() string { return proto.CompactTextString(m) }
+func (*VolumeStatus) ProtoMessage()    {}
+func (*VolumeStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{38}
+}
+
+func (m *VolumeStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VolumeStatus.Unmarshal(m, b)
+}
+func (m *VolumeStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VolumeStatus.Marshal(b, m, deterministic)
+}
+func (m *VolumeStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VolumeStatus.Merge(m, src)
+}
+func (m *VolumeStatus) XXX_Size() int {
+	return xxx_messageInfo_VolumeStatus.Size(m)
+}
+func (m *VolumeStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_VolumeStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VolumeStatus proto.InternalMessageInfo
+
+func (m *VolumeStatus) GetVolume() *VolumeRef {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+func (m *VolumeStatus) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *VolumeStatus) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+func (m *VolumeStatus) GetSizeBytes() int64 {
+	if m != nil {
+		return m.SizeBytes
+	}
+	return 0
+}
+
+func (m *VolumeStatus) GetMountedBy() []string {
+	if m != nil {
+		return m.MountedBy
+	}
+	return nil
+}
+
+type ListVolumesRequest struct {
+	Auth    *auth.BlimpAuth `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Volumes []*VolumeRef    `protobuf:"bytes,2,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	// include_size controls whether the disk usage of each volume is
+	// calculated. Calculating usage may be slow for large volumes.
+	IncludeSize          bool     `protobuf:"varint,3,opt,name=include_size,json=includeSize,proto3" json:"include_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListVolumesRequest) Reset()         { *m = ListVolumesRequest{} }
+func (m *ListVolumesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListVolumesRequest) ProtoMessage()    {}
+func (*ListVolumesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{39}
+}
+
+func (m *ListVolumesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumesRequest.Unmarshal(m, b)
+}
+func (m *ListVolumesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListVolumesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumesRequest.Merge(m, src)
+}
+func (m *ListVolumesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListVolumesRequest.Size(m)
+}
+func (m *ListVolumesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumesRequest proto.InternalMessageInfo
+
+func (m *ListVolumesRequest) GetAuth() *auth.BlimpAuth {
 	if m != nil {
 		return m.Auth
 	}
 	return nil
 }
 
-func (m *TagImagesRequest) GetTagRequests() []*TagImageRequest {
+func (m *ListVolumesRequest) GetVolumes() []*VolumeRef {
 	if m != nil {
-		return m.TagRequests
+		return m.Volumes
 	}
 	return nil
 }
 
-func (m *TagImagesRequest) GetRegistryCredentials() map[string]*RegistryCredential {
+func (m *ListVolumesRequest) GetIncludeSize() bool {
 	if m != nil {
-		return m.RegistryCredentials
+		return m.IncludeSize
+	}
+	return false
+}
+
+type ListVolumesResponse struct {
+	Error   *errors.Error   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Volumes []*VolumeStatus `protobuf:"bytes,2,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	// The total usage and capacity of the sandbox's persistent volume. Only set
+	// if include_size was set in the request.
+	UsedBytes            int64    `protobuf:"varint,3,opt,name=used_bytes,json=usedBytes,proto3" json:"used_bytes,omitempty"`
+	CapacityBytes        int64    `protobuf:"varint,4,opt,name=capacity_bytes,json=capacityBytes,proto3" json:"capacity_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListVolumesResponse) Reset()         { *m = ListVolumesResponse{} }
+func (m *ListVolumesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListVolumesResponse) ProtoMessage()    {}
+func (*ListVolumesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{40}
+}
+
+func (m *ListVolumesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListVolumesResponse.Unmarshal(m, b)
+}
+func (m *ListVolumesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListVolumesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListVolumesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListVolumesResponse.Merge(m, src)
+}
+func (m *ListVolumesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListVolumesResponse.Size(m)
+}
+func (m *ListVolumesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListVolumesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListVolumesResponse proto.InternalMessageInfo
+
+func (m *ListVolumesResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
 	}
 	return nil
 }
 
-type TagImagesResponse struct {
-	Service              string        `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
-	Error                *errors.Error `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+func (m *ListVolumesResponse) GetVolumes() []*VolumeStatus {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+func (m *ListVolumesResponse) GetUsedBytes() int64 {
+	if m != nil {
+		return m.UsedBytes
+	}
+	return 0
+}
+
+func (m *ListVolumesResponse) GetCapacityBytes() int64 {
+	if m != nil {
+		return m.CapacityBytes
+	}
+	return 0
+}
+
+type RemoveVolumeRequest struct {
+	Auth                 *auth.BlimpAuth `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Volume               *VolumeRef      `protobuf:"bytes,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RemoveVolumeRequest) Reset()         { *m = RemoveVolumeRequest{} }
+func (m *RemoveVolumeRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveVolumeRequest) ProtoMessage()    {}
+func (*RemoveVolumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{41}
+}
+
+func (m *RemoveVolumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveVolumeRequest.Unmarshal(m, b)
+}
+func (m *RemoveVolumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveVolumeRequest.Marshal(b, m, deterministic)
+}
+func (m *RemoveVolumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveVolumeRequest.Merge(m, src)
+}
+func (m *RemoveVolumeRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveVolumeRequest.Size(m)
+}
+func (m *RemoveVolumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveVolumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoveVolumeRequest proto.InternalMessageInfo
+
+func (m *RemoveVolumeRequest) GetAuth() *auth.BlimpAuth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *RemoveVolumeRequest) GetVolume() *VolumeRef {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+type RemoveVolumeResponse struct {
+	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *TagImagesResponse) Reset()         { *m = TagImagesResponse{} }
-func (m *TagImagesResponse) String() string { return proto.CompactTextString(m) }
-func (*TagImagesResponse) ProtoMessage()    {}
-func (*TagImagesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{20}
+func (m *RemoveVolumeResponse) Reset()         { *m = RemoveVolumeResponse{} }
+func (m *RemoveVolumeResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveVolumeResponse) ProtoMessage()    {}
+func (*RemoveVolumeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{42}
 }
 
-func (m *TagImagesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TagImagesResponse.Unmarshal(m, b)
+func (m *RemoveVolumeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveVolumeResponse.Unmarshal(m, b)
 }
-func (m *TagImagesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TagImagesResponse.Marshal(b, m, deterministic)
+func (m *RemoveVolumeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveVolumeResponse.Marshal(b, m, deterministic)
 }
-func (m *TagImagesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TagImagesResponse.Merge(m, src)
+func (m *RemoveVolumeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveVolumeResponse.Merge(m, src)
 }
-func (m *TagImagesResponse) XXX_Size() int {
-	return xxx_messageInfo_TagImagesResponse.Size(m)
+func (m *RemoveVolumeResponse) XXX_Size() int {
+	return xxx_messageInfo_RemoveVolumeResponse.Size(m)
 }
-func (m *TagImagesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_TagImagesResponse.DiscardUnknown(m)
+func (m *RemoveVolumeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveVolumeResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TagImagesResponse proto.InternalMessageInfo
+var xxx_messageInfo_RemoveVolumeResponse proto.InternalMessageInfo
 
-func (m *TagImagesResponse) GetService() string {
+func (m *RemoveVolumeResponse) GetError() *errors.Error {
 	if m != nil {
-		return m.Service
+		return m.Error
 	}
-	return ""
+	return nil
 }
 
-func (m *TagImagesResponse) GetError() *errors.Error {
+type StartVolumeTransferRequest struct {
+	Auth                 *auth.BlimpAuth                      `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Volume               *VolumeRef                           `protobuf:"bytes,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	Direction            StartVolumeTransferRequest_Direction `protobuf:"varint,3,opt,name=direction,proto3,enum=blimp.cluster.v0.StartVolumeTransferRequest_Direction" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *StartVolumeTransferRequest) Reset()         { *m = StartVolumeTransferRequest{} }
+func (m *StartVolumeTransferRequest) String() string { return proto.CompactTextString(m) }
+func (*StartVolumeTransferRequest) ProtoMessage()    {}
+func (*StartVolumeTransferRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{43}
+}
+
+func (m *StartVolumeTransferRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartVolumeTransferRequest.Unmarshal(m, b)
+}
+func (m *StartVolumeTransferRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartVolumeTransferRequest.Marshal(b, m, deterministic)
+}
+func (m *StartVolumeTransferRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartVolumeTransferRequest.Merge(m, src)
+}
+func (m *StartVolumeTransferRequest) XXX_Size() int {
+	return xxx_messageInfo_StartVolumeTransferRequest.Size(m)
+}
+func (m *StartVolumeTransferRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartVolumeTransferRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartVolumeTransferRequest proto.InternalMessageInfo
+
+func (m *StartVolumeTransferRequest) GetAuth() *auth.BlimpAuth {
 	if m != nil {
-		return m.Error
+		return m.Auth
 	}
 	return nil
 }
 
-type ExposeRequest struct {
-	OldToken             string          `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
-	Auth                 *auth.BlimpAuth `protobuf:"bytes,4,opt,name=auth,proto3" json:"auth,omitempty"`
-	Service              string          `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
-	Port                 uint32          `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *StartVolumeTransferRequest) GetVolume() *VolumeRef {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
 }
 
-func (m *ExposeRequest) Reset()         { *m = ExposeRequest{} }
-func (m *ExposeRequest) String() string { return proto.CompactTextString(m) }
-func (*ExposeRequest) ProtoMessage()    {}
-func (*ExposeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{21}
+func (m *StartVolumeTransferRequest) GetDirection() StartVolumeTransferRequest_Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return StartVolumeTransferRequest_EXPORT
+}
+
+type StartVolumeTransferResponse struct {
+	Error       *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	NodeAddress string        `protobuf:"bytes,2,opt,name=NodeAddress,proto3" json:"NodeAddress,omitempty"`
+	NodeCert    string        `protobuf:"bytes,3,opt,name=NodeCert,proto3" json:"NodeCert,omitempty"`
+	// PodName is the name of the pod serving the transfer. Each transfer gets
+	// its own pod so that concurrent transfers don't interrupt each other.
+	PodName              string   `protobuf:"bytes,4,opt,name=PodName,proto3" json:"PodName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ExposeRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ExposeRequest.Unmarshal(m, b)
+func (m *StartVolumeTransferResponse) Reset()         { *m = StartVolumeTransferResponse{} }
+func (m *StartVolumeTransferResponse) String() string { return proto.CompactTextString(m) }
+func (*StartVolumeTransferResponse) ProtoMessage()    {}
+func (*StartVolumeTransferResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{44}
 }
-func (m *ExposeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ExposeRequest.Marshal(b, m, deterministic)
+
+func (m *StartVolumeTransferResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartVolumeTransferResponse.Unmarshal(m, b)
 }
-func (m *ExposeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExposeRequest.Merge(m, src)
+func (m *StartVolumeTransferResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartVolumeTransferResponse.Marshal(b, m, deterministic)
 }
-func (m *ExposeRequest) XXX_Size() int {
-	return xxx_messageInfo_ExposeRequest.Size(m)
+func (m *StartVolumeTransferResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartVolumeTransferResponse.Merge(m, src)
 }
-func (m *ExposeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExposeRequest.DiscardUnknown(m)
+func (m *StartVolumeTransferResponse) XXX_Size() int {
+	return xxx_messageInfo_StartVolumeTransferResponse.Size(m)
+}
+func (m *StartVolumeTransferResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartVolumeTransferResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ExposeRequest proto.InternalMessageInfo
+var xxx_messageInfo_StartVolumeTransferResponse proto.InternalMessageInfo
 
-func (m *ExposeRequest) GetOldToken() string {
+func (m *StartVolumeTransferResponse) GetError() *errors.Error {
 	if m != nil {
-		return m.OldToken
+		return m.Error
 	}
-	return ""
+	return nil
 }
 
-func (m *ExposeRequest) GetAuth() *auth.BlimpAuth {
+func (m *StartVolumeTransferResponse) GetNodeAddress() string {
 	if m != nil {
-		return m.Auth
+		return m.NodeAddress
 	}
-	return nil
+	return ""
 }
 
-func (m *ExposeRequest) GetService() string {
+func (m *StartVolumeTransferResponse) GetNodeCert() string {
 	if m != nil {
-		return m.Service
+		return m.NodeCert
 	}
 	return ""
 }
 
-func (m *ExposeRequest) GetPort() uint32 {
+func (m *StartVolumeTransferResponse) GetPodName() string {
 	if m != nil {
-		return m.Port
+		return m.PodName
 	}
-	return 0
+	return ""
 }
 
-type ExposeResponse struct {
-	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	Link                 string        `protobuf:"bytes,2,opt,name=link,proto3" json:"link,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+type GetStatsRequest struct {
+	Auth                 *auth.BlimpAuth `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *ExposeResponse) Reset()         { *m = ExposeResponse{} }
-func (m *ExposeResponse) String() string { return proto.CompactTextString(m) }
-func (*ExposeResponse) ProtoMessage()    {}
-func (*ExposeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{22}
+func (m *GetStatsRequest) Reset()         { *m = GetStatsRequest{} }
+func (m *GetStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStatsRequest) ProtoMessage()    {}
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{45}
 }
 
-func (m *ExposeResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ExposeResponse.Unmarshal(m, b)
+func (m *GetStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatsRequest.Unmarshal(m, b)
 }
-func (m *ExposeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ExposeResponse.Marshal(b, m, deterministic)
+func (m *GetStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatsRequest.Marshal(b, m, deterministic)
 }
-func (m *ExposeResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExposeResponse.Merge(m, src)
+func (m *GetStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatsRequest.Merge(m, src)
 }
-func (m *ExposeResponse) XXX_Size() int {
-	return xxx_messageInfo_ExposeResponse.Size(m)
+func (m *GetStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStatsRequest.Size(m)
 }
-func (m *ExposeResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExposeResponse.DiscardUnknown(m)
+func (m *GetStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ExposeResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetStatsRequest proto.InternalMessageInfo
 
-func (m *ExposeResponse) GetError() *errors.Error {
+func (m *GetStatsRequest) GetAuth() *auth.BlimpAuth {
 	if m != nil {
-		return m.Error
+		return m.Auth
 	}
 	return nil
 }
 
-func (m *ExposeResponse) GetLink() string {
-	if m != nil {
-		return m.Link
-	}
-	return ""
-}
-
-type UnexposeRequest struct {
-	OldToken             string          `protobuf:"bytes,1,opt,name=old_token,json=oldToken,proto3" json:"old_token,omitempty"`
-	Auth                 *auth.BlimpAuth `protobuf:"bytes,2,opt,name=auth,proto3" json:"auth,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+type GetStatsResponse struct {
+	Error                *errors.Error            `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Services             map[string]*ServiceStats `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
-func (m *UnexposeRequest) Reset()         { *m = UnexposeRequest{} }
-func (m *UnexposeRequest) String() string { return proto.CompactTextString(m) }
-func (*UnexposeRequest) ProtoMessage()    {}
-func (*UnexposeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{23}
+func (m *GetStatsResponse) Reset()         { *m = GetStatsResponse{} }
+func (m *GetStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStatsResponse) ProtoMessage()    {}
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{46}
 }
 
-func (m *UnexposeRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UnexposeRequest.Unmarshal(m, b)
+func (m *GetStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStatsResponse.Unmarshal(m, b)
 }
-func (m *UnexposeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UnexposeRequest.Marshal(b, m, deterministic)
+func (m *GetStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStatsResponse.Marshal(b, m, deterministic)
 }
-func (m *UnexposeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UnexposeRequest.Merge(m, src)
+func (m *GetStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStatsResponse.Merge(m, src)
 }
-func (m *UnexposeRequest) XXX_Size() int {
-	return xxx_messageInfo_UnexposeRequest.Size(m)
+func (m *GetStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStatsResponse.Size(m)
 }
-func (m *UnexposeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UnexposeRequest.DiscardUnknown(m)
+func (m *GetStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStatsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UnexposeRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetStatsResponse proto.InternalMessageInfo
 
-func (m *UnexposeRequest) GetOldToken() string {
+func (m *GetStatsResponse) GetError() *errors.Error {
 	if m != nil {
-		return m.OldToken
+		return m.Error
 	}
-	return ""
+	return nil
 }
 
-func (m *UnexposeRequest) GetAuth() *auth.BlimpAuth {
+func (m *GetStatsResponse) GetServices() map[string]*ServiceStats {
 	if m != nil {
-		return m.Auth
+		return m.Services
 	}
 	return nil
 }
 
-type UnexposeResponse struct {
-	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+type ServiceStats struct {
+	// The CPU usage in nanocores, averaged over the kubelet's sample period.
+	CpuNanoCores          uint64 `protobuf:"varint,1,opt,name=cpu_nano_cores,json=cpuNanoCores,proto3" json:"cpu_nano_cores,omitempty"`
+	MemoryWorkingSetBytes uint64 `protobuf:"varint,2,opt,name=memory_working_set_bytes,json=memoryWorkingSetBytes,proto3" json:"memory_working_set_bytes,omitempty"`
+	MemoryLimitBytes      uint64 `protobuf:"varint,3,opt,name=memory_limit_bytes,json=memoryLimitBytes,proto3" json:"memory_limit_bytes,omitempty"`
+	// The total bytes received and sent since the service started.
+	NetworkRxBytes       uint64   `protobuf:"varint,4,opt,name=network_rx_bytes,json=networkRxBytes,proto3" json:"network_rx_bytes,omitempty"`
+	NetworkTxBytes       uint64   `protobuf:"varint,5,opt,name=network_tx_bytes,json=networkTxBytes,proto3" json:"network_tx_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UnexposeResponse) Reset()         { *m = UnexposeResponse{} }
-func (m *UnexposeResponse) String() string { return proto.CompactTextString(m) }
-func (*UnexposeResponse) ProtoMessage()    {}
-func (*UnexposeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{24}
+func (m *ServiceStats) Reset()         { *m = ServiceStats{} }
+func (m *ServiceStats) String() string { return proto.CompactTextString(m) }
+func (*ServiceStats) ProtoMessage()    {}
+func (*ServiceStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{47}
 }
 
-func (m *UnexposeResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UnexposeResponse.Unmarshal(m, b)
+func (m *ServiceStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceStats.Unmarshal(m, b)
 }
-func (m *UnexposeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UnexposeResponse.Marshal(b, m, deterministic)
+func (m *ServiceStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceStats.Marshal(b, m, deterministic)
 }
-func (m *UnexposeResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UnexposeResponse.Merge(m, src)
+func (m *ServiceStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceStats.Merge(m, src)
 }
-func (m *UnexposeResponse) XXX_Size() int {
-	return xxx_messageInfo_UnexposeResponse.Size(m)
+func (m *ServiceStats) XXX_Size() int {
+	return xxx_messageInfo_ServiceStats.Size(m)
 }
-func (m *UnexposeResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UnexposeResponse.DiscardUnknown(m)
+func (m *ServiceStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceStats.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UnexposeResponse proto.InternalMessageInfo
+var xxx_messageInfo_ServiceStats proto.InternalMessageInfo
 
-func (m *UnexposeResponse) GetError() *errors.Error {
+func (m *ServiceStats) GetCpuNanoCores() uint64 {
 	if m != nil {
-		return m.Error
+		return m.CpuNanoCores
 	}
-	return nil
+	return 0
+}
+
+func (m *ServiceStats) GetMemoryWorkingSetBytes() uint64 {
+	if m != nil {
+		return m.MemoryWorkingSetBytes
+	}
+	return 0
+}
+
+func (m *ServiceStats) GetMemoryLimitBytes() uint64 {
+	if m != nil {
+		return m.MemoryLimitBytes
+	}
+	return 0
+}
+
+func (m *ServiceStats) GetNetworkRxBytes() uint64 {
+	if m != nil {
+		return m.NetworkRxBytes
+	}
+	return 0
+}
+
+func (m *ServiceStats) GetNetworkTxBytes() uint64 {
+	if m != nil {
+		return m.NetworkTxBytes
+	}
+	return 0
 }
 
 type GetImageNamespaceRequest struct {
@@ -1470,7 +3068,7 @@ func (m *GetImageNamespaceRequest) Reset()         { *m = GetImageNamespaceReque
 func (m *GetImageNamespaceRequest) String() string { return proto.CompactTextString(m) }
 func (*GetImageNamespaceRequest) ProtoMessage()    {}
 func (*GetImageNamespaceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{25}
+	return fileDescriptor_d156d5389f4d1cd6, []int{48}
 }
 
 func (m *GetImageNamespaceRequest) XXX_Unmarshal(b []byte) error {
@@ -1517,7 +3115,7 @@ func (m *GetImageNamespaceResponse) Reset()         { *m = GetImageNamespaceResp
 func (m *GetImageNamespaceResponse) String() string { return proto.CompactTextString(m) }
 func (*GetImageNamespaceResponse) ProtoMessage()    {}
 func (*GetImageNamespaceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{26}
+	return fileDescriptor_d156d5389f4d1cd6, []int{49}
 }
 
 func (m *GetImageNamespaceResponse) XXX_Unmarshal(b []byte) error {
@@ -1564,7 +3162,7 @@ func (m *GetBuildkitRequest) Reset()         { *m = GetBuildkitRequest{} }
 func (m *GetBuildkitRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBuildkitRequest) ProtoMessage()    {}
 func (*GetBuildkitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{27}
+	return fileDescriptor_d156d5389f4d1cd6, []int{50}
 }
 
 func (m *GetBuildkitRequest) XXX_Unmarshal(b []byte) error {
@@ -1612,7 +3210,7 @@ func (m *GetBuildkitResponse) Reset()         { *m = GetBuildkitResponse{} }
 func (m *GetBuildkitResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBuildkitResponse) ProtoMessage()    {}
 func (*GetBuildkitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{28}
+	return fileDescriptor_d156d5389f4d1cd6, []int{51}
 }
 
 func (m *GetBuildkitResponse) XXX_Unmarshal(b []byte) error {
@@ -1668,114 +3266,298 @@ func (m *BlimpUpPreviewRequest) Reset()         { *m = BlimpUpPreviewRequest{} }
 func (m *BlimpUpPreviewRequest) String() string { return proto.CompactTextString(m) }
 func (*BlimpUpPreviewRequest) ProtoMessage()    {}
 func (*BlimpUpPreviewRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{29}
+	return fileDescriptor_d156d5389f4d1cd6, []int{52}
+}
+
+func (m *BlimpUpPreviewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlimpUpPreviewRequest.Unmarshal(m, b)
+}
+func (m *BlimpUpPreviewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlimpUpPreviewRequest.Marshal(b, m, deterministic)
+}
+func (m *BlimpUpPreviewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlimpUpPreviewRequest.Merge(m, src)
+}
+func (m *BlimpUpPreviewRequest) XXX_Size() int {
+	return xxx_messageInfo_BlimpUpPreviewRequest.Size(m)
+}
+func (m *BlimpUpPreviewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlimpUpPreviewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlimpUpPreviewRequest proto.InternalMessageInfo
+
+func (m *BlimpUpPreviewRequest) GetAuth() *auth.BlimpAuth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *BlimpUpPreviewRequest) GetRepo() string {
+	if m != nil {
+		return m.Repo
+	}
+	return ""
+}
+
+func (m *BlimpUpPreviewRequest) GetComposeFiles() []string {
+	if m != nil {
+		return m.ComposeFiles
+	}
+	return nil
+}
+
+func (m *BlimpUpPreviewRequest) GetEnv() map[string]string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+type BlimpUpPreviewResponse struct {
+	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	StartedCli           bool          `protobuf:"varint,2,opt,name=started_cli,json=startedCli,proto3" json:"started_cli,omitempty"`
+	Output               []byte        `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BlimpUpPreviewResponse) Reset()         { *m = BlimpUpPreviewResponse{} }
+func (m *BlimpUpPreviewResponse) String() string { return proto.CompactTextString(m) }
+func (*BlimpUpPreviewResponse) ProtoMessage()    {}
+func (*BlimpUpPreviewResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{53}
+}
+
+func (m *BlimpUpPreviewResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlimpUpPreviewResponse.Unmarshal(m, b)
+}
+func (m *BlimpUpPreviewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlimpUpPreviewResponse.Marshal(b, m, deterministic)
+}
+func (m *BlimpUpPreviewResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlimpUpPreviewResponse.Merge(m, src)
+}
+func (m *BlimpUpPreviewResponse) XXX_Size() int {
+	return xxx_messageInfo_BlimpUpPreviewResponse.Size(m)
+}
+func (m *BlimpUpPreviewResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlimpUpPreviewResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlimpUpPreviewResponse proto.InternalMessageInfo
+
+func (m *BlimpUpPreviewResponse) GetError() *errors.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *BlimpUpPreviewResponse) GetStartedCli() bool {
+	if m != nil {
+		return m.StartedCli
+	}
+	return false
+}
+
+func (m *BlimpUpPreviewResponse) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+type WatchEventsRequest struct {
+	Auth *auth.BlimpAuth `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	// The Unix time of the oldest event to send. Events from before the
+	// request are only available for about an hour.
+	Since                int64    `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchEventsRequest) Reset()         { *m = WatchEventsRequest{} }
+func (m *WatchEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchEventsRequest) ProtoMessage()    {}
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{54}
 }
 
-func (m *BlimpUpPreviewRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BlimpUpPreviewRequest.Unmarshal(m, b)
+func (m *WatchEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEventsRequest.Unmarshal(m, b)
 }
-func (m *BlimpUpPreviewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BlimpUpPreviewRequest.Marshal(b, m, deterministic)
+func (m *WatchEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEventsRequest.Marshal(b, m, deterministic)
 }
-func (m *BlimpUpPreviewRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BlimpUpPreviewRequest.Merge(m, src)
+func (m *WatchEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEventsRequest.Merge(m, src)
 }
-func (m *BlimpUpPreviewRequest) XXX_Size() int {
-	return xxx_messageInfo_BlimpUpPreviewRequest.Size(m)
+func (m *WatchEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchEventsRequest.Size(m)
 }
-func (m *BlimpUpPreviewRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_BlimpUpPreviewRequest.DiscardUnknown(m)
+func (m *WatchEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEventsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BlimpUpPreviewRequest proto.InternalMessageInfo
+var xxx_messageInfo_WatchEventsRequest proto.InternalMessageInfo
 
-func (m *BlimpUpPreviewRequest) GetAuth() *auth.BlimpAuth {
+func (m *WatchEventsRequest) GetAuth() *auth.BlimpAuth {
 	if m != nil {
 		return m.Auth
 	}
 	return nil
 }
 
-func (m *BlimpUpPreviewRequest) GetRepo() string {
+func (m *WatchEventsRequest) GetSince() int64 {
 	if m != nil {
-		return m.Repo
+		return m.Since
 	}
-	return ""
+	return 0
 }
 
-func (m *BlimpUpPreviewRequest) GetComposeFiles() []string {
+type WatchEventsResponse struct {
+	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Event                *Event        `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *WatchEventsResponse) Reset()         { *m = WatchEventsResponse{} }
+func (m *WatchEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchEventsResponse) ProtoMessage()    {}
+func (*WatchEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{55}
+}
+
+func (m *WatchEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEventsResponse.Unmarshal(m, b)
+}
+func (m *WatchEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEventsResponse.Merge(m, src)
+}
+func (m *WatchEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchEventsResponse.Size(m)
+}
+func (m *WatchEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEventsResponse proto.InternalMessageInfo
+
+func (m *WatchEventsResponse) GetError() *errors.Error {
 	if m != nil {
-		return m.ComposeFiles
+		return m.Error
 	}
 	return nil
 }
 
-func (m *BlimpUpPreviewRequest) GetEnv() map[string]string {
+func (m *WatchEventsResponse) GetEvent() *Event {
 	if m != nil {
-		return m.Env
+		return m.Event
 	}
 	return nil
 }
 
-type BlimpUpPreviewResponse struct {
-	Error                *errors.Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	StartedCli           bool          `protobuf:"varint,2,opt,name=started_cli,json=startedCli,proto3" json:"started_cli,omitempty"`
-	Output               []byte        `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+type Event struct {
+	// The Unix time of the most recent occurrence of the event.
+	Time    int64      `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Service string     `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	Type    Event_Type `protobuf:"varint,3,opt,name=type,proto3,enum=blimp.cluster.v0.Event_Type" json:"type,omitempty"`
+	Message string     `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Warning is whether the event is a sign that something is wrong.
+	Warning bool `protobuf:"varint,5,opt,name=warning,proto3" json:"warning,omitempty"`
+	// The number of times the event occurred. Repeated occurrences are sent
+	// again with an updated time and count.
+	Count                int32    `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *BlimpUpPreviewResponse) Reset()         { *m = BlimpUpPreviewResponse{} }
-func (m *BlimpUpPreviewResponse) String() string { return proto.CompactTextString(m) }
-func (*BlimpUpPreviewResponse) ProtoMessage()    {}
-func (*BlimpUpPreviewResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d156d5389f4d1cd6, []int{30}
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d156d5389f4d1cd6, []int{56}
 }
 
-func (m *BlimpUpPreviewResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BlimpUpPreviewResponse.Unmarshal(m, b)
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
 }
-func (m *BlimpUpPreviewResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BlimpUpPreviewResponse.Marshal(b, m, deterministic)
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
 }
-func (m *BlimpUpPreviewResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BlimpUpPreviewResponse.Merge(m, src)
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
 }
-func (m *BlimpUpPreviewResponse) XXX_Size() int {
-	return xxx_messageInfo_BlimpUpPreviewResponse.Size(m)
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
 }
-func (m *BlimpUpPreviewResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_BlimpUpPreviewResponse.DiscardUnknown(m)
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BlimpUpPreviewResponse proto.InternalMessageInfo
+var xxx_messageInfo_Event proto.InternalMessageInfo
 
-func (m *BlimpUpPreviewResponse) GetError() *errors.Error {
+func (m *Event) GetTime() int64 {
 	if m != nil {
-		return m.Error
+		return m.Time
 	}
-	return nil
+	return 0
 }
 
-func (m *BlimpUpPreviewResponse) GetStartedCli() bool {
+func (m *Event) GetService() string {
 	if m != nil {
-		return m.StartedCli
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Event) GetType() Event_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Event_OTHER
+}
+
+func (m *Event) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Event) GetWarning() bool {
+	if m != nil {
+		return m.Warning
 	}
 	return false
 }
 
-func (m *BlimpUpPreviewResponse) GetOutput() []byte {
+func (m *Event) GetCount() int32 {
 	if m != nil {
-		return m.Output
+		return m.Count
 	}
-	return nil
+	return 0
 }
 
 func init() {
 	proto.RegisterEnum("blimp.cluster.v0.CLIAction", CLIAction_name, CLIAction_value)
 	proto.RegisterEnum("blimp.cluster.v0.ServicePhase", ServicePhase_name, ServicePhase_value)
+	proto.RegisterEnum("blimp.cluster.v0.ExposeProtocol", ExposeProtocol_name, ExposeProtocol_value)
+	proto.RegisterEnum("blimp.cluster.v0.VolumeType", VolumeType_name, VolumeType_value)
+	proto.RegisterEnum("blimp.cluster.v0.ServiceDeployPlan_Action", ServiceDeployPlan_Action_name, ServiceDeployPlan_Action_value)
 	proto.RegisterEnum("blimp.cluster.v0.SandboxStatus_SandboxPhase", SandboxStatus_SandboxPhase_name, SandboxStatus_SandboxPhase_value)
+	proto.RegisterEnum("blimp.cluster.v0.StartVolumeTransferRequest_Direction", StartVolumeTransferRequest_Direction_name, StartVolumeTransferRequest_Direction_value)
+	proto.RegisterEnum("blimp.cluster.v0.Event_Type", Event_Type_name, Event_Type_value)
 	proto.RegisterType((*CheckVersionRequest)(nil), "blimp.cluster.v0.CheckVersionRequest")
 	proto.RegisterType((*CheckVersionResponse)(nil), "blimp.cluster.v0.CheckVersionResponse")
 	proto.RegisterType((*CreateSandboxRequest)(nil), "blimp.cluster.v0.CreateSandboxRequest")
@@ -1788,6 +3570,7 @@ func init() {
 	proto.RegisterType((*DeployRequest)(nil), "blimp.cluster.v0.DeployRequest")
 	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.DeployRequest.BuiltImagesEntry")
 	proto.RegisterType((*DeployResponse)(nil), "blimp.cluster.v0.DeployResponse")
+	proto.RegisterType((*ServiceDeployPlan)(nil), "blimp.cluster.v0.ServiceDeployPlan")
 	proto.RegisterType((*KubeCredentials)(nil), "blimp.cluster.v0.KubeCredentials")
 	proto.RegisterType((*DeleteSandboxRequest)(nil), "blimp.cluster.v0.DeleteSandboxRequest")
 	proto.RegisterType((*DeleteSandboxResponse)(nil), "blimp.cluster.v0.DeleteSandboxResponse")
@@ -1796,8 +3579,18 @@ func init() {
 	proto.RegisterType((*SandboxStatus)(nil), "blimp.cluster.v0.SandboxStatus")
 	proto.RegisterMapType((map[string]*ServiceStatus)(nil), "blimp.cluster.v0.SandboxStatus.ServicesEntry")
 	proto.RegisterType((*ServiceStatus)(nil), "blimp.cluster.v0.ServiceStatus")
+	proto.RegisterMapType((map[int32]*ServiceStatus)(nil), "blimp.cluster.v0.ServiceStatus.ReplicasEntry")
+	proto.RegisterType((*HealthCheckResult)(nil), "blimp.cluster.v0.HealthCheckResult")
 	proto.RegisterType((*RestartRequest)(nil), "blimp.cluster.v0.RestartRequest")
 	proto.RegisterType((*RestartResponse)(nil), "blimp.cluster.v0.RestartResponse")
+	proto.RegisterType((*StopRequest)(nil), "blimp.cluster.v0.StopRequest")
+	proto.RegisterType((*StopResponse)(nil), "blimp.cluster.v0.StopResponse")
+	proto.RegisterType((*StartRequest)(nil), "blimp.cluster.v0.StartRequest")
+	proto.RegisterType((*StartResponse)(nil), "blimp.cluster.v0.StartResponse")
+	proto.RegisterType((*RunOneOffRequest)(nil), "blimp.cluster.v0.RunOneOffRequest")
+	proto.RegisterType((*RunOneOffResponse)(nil), "blimp.cluster.v0.RunOneOffResponse")
+	proto.RegisterType((*RunOneOffAttach)(nil), "blimp.cluster.v0.RunOneOffAttach")
+	proto.RegisterType((*RunOneOffExit)(nil), "blimp.cluster.v0.RunOneOffExit")
 	proto.RegisterType((*TagImageRequest)(nil), "blimp.cluster.v0.TagImageRequest")
 	proto.RegisterType((*TagImagesRequest)(nil), "blimp.cluster.v0.TagImagesRequest")
 	proto.RegisterMapType((map[string]*RegistryCredential)(nil), "blimp.cluster.v0.TagImagesRequest.RegistryCredentialsEntry")
@@ -1806,6 +3599,20 @@ func init() {
 	proto.RegisterType((*ExposeResponse)(nil), "blimp.cluster.v0.ExposeResponse")
 	proto.RegisterType((*UnexposeRequest)(nil), "blimp.cluster.v0.UnexposeRequest")
 	proto.RegisterType((*UnexposeResponse)(nil), "blimp.cluster.v0.UnexposeResponse")
+	proto.RegisterType((*ResolveExposedLinkRequest)(nil), "blimp.cluster.v0.ResolveExposedLinkRequest")
+	proto.RegisterType((*ResolveExposedLinkResponse)(nil), "blimp.cluster.v0.ResolveExposedLinkResponse")
+	proto.RegisterType((*VolumeRef)(nil), "blimp.cluster.v0.VolumeRef")
+	proto.RegisterType((*VolumeStatus)(nil), "blimp.cluster.v0.VolumeStatus")
+	proto.RegisterType((*ListVolumesRequest)(nil), "blimp.cluster.v0.ListVolumesRequest")
+	proto.RegisterType((*ListVolumesResponse)(nil), "blimp.cluster.v0.ListVolumesResponse")
+	proto.RegisterType((*RemoveVolumeRequest)(nil), "blimp.cluster.v0.RemoveVolumeRequest")
+	proto.RegisterType((*RemoveVolumeResponse)(nil), "blimp.cluster.v0.RemoveVolumeResponse")
+	proto.RegisterType((*StartVolumeTransferRequest)(nil), "blimp.cluster.v0.StartVolumeTransferRequest")
+	proto.RegisterType((*StartVolumeTransferResponse)(nil), "blimp.cluster.v0.StartVolumeTransferResponse")
+	proto.RegisterType((*GetStatsRequest)(nil), "blimp.cluster.v0.GetStatsRequest")
+	proto.RegisterType((*GetStatsResponse)(nil), "blimp.cluster.v0.GetStatsResponse")
+	proto.RegisterMapType((map[string]*ServiceStats)(nil), "blimp.cluster.v0.GetStatsResponse.ServicesEntry")
+	proto.RegisterType((*ServiceStats)(nil), "blimp.cluster.v0.ServiceStats")
 	proto.RegisterType((*GetImageNamespaceRequest)(nil), "blimp.cluster.v0.GetImageNamespaceRequest")
 	proto.RegisterType((*GetImageNamespaceResponse)(nil), "blimp.cluster.v0.GetImageNamespaceResponse")
 	proto.RegisterType((*GetBuildkitRequest)(nil), "blimp.cluster.v0.GetBuildkitRequest")
@@ -1813,6 +3620,9 @@ func init() {
 	proto.RegisterType((*BlimpUpPreviewRequest)(nil), "blimp.cluster.v0.BlimpUpPreviewRequest")
 	proto.RegisterMapType((map[string]string)(nil), "blimp.cluster.v0.BlimpUpPreviewRequest.EnvEntry")
 	proto.RegisterType((*BlimpUpPreviewResponse)(nil), "blimp.cluster.v0.BlimpUpPreviewResponse")
+	proto.RegisterType((*WatchEventsRequest)(nil), "blimp.cluster.v0.WatchEventsRequest")
+	proto.RegisterType((*WatchEventsResponse)(nil), "blimp.cluster.v0.WatchEventsResponse")
+	proto.RegisterType((*Event)(nil), "blimp.cluster.v0.Event")
 }
 
 func init() {
@@ -1820,115 +3630,211 @@ func init() {
 }
 
 var fileDescriptor_d156d5389f4d1cd6 = []byte{
-	// 1724 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x19, 0x5d, 0x73, 0xda, 0xc6,
-	0xd6, 0x02, 0x8c, 0xe1, 0x60, 0x40, 0x59, 0x3b, 0xbe, 0x5c, 0x25, 0x37, 0x76, 0x74, 0x6f, 0x12,
-	0x5f, 0xdf, 0x5c, 0xec, 0x71, 0xee, 0x67, 0x3a, 0x93, 0x04, 0x83, 0xe2, 0x50, 0xdb, 0xb2, 0x47,
-	0x80, 0xf3, 0x51, 0x77, 0x18, 0x01, 0x3b, 0xa0, 0x41, 0x20, 0xa2, 0x15, 0x24, 0xee, 0x4b, 0xa7,
-	0x4f, 0xcd, 0x63, 0x7f, 0x45, 0x5f, 0xfb, 0x07, 0xfa, 0xde, 0xf7, 0x3e, 0xf6, 0xcf, 0xa4, 0xb3,
-	0x5a, 0x49, 0x96, 0x40, 0xb6, 0x31, 0x13, 0x67, 0xa6, 0x4f, 0xec, 0x9e, 0x3d, 0xdf, 0x7b, 0xce,
-	0xd9, 0x73, 0x04, 0xdc, 0x69, 0xe8, 0x5a, 0x6f, 0xb0, 0xd9, 0xd4, 0x87, 0xc4, 0xc2, 0xe6, 0xe6,
-	0x68, 0x6b, 0xb3, 0xa7, 0xf6, 0xd5, 0x36, 0x36, 0xf3, 0x03, 0xd3, 0xb0, 0x0c, 0xc4, 0xdb, 0xe7,
-	0x79, 0xe7, 0x3c, 0x3f, 0xda, 0x12, 0x72, 0x8c, 0x42, 0x1d, 0x5a, 0x1d, 0x8a, 0x4e, 0x7f, 0x19,
-	0xae, 0x70, 0x9b, 0x9d, 0x60, 0xd3, 0x34, 0x4c, 0x42, 0xcf, 0xd8, 0x8a, 0x9d, 0x8a, 0x9b, 0xb0,
-	0x54, 0xec, 0xe0, 0x66, 0xf7, 0x18, 0x9b, 0x44, 0x33, 0xfa, 0x0a, 0x7e, 0x3b, 0xc4, 0xc4, 0x42,
-	0x39, 0x58, 0x18, 0x31, 0x48, 0x8e, 0x5b, 0xe3, 0xd6, 0x93, 0x8a, 0xbb, 0x15, 0x7f, 0xe6, 0x60,
-	0x39, 0x48, 0x41, 0x06, 0x46, 0x9f, 0xe0, 0xf3, 0x49, 0xd0, 0x03, 0xc8, 0xb6, 0x34, 0x32, 0xd0,
-	0xd5, 0xd3, 0x7a, 0x0f, 0x13, 0xa2, 0xb6, 0x71, 0x2e, 0x62, 0x63, 0x64, 0x1c, 0xf0, 0x01, 0x83,
-	0xa2, 0x47, 0x10, 0x57, 0x9b, 0x16, 0xe5, 0x10, 0x5d, 0xe3, 0xd6, 0x33, 0xdb, 0xb7, 0xf2, 0xe3,
-	0x76, 0xe6, 0x8b, 0xfb, 0xe5, 0x82, 0x8d, 0xa2, 0x38, 0xa8, 0xe8, 0x21, 0xcc, 0xdb, 0x16, 0xe5,
-	0x62, 0x6b, 0xdc, 0x7a, 0x6a, 0x7b, 0xc5, 0xa1, 0x71, 0xac, 0x1c, 0x6d, 0xe5, 0x25, 0xba, 0x52,
-	0x18, 0x92, 0xf8, 0x7d, 0x0c, 0x96, 0x8b, 0x26, 0x56, 0x2d, 0x5c, 0x51, 0xfb, 0xad, 0x86, 0xf1,
-	0xde, 0xb5, 0xf8, 0x16, 0x24, 0x0d, 0xbd, 0x55, 0xb7, 0x8c, 0x2e, 0x76, 0x0d, 0x48, 0x18, 0x7a,
-	0xab, 0x4a, 0xf7, 0xe8, 0x21, 0xc4, 0xa8, 0x47, 0x73, 0xf3, 0xb6, 0x88, 0x9c, 0x23, 0xc2, 0x76,
-	0xf2, 0x68, 0x2b, 0xbf, 0x43, 0x77, 0x85, 0xa1, 0xd5, 0x51, 0x6c, 0x2c, 0xb4, 0x06, 0xa9, 0xa6,
-	0xd1, 0x1b, 0x18, 0x04, 0x3f, 0xd7, 0x74, 0xd7, 0x56, 0x3f, 0x08, 0xbd, 0x85, 0x25, 0x13, 0xb7,
-	0x35, 0x62, 0x99, 0xa7, 0x45, 0x13, 0xb7, 0x70, 0xdf, 0xd2, 0x54, 0x9d, 0xe4, 0xa2, 0x6b, 0xd1,
-	0xf5, 0xd4, 0xf6, 0xd3, 0x10, 0xab, 0x43, 0x34, 0xce, 0x2b, 0x93, 0x1c, 0xa4, 0xbe, 0x65, 0x9e,
-	0x2a, 0x61, 0xbc, 0x51, 0x1d, 0xd2, 0xe4, 0xb4, 0xdf, 0xc4, 0xad, 0xe7, 0x86, 0xde, 0xc2, 0x26,
-	0xc9, 0xc5, 0x6c, 0x61, 0xff, 0x9f, 0x52, 0x58, 0xc5, 0x4f, 0xcb, 0xc4, 0x04, 0xf9, 0x09, 0x3a,
-	0xe4, 0xce, 0xd3, 0x08, 0xf1, 0x10, 0xed, 0xe2, 0x53, 0xc7, 0xad, 0x74, 0x89, 0x1e, 0xc3, 0xfc,
-	0x48, 0xd5, 0x87, 0xcc, 0x3b, 0xa9, 0xed, 0xbf, 0x4d, 0xaa, 0x31, 0xc9, 0x4c, 0x61, 0x24, 0x8f,
-	0x23, 0xff, 0xe3, 0x84, 0x67, 0x80, 0x26, 0x55, 0x0a, 0x91, 0xb3, 0xec, 0x97, 0x93, 0xf4, 0x71,
-	0x10, 0xf7, 0x01, 0x4d, 0x8a, 0x40, 0x02, 0x24, 0x86, 0x04, 0x9b, 0x7d, 0xb5, 0x87, 0xdd, 0x28,
-	0x70, 0xf7, 0xf4, 0x6c, 0xa0, 0x12, 0xf2, 0xce, 0x30, 0x5b, 0x0e, 0x3b, 0x6f, 0x2f, 0x36, 0x61,
-	0xa5, 0x60, 0x59, 0x6a, 0xb3, 0x53, 0x35, 0x66, 0x09, 0xac, 0xc8, 0x34, 0x81, 0x25, 0xfe, 0xca,
-	0xc1, 0x9f, 0x26, 0xa4, 0x38, 0xe9, 0xe7, 0xa5, 0x01, 0x37, 0x45, 0x1a, 0xd0, 0x10, 0x95, 0x8d,
-	0x16, 0x2e, 0xb4, 0x5a, 0x26, 0x26, 0xc4, 0x0d, 0x51, 0x1f, 0x88, 0x1a, 0x4b, 0xb7, 0x45, 0x6c,
-	0x5a, 0x76, 0x36, 0x26, 0x15, 0x6f, 0x8f, 0xf6, 0x20, 0xdb, 0x1d, 0x36, 0xb0, 0x3f, 0x74, 0x59,
-	0xf2, 0xdd, 0x9d, 0xbc, 0xc6, 0xbd, 0x20, 0xa2, 0x32, 0x4e, 0x29, 0xfe, 0x12, 0x81, 0x9b, 0x63,
-	0x21, 0xf7, 0x07, 0x37, 0x09, 0xdd, 0x87, 0x4c, 0xb9, 0xa7, 0xb6, 0xb1, 0xac, 0xf6, 0x30, 0x19,
-	0xa8, 0x4d, 0x6c, 0x17, 0x8e, 0xa4, 0x32, 0x06, 0xa5, 0x25, 0xd3, 0x2d, 0x88, 0x71, 0x56, 0x32,
-	0x7b, 0x13, 0x95, 0x70, 0x61, 0xea, 0x4a, 0x28, 0xfe, 0x10, 0x81, 0x74, 0x09, 0x0f, 0x74, 0xe3,
-	0xf4, 0x4a, 0xb1, 0x17, 0xfb, 0x44, 0x45, 0x4d, 0x81, 0x54, 0x63, 0xa8, 0xe9, 0x96, 0x6d, 0xa4,
-	0x5b, 0xcc, 0xb6, 0x26, 0x15, 0x0f, 0xa8, 0x98, 0xdf, 0x39, 0x23, 0x61, 0x65, 0xc5, 0xcf, 0x44,
-	0x78, 0x02, 0xfc, 0x38, 0xc2, 0x95, 0x92, 0xfc, 0x09, 0x64, 0x5c, 0x71, 0xb3, 0x04, 0x95, 0x68,
-	0x40, 0x76, 0xec, 0xb6, 0x11, 0x82, 0x58, 0xc7, 0x20, 0x96, 0x23, 0xdf, 0x5e, 0x53, 0x05, 0x9a,
-	0x6a, 0xd1, 0xb4, 0x5c, 0x05, 0xec, 0x0d, 0x85, 0x32, 0xcf, 0xb3, 0x60, 0x63, 0x1b, 0x74, 0x1b,
-	0x92, 0x7d, 0x2f, 0x2e, 0x62, 0xf6, 0xc9, 0x19, 0x40, 0xfc, 0xc0, 0xc1, 0x72, 0x09, 0xeb, 0x78,
-	0xb6, 0xf7, 0x29, 0x3a, 0xd5, 0x55, 0xde, 0x83, 0x4c, 0xcb, 0x16, 0x51, 0x1f, 0x19, 0xfa, 0xb0,
-	0x87, 0x59, 0xb2, 0x24, 0x94, 0x34, 0x83, 0x1e, 0x33, 0xa0, 0x28, 0xc1, 0xcd, 0x31, 0x4d, 0x66,
-	0x72, 0xe1, 0xd7, 0xc0, 0xef, 0x62, 0xab, 0x62, 0xa9, 0xd6, 0x90, 0x5c, 0x43, 0x4d, 0xfc, 0x06,
-	0x6e, 0xf8, 0xd8, 0xcf, 0x54, 0x39, 0xfe, 0x0b, 0x71, 0x62, 0xd3, 0x3b, 0x22, 0x57, 0x27, 0x63,
-	0xd6, 0x71, 0x81, 0x23, 0xc6, 0x41, 0x17, 0x7f, 0x8b, 0x40, 0x3a, 0x70, 0x82, 0xca, 0x90, 0x20,
-	0xd8, 0x1c, 0x69, 0x4d, 0x4c, 0x72, 0x9c, 0x9d, 0x00, 0xff, 0xbc, 0x84, 0x59, 0xbe, 0xe2, 0xe0,
-	0xb3, 0xe8, 0xf7, 0xc8, 0xd1, 0x0e, 0xcc, 0x0f, 0x3a, 0x2a, 0x61, 0x41, 0x9d, 0xd9, 0x7e, 0x78,
-	0x29, 0x1f, 0xb6, 0x3b, 0xa2, 0x34, 0x0a, 0x23, 0x15, 0x4e, 0x20, 0x1d, 0x60, 0x1f, 0x92, 0x3b,
-	0xff, 0x0e, 0x3e, 0xc4, 0x61, 0xb6, 0x33, 0x0e, 0x8e, 0xed, 0xbe, 0xe4, 0x3a, 0x81, 0x45, 0xbf,
-	0x50, 0x94, 0x82, 0x85, 0x9a, 0xbc, 0x27, 0x1f, 0xbe, 0x94, 0xf9, 0x39, 0xba, 0x51, 0x6a, 0xb2,
-	0x5c, 0x96, 0x77, 0x79, 0x0e, 0x65, 0x21, 0x55, 0x95, 0x94, 0x83, 0xb2, 0x5c, 0xa8, 0x52, 0x40,
-	0x04, 0x21, 0xc8, 0x94, 0x0e, 0xa5, 0x4a, 0x5d, 0x3e, 0xac, 0xd6, 0xa5, 0x57, 0xe5, 0x4a, 0x95,
-	0x8f, 0xa2, 0x34, 0x24, 0x8f, 0x14, 0xe9, 0xa8, 0xa0, 0x50, 0x94, 0x98, 0xf8, 0xde, 0xd3, 0xdd,
-	0xf1, 0xed, 0xbf, 0x5c, 0x87, 0x70, 0xb6, 0x43, 0xee, 0x9c, 0xab, 0xa9, 0xdf, 0x05, 0xd4, 0xe2,
-	0x1e, 0x69, 0x3b, 0x89, 0x49, 0x97, 0x68, 0x15, 0x52, 0x1d, 0x95, 0xd4, 0x89, 0xa5, 0x9a, 0x16,
-	0x6e, 0xd9, 0x39, 0x93, 0x50, 0xa0, 0xa3, 0x92, 0x0a, 0x83, 0x88, 0x43, 0xc8, 0x28, 0xd8, 0x3e,
-	0xbe, 0x86, 0xe4, 0xcb, 0xc1, 0x82, 0x73, 0xc5, 0x8e, 0x4e, 0xee, 0x56, 0x7c, 0x0a, 0x59, 0x4f,
-	0xec, 0x4c, 0x99, 0x56, 0x81, 0x6c, 0x55, 0x6d, 0xdb, 0xa5, 0xd2, 0xd7, 0xc7, 0xbb, 0xd2, 0xb8,
-	0x80, 0x34, 0x5a, 0x9c, 0xb4, 0xde, 0x59, 0x2b, 0xce, 0x36, 0xd4, 0x5b, 0x96, 0xda, 0x76, 0x0a,
-	0x16, 0x5d, 0x8a, 0x1f, 0x23, 0xc0, 0xbb, 0x5c, 0xc9, 0x35, 0xbc, 0x2b, 0x45, 0x48, 0x59, 0x6a,
-	0xdb, 0x61, 0x4c, 0x33, 0x30, 0x1a, 0xfe, 0xe8, 0x8e, 0x59, 0xa6, 0xf8, 0xa9, 0x50, 0xef, 0xa2,
-	0x7e, 0xfa, 0x8b, 0xf3, 0x99, 0x91, 0x99, 0x7a, 0xe9, 0xcf, 0xdb, 0xea, 0x8a, 0x5f, 0xc1, 0x0d,
-	0x9f, 0xbe, 0x67, 0xd3, 0xd6, 0x39, 0x17, 0xeb, 0xc5, 0x4c, 0x64, 0x9a, 0x98, 0xf9, 0xc0, 0x41,
-	0x5a, 0x7a, 0x4f, 0xdf, 0xf0, 0x6b, 0xb8, 0xdb, 0x73, 0x63, 0x9d, 0x3e, 0xa2, 0x03, 0xc3, 0x69,
-	0xc3, 0xd2, 0x8a, 0xbd, 0x16, 0x15, 0xc8, 0xb8, 0x9a, 0xcc, 0x54, 0xc6, 0x11, 0xc4, 0x74, 0xad,
-	0xdf, 0x75, 0x44, 0xd9, 0x6b, 0xf1, 0x04, 0xb2, 0xb5, 0x3e, 0xbe, 0xba, 0x7d, 0xd3, 0xbd, 0x3d,
-	0xcf, 0x80, 0x3f, 0xe3, 0x3e, 0x53, 0xca, 0x62, 0xc8, 0xed, 0x62, 0x2b, 0xd8, 0x16, 0x5e, 0x83,
-	0xa2, 0x6d, 0xf8, 0x73, 0x88, 0x98, 0x99, 0xbc, 0x1c, 0x68, 0x5f, 0x22, 0xe3, 0xed, 0x4b, 0x1d,
-	0xd0, 0x2e, 0xb6, 0x68, 0xcb, 0xd6, 0xea, 0x6a, 0xd6, 0x35, 0x58, 0xf2, 0x1d, 0x07, 0x4b, 0x01,
-	0x09, 0x9f, 0x7f, 0x56, 0x10, 0x3f, 0x72, 0x70, 0xd3, 0xd6, 0xab, 0x36, 0x38, 0x32, 0xf1, 0x48,
-	0xc3, 0xef, 0x5c, 0x43, 0xaf, 0xf6, 0x9d, 0x00, 0x41, 0xcc, 0xc4, 0x03, 0xc3, 0x0d, 0x58, 0xba,
-	0x46, 0x22, 0x2c, 0xfa, 0x7a, 0x6a, 0x56, 0xc2, 0x92, 0x4a, 0x00, 0x86, 0x76, 0x20, 0x8a, 0xfb,
-	0x23, 0x67, 0x80, 0x0f, 0x69, 0xb0, 0x43, 0x75, 0xcb, 0x4b, 0xfd, 0x11, 0x2b, 0x69, 0x94, 0x58,
-	0xf8, 0x0f, 0x24, 0x5c, 0xc0, 0x55, 0x1a, 0xea, 0x2f, 0x63, 0x09, 0x8e, 0x8f, 0x88, 0xdf, 0xc2,
-	0xca, 0xb8, 0x90, 0x99, 0xee, 0x61, 0x15, 0x52, 0xce, 0x33, 0x5c, 0x6f, 0xea, 0x9a, 0xd3, 0x86,
-	0x82, 0x03, 0x2a, 0xea, 0x1a, 0x5a, 0x81, 0xb8, 0x31, 0xb4, 0x06, 0x43, 0x76, 0x09, 0x8b, 0x8a,
-	0xb3, 0xdb, 0xf8, 0x0b, 0x24, 0xbd, 0xf9, 0x07, 0xc5, 0x21, 0x72, 0xb8, 0xc7, 0xcf, 0xa1, 0x04,
-	0xc4, 0xa4, 0x57, 0xe5, 0x2a, 0xcf, 0x6d, 0xfc, 0xc8, 0xc1, 0xa2, 0xbf, 0x19, 0x08, 0xb6, 0x26,
-	0x39, 0x58, 0x2e, 0xcb, 0xe5, 0x6a, 0xb9, 0xb0, 0x5f, 0x7e, 0x53, 0x96, 0x77, 0xeb, 0xc7, 0x87,
-	0xfb, 0xb5, 0x03, 0xa9, 0xc2, 0x73, 0x68, 0x09, 0xb2, 0x2f, 0x0b, 0xe5, 0x6a, 0xbd, 0x24, 0x1d,
-	0x49, 0x72, 0xa9, 0x52, 0x3f, 0x94, 0x59, 0xaf, 0x62, 0x03, 0x2b, 0xaf, 0xe5, 0x62, 0x7d, 0xa7,
-	0x2c, 0x97, 0xf8, 0x28, 0xe5, 0x47, 0x31, 0xec, 0x4e, 0xc5, 0xdf, 0xea, 0xcc, 0x23, 0x80, 0x38,
-	0x55, 0x42, 0x2a, 0xf1, 0x71, 0xda, 0xd1, 0xd4, 0xe4, 0x17, 0x52, 0x61, 0xbf, 0xfa, 0xe2, 0x35,
-	0xbf, 0x80, 0x6e, 0x40, 0xba, 0x26, 0x57, 0x8a, 0x2f, 0xa4, 0x52, 0x6d, 0xbf, 0xb0, 0xb3, 0x2f,
-	0xf1, 0x89, 0xed, 0x9f, 0x00, 0x16, 0x0e, 0xd8, 0xa7, 0x3d, 0xd4, 0x81, 0xec, 0xd8, 0x70, 0x8f,
-	0xd6, 0x27, 0x2f, 0x37, 0xfc, 0x2b, 0x83, 0xf0, 0xf7, 0x29, 0x30, 0xd9, 0x15, 0x89, 0x73, 0xa8,
-	0x0d, 0x99, 0xe0, 0xf5, 0xa1, 0x07, 0x53, 0x46, 0x91, 0xb0, 0x7e, 0x39, 0xa2, 0x2b, 0x66, 0x8b,
-	0x43, 0x0d, 0x48, 0x07, 0x46, 0x7b, 0x74, 0x7f, 0xba, 0xcf, 0x4d, 0xc2, 0x83, 0x4b, 0xf1, 0x3c,
-	0x63, 0x8e, 0x21, 0xcb, 0x46, 0xbc, 0x33, 0xb7, 0xad, 0x5e, 0x32, 0x74, 0x0a, 0x6b, 0xe7, 0x23,
-	0x78, 0x7c, 0x1b, 0x74, 0x98, 0xf6, 0x8d, 0x3f, 0x61, 0xba, 0x87, 0x4d, 0x6a, 0x61, 0xba, 0x87,
-	0xce, 0x51, 0xe2, 0x1c, 0x3a, 0x81, 0x94, 0xaf, 0x98, 0xa1, 0x90, 0xd6, 0x60, 0xb2, 0x9a, 0x0a,
-	0xf7, 0x2e, 0xc1, 0xf2, 0x79, 0x26, 0xe9, 0x8d, 0x46, 0x48, 0x0c, 0xa5, 0x0a, 0x8c, 0x65, 0xc2,
-	0x5f, 0x2f, 0xc4, 0xf1, 0xf8, 0xf6, 0xed, 0x91, 0x6b, 0xec, 0x5b, 0xc6, 0x46, 0x28, 0x6d, 0xe8,
-	0xcb, 0x26, 0xfc, 0x63, 0x2a, 0x5c, 0x4f, 0xde, 0x1b, 0x48, 0xbd, 0x54, 0xad, 0x66, 0xe7, 0x93,
-	0x5b, 0xb2, 0xc5, 0xa1, 0x3a, 0x2c, 0xfa, 0xbf, 0x66, 0xa3, 0x10, 0xe7, 0x86, 0x7c, 0x1f, 0x17,
-	0xee, 0x5f, 0x86, 0xe6, 0x29, 0x7f, 0x04, 0x0b, 0x4e, 0x57, 0x8f, 0xd6, 0xc2, 0x3a, 0x3f, 0xff,
-	0x9c, 0x21, 0xdc, 0xbd, 0x00, 0xc3, 0xe3, 0xf8, 0x0a, 0x92, 0x5e, 0x3f, 0x18, 0xe6, 0x8c, 0xf1,
-	0xe6, 0x36, 0xcc, 0x19, 0x13, 0x0d, 0xa5, 0xed, 0x8c, 0x03, 0x88, 0xb3, 0x0e, 0x2c, 0x2c, 0x83,
-	0x02, 0x5d, 0x62, 0x58, 0x06, 0x05, 0x9b, 0x37, 0x71, 0x0e, 0x55, 0x20, 0xe1, 0xb6, 0x47, 0x28,
-	0xc4, 0xb2, 0xb1, 0xc6, 0x4c, 0x10, 0x2f, 0x42, 0x71, 0x99, 0xee, 0x6c, 0xbc, 0x59, 0x6f, 0x6b,
-	0x56, 0x67, 0xd8, 0xc8, 0x37, 0x8d, 0xde, 0x66, 0x17, 0xeb, 0x2d, 0x75, 0x93, 0xfd, 0xc3, 0x31,
-	0xe8, 0xb6, 0x37, 0xed, 0x3f, 0x35, 0xdc, 0xff, 0x4d, 0x1a, 0x71, 0x7b, 0xfb, 0xe8, 0xf7, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xf6, 0x90, 0x1d, 0xe1, 0x4f, 0x19, 0x00, 0x00,
+	// 3262 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3a, 0x4d, 0x73, 0xdb, 0x58,
+	0x72, 0x02, 0xbf, 0x44, 0xb6, 0x44, 0x0a, 0x7a, 0xb2, 0x3d, 0x1c, 0x78, 0x6c, 0x6b, 0xe0, 0xb1,
+	0xad, 0x78, 0x3c, 0x94, 0xca, 0xf3, 0xfd, 0x91, 0xcc, 0x50, 0x14, 0x46, 0xa2, 0x4d, 0x91, 0x0c,
+	0x48, 0xd9, 0x9e, 0x19, 0x27, 0x28, 0x88, 0x7c, 0xa2, 0x50, 0x02, 0x01, 0x0e, 0x00, 0xca, 0xe2,
+	0x5c, 0x52, 0xb9, 0xe5, 0x9e, 0x6b, 0x6e, 0xa9, 0xca, 0x2d, 0xa7, 0xa4, 0x2a, 0x97, 0xdc, 0x52,
+	0x95, 0x54, 0xcd, 0x39, 0x55, 0x5b, 0xb5, 0x7f, 0x61, 0x0f, 0x7b, 0xd9, 0xad, 0xad, 0xda, 0xc3,
+	0xce, 0xd6, 0xfb, 0x00, 0x04, 0x90, 0xa0, 0x45, 0xd3, 0xf6, 0x4c, 0xed, 0x89, 0x78, 0xfd, 0xfa,
+	0xf5, 0xeb, 0xee, 0xd7, 0xdd, 0xaf, 0xbb, 0x1f, 0xe1, 0xfa, 0xa1, 0x69, 0xf4, 0x07, 0x9b, 0x1d,
+	0x73, 0xe8, 0x7a, 0xd8, 0xd9, 0x3c, 0xdd, 0xda, 0xec, 0xeb, 0x96, 0xde, 0xc3, 0x4e, 0x69, 0xe0,
+	0xd8, 0x9e, 0x8d, 0x44, 0x3a, 0x5f, 0xe2, 0xf3, 0xa5, 0xd3, 0x2d, 0xa9, 0xc8, 0x56, 0xe8, 0x43,
+	0xef, 0x98, 0xa0, 0x93, 0x5f, 0x86, 0x2b, 0xbd, 0xc5, 0x66, 0xb0, 0xe3, 0xd8, 0x8e, 0x4b, 0xe6,
+	0xd8, 0x17, 0x9b, 0x95, 0x37, 0x61, 0xad, 0x72, 0x8c, 0x3b, 0x27, 0x8f, 0xb0, 0xe3, 0x1a, 0xb6,
+	0xa5, 0xe2, 0xef, 0x87, 0xd8, 0xf5, 0x50, 0x11, 0x16, 0x4f, 0x19, 0xa4, 0x28, 0xac, 0x0b, 0x1b,
+	0x39, 0xd5, 0x1f, 0xca, 0xff, 0x2d, 0xc0, 0xa5, 0xe8, 0x0a, 0x77, 0x60, 0x5b, 0x2e, 0x9e, 0xbe,
+	0x04, 0xdd, 0x81, 0x95, 0xae, 0xe1, 0x0e, 0x4c, 0x7d, 0xa4, 0xf5, 0xb1, 0xeb, 0xea, 0x3d, 0x5c,
+	0x4c, 0x50, 0x8c, 0x02, 0x07, 0xef, 0x33, 0x28, 0x7a, 0x1f, 0x32, 0x7a, 0xc7, 0x23, 0x14, 0x92,
+	0xeb, 0xc2, 0x46, 0xe1, 0xfe, 0xd5, 0xd2, 0xb8, 0x9c, 0xa5, 0x4a, 0xad, 0x5a, 0xa6, 0x28, 0x2a,
+	0x47, 0x45, 0xf7, 0x20, 0x4d, 0x25, 0x2a, 0xa6, 0xd6, 0x85, 0x8d, 0xa5, 0xfb, 0x57, 0xf8, 0x1a,
+	0x2e, 0xe5, 0xe9, 0x56, 0x49, 0x21, 0x5f, 0x2a, 0x43, 0x92, 0xff, 0x2b, 0x05, 0x97, 0x2a, 0x0e,
+	0xd6, 0x3d, 0xdc, 0xd2, 0xad, 0xee, 0xa1, 0x7d, 0xe6, 0x4b, 0x7c, 0x15, 0x72, 0xb6, 0xd9, 0xd5,
+	0x3c, 0xfb, 0x04, 0xfb, 0x02, 0x64, 0x6d, 0xb3, 0xdb, 0x26, 0x63, 0x74, 0x0f, 0x52, 0x44, 0xa3,
+	0xc5, 0x34, 0xdd, 0xa2, 0xc8, 0xb7, 0xa0, 0x4a, 0x3e, 0xdd, 0x2a, 0x6d, 0x93, 0x51, 0x79, 0xe8,
+	0x1d, 0xab, 0x14, 0x0b, 0xad, 0xc3, 0x52, 0xc7, 0xee, 0x0f, 0x6c, 0x17, 0x7f, 0x6d, 0x98, 0xbe,
+	0xac, 0x61, 0x10, 0xfa, 0x1e, 0xd6, 0x1c, 0xdc, 0x33, 0x5c, 0xcf, 0x19, 0x55, 0x1c, 0xdc, 0xc5,
+	0x96, 0x67, 0xe8, 0xa6, 0x5b, 0x4c, 0xae, 0x27, 0x37, 0x96, 0xee, 0x7f, 0x19, 0x23, 0x75, 0x0c,
+	0xc7, 0x25, 0x75, 0x92, 0x82, 0x62, 0x79, 0xce, 0x48, 0x8d, 0xa3, 0x8d, 0x34, 0xc8, 0xbb, 0x23,
+	0xab, 0x83, 0xbb, 0x5f, 0xdb, 0x66, 0x17, 0x3b, 0x6e, 0x31, 0x45, 0x37, 0xfb, 0x74, 0xc6, 0xcd,
+	0x5a, 0xe1, 0xb5, 0x6c, 0x9b, 0x28, 0x3d, 0x54, 0x02, 0xe4, 0xe0, 0x0e, 0x36, 0x4e, 0x71, 0xc3,
+	0x32, 0x47, 0xfe, 0x2e, 0x99, 0xf5, 0xe4, 0x46, 0x4e, 0x8d, 0x99, 0x91, 0x4c, 0x28, 0x4e, 0x93,
+	0x00, 0x89, 0x90, 0x3c, 0xc1, 0x23, 0x7e, 0x0c, 0xe4, 0x13, 0x7d, 0x06, 0xe9, 0x53, 0xdd, 0x1c,
+	0x32, 0x6d, 0x2e, 0xdd, 0x7f, 0x67, 0x92, 0xed, 0x49, 0x62, 0x2a, 0x5b, 0xf2, 0x59, 0xe2, 0x13,
+	0x41, 0xfa, 0x0a, 0xd0, 0xa4, 0x08, 0x31, 0xfb, 0x5c, 0x0a, 0xef, 0x93, 0x0b, 0x51, 0x90, 0x6b,
+	0x80, 0x26, 0xb7, 0x40, 0x12, 0x64, 0x87, 0x2e, 0x76, 0x2c, 0xbd, 0x8f, 0x7d, 0xab, 0xf1, 0xc7,
+	0x64, 0x6e, 0xa0, 0xbb, 0xee, 0x33, 0xdb, 0xe9, 0x72, 0x72, 0xc1, 0x58, 0xee, 0xc0, 0x95, 0xb2,
+	0xe7, 0xe9, 0x9d, 0xe3, 0xb6, 0x3d, 0x8f, 0x21, 0x26, 0x66, 0x31, 0x44, 0xf9, 0xf7, 0x02, 0xbc,
+	0x31, 0xb1, 0x0b, 0x77, 0xd7, 0xc0, 0x6d, 0x84, 0x19, 0xdc, 0x86, 0x98, 0x74, 0xdd, 0xee, 0xe2,
+	0x72, 0xb7, 0xeb, 0x60, 0xd7, 0xf5, 0x4d, 0x3a, 0x04, 0x22, 0xc2, 0x92, 0x61, 0x05, 0x3b, 0x1e,
+	0xf5, 0xde, 0x9c, 0x1a, 0x8c, 0xd1, 0x43, 0x58, 0x39, 0x19, 0x1e, 0xe2, 0xb0, 0xa9, 0x33, 0x67,
+	0x7d, 0x7b, 0xf2, 0x18, 0x1f, 0x46, 0x11, 0xd5, 0xf1, 0x95, 0xe8, 0x6d, 0x58, 0xe6, 0xae, 0xa4,
+	0x1d, 0x11, 0xf7, 0x4a, 0x4f, 0xb8, 0x97, 0xfc, 0x7f, 0x09, 0xb8, 0x3c, 0x66, 0xc5, 0x7f, 0xe9,
+	0x52, 0xdf, 0x86, 0x42, 0xb5, 0xaf, 0xf7, 0x70, 0x5d, 0xef, 0x63, 0x77, 0xa0, 0x77, 0x7c, 0xb9,
+	0xc7, 0xa0, 0x24, 0x0a, 0xfb, 0x31, 0x36, 0xc3, 0xa2, 0x70, 0x7f, 0x22, 0xb8, 0x2e, 0xce, 0x1c,
+	0x5c, 0xe5, 0x1f, 0x13, 0x90, 0xdf, 0xc1, 0x03, 0xd3, 0x1e, 0xbd, 0x90, 0x79, 0xa6, 0x5e, 0x51,
+	0x9c, 0x54, 0x61, 0xe9, 0x70, 0x68, 0x98, 0x1e, 0x15, 0xd2, 0x8f, 0x8f, 0x5b, 0x93, 0x8c, 0x47,
+	0x58, 0x2c, 0x6d, 0x9f, 0x2f, 0x61, 0x91, 0x2a, 0x4c, 0x04, 0xbd, 0x01, 0x8b, 0x5d, 0x67, 0xa4,
+	0x39, 0x43, 0x8b, 0xaa, 0x30, 0xab, 0x66, 0xba, 0xce, 0x48, 0x1d, 0x5a, 0xe8, 0x06, 0x2c, 0x59,
+	0xb6, 0xe6, 0xe0, 0x0e, 0xb5, 0x1c, 0x1e, 0xb9, 0xc0, 0xb2, 0x55, 0x0e, 0x91, 0xfe, 0x06, 0xc4,
+	0x71, 0xd2, 0x2f, 0x14, 0x41, 0xfe, 0x59, 0x80, 0x82, 0xcf, 0xe9, 0x5c, 0xf6, 0x28, 0x41, 0xf6,
+	0x99, 0xee, 0x58, 0x86, 0xd5, 0x23, 0xc6, 0x48, 0xd8, 0x0b, 0xc6, 0xe8, 0x63, 0x48, 0x0d, 0x4c,
+	0xdd, 0xe2, 0x3a, 0xba, 0x39, 0xa9, 0xa3, 0x16, 0x76, 0x4e, 0x8d, 0x0e, 0x66, 0x0c, 0x34, 0x4d,
+	0xdd, 0x52, 0xe9, 0x02, 0xf9, 0x57, 0x02, 0xac, 0x4e, 0xcc, 0x11, 0x3b, 0x72, 0x19, 0xd0, 0xbf,
+	0xcd, 0xf9, 0x10, 0x6d, 0x07, 0x76, 0x94, 0xa0, 0x76, 0x74, 0x77, 0x86, 0xad, 0x4a, 0x63, 0x77,
+	0x36, 0x82, 0x54, 0xd7, 0x38, 0x3a, 0xa2, 0xcc, 0xe6, 0x54, 0xfa, 0x2d, 0x37, 0x21, 0xc3, 0xb0,
+	0x50, 0x1e, 0x72, 0x07, 0xf5, 0xca, 0x5e, 0xb9, 0xbe, 0xab, 0xec, 0x88, 0x0b, 0x08, 0x20, 0x53,
+	0x51, 0x95, 0x72, 0x5b, 0x11, 0x05, 0xb4, 0x0c, 0x59, 0x55, 0xe1, 0xa3, 0x04, 0x99, 0xd9, 0x51,
+	0x6a, 0x4a, 0x5b, 0x11, 0x93, 0x68, 0x15, 0xf2, 0xad, 0x87, 0xd5, 0xa6, 0x16, 0x4c, 0xa7, 0x64,
+	0x1b, 0x56, 0xc6, 0xfc, 0x8a, 0x6c, 0x7c, 0x6c, 0xbb, 0x1e, 0x97, 0x89, 0x7e, 0x93, 0x03, 0xeb,
+	0xe8, 0x15, 0xc7, 0xf3, 0x0f, 0x8c, 0x0e, 0x08, 0x94, 0xd9, 0x38, 0x73, 0x6b, 0x36, 0x40, 0x6f,
+	0x41, 0xce, 0x0a, 0x3c, 0x30, 0x45, 0x67, 0xce, 0x01, 0xf2, 0x3f, 0x09, 0x70, 0x69, 0x07, 0x9b,
+	0x78, 0xbe, 0xe4, 0x22, 0x39, 0x93, 0xd3, 0xdc, 0x82, 0x42, 0x97, 0x6e, 0xa1, 0x9d, 0xda, 0xe6,
+	0xb0, 0x8f, 0x59, 0x58, 0xca, 0xaa, 0x79, 0x06, 0x7d, 0xc4, 0x80, 0xb2, 0x02, 0x97, 0xc7, 0x38,
+	0x99, 0xc7, 0xe2, 0xe4, 0xbf, 0x03, 0x71, 0x17, 0x7b, 0x2d, 0x4f, 0xf7, 0x86, 0xee, 0x6b, 0xb8,
+	0xa0, 0x7e, 0x80, 0xd5, 0x10, 0xf9, 0xb9, 0x7c, 0xe2, 0x63, 0xc8, 0xb8, 0x74, 0x3d, 0xdf, 0xf2,
+	0x46, 0x8c, 0x39, 0x32, 0x15, 0xf0, 0x6d, 0x38, 0xba, 0xfc, 0xeb, 0x04, 0xe4, 0x23, 0x33, 0xa8,
+	0x0a, 0x59, 0x6e, 0xe4, 0x6e, 0x51, 0xa0, 0x6e, 0xf4, 0xde, 0x05, 0xc4, 0x7c, 0x4b, 0xe7, 0x71,
+	0x26, 0x58, 0x8e, 0xb6, 0x21, 0x3d, 0x38, 0xd6, 0x5d, 0xcc, 0x7d, 0xe4, 0xde, 0x85, 0x74, 0xd8,
+	0xa8, 0x49, 0xd6, 0xa8, 0x6c, 0xa9, 0xf4, 0x14, 0xf2, 0x11, 0xf2, 0x31, 0xb1, 0xe6, 0xc3, 0x68,
+	0x56, 0x74, 0x63, 0xaa, 0x2b, 0x72, 0xd9, 0x43, 0xc1, 0xe8, 0x29, 0x2c, 0x87, 0x37, 0x45, 0x4b,
+	0xb0, 0x78, 0x50, 0x7f, 0x58, 0x6f, 0x3c, 0xae, 0x8b, 0x0b, 0x64, 0xa0, 0x1e, 0xd4, 0xeb, 0xd5,
+	0xfa, 0xae, 0x28, 0xa0, 0x15, 0x58, 0x6a, 0x2b, 0xea, 0x7e, 0xb5, 0x5e, 0x6e, 0x13, 0x40, 0x02,
+	0x21, 0x28, 0xec, 0x34, 0x94, 0x96, 0x56, 0x6f, 0xb4, 0x35, 0xe5, 0x49, 0xb5, 0xd5, 0x16, 0x93,
+	0xc4, 0x67, 0x9b, 0xaa, 0xd2, 0x2c, 0xab, 0x04, 0x25, 0x25, 0xff, 0x67, 0x12, 0xf2, 0x91, 0xad,
+	0xd1, 0x07, 0xbe, 0x46, 0x04, 0xaa, 0x91, 0xeb, 0x53, 0x59, 0x0d, 0xeb, 0x80, 0x88, 0xdc, 0x77,
+	0x7b, 0xdc, 0x33, 0xc9, 0x27, 0x89, 0xd2, 0xc7, 0xba, 0xab, 0xb9, 0x9e, 0xee, 0x78, 0xb8, 0x4b,
+	0x9d, 0x26, 0xab, 0xc2, 0xb1, 0xee, 0xb6, 0x18, 0x04, 0x6d, 0x03, 0x1c, 0x63, 0xdd, 0xf4, 0x8e,
+	0x35, 0xd3, 0xee, 0x15, 0x53, 0xd3, 0xc2, 0xe1, 0x1e, 0xc5, 0xa1, 0x95, 0x8c, 0x8a, 0xdd, 0xa1,
+	0xe9, 0xa9, 0x39, 0xb6, 0xac, 0x66, 0xf7, 0xd0, 0x4d, 0xc8, 0x3b, 0x98, 0x6e, 0xa1, 0x75, 0xec,
+	0xa1, 0xe5, 0xd1, 0x9b, 0x22, 0xad, 0x2e, 0x73, 0x60, 0x85, 0xc0, 0xd0, 0x06, 0x88, 0xa6, 0xee,
+	0x7a, 0x1a, 0x3e, 0x33, 0x3c, 0xcd, 0xc1, 0xba, 0x6b, 0x5b, 0xfc, 0xce, 0x2d, 0x10, 0xb8, 0x72,
+	0x66, 0x78, 0x2a, 0x85, 0x12, 0xc3, 0x72, 0xf0, 0xc0, 0x34, 0x3a, 0xba, 0x5b, 0x5c, 0x9c, 0x6a,
+	0x58, 0x61, 0x75, 0x95, 0x54, 0x8e, 0xcf, 0x0d, 0xcb, 0x5f, 0x4e, 0x8c, 0x22, 0x32, 0x15, 0x36,
+	0x8a, 0xf4, 0x4b, 0x1b, 0x85, 0x0d, 0xab, 0x13, 0x7a, 0x21, 0x31, 0xd3, 0x33, 0x78, 0x7a, 0x9b,
+	0x54, 0xe9, 0x37, 0xb9, 0x1e, 0x98, 0xb6, 0x46, 0x3c, 0xfc, 0xf8, 0x43, 0x74, 0x05, 0x32, 0xf6,
+	0xd0, 0x1b, 0x0c, 0xfd, 0x7c, 0x88, 0x8f, 0x68, 0x94, 0xa5, 0xaa, 0x4c, 0x51, 0x4e, 0xd9, 0x40,
+	0x1e, 0x42, 0x41, 0x65, 0x3a, 0x7d, 0x0d, 0xa1, 0x32, 0x74, 0x87, 0x25, 0x22, 0x77, 0x98, 0xfc,
+	0x25, 0xac, 0x04, 0xdb, 0xce, 0x15, 0x17, 0x0f, 0x60, 0xa9, 0xe5, 0xd9, 0x03, 0x9f, 0x69, 0x9f,
+	0x2f, 0xe1, 0x25, 0xf9, 0xfa, 0x02, 0x96, 0x19, 0xd9, 0xb9, 0x98, 0x7a, 0x44, 0x56, 0x87, 0x54,
+	0xf9, 0xaa, 0xb8, 0xfa, 0x6b, 0xc8, 0xb7, 0x5e, 0x42, 0x57, 0xff, 0x26, 0x80, 0xa8, 0x0e, 0xad,
+	0x86, 0x85, 0x1b, 0x47, 0x47, 0x2f, 0xcd, 0x5b, 0x2a, 0x9a, 0x8d, 0x14, 0x61, 0xb1, 0x63, 0xf7,
+	0xfb, 0xba, 0xd5, 0x2d, 0xa6, 0x69, 0x32, 0xe1, 0x0f, 0x89, 0x63, 0x78, 0xde, 0x88, 0x7a, 0x64,
+	0x56, 0x25, 0x9f, 0xc4, 0x90, 0x4f, 0x30, 0x1e, 0xd0, 0xfc, 0x37, 0xab, 0xd2, 0xef, 0x07, 0xa9,
+	0x6c, 0x42, 0x4c, 0x3e, 0x48, 0x65, 0x93, 0x62, 0x4a, 0xfe, 0x83, 0x00, 0xab, 0x21, 0x46, 0xe7,
+	0xba, 0x8e, 0x3e, 0x9d, 0xe1, 0x3a, 0x0a, 0x7b, 0xdf, 0xde, 0x82, 0x7f, 0x21, 0xa1, 0xcf, 0x21,
+	0xa3, 0xd3, 0x62, 0xad, 0x98, 0x9c, 0x56, 0x26, 0x04, 0xdc, 0xb1, 0xaa, 0x8e, 0x2c, 0x66, 0x4b,
+	0xd0, 0x87, 0x90, 0x22, 0x71, 0xa8, 0x98, 0x9a, 0xb6, 0x6b, 0xb0, 0x94, 0xc4, 0xa5, 0xbd, 0x05,
+	0x95, 0xa2, 0x6f, 0xa7, 0x69, 0x7c, 0x95, 0x4d, 0x58, 0x19, 0x23, 0x8d, 0xde, 0x84, 0xec, 0xc0,
+	0xee, 0x6a, 0xa1, 0xc2, 0x76, 0x71, 0x60, 0x77, 0x49, 0xa1, 0x41, 0x2a, 0x30, 0xcb, 0xee, 0x62,
+	0x4d, 0x8f, 0xd6, 0x45, 0x56, 0xa8, 0x2e, 0xba, 0x0a, 0x39, 0x8a, 0xd2, 0x09, 0x15, 0x46, 0x16,
+	0x2f, 0x8c, 0xe4, 0x47, 0x90, 0x8f, 0x70, 0x43, 0x0e, 0xa6, 0x63, 0x77, 0x31, 0x0f, 0x62, 0xf4,
+	0x3b, 0xb2, 0x7f, 0x22, 0xba, 0x7f, 0x34, 0xc4, 0x2c, 0xfb, 0x21, 0x46, 0x6e, 0xc1, 0x4a, 0x5b,
+	0xef, 0xd1, 0xec, 0x3c, 0xd4, 0xc7, 0x9a, 0x92, 0xc6, 0x5e, 0x82, 0xb4, 0xd1, 0x3f, 0x6f, 0x45,
+	0xb1, 0x01, 0x35, 0x1a, 0xbd, 0xc7, 0x39, 0x26, 0x9f, 0xf2, 0x4f, 0x09, 0x10, 0x7d, 0xaa, 0xee,
+	0x6b, 0x28, 0x82, 0x2a, 0xb0, 0xe4, 0xe9, 0x3d, 0x4e, 0x98, 0xa5, 0xf5, 0xb1, 0x47, 0x3f, 0x26,
+	0x99, 0x1a, 0x5e, 0x85, 0xfa, 0xcf, 0xeb, 0x27, 0x7d, 0x3e, 0x9d, 0x98, 0x3b, 0x57, 0x2f, 0xe9,
+	0xe7, 0x6d, 0xdd, 0xc8, 0xdf, 0xc1, 0x6a, 0x88, 0xdf, 0xf3, 0x6e, 0xe3, 0x94, 0x83, 0x0d, 0xfc,
+	0x35, 0x31, 0x4b, 0x70, 0xfa, 0x5f, 0x01, 0xf2, 0xca, 0x19, 0x29, 0x38, 0x5f, 0xc3, 0xd9, 0x4e,
+	0x0d, 0xa9, 0xc4, 0xe2, 0x07, 0x36, 0x77, 0x8d, 0xbc, 0x4a, 0xbf, 0xd1, 0x17, 0x90, 0xa5, 0x3d,
+	0xd9, 0x8e, 0x6d, 0xd2, 0x7c, 0xa3, 0x70, 0x7f, 0x7d, 0x52, 0x55, 0x8c, 0xd7, 0x26, 0xc7, 0x53,
+	0x83, 0x15, 0xb2, 0x0a, 0x05, 0x5f, 0x8e, 0xb9, 0x02, 0x17, 0x82, 0x94, 0x69, 0x58, 0x27, 0x9c,
+	0x51, 0xfa, 0x2d, 0x3f, 0x85, 0x95, 0x03, 0x0b, 0xbf, 0xb8, 0x76, 0x66, 0x4b, 0xfe, 0xbf, 0x02,
+	0xf1, 0x9c, 0xfa, 0x5c, 0x37, 0x4b, 0x03, 0xde, 0x54, 0xb1, 0x6b, 0x9b, 0xa7, 0x98, 0x89, 0xde,
+	0xad, 0x19, 0xd6, 0x89, 0xcf, 0x69, 0xa4, 0x54, 0x13, 0xc6, 0x4a, 0xb5, 0xf3, 0xf2, 0x2e, 0x11,
+	0x2a, 0xef, 0xe4, 0xdf, 0x09, 0x20, 0xc5, 0x51, 0xfc, 0x05, 0xba, 0x47, 0xd3, 0xaf, 0x3c, 0xdf,
+	0x76, 0xd2, 0x53, 0x6c, 0x27, 0xf3, 0xc2, 0xb6, 0xf3, 0xb7, 0x90, 0x63, 0x75, 0xa3, 0x8a, 0x8f,
+	0x08, 0xf9, 0x50, 0xd0, 0xa7, 0xdf, 0x68, 0x0b, 0x52, 0xde, 0x68, 0xe0, 0x57, 0x33, 0x6f, 0x4d,
+	0x92, 0x66, 0xcb, 0xdb, 0xa3, 0x01, 0x56, 0x29, 0xa6, 0xfc, 0xef, 0x02, 0x2c, 0x33, 0x20, 0xcf,
+	0xff, 0xdf, 0x87, 0x0c, 0x2b, 0x58, 0xb9, 0xf2, 0xae, 0x4e, 0x23, 0xa2, 0xe2, 0x23, 0x95, 0xa3,
+	0x52, 0x51, 0x75, 0x6e, 0x50, 0x39, 0x95, 0x7e, 0x93, 0xe8, 0x8f, 0xcf, 0x0c, 0x12, 0x2b, 0x59,
+	0xee, 0xcf, 0x47, 0xe8, 0x1a, 0x80, 0x6b, 0xfc, 0x80, 0xb5, 0xc3, 0x91, 0x87, 0x59, 0xa7, 0x2d,
+	0xa9, 0xe6, 0x08, 0x64, 0x9b, 0x00, 0xc8, 0x74, 0x9f, 0xa4, 0x9c, 0xb8, 0xab, 0x1d, 0x8e, 0x78,
+	0xae, 0x90, 0xe3, 0x90, 0xed, 0x91, 0xfc, 0x2f, 0x02, 0xa0, 0x9a, 0xe1, 0x7a, 0x8c, 0x07, 0x77,
+	0xbe, 0x34, 0xe5, 0x43, 0x58, 0x3c, 0x2f, 0xca, 0x93, 0x17, 0x09, 0xe9, 0xe3, 0x92, 0xfb, 0xd4,
+	0xb0, 0x3a, 0xe6, 0xb0, 0x8b, 0x35, 0xc2, 0x2f, 0x97, 0x6b, 0x89, 0xc3, 0x5a, 0xc6, 0x0f, 0x58,
+	0xfe, 0x1f, 0x01, 0xd6, 0x22, 0xec, 0xcd, 0x65, 0x91, 0x9f, 0x8c, 0xf3, 0x77, 0x7d, 0x1a, 0x7f,
+	0xbc, 0x34, 0x08, 0x58, 0xbc, 0x06, 0x30, 0x74, 0x71, 0x97, 0x2b, 0x37, 0xc9, 0x94, 0x4b, 0x20,
+	0x4c, 0xb9, 0xb7, 0xa0, 0xd0, 0xd1, 0x07, 0x7a, 0xc7, 0xf0, 0x46, 0x11, 0xfd, 0xe7, 0x7d, 0x28,
+	0x45, 0x93, 0xcf, 0x60, 0x4d, 0xc5, 0x7d, 0xfb, 0x14, 0xfb, 0x4a, 0x98, 0x47, 0xc9, 0xe7, 0x86,
+	0x94, 0x98, 0xd9, 0x90, 0xe4, 0x1d, 0xb8, 0x14, 0xdd, 0x79, 0xae, 0x78, 0xf3, 0x47, 0x01, 0x24,
+	0x9a, 0x09, 0x73, 0x73, 0x77, 0x74, 0xcb, 0x3d, 0xc2, 0xce, 0xcf, 0x27, 0x07, 0x6a, 0x43, 0xae,
+	0x6b, 0x38, 0x38, 0xfc, 0x48, 0xf6, 0xd1, 0xe4, 0xba, 0xe9, 0x3c, 0x96, 0x76, 0xfc, 0xd5, 0xea,
+	0x39, 0x21, 0xf9, 0x26, 0xe4, 0x02, 0x38, 0x69, 0xaa, 0x29, 0x4f, 0x9a, 0x0d, 0xb5, 0xcd, 0x5a,
+	0x6f, 0xd5, 0x7d, 0xfa, 0x2d, 0xc8, 0xff, 0x2a, 0xc0, 0xd5, 0x58, 0xc2, 0xbf, 0x4c, 0x70, 0x6c,
+	0xb2, 0x64, 0xd0, 0x0f, 0x8e, 0x7c, 0x48, 0x2a, 0x3b, 0xde, 0x51, 0x9a, 0xcf, 0x87, 0xe5, 0xdf,
+	0x08, 0x41, 0xcb, 0x6b, 0x5e, 0x37, 0xab, 0x85, 0xfa, 0x48, 0x89, 0x69, 0x2d, 0xeb, 0xf1, 0x3d,
+	0xa6, 0xb5, 0x92, 0xa4, 0xef, 0x2e, 0x6e, 0x03, 0x7d, 0x10, 0xcd, 0xb0, 0xae, 0x3f, 0xb7, 0xe6,
+	0x88, 0x14, 0xfc, 0xbf, 0x15, 0x60, 0x39, 0x3c, 0x87, 0xde, 0x81, 0x42, 0x67, 0x30, 0xd4, 0x2c,
+	0xdd, 0xb2, 0xb5, 0x8e, 0xed, 0xd0, 0x4e, 0x98, 0xb0, 0x91, 0x52, 0x97, 0x3b, 0x83, 0x61, 0x5d,
+	0xb7, 0xec, 0x0a, 0x81, 0xa1, 0x8f, 0xa1, 0xd8, 0xc7, 0x7d, 0xdb, 0x19, 0x69, 0xcf, 0x6c, 0xe7,
+	0xc4, 0xb0, 0x7a, 0x9a, 0x8b, 0x3d, 0xee, 0xf9, 0x09, 0x8a, 0x7f, 0x99, 0xcd, 0x3f, 0x66, 0xd3,
+	0x2d, 0xec, 0xb1, 0x40, 0x71, 0x0f, 0x10, 0x5f, 0x68, 0x1a, 0x7d, 0xc3, 0x0b, 0xc5, 0x93, 0x94,
+	0x2a, 0xb2, 0x99, 0x1a, 0x99, 0x60, 0xd8, 0x1b, 0x20, 0x5a, 0xd8, 0x23, 0x5b, 0x68, 0xce, 0x59,
+	0x28, 0xb0, 0xa4, 0xd4, 0x02, 0x87, 0xab, 0x67, 0x13, 0x98, 0x9e, 0x8f, 0x99, 0x8e, 0x60, 0xb6,
+	0x19, 0xa6, 0x8c, 0xa1, 0xb8, 0x8b, 0xbd, 0xe8, 0xab, 0xc9, 0x6b, 0x48, 0x6e, 0x7a, 0xf0, 0x66,
+	0xcc, 0x36, 0x73, 0x99, 0x53, 0x24, 0x91, 0x49, 0x8c, 0xf7, 0x9c, 0x35, 0x40, 0xbb, 0xd8, 0x23,
+	0xef, 0x12, 0xdd, 0x13, 0xc3, 0x7b, 0x0d, 0x92, 0xfc, 0xa3, 0x00, 0x6b, 0x91, 0x1d, 0x7e, 0x7e,
+	0x7f, 0x97, 0x7f, 0x12, 0xe0, 0x32, 0xe5, 0xeb, 0x60, 0xd0, 0x74, 0xf0, 0xa9, 0x81, 0x9f, 0x8d,
+	0x3b, 0xf7, 0x6c, 0x2f, 0xf3, 0x08, 0x52, 0x0e, 0x1e, 0xd8, 0x7e, 0x3e, 0x41, 0xbe, 0x91, 0x0c,
+	0xcb, 0xa1, 0x27, 0x27, 0x97, 0xbf, 0x49, 0x44, 0x60, 0x68, 0x1b, 0x92, 0xd8, 0x3a, 0x2d, 0xa6,
+	0xa6, 0x39, 0x73, 0x2c, 0x6f, 0x25, 0xc5, 0x3a, 0x65, 0xce, 0x4c, 0x16, 0x4b, 0x1f, 0x41, 0xd6,
+	0x07, 0xbc, 0xc8, 0xab, 0xd1, 0x83, 0x54, 0x56, 0x10, 0x13, 0xf2, 0x3f, 0xc0, 0x95, 0xf1, 0x4d,
+	0xe6, 0x3a, 0x87, 0x1b, 0xb0, 0xc4, 0x5b, 0xa7, 0x5a, 0xc7, 0x34, 0x78, 0xf3, 0x0e, 0x38, 0xa8,
+	0x62, 0x1a, 0x53, 0x8b, 0xeb, 0x27, 0x80, 0x1e, 0xeb, 0x5e, 0xe7, 0x58, 0x39, 0xc5, 0xd6, 0x9c,
+	0xb1, 0x95, 0x08, 0xe9, 0x1a, 0x16, 0xb7, 0xe2, 0xa4, 0xca, 0x06, 0xb2, 0x03, 0x6b, 0x11, 0xca,
+	0x73, 0xc9, 0xf5, 0x1e, 0xa4, 0x31, 0x59, 0xcf, 0x8d, 0xfa, 0x8d, 0x98, 0xec, 0x97, 0x4c, 0xab,
+	0x0c, 0x4b, 0xfe, 0x53, 0x02, 0xd2, 0x14, 0x30, 0xad, 0xbb, 0x39, 0xa5, 0x6e, 0xf3, 0x13, 0xe1,
+	0xe4, 0xb4, 0x44, 0x98, 0x12, 0x2d, 0x9d, 0x27, 0xc2, 0xe1, 0x07, 0xd9, 0x54, 0xf4, 0x41, 0xb6,
+	0x08, 0x8b, 0xfc, 0xf5, 0x8e, 0x3f, 0x44, 0xfa, 0xc3, 0xf3, 0x5e, 0x69, 0x26, 0xdc, 0x2b, 0xfd,
+	0x51, 0x80, 0x14, 0x21, 0x8c, 0x72, 0x90, 0x6e, 0xb4, 0xf7, 0x14, 0x55, 0x5c, 0x40, 0x97, 0x61,
+	0xb5, 0x55, 0xd9, 0x53, 0x76, 0x0e, 0x6a, 0xd5, 0xfa, 0xae, 0xf6, 0x75, 0xb9, 0x5a, 0x53, 0x76,
+	0x44, 0x81, 0xf4, 0xef, 0x1f, 0x97, 0xab, 0xbc, 0x5d, 0xbf, 0x0a, 0xf9, 0xe6, 0x41, 0x8d, 0x22,
+	0x54, 0xf7, 0xcb, 0xbb, 0xe4, 0xb1, 0x4c, 0x84, 0x65, 0x02, 0x52, 0x76, 0x38, 0x24, 0x45, 0x9a,
+	0xfc, 0x04, 0xe2, 0x93, 0x48, 0x13, 0x12, 0xad, 0x76, 0x59, 0x6d, 0x2b, 0x3b, 0x62, 0x06, 0x5d,
+	0x01, 0xb4, 0xa7, 0x94, 0x6b, 0xed, 0xbd, 0xca, 0x9e, 0x52, 0x79, 0xe8, 0x23, 0x2d, 0xb2, 0x5c,
+	0xa1, 0x4a, 0x70, 0xb2, 0xa8, 0x00, 0xd0, 0x68, 0xec, 0x6b, 0x0f, 0xab, 0x84, 0xae, 0x98, 0x23,
+	0xe3, 0x8a, 0x5a, 0x6e, 0xed, 0x69, 0xb5, 0x46, 0xa3, 0x29, 0x02, 0x79, 0xba, 0x6b, 0xb5, 0x1b,
+	0xcd, 0x26, 0x61, 0x6a, 0xe9, 0xee, 0x35, 0xc8, 0x05, 0xaf, 0xcd, 0x28, 0x03, 0x89, 0xc6, 0x43,
+	0x71, 0x01, 0x65, 0x21, 0x45, 0xc8, 0x89, 0xc2, 0xdd, 0xff, 0x38, 0xbf, 0x97, 0x62, 0x9e, 0x27,
+	0x8a, 0x70, 0xa9, 0x5a, 0xaf, 0xb6, 0xab, 0xe5, 0x5a, 0xf5, 0x5b, 0x22, 0xd6, 0xa3, 0x46, 0xed,
+	0x60, 0x5f, 0x69, 0x89, 0x02, 0x5a, 0x83, 0x15, 0x22, 0xb8, 0xb6, 0xa3, 0x34, 0x95, 0xfa, 0x4e,
+	0x4b, 0x6b, 0xd4, 0xd9, 0x7b, 0x05, 0x05, 0xb6, 0xbe, 0xa9, 0x57, 0xb4, 0xed, 0x6a, 0x7d, 0x47,
+	0x4c, 0x12, 0x7a, 0x04, 0x83, 0xbe, 0x56, 0x84, 0x9f, 0x3b, 0xd2, 0x21, 0x99, 0x32, 0xec, 0x25,
+	0x92, 0x49, 0xfe, 0x8d, 0xb8, 0x48, 0x34, 0x79, 0x50, 0xe7, 0xfa, 0x2e, 0x6f, 0xd7, 0x14, 0x31,
+	0xcb, 0xd4, 0xd4, 0x68, 0x36, 0x89, 0xc8, 0x77, 0x6f, 0x42, 0x21, 0x5a, 0x63, 0x11, 0x89, 0xf6,
+	0xda, 0xed, 0xa6, 0xb8, 0x80, 0x16, 0x21, 0xb9, 0x77, 0xbf, 0x22, 0x0a, 0x77, 0x37, 0x01, 0xce,
+	0xab, 0x25, 0x72, 0x12, 0xf5, 0xf2, 0xbe, 0xb2, 0xc3, 0x65, 0x10, 0x17, 0xc8, 0x49, 0x10, 0x1e,
+	0x7d, 0x80, 0x70, 0xff, 0xff, 0x57, 0x61, 0x71, 0x9f, 0xfd, 0xfd, 0x0b, 0x1d, 0xc3, 0xca, 0xd8,
+	0x1f, 0x3a, 0xd0, 0xc6, 0xa4, 0x11, 0xc6, 0xff, 0xb3, 0x44, 0xfa, 0xab, 0x19, 0x30, 0x99, 0xf3,
+	0xc9, 0x0b, 0xa8, 0x07, 0x85, 0x68, 0xc0, 0x41, 0x77, 0x66, 0x8c, 0x7b, 0xd2, 0xc6, 0xc5, 0x88,
+	0xfe, 0x36, 0x5b, 0x02, 0x3a, 0x84, 0x7c, 0xe4, 0xbf, 0x1a, 0xe8, 0xf6, 0x6c, 0x7f, 0x49, 0x92,
+	0xee, 0x5c, 0x88, 0x17, 0x08, 0xf3, 0x08, 0x56, 0xd8, 0x63, 0xf4, 0xb9, 0xda, 0x6e, 0x5c, 0xf0,
+	0x2f, 0x02, 0x69, 0x7d, 0x3a, 0x42, 0x40, 0xf7, 0x10, 0xf2, 0x91, 0x57, 0xd6, 0x38, 0xde, 0xe3,
+	0x1e, 0x84, 0xa5, 0x3b, 0x17, 0xe2, 0x05, 0x7b, 0x3c, 0x85, 0xa5, 0xd0, 0xf5, 0x8b, 0xde, 0x89,
+	0x4d, 0x25, 0xc7, 0xee, 0x7f, 0xe9, 0xd6, 0x05, 0x58, 0x21, 0xcd, 0xe4, 0x82, 0x17, 0x58, 0x24,
+	0x4f, 0x4d, 0x53, 0x83, 0xd7, 0x5f, 0xe9, 0xe6, 0x73, 0x71, 0x02, 0xba, 0x16, 0xac, 0x4e, 0xe4,
+	0x3f, 0xe8, 0x6e, 0xec, 0xda, 0xd8, 0x5c, 0x4c, 0x7a, 0x77, 0x26, 0xdc, 0x60, 0xbf, 0x6f, 0x61,
+	0x89, 0x5e, 0x22, 0xaf, 0x5c, 0x92, 0x2d, 0x01, 0x69, 0xb0, 0x1c, 0xfe, 0xc7, 0x23, 0x8a, 0x51,
+	0x6e, 0xcc, 0x7f, 0x28, 0xa5, 0xdb, 0x17, 0xa1, 0x05, 0xcc, 0x37, 0x61, 0x91, 0x3f, 0x47, 0xa1,
+	0xf5, 0xb8, 0xee, 0x68, 0xf8, 0x81, 0x4c, 0x7a, 0xfb, 0x39, 0x18, 0x01, 0xc5, 0x5d, 0x48, 0x91,
+	0x87, 0x24, 0x74, 0x2d, 0xae, 0x38, 0x0c, 0xde, 0xad, 0xa4, 0xeb, 0xd3, 0xa6, 0x03, 0x42, 0x0f,
+	0x20, 0x4d, 0x8b, 0x3e, 0x74, 0x7d, 0x4a, 0x99, 0xe9, 0x93, 0xba, 0x31, 0x75, 0x3e, 0xa0, 0xf5,
+	0x04, 0x72, 0x41, 0xdf, 0x3f, 0xee, 0x84, 0xc6, 0x1f, 0x89, 0xa4, 0x9b, 0xcf, 0xc5, 0x09, 0x9d,
+	0xd0, 0x13, 0xc8, 0x05, 0x2d, 0xe2, 0x38, 0xca, 0xe3, 0xfd, 0x6e, 0xe9, 0xe6, 0x73, 0x71, 0x42,
+	0x94, 0xf7, 0x21, 0xc3, 0x42, 0x7a, 0x5c, 0xc0, 0x88, 0x34, 0x8e, 0xa5, 0xf5, 0xe9, 0x08, 0x81,
+	0x0a, 0x5a, 0x90, 0xf5, 0x7b, 0x9e, 0x28, 0xe6, 0x20, 0xc7, 0xba, 0xad, 0x92, 0xfc, 0x3c, 0x94,
+	0x80, 0xe8, 0xf7, 0x80, 0x26, 0x9b, 0x96, 0xe8, 0xdd, 0x58, 0x3b, 0x89, 0x6f, 0x96, 0x4a, 0xf7,
+	0x66, 0x43, 0x0e, 0x07, 0xa5, 0x50, 0x3b, 0x2a, 0x2e, 0x28, 0x4d, 0x36, 0xd3, 0xa4, 0x5b, 0x17,
+	0x60, 0x05, 0xd4, 0x35, 0x58, 0x0e, 0x77, 0x6b, 0xe2, 0x1c, 0x2e, 0xa6, 0x8f, 0x24, 0xdd, 0xbe,
+	0x08, 0x2d, 0xd8, 0xc0, 0x83, 0xb5, 0x98, 0x56, 0x06, 0xba, 0xf7, 0x22, 0xad, 0x14, 0xe9, 0xbd,
+	0x19, 0xb1, 0xc3, 0x87, 0xef, 0x57, 0xfd, 0x71, 0x87, 0x3f, 0xd6, 0xb7, 0x90, 0xe4, 0x8b, 0x9b,
+	0x06, 0xf2, 0x02, 0x7a, 0x0c, 0x10, 0x04, 0xbe, 0x57, 0x47, 0x76, 0x4b, 0x40, 0x7f, 0xcf, 0x23,
+	0x2a, 0x4b, 0xcb, 0xe3, 0x8e, 0x78, 0xb2, 0x1e, 0x90, 0x6e, 0x5d, 0x80, 0x75, 0x4e, 0x7f, 0xfb,
+	0xee, 0xb7, 0x1b, 0x3d, 0xc3, 0x3b, 0x1e, 0x1e, 0x96, 0x3a, 0x76, 0x7f, 0xf3, 0x04, 0x9b, 0x5d,
+	0x7d, 0x93, 0xfd, 0x55, 0x7d, 0x70, 0xd2, 0xdb, 0xa4, 0xbd, 0x69, 0xff, 0x0f, 0xf0, 0x87, 0x19,
+	0x3a, 0x7c, 0xff, 0xcf, 0x03, 0x00, 0xe4, 0xfe, 0x81, 0x73, 0x18, 0x2f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1954,9 +3860,33 @@ type ManagerClient interface {
 	WatchStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (Manager_WatchStatusClient, error)
 	CheckVersion(ctx context.Context, in *CheckVersionRequest, opts ...grpc.CallOption) (*CheckVersionResponse, error)
 	Restart(ctx context.Context, in *RestartRequest, opts ...grpc.CallOption) (*RestartResponse, error)
+	// Stop deletes a service's pod, but remembers its spec so that Start can
+	// boot it again without redeploying the whole sandbox.
+	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
+	// RunOneOff boots a temporary pod for running a command in a service's
+	// environment. The CLI attaches to the pod through the node controller
+	// once it's running.
+	RunOneOff(ctx context.Context, in *RunOneOffRequest, opts ...grpc.CallOption) (Manager_RunOneOffClient, error)
 	TagImages(ctx context.Context, in *TagImagesRequest, opts ...grpc.CallOption) (Manager_TagImagesClient, error)
 	Expose(ctx context.Context, in *ExposeRequest, opts ...grpc.CallOption) (*ExposeResponse, error)
 	Unexpose(ctx context.Context, in *UnexposeRequest, opts ...grpc.CallOption) (*UnexposeResponse, error)
+	// ResolveExposedLink is used by the link proxy to find the node controller
+	// that should be used to tunnel traffic for an exposed link.
+	ResolveExposedLink(ctx context.Context, in *ResolveExposedLinkRequest, opts ...grpc.CallOption) (*ResolveExposedLinkResponse, error)
+	ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error)
+	RemoveVolume(ctx context.Context, in *RemoveVolumeRequest, opts ...grpc.CallOption) (*RemoveVolumeResponse, error)
+	// StartVolumeTransfer boots a pod that exports or imports the contents of
+	// a volume. The CLI then streams the volume's archive over a node
+	// controller tunnel.
+	StartVolumeTransfer(ctx context.Context, in *StartVolumeTransferRequest, opts ...grpc.CallOption) (*StartVolumeTransferResponse, error)
+	// GetStats returns the resource usage of each service, as reported by the
+	// kubelet. WatchStats streams the usage every time it's refreshed.
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	WatchStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (Manager_WatchStatsClient, error)
+	// WatchEvents streams the lifecycle events of the sandbox's services, such
+	// as image pulls, crashes, and failed healthchecks.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (Manager_WatchEventsClient, error)
 }
 
 type managerClient struct {
@@ -1996,78 +3926,273 @@ type Manager_BlimpUpPreviewClient interface {
 	grpc.ClientStream
 }
 
-type managerBlimpUpPreviewClient struct {
+type managerBlimpUpPreviewClient struct {
+	grpc.ClientStream
+}
+
+func (x *managerBlimpUpPreviewClient) Recv() (*BlimpUpPreviewResponse, error) {
+	m := new(BlimpUpPreviewResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managerClient) CreateSandbox(ctx context.Context, in *CreateSandboxRequest, opts ...grpc.CallOption) (*CreateSandboxResponse, error) {
+	out := new(CreateSandboxResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/CreateSandbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) DeployToSandbox(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployResponse, error) {
+	out := new(DeployResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/DeployToSandbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) DeleteSandbox(ctx context.Context, in *DeleteSandboxRequest, opts ...grpc.CallOption) (*DeleteSandboxResponse, error) {
+	out := new(DeleteSandboxResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/DeleteSandbox", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) GetBuildkit(ctx context.Context, in *GetBuildkitRequest, opts ...grpc.CallOption) (*GetBuildkitResponse, error) {
+	out := new(GetBuildkitResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetBuildkit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) GetImageNamespace(ctx context.Context, in *GetImageNamespaceRequest, opts ...grpc.CallOption) (*GetImageNamespaceResponse, error) {
+	out := new(GetImageNamespaceResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetImageNamespace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) WatchStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (Manager_WatchStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[1], "/blimp.cluster.v0.Manager/WatchStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managerWatchStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Manager_WatchStatusClient interface {
+	Recv() (*GetStatusResponse, error)
+	grpc.ClientStream
+}
+
+type managerWatchStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *managerWatchStatusClient) Recv() (*GetStatusResponse, error) {
+	m := new(GetStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managerClient) CheckVersion(ctx context.Context, in *CheckVersionRequest, opts ...grpc.CallOption) (*CheckVersionResponse, error) {
+	out := new(CheckVersionResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/CheckVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) Restart(ctx context.Context, in *RestartRequest, opts ...grpc.CallOption) (*RestartResponse, error) {
+	out := new(RestartResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/Restart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	out := new(StopResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/Stop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error) {
+	out := new(StartResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/Start", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managerClient) RunOneOff(ctx context.Context, in *RunOneOffRequest, opts ...grpc.CallOption) (Manager_RunOneOffClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[2], "/blimp.cluster.v0.Manager/RunOneOff", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managerRunOneOffClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Manager_RunOneOffClient interface {
+	Recv() (*RunOneOffResponse, error)
+	grpc.ClientStream
+}
+
+type managerRunOneOffClient struct {
+	grpc.ClientStream
+}
+
+func (x *managerRunOneOffClient) Recv() (*RunOneOffResponse, error) {
+	m := new(RunOneOffResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *managerClient) TagImages(ctx context.Context, in *TagImagesRequest, opts ...grpc.CallOption) (Manager_TagImagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[3], "/blimp.cluster.v0.Manager/TagImages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managerTagImagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Manager_TagImagesClient interface {
+	Recv() (*TagImagesResponse, error)
+	grpc.ClientStream
+}
+
+type managerTagImagesClient struct {
 	grpc.ClientStream
 }
 
-func (x *managerBlimpUpPreviewClient) Recv() (*BlimpUpPreviewResponse, error) {
-	m := new(BlimpUpPreviewResponse)
+func (x *managerTagImagesClient) Recv() (*TagImagesResponse, error) {
+	m := new(TagImagesResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *managerClient) CreateSandbox(ctx context.Context, in *CreateSandboxRequest, opts ...grpc.CallOption) (*CreateSandboxResponse, error) {
-	out := new(CreateSandboxResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/CreateSandbox", in, out, opts...)
+func (c *managerClient) Expose(ctx context.Context, in *ExposeRequest, opts ...grpc.CallOption) (*ExposeResponse, error) {
+	out := new(ExposeResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/Expose", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *managerClient) DeployToSandbox(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployResponse, error) {
-	out := new(DeployResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/DeployToSandbox", in, out, opts...)
+func (c *managerClient) Unexpose(ctx context.Context, in *UnexposeRequest, opts ...grpc.CallOption) (*UnexposeResponse, error) {
+	out := new(UnexposeResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/Unexpose", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *managerClient) DeleteSandbox(ctx context.Context, in *DeleteSandboxRequest, opts ...grpc.CallOption) (*DeleteSandboxResponse, error) {
-	out := new(DeleteSandboxResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/DeleteSandbox", in, out, opts...)
+func (c *managerClient) ResolveExposedLink(ctx context.Context, in *ResolveExposedLinkRequest, opts ...grpc.CallOption) (*ResolveExposedLinkResponse, error) {
+	out := new(ResolveExposedLinkResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/ResolveExposedLink", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *managerClient) GetBuildkit(ctx context.Context, in *GetBuildkitRequest, opts ...grpc.CallOption) (*GetBuildkitResponse, error) {
-	out := new(GetBuildkitResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetBuildkit", in, out, opts...)
+func (c *managerClient) ListVolumes(ctx context.Context, in *ListVolumesRequest, opts ...grpc.CallOption) (*ListVolumesResponse, error) {
+	out := new(ListVolumesResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/ListVolumes", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *managerClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
-	out := new(GetStatusResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetStatus", in, out, opts...)
+func (c *managerClient) RemoveVolume(ctx context.Context, in *RemoveVolumeRequest, opts ...grpc.CallOption) (*RemoveVolumeResponse, error) {
+	out := new(RemoveVolumeResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/RemoveVolume", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *managerClient) GetImageNamespace(ctx context.Context, in *GetImageNamespaceRequest, opts ...grpc.CallOption) (*GetImageNamespaceResponse, error) {
-	out := new(GetImageNamespaceResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetImageNamespace", in, out, opts...)
+func (c *managerClient) StartVolumeTransfer(ctx context.Context, in *StartVolumeTransferRequest, opts ...grpc.CallOption) (*StartVolumeTransferResponse, error) {
+	out := new(StartVolumeTransferResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/StartVolumeTransfer", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *managerClient) WatchStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (Manager_WatchStatusClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[1], "/blimp.cluster.v0.Manager/WatchStatus", opts...)
+func (c *managerClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/GetStats", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &managerWatchStatusClient{stream}
+	return out, nil
+}
+
+func (c *managerClient) WatchStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (Manager_WatchStatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[4], "/blimp.cluster.v0.Manager/WatchStats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &managerWatchStatsClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2077,47 +4202,29 @@ func (c *managerClient) WatchStatus(ctx context.Context, in *GetStatusRequest, o
 	return x, nil
 }
 
-type Manager_WatchStatusClient interface {
-	Recv() (*GetStatusResponse, error)
+type Manager_WatchStatsClient interface {
+	Recv() (*GetStatsResponse, error)
 	grpc.ClientStream
 }
 
-type managerWatchStatusClient struct {
+type managerWatchStatsClient struct {
 	grpc.ClientStream
 }
 
-func (x *managerWatchStatusClient) Recv() (*GetStatusResponse, error) {
-	m := new(GetStatusResponse)
+func (x *managerWatchStatsClient) Recv() (*GetStatsResponse, error) {
+	m := new(GetStatsResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *managerClient) CheckVersion(ctx context.Context, in *CheckVersionRequest, opts ...grpc.CallOption) (*CheckVersionResponse, error) {
-	out := new(CheckVersionResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/CheckVersion", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *managerClient) Restart(ctx context.Context, in *RestartRequest, opts ...grpc.CallOption) (*RestartResponse, error) {
-	out := new(RestartResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/Restart", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *managerClient) TagImages(ctx context.Context, in *TagImagesRequest, opts ...grpc.CallOption) (Manager_TagImagesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[2], "/blimp.cluster.v0.Manager/TagImages", opts...)
+func (c *managerClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (Manager_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Manager_serviceDesc.Streams[5], "/blimp.cluster.v0.Manager/WatchEvents", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &managerTagImagesClient{stream}
+	x := &managerWatchEventsClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2127,41 +4234,23 @@ func (c *managerClient) TagImages(ctx context.Context, in *TagImagesRequest, opt
 	return x, nil
 }
 
-type Manager_TagImagesClient interface {
-	Recv() (*TagImagesResponse, error)
+type Manager_WatchEventsClient interface {
+	Recv() (*WatchEventsResponse, error)
 	grpc.ClientStream
 }
 
-type managerTagImagesClient struct {
+type managerWatchEventsClient struct {
 	grpc.ClientStream
 }
 
-func (x *managerTagImagesClient) Recv() (*TagImagesResponse, error) {
-	m := new(TagImagesResponse)
+func (x *managerWatchEventsClient) Recv() (*WatchEventsResponse, error) {
+	m := new(WatchEventsResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *managerClient) Expose(ctx context.Context, in *ExposeRequest, opts ...grpc.CallOption) (*ExposeResponse, error) {
-	out := new(ExposeResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/Expose", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-func (c *managerClient) Unexpose(ctx context.Context, in *UnexposeRequest, opts ...grpc.CallOption) (*UnexposeResponse, error) {
-	out := new(UnexposeResponse)
-	err := c.cc.Invoke(ctx, "/blimp.cluster.v0.Manager/Unexpose", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
 // ManagerServer is the server API for Manager service.
 type ManagerServer interface {
 	AttachToSandbox(context.Context, *AttachToSandboxRequest) (*AttachToSandboxResponse, error)
@@ -2175,9 +4264,33 @@ type ManagerServer interface {
 	WatchStatus(*GetStatusRequest, Manager_WatchStatusServer) error
 	CheckVersion(context.Context, *CheckVersionRequest) (*CheckVersionResponse, error)
 	Restart(context.Context, *RestartRequest) (*RestartResponse, error)
+	// Stop deletes a service's pod, but remembers its spec so that Start can
+	// boot it again without redeploying the whole sandbox.
+	Stop(context.Context, *StopRequest) (*StopResponse, error)
+	Start(context.Context, *StartRequest) (*StartResponse, error)
+	// RunOneOff boots a temporary pod for running a command in a service's
+	// environment. The CLI attaches to the pod through the node controller
+	// once it's running.
+	RunOneOff(*RunOneOffRequest, Manager_RunOneOffServer) error
 	TagImages(*TagImagesRequest, Manager_TagImagesServer) error
 	Expose(context.Context, *ExposeRequest) (*ExposeResponse, error)
 	Unexpose(context.Context, *UnexposeRequest) (*UnexposeResponse, error)
+	// ResolveExposedLink is used by the link proxy to find the node controller
+	// that should be used to tunnel traffic for an exposed link.
+	ResolveExposedLink(context.Context, *ResolveExposedLinkRequest) (*ResolveExposedLinkResponse, error)
+	ListVolumes(context.Context, *ListVolumesRequest) (*ListVolumesResponse, error)
+	RemoveVolume(context.Context, *RemoveVolumeRequest) (*RemoveVolumeResponse, error)
+	// StartVolumeTransfer boots a pod that exports or imports the contents of
+	// a volume. The CLI then streams the volume's archive over a node
+	// controller tunnel.
+	StartVolumeTransfer(context.Context, *StartVolumeTransferRequest) (*StartVolumeTransferResponse, error)
+	// GetStats returns the resource usage of each service, as reported by the
+	// kubelet. WatchStats streams the usage every time it's refreshed.
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	WatchStats(*GetStatsRequest, Manager_WatchStatsServer) error
+	// WatchEvents streams the lifecycle events of the sandbox's services, such
+	// as image pulls, crashes, and failed healthchecks.
+	WatchEvents(*WatchEventsRequest, Manager_WatchEventsServer) error
 }
 
 // UnimplementedManagerServer can be embedded to have forward compatible implementations.
@@ -2217,6 +4330,15 @@ func (*UnimplementedManagerServer) CheckVersion(ctx context.Context, req *CheckV
 func (*UnimplementedManagerServer) Restart(ctx context.Context, req *RestartRequest) (*RestartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Restart not implemented")
 }
+func (*UnimplementedManagerServer) Stop(ctx context.Context, req *StopRequest) (*StopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
+}
+func (*UnimplementedManagerServer) Start(ctx context.Context, req *StartRequest) (*StartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
+}
+func (*UnimplementedManagerServer) RunOneOff(req *RunOneOffRequest, srv Manager_RunOneOffServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunOneOff not implemented")
+}
 func (*UnimplementedManagerServer) TagImages(req *TagImagesRequest, srv Manager_TagImagesServer) error {
 	return status.Errorf(codes.Unimplemented, "method TagImages not implemented")
 }
@@ -2226,6 +4348,27 @@ func (*UnimplementedManagerServer) Expose(ctx context.Context, req *ExposeReques
 func (*UnimplementedManagerServer) Unexpose(ctx context.Context, req *UnexposeRequest) (*UnexposeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Unexpose not implemented")
 }
+func (*UnimplementedManagerServer) ResolveExposedLink(ctx context.Context, req *ResolveExposedLinkRequest) (*ResolveExposedLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveExposedLink not implemented")
+}
+func (*UnimplementedManagerServer) ListVolumes(ctx context.Context, req *ListVolumesRequest) (*ListVolumesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVolumes not implemented")
+}
+func (*UnimplementedManagerServer) RemoveVolume(ctx context.Context, req *RemoveVolumeRequest) (*RemoveVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveVolume not implemented")
+}
+func (*UnimplementedManagerServer) StartVolumeTransfer(ctx context.Context, req *StartVolumeTransferRequest) (*StartVolumeTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartVolumeTransfer not implemented")
+}
+func (*UnimplementedManagerServer) GetStats(ctx context.Context, req *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (*UnimplementedManagerServer) WatchStats(req *GetStatsRequest, srv Manager_WatchStatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStats not implemented")
+}
+func (*UnimplementedManagerServer) WatchEvents(req *WatchEventsRequest, srv Manager_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
 
 func RegisterManagerServer(s *grpc.Server, srv ManagerServer) {
 	s.RegisterService(&_Manager_serviceDesc, srv)
@@ -2435,6 +4578,63 @@ func _Manager_Restart_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Manager_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).Stop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/Stop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).Stop(ctx, req.(*StopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).Start(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/Start",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).Start(ctx, req.(*StartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_RunOneOff_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunOneOffRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManagerServer).RunOneOff(m, &managerRunOneOffServer{stream})
+}
+
+type Manager_RunOneOffServer interface {
+	Send(*RunOneOffResponse) error
+	grpc.ServerStream
+}
+
+type managerRunOneOffServer struct {
+	grpc.ServerStream
+}
+
+func (x *managerRunOneOffServer) Send(m *RunOneOffResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Manager_TagImages_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(TagImagesRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -2492,6 +4692,138 @@ func _Manager_Unexpose_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Manager_ResolveExposedLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveExposedLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).ResolveExposedLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/ResolveExposedLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).ResolveExposedLink(ctx, req.(*ResolveExposedLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_ListVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVolumesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).ListVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/ListVolumes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).ListVolumes(ctx, req.(*ListVolumesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_RemoveVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).RemoveVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/RemoveVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).RemoveVolume(ctx, req.(*RemoveVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_StartVolumeTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartVolumeTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).StartVolumeTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/StartVolumeTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).StartVolumeTransfer(ctx, req.(*StartVolumeTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/blimp.cluster.v0.Manager/GetStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Manager_WatchStats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetStatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManagerServer).WatchStats(m, &managerWatchStatsServer{stream})
+}
+
+type Manager_WatchStatsServer interface {
+	Send(*GetStatsResponse) error
+	grpc.ServerStream
+}
+
+type managerWatchStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *managerWatchStatsServer) Send(m *GetStatsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Manager_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ManagerServer).WatchEvents(m, &managerWatchEventsServer{stream})
+}
+
+type Manager_WatchEventsServer interface {
+	Send(*WatchEventsResponse) error
+	grpc.ServerStream
+}
+
+type managerWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *managerWatchEventsServer) Send(m *WatchEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Manager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "blimp.cluster.v0.Manager",
 	HandlerType: (*ManagerServer)(nil),
@@ -2532,6 +4864,14 @@ var _Manager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Restart",
 			Handler:    _Manager_Restart_Handler,
 		},
+		{
+			MethodName: "Stop",
+			Handler:    _Manager_Stop_Handler,
+		},
+		{
+			MethodName: "Start",
+			Handler:    _Manager_Start_Handler,
+		},
 		{
 			MethodName: "Expose",
 			Handler:    _Manager_Expose_Handler,
@@ -2540,6 +4880,26 @@ var _Manager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Unexpose",
 			Handler:    _Manager_Unexpose_Handler,
 		},
+		{
+			MethodName: "ResolveExposedLink",
+			Handler:    _Manager_ResolveExposedLink_Handler,
+		},
+		{
+			MethodName: "ListVolumes",
+			Handler:    _Manager_ListVolumes_Handler,
+		},
+		{
+			MethodName: "RemoveVolume",
+			Handler:    _Manager_RemoveVolume_Handler,
+		},
+		{
+			MethodName: "StartVolumeTransfer",
+			Handler:    _Manager_StartVolumeTransfer_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Manager_GetStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2552,11 +4912,26 @@ var _Manager_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Manager_WatchStatus_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "RunOneOff",
+			Handler:       _Manager_RunOneOff_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "TagImages",
 			Handler:       _Manager_TagImages_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchStats",
+			Handler:       _Manager_WatchStats_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Manager_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "blimp/cluster/v0/manager.proto",
 }
@@ -136,6 +136,147 @@ func (m *ExposedTunnelHeader) GetNamespace() string {
 	return ""
 }
 
+type ReverseTunnelRequest struct {
+	Auth                 *auth.BlimpAuth `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Ports                []uint32        `protobuf:"varint,2,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ReverseTunnelRequest) Reset()         { *m = ReverseTunnelRequest{} }
+func (m *ReverseTunnelRequest) String() string { return proto.CompactTextString(m) }
+func (*ReverseTunnelRequest) ProtoMessage()    {}
+func (*ReverseTunnelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{2}
+}
+
+func (m *ReverseTunnelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReverseTunnelRequest.Unmarshal(m, b)
+}
+func (m *ReverseTunnelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReverseTunnelRequest.Marshal(b, m, deterministic)
+}
+func (m *ReverseTunnelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReverseTunnelRequest.Merge(m, src)
+}
+func (m *ReverseTunnelRequest) XXX_Size() int {
+	return xxx_messageInfo_ReverseTunnelRequest.Size(m)
+}
+func (m *ReverseTunnelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReverseTunnelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReverseTunnelRequest proto.InternalMessageInfo
+
+func (m *ReverseTunnelRequest) GetAuth() *auth.BlimpAuth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *ReverseTunnelRequest) GetPorts() []uint32 {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+type ReverseTunnelConn struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Port                 uint32   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReverseTunnelConn) Reset()         { *m = ReverseTunnelConn{} }
+func (m *ReverseTunnelConn) String() string { return proto.CompactTextString(m) }
+func (*ReverseTunnelConn) ProtoMessage()    {}
+func (*ReverseTunnelConn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{3}
+}
+
+func (m *ReverseTunnelConn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReverseTunnelConn.Unmarshal(m, b)
+}
+func (m *ReverseTunnelConn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReverseTunnelConn.Marshal(b, m, deterministic)
+}
+func (m *ReverseTunnelConn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReverseTunnelConn.Merge(m, src)
+}
+func (m *ReverseTunnelConn) XXX_Size() int {
+	return xxx_messageInfo_ReverseTunnelConn.Size(m)
+}
+func (m *ReverseTunnelConn) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReverseTunnelConn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReverseTunnelConn proto.InternalMessageInfo
+
+func (m *ReverseTunnelConn) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ReverseTunnelConn) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+type ReverseTunnelHeader struct {
+	Auth                 *auth.BlimpAuth `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	Id                   string          `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ReverseTunnelHeader) Reset()         { *m = ReverseTunnelHeader{} }
+func (m *ReverseTunnelHeader) String() string { return proto.CompactTextString(m) }
+func (*ReverseTunnelHeader) ProtoMessage()    {}
+func (*ReverseTunnelHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{4}
+}
+
+func (m *ReverseTunnelHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReverseTunnelHeader.Unmarshal(m, b)
+}
+func (m *ReverseTunnelHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReverseTunnelHeader.Marshal(b, m, deterministic)
+}
+func (m *ReverseTunnelHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReverseTunnelHeader.Merge(m, src)
+}
+func (m *ReverseTunnelHeader) XXX_Size() int {
+	return xxx_messageInfo_ReverseTunnelHeader.Size(m)
+}
+func (m *ReverseTunnelHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReverseTunnelHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReverseTunnelHeader proto.InternalMessageInfo
+
+func (m *ReverseTunnelHeader) GetAuth() *auth.BlimpAuth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *ReverseTunnelHeader) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
 type EOF struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -146,7 +287,7 @@ func (m *EOF) Reset()         { *m = EOF{} }
 func (m *EOF) String() string { return proto.CompactTextString(m) }
 func (*EOF) ProtoMessage()    {}
 func (*EOF) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ffe3c8ce6343e9a1, []int{2}
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{5}
 }
 
 func (m *EOF) XXX_Unmarshal(b []byte) error {
@@ -175,6 +316,7 @@ type TunnelMsg struct {
 	//	*TunnelMsg_Error
 	//	*TunnelMsg_Header
 	//	*TunnelMsg_ExposedHeader
+	//	*TunnelMsg_ReverseHeader
 	//	*TunnelMsg_Buf
 	//	*TunnelMsg_Eof
 	Msg                  isTunnelMsg_Msg `protobuf_oneof:"msg"`
@@ -187,7 +329,7 @@ func (m *TunnelMsg) Reset()         { *m = TunnelMsg{} }
 func (m *TunnelMsg) String() string { return proto.CompactTextString(m) }
 func (*TunnelMsg) ProtoMessage()    {}
 func (*TunnelMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ffe3c8ce6343e9a1, []int{3}
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{6}
 }
 
 func (m *TunnelMsg) XXX_Unmarshal(b []byte) error {
@@ -224,6 +366,10 @@ type TunnelMsg_ExposedHeader struct {
 	ExposedHeader *ExposedTunnelHeader `protobuf:"bytes,5,opt,name=exposed_header,json=exposedHeader,proto3,oneof"`
 }
 
+type TunnelMsg_ReverseHeader struct {
+	ReverseHeader *ReverseTunnelHeader `protobuf:"bytes,6,opt,name=reverse_header,json=reverseHeader,proto3,oneof"`
+}
+
 type TunnelMsg_Buf struct {
 	Buf []byte `protobuf:"bytes,3,opt,name=buf,proto3,oneof"`
 }
@@ -238,6 +384,8 @@ func (*TunnelMsg_Header) isTunnelMsg_Msg() {}
 
 func (*TunnelMsg_ExposedHeader) isTunnelMsg_Msg() {}
 
+func (*TunnelMsg_ReverseHeader) isTunnelMsg_Msg() {}
+
 func (*TunnelMsg_Buf) isTunnelMsg_Msg() {}
 
 func (*TunnelMsg_Eof) isTunnelMsg_Msg() {}
@@ -270,6 +418,13 @@ func (m *TunnelMsg) GetExposedHeader() *ExposedTunnelHeader {
 	return nil
 }
 
+func (m *TunnelMsg) GetReverseHeader() *ReverseTunnelHeader {
+	if x, ok := m.GetMsg().(*TunnelMsg_ReverseHeader); ok {
+		return x.ReverseHeader
+	}
+	return nil
+}
+
 func (m *TunnelMsg) GetBuf() []byte {
 	if x, ok := m.GetMsg().(*TunnelMsg_Buf); ok {
 		return x.Buf
@@ -290,16 +445,264 @@ func (*TunnelMsg) XXX_OneofWrappers() []interface{} {
 		(*TunnelMsg_Error)(nil),
 		(*TunnelMsg_Header)(nil),
 		(*TunnelMsg_ExposedHeader)(nil),
+		(*TunnelMsg_ReverseHeader)(nil),
 		(*TunnelMsg_Buf)(nil),
 		(*TunnelMsg_Eof)(nil),
 	}
 }
 
+type AttachHeader struct {
+	Auth                 *auth.BlimpAuth `protobuf:"bytes,1,opt,name=auth,proto3" json:"auth,omitempty"`
+	PodName              string          `protobuf:"bytes,2,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	Tty                  bool            `protobuf:"varint,3,opt,name=tty,proto3" json:"tty,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *AttachHeader) Reset()         { *m = AttachHeader{} }
+func (m *AttachHeader) String() string { return proto.CompactTextString(m) }
+func (*AttachHeader) ProtoMessage()    {}
+func (*AttachHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{7}
+}
+
+func (m *AttachHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttachHeader.Unmarshal(m, b)
+}
+func (m *AttachHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttachHeader.Marshal(b, m, deterministic)
+}
+func (m *AttachHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttachHeader.Merge(m, src)
+}
+func (m *AttachHeader) XXX_Size() int {
+	return xxx_messageInfo_AttachHeader.Size(m)
+}
+func (m *AttachHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttachHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttachHeader proto.InternalMessageInfo
+
+func (m *AttachHeader) GetAuth() *auth.BlimpAuth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (m *AttachHeader) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+func (m *AttachHeader) GetTty() bool {
+	if m != nil {
+		return m.Tty
+	}
+	return false
+}
+
+type TerminalSize struct {
+	Width                uint32   `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height               uint32   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TerminalSize) Reset()         { *m = TerminalSize{} }
+func (m *TerminalSize) String() string { return proto.CompactTextString(m) }
+func (*TerminalSize) ProtoMessage()    {}
+func (*TerminalSize) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{8}
+}
+
+func (m *TerminalSize) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TerminalSize.Unmarshal(m, b)
+}
+func (m *TerminalSize) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TerminalSize.Marshal(b, m, deterministic)
+}
+func (m *TerminalSize) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TerminalSize.Merge(m, src)
+}
+func (m *TerminalSize) XXX_Size() int {
+	return xxx_messageInfo_TerminalSize.Size(m)
+}
+func (m *TerminalSize) XXX_DiscardUnknown() {
+	xxx_messageInfo_TerminalSize.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TerminalSize proto.InternalMessageInfo
+
+func (m *TerminalSize) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *TerminalSize) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// The first message the client sends to the server must be a header. After
+// that, the client sends stdin and terminal resizes, and the server sends
+// stdout and stderr. The server closes the stream once the pod's process
+// exits.
+type AttachMsg struct {
+	// Types that are valid to be assigned to Msg:
+	//	*AttachMsg_Header
+	//	*AttachMsg_Stdin
+	//	*AttachMsg_StdinEof
+	//	*AttachMsg_Resize
+	//	*AttachMsg_Stdout
+	//	*AttachMsg_Stderr
+	Msg                  isAttachMsg_Msg `protobuf_oneof:"msg"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *AttachMsg) Reset()         { *m = AttachMsg{} }
+func (m *AttachMsg) String() string { return proto.CompactTextString(m) }
+func (*AttachMsg) ProtoMessage()    {}
+func (*AttachMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{9}
+}
+
+func (m *AttachMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttachMsg.Unmarshal(m, b)
+}
+func (m *AttachMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttachMsg.Marshal(b, m, deterministic)
+}
+func (m *AttachMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttachMsg.Merge(m, src)
+}
+func (m *AttachMsg) XXX_Size() int {
+	return xxx_messageInfo_AttachMsg.Size(m)
+}
+func (m *AttachMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttachMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttachMsg proto.InternalMessageInfo
+
+type isAttachMsg_Msg interface {
+	isAttachMsg_Msg()
+}
+
+type AttachMsg_Header struct {
+	Header *AttachHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type AttachMsg_Stdin struct {
+	Stdin []byte `protobuf:"bytes,2,opt,name=stdin,proto3,oneof"`
+}
+
+type AttachMsg_StdinEof struct {
+	StdinEof *EOF `protobuf:"bytes,3,opt,name=stdin_eof,json=stdinEof,proto3,oneof"`
+}
+
+type AttachMsg_Resize struct {
+	Resize *TerminalSize `protobuf:"bytes,4,opt,name=resize,proto3,oneof"`
+}
+
+type AttachMsg_Stdout struct {
+	Stdout []byte `protobuf:"bytes,5,opt,name=stdout,proto3,oneof"`
+}
+
+type AttachMsg_Stderr struct {
+	Stderr []byte `protobuf:"bytes,6,opt,name=stderr,proto3,oneof"`
+}
+
+func (*AttachMsg_Header) isAttachMsg_Msg() {}
+
+func (*AttachMsg_Stdin) isAttachMsg_Msg() {}
+
+func (*AttachMsg_StdinEof) isAttachMsg_Msg() {}
+
+func (*AttachMsg_Resize) isAttachMsg_Msg() {}
+
+func (*AttachMsg_Stdout) isAttachMsg_Msg() {}
+
+func (*AttachMsg_Stderr) isAttachMsg_Msg() {}
+
+func (m *AttachMsg) GetMsg() isAttachMsg_Msg {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *AttachMsg) GetHeader() *AttachHeader {
+	if x, ok := m.GetMsg().(*AttachMsg_Header); ok {
+		return x.Header
+	}
+	return nil
+}
+
+func (m *AttachMsg) GetStdin() []byte {
+	if x, ok := m.GetMsg().(*AttachMsg_Stdin); ok {
+		return x.Stdin
+	}
+	return nil
+}
+
+func (m *AttachMsg) GetStdinEof() *EOF {
+	if x, ok := m.GetMsg().(*AttachMsg_StdinEof); ok {
+		return x.StdinEof
+	}
+	return nil
+}
+
+func (m *AttachMsg) GetResize() *TerminalSize {
+	if x, ok := m.GetMsg().(*AttachMsg_Resize); ok {
+		return x.Resize
+	}
+	return nil
+}
+
+func (m *AttachMsg) GetStdout() []byte {
+	if x, ok := m.GetMsg().(*AttachMsg_Stdout); ok {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (m *AttachMsg) GetStderr() []byte {
+	if x, ok := m.GetMsg().(*AttachMsg_Stderr); ok {
+		return x.Stderr
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*AttachMsg) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*AttachMsg_Header)(nil),
+		(*AttachMsg_Stdin)(nil),
+		(*AttachMsg_StdinEof)(nil),
+		(*AttachMsg_Resize)(nil),
+		(*AttachMsg_Stdout)(nil),
+		(*AttachMsg_Stderr)(nil),
+	}
+}
+
 type SyncStatusResponse struct {
 	// Types that are valid to be assigned to Msg:
 	//	*SyncStatusResponse_OldToken
 	//	*SyncStatusResponse_Auth
 	//	*SyncStatusResponse_Synced
+	//	*SyncStatusResponse_Folders
 	Msg                  isSyncStatusResponse_Msg `protobuf_oneof:"msg"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
@@ -310,7 +713,7 @@ func (m *SyncStatusResponse) Reset()         { *m = SyncStatusResponse{} }
 func (m *SyncStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*SyncStatusResponse) ProtoMessage()    {}
 func (*SyncStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ffe3c8ce6343e9a1, []int{4}
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{10}
 }
 
 func (m *SyncStatusResponse) XXX_Unmarshal(b []byte) error {
@@ -347,12 +750,18 @@ type SyncStatusResponse_Synced struct {
 	Synced bool `protobuf:"varint,2,opt,name=synced,proto3,oneof"`
 }
 
+type SyncStatusResponse_Folders struct {
+	Folders *FolderSyncStatuses `protobuf:"bytes,4,opt,name=folders,proto3,oneof"`
+}
+
 func (*SyncStatusResponse_OldToken) isSyncStatusResponse_Msg() {}
 
 func (*SyncStatusResponse_Auth) isSyncStatusResponse_Msg() {}
 
 func (*SyncStatusResponse_Synced) isSyncStatusResponse_Msg() {}
 
+func (*SyncStatusResponse_Folders) isSyncStatusResponse_Msg() {}
+
 func (m *SyncStatusResponse) GetMsg() isSyncStatusResponse_Msg {
 	if m != nil {
 		return m.Msg
@@ -381,13 +790,110 @@ func (m *SyncStatusResponse) GetSynced() bool {
 	return false
 }
 
+func (m *SyncStatusResponse) GetFolders() *FolderSyncStatuses {
+	if x, ok := m.GetMsg().(*SyncStatusResponse_Folders); ok {
+		return x.Folders
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*SyncStatusResponse) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*SyncStatusResponse_OldToken)(nil),
 		(*SyncStatusResponse_Auth)(nil),
 		(*SyncStatusResponse_Synced)(nil),
+		(*SyncStatusResponse_Folders)(nil),
+	}
+}
+
+type FolderSyncStatuses struct {
+	// Maps the folder ID to its status.
+	Folders              map[string]*FolderSyncStatus `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *FolderSyncStatuses) Reset()         { *m = FolderSyncStatuses{} }
+func (m *FolderSyncStatuses) String() string { return proto.CompactTextString(m) }
+func (*FolderSyncStatuses) ProtoMessage()    {}
+func (*FolderSyncStatuses) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{11}
+}
+
+func (m *FolderSyncStatuses) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FolderSyncStatuses.Unmarshal(m, b)
+}
+func (m *FolderSyncStatuses) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FolderSyncStatuses.Marshal(b, m, deterministic)
+}
+func (m *FolderSyncStatuses) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FolderSyncStatuses.Merge(m, src)
+}
+func (m *FolderSyncStatuses) XXX_Size() int {
+	return xxx_messageInfo_FolderSyncStatuses.Size(m)
+}
+func (m *FolderSyncStatuses) XXX_DiscardUnknown() {
+	xxx_messageInfo_FolderSyncStatuses.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FolderSyncStatuses proto.InternalMessageInfo
+
+func (m *FolderSyncStatuses) GetFolders() map[string]*FolderSyncStatus {
+	if m != nil {
+		return m.Folders
+	}
+	return nil
+}
+
+type FolderSyncStatus struct {
+	// The path of the folder on the user's machine.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Whether the folder has finished its initial sync.
+	Synced               bool     `protobuf:"varint,2,opt,name=synced,proto3" json:"synced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FolderSyncStatus) Reset()         { *m = FolderSyncStatus{} }
+func (m *FolderSyncStatus) String() string { return proto.CompactTextString(m) }
+func (*FolderSyncStatus) ProtoMessage()    {}
+func (*FolderSyncStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{12}
+}
+
+func (m *FolderSyncStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FolderSyncStatus.Unmarshal(m, b)
+}
+func (m *FolderSyncStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FolderSyncStatus.Marshal(b, m, deterministic)
+}
+func (m *FolderSyncStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FolderSyncStatus.Merge(m, src)
+}
+func (m *FolderSyncStatus) XXX_Size() int {
+	return xxx_messageInfo_FolderSyncStatus.Size(m)
+}
+func (m *FolderSyncStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_FolderSyncStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FolderSyncStatus proto.InternalMessageInfo
+
+func (m *FolderSyncStatus) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
+	return ""
+}
+
+func (m *FolderSyncStatus) GetSynced() bool {
+	if m != nil {
+		return m.Synced
+	}
+	return false
 }
 
 type GetSyncStatusRequest struct {
@@ -400,7 +906,7 @@ func (m *GetSyncStatusRequest) Reset()         { *m = GetSyncStatusRequest{} }
 func (m *GetSyncStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*GetSyncStatusRequest) ProtoMessage()    {}
 func (*GetSyncStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ffe3c8ce6343e9a1, []int{5}
+	return fileDescriptor_ffe3c8ce6343e9a1, []int{13}
 }
 
 func (m *GetSyncStatusRequest) XXX_Unmarshal(b []byte) error {
@@ -424,9 +930,18 @@ var xxx_messageInfo_GetSyncStatusRequest proto.InternalMessageInfo
 func init() {
 	proto.RegisterType((*TunnelHeader)(nil), "blimp.node.v0.TunnelHeader")
 	proto.RegisterType((*ExposedTunnelHeader)(nil), "blimp.node.v0.ExposedTunnelHeader")
+	proto.RegisterType((*ReverseTunnelRequest)(nil), "blimp.node.v0.ReverseTunnelRequest")
+	proto.RegisterType((*ReverseTunnelConn)(nil), "blimp.node.v0.ReverseTunnelConn")
+	proto.RegisterType((*ReverseTunnelHeader)(nil), "blimp.node.v0.ReverseTunnelHeader")
 	proto.RegisterType((*EOF)(nil), "blimp.node.v0.EOF")
 	proto.RegisterType((*TunnelMsg)(nil), "blimp.node.v0.TunnelMsg")
+	proto.RegisterType((*AttachHeader)(nil), "blimp.node.v0.AttachHeader")
+	proto.RegisterType((*TerminalSize)(nil), "blimp.node.v0.TerminalSize")
+	proto.RegisterType((*AttachMsg)(nil), "blimp.node.v0.AttachMsg")
 	proto.RegisterType((*SyncStatusResponse)(nil), "blimp.node.v0.SyncStatusResponse")
+	proto.RegisterType((*FolderSyncStatuses)(nil), "blimp.node.v0.FolderSyncStatuses")
+	proto.RegisterMapType((map[string]*FolderSyncStatus)(nil), "blimp.node.v0.FolderSyncStatuses.FoldersEntry")
+	proto.RegisterType((*FolderSyncStatus)(nil), "blimp.node.v0.FolderSyncStatus")
 	proto.RegisterType((*GetSyncStatusRequest)(nil), "blimp.node.v0.GetSyncStatusRequest")
 }
 
@@ -435,40 +950,65 @@ func init() {
 }
 
 var fileDescriptor_ffe3c8ce6343e9a1 = []byte{
-	// 526 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x51, 0x6f, 0xd3, 0x30,
-	0x10, 0x4e, 0x96, 0xa4, 0x6a, 0xae, 0x2b, 0x02, 0x33, 0x4d, 0x51, 0x37, 0x50, 0x09, 0x02, 0xfa,
-	0x80, 0x9c, 0xa8, 0x88, 0x1f, 0x40, 0x51, 0x47, 0x06, 0x1a, 0x93, 0xb2, 0x3d, 0xf1, 0x32, 0xa5,
-	0x89, 0x9b, 0x46, 0x4d, 0xed, 0x10, 0x3b, 0x15, 0x7b, 0x45, 0x48, 0xfc, 0x6b, 0x84, 0x6c, 0x87,
-	0xd1, 0x96, 0xc2, 0x0b, 0x4f, 0xbd, 0xf3, 0x7d, 0x77, 0xfe, 0xee, 0xfb, 0xea, 0xc0, 0xe3, 0x59,
-	0x59, 0xac, 0xaa, 0x80, 0xb2, 0x8c, 0x04, 0xeb, 0x30, 0x48, 0x19, 0x15, 0x35, 0x2b, 0x4b, 0x52,
-	0xe3, 0xaa, 0x66, 0x82, 0xa1, 0xbe, 0xaa, 0x63, 0x59, 0xc7, 0xeb, 0x70, 0xe0, 0x69, 0x78, 0xd2,
-	0x88, 0x85, 0x84, 0xcb, 0x5f, 0x0d, 0x1c, 0x9c, 0xea, 0x0a, 0xa9, 0x6b, 0x56, 0x73, 0x59, 0xd3,
-	0x91, 0xae, 0xfa, 0xdf, 0x4d, 0x38, 0xbc, 0x6e, 0x28, 0x25, 0x65, 0x44, 0x92, 0x8c, 0xd4, 0x08,
-	0x81, 0x4d, 0x93, 0x15, 0xf1, 0xcc, 0xa1, 0x39, 0x72, 0x63, 0x15, 0xcb, 0xb3, 0x8a, 0xd5, 0xc2,
-	0x3b, 0x18, 0x9a, 0xa3, 0x7e, 0xac, 0x62, 0x74, 0x02, 0x2e, 0x2b, 0xb3, 0x1b, 0xc1, 0x96, 0x84,
-	0x7a, 0x96, 0x02, 0x77, 0x59, 0x99, 0x5d, 0xcb, 0x1c, 0xbd, 0x04, 0x5b, 0x32, 0xf0, 0x9c, 0xa1,
-	0x39, 0xea, 0x8d, 0x3d, 0xac, 0xb9, 0x2a, 0x52, 0xeb, 0x10, 0x4f, 0x64, 0xf6, 0xa6, 0x11, 0x8b,
-	0x58, 0xa1, 0xde, 0xdb, 0x5d, 0xfb, 0xbe, 0xe3, 0x9f, 0xc3, 0xc3, 0xe9, 0x97, 0x8a, 0x71, 0x92,
-	0x6d, 0xf1, 0x39, 0x02, 0x47, 0xdf, 0xa1, 0x09, 0xe9, 0x04, 0x9d, 0x82, 0x2b, 0x99, 0xf1, 0x2a,
-	0x49, 0x89, 0xa2, 0xe5, 0xc6, 0xbf, 0x0f, 0x7c, 0x07, 0xac, 0xe9, 0xe5, 0x99, 0xff, 0xf5, 0x00,
-	0x5c, 0x3d, 0xeb, 0x82, 0xe7, 0x08, 0x83, 0xa3, 0x36, 0x57, 0x83, 0x7a, 0xe3, 0xe3, 0x96, 0x54,
-	0xab, 0xc6, 0x3a, 0xc4, 0x53, 0x19, 0x45, 0x46, 0xac, 0x61, 0xe8, 0x35, 0x74, 0x16, 0x8a, 0x82,
-	0x9a, 0xdf, 0x1b, 0x9f, 0xe0, 0x2d, 0xc5, 0xf1, 0x26, 0xcb, 0xc8, 0x88, 0x5b, 0x30, 0xfa, 0x00,
-	0xf7, 0x88, 0x5e, 0xe3, 0xa6, 0x6d, 0xd7, 0x22, 0xf8, 0x3b, 0xed, 0x7b, 0x76, 0x8d, 0x8c, 0xb8,
-	0xdf, 0xf6, 0xde, 0x99, 0x61, 0xcd, 0x9a, 0xb9, 0x92, 0xf7, 0x30, 0x32, 0x62, 0x99, 0xa0, 0xe7,
-	0x60, 0x11, 0x36, 0xf7, 0x6c, 0x35, 0x15, 0xed, 0x4e, 0xbd, 0x3c, 0x93, 0x38, 0xc2, 0xe6, 0x13,
-	0x07, 0xac, 0x15, 0xcf, 0xfd, 0x6f, 0x26, 0xa0, 0xab, 0x5b, 0x9a, 0x5e, 0x89, 0x44, 0x34, 0x3c,
-	0x26, 0xbc, 0x62, 0x94, 0x13, 0xf4, 0x68, 0xd3, 0x3e, 0x25, 0x6d, 0x64, 0x6c, 0x18, 0x88, 0x5b,
-	0x03, 0xad, 0x7f, 0x1b, 0x18, 0x19, 0xda, 0x42, 0xe4, 0x41, 0x87, 0xdf, 0xd2, 0x94, 0x64, 0x4a,
-	0xac, 0xae, 0xd4, 0x43, 0xe7, 0xbf, 0x68, 0x1c, 0xc3, 0xd1, 0x3b, 0x22, 0x36, 0x89, 0x7c, 0x6e,
-	0x08, 0x17, 0xe3, 0x1f, 0x26, 0xc0, 0xdb, 0xbb, 0xff, 0x36, 0x9a, 0x40, 0x47, 0x2b, 0x82, 0xbc,
-	0xbd, 0x72, 0x5f, 0xf0, 0x7c, 0xf0, 0xd7, 0x8a, 0x6f, 0x8c, 0xcc, 0xd0, 0x44, 0xe7, 0xd0, 0xdf,
-	0x12, 0xf7, 0x3f, 0x46, 0x25, 0xf0, 0x40, 0x52, 0xfe, 0xc8, 0x44, 0x31, 0x2f, 0xd2, 0x44, 0x14,
-	0x8c, 0x72, 0xf4, 0x64, 0xa7, 0xe9, 0x4f, 0x75, 0x07, 0x4f, 0x77, 0x20, 0xfb, 0x56, 0xd7, 0x57,
-	0x4c, 0x5e, 0x7c, 0x7a, 0x96, 0x17, 0x62, 0xd1, 0xcc, 0x70, 0xca, 0x56, 0xc1, 0x92, 0x94, 0x59,
-	0x12, 0xe8, 0x17, 0x5b, 0x2d, 0xf3, 0x40, 0x3d, 0x52, 0xf5, 0x11, 0x98, 0x75, 0x54, 0xfc, 0xea,
-	0x67, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2b, 0x8d, 0x67, 0x05, 0x19, 0x04, 0x00, 0x00,
+	// 922 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xef, 0x6e, 0xe3, 0x44,
+	0x10, 0xb7, 0xe3, 0x38, 0x97, 0x4c, 0x93, 0x53, 0x6f, 0x5b, 0x55, 0x26, 0x77, 0x40, 0x30, 0x02,
+	0xf2, 0x01, 0x39, 0xa1, 0xe8, 0x04, 0x42, 0x80, 0xd4, 0x9c, 0x52, 0x7c, 0xa0, 0xbb, 0x4a, 0x6e,
+	0x3f, 0x95, 0x0f, 0x95, 0x6b, 0x4f, 0x12, 0xab, 0x8e, 0xd7, 0x78, 0xd7, 0x81, 0xdc, 0x0b, 0xf0,
+	0x50, 0x7c, 0xe4, 0x01, 0x78, 0x14, 0x1e, 0x01, 0xb4, 0x7f, 0x92, 0x3a, 0x69, 0xae, 0x07, 0x77,
+	0x9f, 0x32, 0xe3, 0x99, 0x9d, 0xf9, 0xcd, 0xfc, 0x66, 0x27, 0x0b, 0x1f, 0x5c, 0xa7, 0xc9, 0x3c,
+	0x1f, 0x64, 0x34, 0xc6, 0xc1, 0x62, 0x38, 0x88, 0x68, 0xc6, 0x0b, 0x9a, 0xa6, 0x58, 0x78, 0x79,
+	0x41, 0x39, 0x25, 0x1d, 0x69, 0xf7, 0x84, 0xdd, 0x5b, 0x0c, 0xbb, 0x8e, 0x72, 0x0f, 0x4b, 0x3e,
+	0x13, 0xee, 0xe2, 0x57, 0x39, 0x76, 0x9f, 0x28, 0x0b, 0x16, 0x05, 0x2d, 0x98, 0xb0, 0x29, 0x49,
+	0x59, 0xdd, 0xdf, 0x4d, 0x68, 0x5f, 0x94, 0x59, 0x86, 0xa9, 0x8f, 0x61, 0x8c, 0x05, 0x21, 0x50,
+	0xcf, 0xc2, 0x39, 0x3a, 0x66, 0xcf, 0xec, 0xb7, 0x02, 0x29, 0x8b, 0x6f, 0x39, 0x2d, 0xb8, 0x53,
+	0xeb, 0x99, 0xfd, 0x4e, 0x20, 0x65, 0xf2, 0x18, 0x5a, 0x34, 0x8d, 0xaf, 0x38, 0xbd, 0xc1, 0xcc,
+	0xb1, 0xa4, 0x73, 0x93, 0xa6, 0xf1, 0x85, 0xd0, 0xc9, 0xe7, 0x50, 0x17, 0x08, 0x1c, 0xbb, 0x67,
+	0xf6, 0xf7, 0x8e, 0x1d, 0x4f, 0x61, 0x95, 0xa0, 0x16, 0x43, 0x6f, 0x24, 0xb4, 0x93, 0x92, 0xcf,
+	0x02, 0xe9, 0xf5, 0x63, 0xbd, 0x59, 0xdf, 0xb7, 0xdd, 0xe7, 0x70, 0x30, 0xfe, 0x2d, 0xa7, 0x0c,
+	0xe3, 0x0d, 0x3c, 0x87, 0x60, 0xab, 0x1c, 0x0a, 0x90, 0x52, 0xc8, 0x13, 0x68, 0x09, 0x64, 0x2c,
+	0x0f, 0x23, 0x94, 0xb0, 0x5a, 0xc1, 0xed, 0x07, 0xf7, 0x12, 0x0e, 0x03, 0x5c, 0x60, 0xc1, 0x50,
+	0x85, 0x0a, 0xf0, 0x97, 0x12, 0x19, 0x5f, 0xc3, 0x32, 0xff, 0x0b, 0x2c, 0x91, 0x59, 0x54, 0xca,
+	0x9c, 0x5a, 0xcf, 0xea, 0x77, 0x02, 0xa5, 0xb8, 0x5f, 0xc1, 0xa3, 0x8d, 0xd8, 0xcf, 0x68, 0x96,
+	0x91, 0x87, 0x50, 0x4b, 0x62, 0x8d, 0xb0, 0x96, 0xc4, 0xbb, 0x1a, 0xe6, 0x9e, 0xc3, 0xc1, 0xc6,
+	0x41, 0x5d, 0xdf, 0xff, 0xc3, 0xa4, 0x12, 0xd5, 0x56, 0x89, 0x5c, 0x1b, 0xac, 0xf1, 0xd9, 0xa9,
+	0xfb, 0x57, 0x0d, 0x5a, 0x2a, 0xea, 0x0b, 0x36, 0x25, 0x1e, 0xd8, 0x92, 0x63, 0x1d, 0xf3, 0x48,
+	0xc7, 0xd4, 0xbc, 0x2f, 0x86, 0xde, 0x58, 0x48, 0xbe, 0x11, 0x28, 0x37, 0xf2, 0x14, 0x1a, 0x33,
+	0x09, 0x46, 0x06, 0xde, 0x3b, 0x7e, 0xec, 0x6d, 0xcc, 0x96, 0x57, 0xc5, 0xeb, 0x1b, 0x81, 0x76,
+	0x26, 0x3f, 0xc1, 0x43, 0x54, 0x84, 0x5d, 0xe9, 0xe3, 0x8a, 0x6e, 0x77, 0xeb, 0xf8, 0x0e, 0x56,
+	0x7d, 0x23, 0xe8, 0xe8, 0xb3, 0xfe, 0x3a, 0x58, 0xa1, 0xba, 0xb3, 0x0a, 0xd6, 0xd8, 0x19, 0x6c,
+	0x47, 0x0b, 0x45, 0x30, 0x7d, 0x76, 0x3d, 0xc3, 0xd6, 0x75, 0x39, 0x91, 0x53, 0xd9, 0xf6, 0x8d,
+	0x40, 0x28, 0xe4, 0x53, 0xb0, 0x90, 0x4e, 0x9c, 0xba, 0x8c, 0x4a, 0xb6, 0x21, 0x9e, 0x9d, 0x0a,
+	0x3f, 0xa4, 0x93, 0x91, 0x0d, 0xd6, 0x9c, 0x4d, 0xdd, 0x04, 0xda, 0x27, 0x9c, 0x87, 0xd1, 0xec,
+	0xad, 0x68, 0x7a, 0x0f, 0x9a, 0x39, 0x8d, 0xaf, 0xe4, 0x45, 0x52, 0x64, 0x3d, 0xc8, 0x69, 0xfc,
+	0x52, 0xdc, 0xa5, 0x7d, 0xb0, 0x38, 0x5f, 0x4a, 0x6c, 0xcd, 0x40, 0x88, 0xee, 0xb7, 0xd0, 0xbe,
+	0xc0, 0x62, 0x9e, 0x64, 0x61, 0x7a, 0x9e, 0xbc, 0x42, 0x31, 0x77, 0xbf, 0x26, 0xb1, 0xce, 0xd5,
+	0x09, 0x94, 0x42, 0x8e, 0x04, 0x49, 0xc9, 0x74, 0xb6, 0x1a, 0x2a, 0xad, 0xb9, 0xff, 0x98, 0xd0,
+	0x52, 0x48, 0x05, 0xf5, 0xb7, 0x54, 0x9a, 0x3b, 0xa9, 0xac, 0xd6, 0x54, 0xa1, 0xf2, 0x08, 0x6c,
+	0xc6, 0xe3, 0x24, 0x73, 0x6a, 0xba, 0x65, 0x4a, 0x25, 0x5f, 0x40, 0x4b, 0x0a, 0x57, 0xa2, 0x75,
+	0xd6, 0x3d, 0xad, 0x6b, 0x4a, 0xb7, 0x31, 0x9d, 0x08, 0x04, 0x05, 0xb2, 0xe4, 0x15, 0x3a, 0xf5,
+	0x9d, 0x08, 0xaa, 0xa5, 0x0a, 0x04, 0xca, 0x99, 0x38, 0xd0, 0x60, 0x3c, 0xa6, 0x25, 0x77, 0x6c,
+	0x0d, 0x41, 0xeb, 0xda, 0x82, 0x85, 0x9a, 0x88, 0x95, 0x05, 0x8b, 0x62, 0x45, 0xd5, 0x9f, 0x26,
+	0x90, 0xf3, 0x65, 0x16, 0x9d, 0xf3, 0x90, 0x97, 0x2c, 0x40, 0x96, 0xd3, 0x8c, 0x21, 0x79, 0xbf,
+	0xba, 0xa0, 0xe4, 0xd5, 0xf4, 0x8d, 0xca, 0x8a, 0xf2, 0x34, 0xa1, 0xd6, 0xfd, 0x84, 0xfa, 0x86,
+	0xa6, 0x54, 0xc0, 0x58, 0x66, 0x11, 0xaa, 0xdb, 0xd7, 0x94, 0x30, 0xa4, 0x4e, 0xbe, 0x83, 0x07,
+	0x13, 0x9a, 0xc6, 0x58, 0x30, 0x5d, 0xf2, 0x47, 0x5b, 0x25, 0x9f, 0x4a, 0xeb, 0x2d, 0x44, 0x64,
+	0xbe, 0x11, 0xac, 0xce, 0xac, 0xaa, 0xf8, 0xc3, 0x04, 0x72, 0xd7, 0x91, 0xf8, 0xb7, 0xc1, 0xcd,
+	0x9e, 0xd5, 0xdf, 0x3b, 0xf6, 0xde, 0x18, 0x5c, 0x7f, 0x62, 0xe3, 0x8c, 0x17, 0xcb, 0x75, 0x9e,
+	0xee, 0xcf, 0xd0, 0xae, 0x1a, 0xc4, 0x20, 0xde, 0xe0, 0x52, 0x2f, 0x2d, 0x21, 0x92, 0xa7, 0x60,
+	0x2f, 0xc2, 0xb4, 0x44, 0xbd, 0x06, 0x3e, 0x7c, 0x43, 0xa6, 0x40, 0x79, 0x7f, 0x53, 0xfb, 0xda,
+	0x74, 0xbf, 0x87, 0xfd, 0x6d, 0xb3, 0x5c, 0x82, 0xa1, 0x1e, 0xe3, 0x56, 0x20, 0x65, 0x31, 0xc5,
+	0xd5, 0x2e, 0xae, 0x7a, 0xe8, 0x1e, 0xc1, 0xe1, 0x0f, 0xc8, 0xab, 0x2c, 0xca, 0x8d, 0x7d, 0xfc,
+	0xb7, 0x05, 0xf0, 0x6c, 0xfd, 0xd7, 0x47, 0x46, 0xd0, 0x50, 0x37, 0x9f, 0x38, 0x3b, 0x77, 0xd4,
+	0x0b, 0x36, 0xed, 0xbe, 0xd6, 0xe2, 0x1a, 0x7d, 0x73, 0x68, 0x92, 0xe7, 0xd0, 0xd9, 0xd8, 0x48,
+	0xef, 0x10, 0xea, 0x12, 0x3a, 0x1b, 0xfb, 0x88, 0x7c, 0x7c, 0xdf, 0xb6, 0xd2, 0x35, 0x75, 0x7b,
+	0xf7, 0x39, 0x89, 0xbf, 0x13, 0xd7, 0x18, 0x9a, 0xe4, 0x0c, 0x0e, 0x4e, 0xa2, 0x08, 0x73, 0xbe,
+	0x99, 0xe1, 0xed, 0xc1, 0x8e, 0xa0, 0xa1, 0x6e, 0xff, 0x9d, 0x18, 0xeb, 0xf5, 0xd1, 0x7d, 0xad,
+	0x45, 0xc7, 0x08, 0xe1, 0x91, 0xe0, 0xe8, 0x25, 0xe5, 0xc9, 0x24, 0x89, 0x42, 0x9e, 0xd0, 0x8c,
+	0x91, 0xed, 0x71, 0xbf, 0x7b, 0x17, 0xbb, 0xdb, 0x7d, 0xd9, 0xc5, 0xb5, 0x4a, 0x31, 0xfa, 0xec,
+	0xf2, 0x93, 0x69, 0xc2, 0x67, 0xe5, 0xb5, 0x17, 0xd1, 0xf9, 0xe0, 0x06, 0xd3, 0x38, 0x1c, 0xa8,
+	0x17, 0x4c, 0x7e, 0x33, 0x1d, 0xc8, 0x47, 0x8b, 0x7c, 0x14, 0x5d, 0x37, 0xa4, 0xfc, 0xe5, 0xbf,
+	0x03, 0x00, 0x97, 0x32, 0x02, 0x89, 0x29, 0x09, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -485,6 +1025,13 @@ const _ = grpc.SupportPackageIsVersion6
 type ControllerClient interface {
 	Tunnel(ctx context.Context, opts ...grpc.CallOption) (Controller_TunnelClient, error)
 	ExposedTunnel(ctx context.Context, opts ...grpc.CallOption) (Controller_ExposedTunnelClient, error)
+	// ReverseTunnel forwards connections to `host.docker.internal` on the given
+	// ports to the CLI. The server sends a message for each new connection,
+	// which the CLI then accepts with AcceptReverseTunnel.
+	ReverseTunnel(ctx context.Context, in *ReverseTunnelRequest, opts ...grpc.CallOption) (Controller_ReverseTunnelClient, error)
+	AcceptReverseTunnel(ctx context.Context, opts ...grpc.CallOption) (Controller_AcceptReverseTunnelClient, error)
+	// Attach connects to the stdin and stdout of a pod created by `blimp run`.
+	Attach(ctx context.Context, opts ...grpc.CallOption) (Controller_AttachClient, error)
 	// The request and responses are flipped because the node controller is
 	// querying the CLI for status updates, but the CLI is initiating the
 	// connection.
@@ -561,8 +1108,102 @@ func (x *controllerExposedTunnelClient) Recv() (*TunnelMsg, error) {
 	return m, nil
 }
 
+func (c *controllerClient) ReverseTunnel(ctx context.Context, in *ReverseTunnelRequest, opts ...grpc.CallOption) (Controller_ReverseTunnelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Controller_serviceDesc.Streams[2], "/blimp.node.v0.Controller/ReverseTunnel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controllerReverseTunnelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Controller_ReverseTunnelClient interface {
+	Recv() (*ReverseTunnelConn, error)
+	grpc.ClientStream
+}
+
+type controllerReverseTunnelClient struct {
+	grpc.ClientStream
+}
+
+func (x *controllerReverseTunnelClient) Recv() (*ReverseTunnelConn, error) {
+	m := new(ReverseTunnelConn)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *controllerClient) AcceptReverseTunnel(ctx context.Context, opts ...grpc.CallOption) (Controller_AcceptReverseTunnelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Controller_serviceDesc.Streams[3], "/blimp.node.v0.Controller/AcceptReverseTunnel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controllerAcceptReverseTunnelClient{stream}
+	return x, nil
+}
+
+type Controller_AcceptReverseTunnelClient interface {
+	Send(*TunnelMsg) error
+	Recv() (*TunnelMsg, error)
+	grpc.ClientStream
+}
+
+type controllerAcceptReverseTunnelClient struct {
+	grpc.ClientStream
+}
+
+func (x *controllerAcceptReverseTunnelClient) Send(m *TunnelMsg) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *controllerAcceptReverseTunnelClient) Recv() (*TunnelMsg, error) {
+	m := new(TunnelMsg)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *controllerClient) Attach(ctx context.Context, opts ...grpc.CallOption) (Controller_AttachClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Controller_serviceDesc.Streams[4], "/blimp.node.v0.Controller/Attach", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controllerAttachClient{stream}
+	return x, nil
+}
+
+type Controller_AttachClient interface {
+	Send(*AttachMsg) error
+	Recv() (*AttachMsg, error)
+	grpc.ClientStream
+}
+
+type controllerAttachClient struct {
+	grpc.ClientStream
+}
+
+func (x *controllerAttachClient) Send(m *AttachMsg) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *controllerAttachClient) Recv() (*AttachMsg, error) {
+	m := new(AttachMsg)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *controllerClient) SyncNotifications(ctx context.Context, opts ...grpc.CallOption) (Controller_SyncNotificationsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Controller_serviceDesc.Streams[2], "/blimp.node.v0.Controller/SyncNotifications", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Controller_serviceDesc.Streams[5], "/blimp.node.v0.Controller/SyncNotifications", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -596,6 +1237,13 @@ func (x *controllerSyncNotificationsClient) Recv() (*GetSyncStatusRequest, error
 type ControllerServer interface {
 	Tunnel(Controller_TunnelServer) error
 	ExposedTunnel(Controller_ExposedTunnelServer) error
+	// ReverseTunnel forwards connections to `host.docker.internal` on the given
+	// ports to the CLI. The server sends a message for each new connection,
+	// which the CLI then accepts with AcceptReverseTunnel.
+	ReverseTunnel(*ReverseTunnelRequest, Controller_ReverseTunnelServer) error
+	AcceptReverseTunnel(Controller_AcceptReverseTunnelServer) error
+	// Attach connects to the stdin and stdout of a pod created by `blimp run`.
+	Attach(Controller_AttachServer) error
 	// The request and responses are flipped because the node controller is
 	// querying the CLI for status updates, but the CLI is initiating the
 	// connection.
@@ -612,6 +1260,15 @@ func (*UnimplementedControllerServer) Tunnel(srv Controller_TunnelServer) error
 func (*UnimplementedControllerServer) ExposedTunnel(srv Controller_ExposedTunnelServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExposedTunnel not implemented")
 }
+func (*UnimplementedControllerServer) ReverseTunnel(req *ReverseTunnelRequest, srv Controller_ReverseTunnelServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReverseTunnel not implemented")
+}
+func (*UnimplementedControllerServer) AcceptReverseTunnel(srv Controller_AcceptReverseTunnelServer) error {
+	return status.Errorf(codes.Unimplemented, "method AcceptReverseTunnel not implemented")
+}
+func (*UnimplementedControllerServer) Attach(srv Controller_AttachServer) error {
+	return status.Errorf(codes.Unimplemented, "method Attach not implemented")
+}
 func (*UnimplementedControllerServer) SyncNotifications(srv Controller_SyncNotificationsServer) error {
 	return status.Errorf(codes.Unimplemented, "method SyncNotifications not implemented")
 }
@@ -672,6 +1329,79 @@ func (x *controllerExposedTunnelServer) Recv() (*TunnelMsg, error) {
 	return m, nil
 }
 
+func _Controller_ReverseTunnel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReverseTunnelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControllerServer).ReverseTunnel(m, &controllerReverseTunnelServer{stream})
+}
+
+type Controller_ReverseTunnelServer interface {
+	Send(*ReverseTunnelConn) error
+	grpc.ServerStream
+}
+
+type controllerReverseTunnelServer struct {
+	grpc.ServerStream
+}
+
+func (x *controllerReverseTunnelServer) Send(m *ReverseTunnelConn) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Controller_AcceptReverseTunnel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ControllerServer).AcceptReverseTunnel(&controllerAcceptReverseTunnelServer{stream})
+}
+
+type Controller_AcceptReverseTunnelServer interface {
+	Send(*TunnelMsg) error
+	Recv() (*TunnelMsg, error)
+	grpc.ServerStream
+}
+
+type controllerAcceptReverseTunnelServer struct {
+	grpc.ServerStream
+}
+
+func (x *controllerAcceptReverseTunnelServer) Send(m *TunnelMsg) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *controllerAcceptReverseTunnelServer) Recv() (*TunnelMsg, error) {
+	m := new(TunnelMsg)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Controller_Attach_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ControllerServer).Attach(&controllerAttachServer{stream})
+}
+
+type Controller_AttachServer interface {
+	Send(*AttachMsg) error
+	Recv() (*AttachMsg, error)
+	grpc.ServerStream
+}
+
+type controllerAttachServer struct {
+	grpc.ServerStream
+}
+
+func (x *controllerAttachServer) Send(m *AttachMsg) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *controllerAttachServer) Recv() (*AttachMsg, error) {
+	m := new(AttachMsg)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Controller_SyncNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(ControllerServer).SyncNotifications(&controllerSyncNotificationsServer{stream})
 }
@@ -715,6 +1445,23 @@ var _Controller_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ReverseTunnel",
+			Handler:       _Controller_ReverseTunnel_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AcceptReverseTunnel",
+			Handler:       _Controller_AcceptReverseTunnel_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Attach",
+			Handler:       _Controller_Attach_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "SyncNotifications",
 			Handler:       _Controller_SyncNotifications_Handler,
@@ -26,11 +26,14 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type CheckReadyRequest struct {
-	Namespace            string    `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	WaitSpec             *WaitSpec `protobuf:"bytes,2,opt,name=wait_spec,json=waitSpec,proto3" json:"wait_spec,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Namespace string    `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	WaitSpec  *WaitSpec `protobuf:"bytes,2,opt,name=wait_spec,json=waitSpec,proto3" json:"wait_spec,omitempty"`
+	// The name of the pod that's waiting. If set, the waiter's progress is
+	// recorded in events on the pod.
+	PodName              string   `protobuf:"bytes,3,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CheckReadyRequest) Reset()         { *m = CheckReadyRequest{} }
@@ -72,12 +75,20 @@ func (m *CheckReadyRequest) GetWaitSpec() *WaitSpec {
 	return nil
 }
 
+func (m *CheckReadyRequest) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
 type WaitSpec struct {
 	// depends_on is a list of services that must be running or healthy
 	// before the service can start.
 	DependsOn []*ServiceCondition `protobuf:"bytes,1,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
-	// bind_volumes is a list of paths that must be fully synced before the
-	// service can start.
+	// bind_volumes is a list of paths on the user's machine that must be
+	// fully synced before the service can start. The service only waits for
+	// the synced folders that contain these paths.
 	BindVolumes []string `protobuf:"bytes,2,rep,name=bind_volumes,json=bindVolumes,proto3" json:"bind_volumes,omitempty"`
 	// finished_volume_init is a list of services that must have finished initializing
 	// volumes before the service can start.
@@ -249,32 +260,33 @@ func init() {
 }
 
 var fileDescriptor_d3a1998debca718e = []byte{
-	// 399 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x51, 0x8b, 0xd4, 0x30,
-	0x10, 0xc7, 0xed, 0x2d, 0x77, 0x6e, 0x67, 0x15, 0x34, 0x1c, 0x67, 0x38, 0x0e, 0xec, 0x15, 0xc4,
-	0x3e, 0x48, 0x5b, 0xaa, 0xcf, 0x3e, 0xdc, 0xe1, 0x83, 0xbe, 0x08, 0x39, 0x54, 0xf0, 0xa5, 0xb4,
-	0xe9, 0xb8, 0x1b, 0x76, 0x9b, 0xc4, 0x24, 0xdb, 0xc3, 0x4f, 0xe3, 0x57, 0x95, 0x26, 0x2d, 0xab,
-	0x2b, 0xdc, 0x53, 0x67, 0xfe, 0xf9, 0x65, 0xa6, 0xf9, 0xcf, 0x00, 0x6d, 0x77, 0xa2, 0xd7, 0xc5,
-	0x7d, 0x23, 0x5c, 0x31, 0x94, 0xfe, 0x9b, 0x6b, 0xa3, 0x9c, 0x22, 0x4f, 0xfd, 0x49, 0xee, 0x95,
-	0xa1, 0xbc, 0xbc, 0x0a, 0x20, 0x1a, 0xa3, 0x8c, 0x1d, 0xd1, 0x10, 0x05, 0x38, 0x5d, 0xc3, 0xf3,
-	0xdb, 0x0d, 0xf2, 0x2d, 0xc3, 0xa6, 0xfb, 0xc5, 0xf0, 0xe7, 0x1e, 0xad, 0x23, 0x57, 0x10, 0xcb,
-	0xa6, 0x47, 0xab, 0x1b, 0x8e, 0x34, 0x4a, 0xa2, 0x2c, 0x66, 0x07, 0x81, 0xbc, 0x83, 0x78, 0xac,
-	0x5d, 0x5b, 0x8d, 0x9c, 0x9e, 0x24, 0x51, 0xb6, 0xaa, 0x5e, 0xe4, 0xff, 0xf4, 0xcc, 0xbf, 0x35,
-	0xc2, 0xdd, 0x69, 0xe4, 0x6c, 0x79, 0x3f, 0x45, 0xe9, 0xef, 0x08, 0x96, 0xb3, 0x4c, 0xde, 0x03,
-	0x74, 0xa8, 0x51, 0x76, 0xb6, 0x56, 0x92, 0x46, 0xc9, 0x22, 0x5b, 0x55, 0x2f, 0x8f, 0x6a, 0xdc,
-	0xa1, 0x19, 0x04, 0xc7, 0x5b, 0x25, 0x3b, 0xe1, 0x84, 0x92, 0x2c, 0x9e, 0xae, 0x7c, 0x96, 0xe4,
-	0x1a, 0x9e, 0xb4, 0x42, 0x76, 0xf5, 0xa0, 0x76, 0xfb, 0x1e, 0x2d, 0x3d, 0x49, 0x16, 0x59, 0xcc,
-	0x56, 0xa3, 0xf6, 0x35, 0x48, 0xa4, 0x84, 0xf3, 0x1f, 0x42, 0x0a, 0xbb, 0xc1, 0x19, 0xab, 0x85,
-	0x14, 0x8e, 0x2e, 0x3c, 0x4a, 0xe6, 0xb3, 0x80, 0x7f, 0x94, 0xc2, 0xa5, 0x9f, 0xe0, 0xd9, 0x71,
-	0x4f, 0x42, 0xe1, 0xb1, 0x0d, 0xda, 0xe4, 0xc3, 0x9c, 0x8e, 0x1e, 0xf1, 0x19, 0xf3, 0x2e, 0xc4,
-	0xec, 0x20, 0xa4, 0x1a, 0xc8, 0xdf, 0xb6, 0x5a, 0xad, 0xa4, 0x45, 0xf2, 0x06, 0x4e, 0xbd, 0xf9,
-	0xbe, 0xd6, 0xaa, 0xba, 0x98, 0x5e, 0x3c, 0x0d, 0x64, 0x28, 0xf3, 0x0f, 0x63, 0xc4, 0x02, 0x44,
-	0xce, 0xe1, 0xd4, 0x8c, 0xd7, 0x7d, 0xf5, 0x25, 0x0b, 0x09, 0xb9, 0x80, 0x33, 0x83, 0x8d, 0x55,
-	0x92, 0x2e, 0x7c, 0xd3, 0x29, 0xab, 0x38, 0xc0, 0x8d, 0x52, 0x6e, 0xb4, 0x18, 0x0d, 0xf9, 0x02,
-	0x70, 0xe8, 0x4f, 0x92, 0x23, 0x6b, 0xff, 0x9b, 0xf8, 0xe5, 0xf5, 0x03, 0x44, 0xf8, 0xf9, 0xf4,
-	0x51, 0x19, 0xdd, 0xbc, 0xfe, 0xfe, 0x6a, 0x2d, 0xdc, 0x66, 0xdf, 0xe6, 0x5c, 0xf5, 0xc5, 0x16,
-	0x77, 0x5d, 0x53, 0x84, 0xf5, 0xd2, 0xdb, 0x75, 0xe1, 0x37, 0xca, 0x6f, 0x62, 0x7b, 0xe6, 0xe3,
-	0xb7, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x34, 0x8a, 0x0b, 0x9d, 0xa6, 0x02, 0x00, 0x00,
+	// 415 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0x26, 0xab, 0xb6, 0x35, 0xaf, 0x20, 0x81, 0x35, 0x8d, 0x30, 0x4d, 0x22, 0x8b, 0x84, 0xe8,
+	0x01, 0x25, 0x51, 0xe1, 0xcc, 0x61, 0x13, 0x07, 0x38, 0x80, 0xe4, 0x09, 0x90, 0xb8, 0x44, 0xa9,
+	0xfd, 0x58, 0xad, 0x36, 0xb6, 0xb1, 0xdd, 0x54, 0x5c, 0xf9, 0x23, 0xfc, 0x55, 0x64, 0x3b, 0x51,
+	0xa1, 0x48, 0x3b, 0xe5, 0xbd, 0xcf, 0xdf, 0xfb, 0x5e, 0xfc, 0xf9, 0x83, 0x6c, 0xb9, 0x11, 0x9d,
+	0xae, 0x76, 0xad, 0x70, 0x55, 0x5f, 0x87, 0x6f, 0xa9, 0x8d, 0x72, 0x8a, 0x3c, 0x0a, 0x27, 0x65,
+	0x40, 0xfa, 0xfa, 0xe2, 0x32, 0x12, 0xd1, 0x18, 0x65, 0xac, 0xa7, 0xc6, 0x2a, 0x92, 0x8b, 0x5f,
+	0x09, 0x3c, 0xb9, 0x59, 0x21, 0x5b, 0x53, 0x6c, 0xf9, 0x4f, 0x8a, 0x3f, 0xb6, 0x68, 0x1d, 0xb9,
+	0x84, 0x54, 0xb6, 0x1d, 0x5a, 0xdd, 0x32, 0xcc, 0x92, 0x3c, 0x99, 0xa7, 0x74, 0x0f, 0x90, 0x37,
+	0x90, 0x7a, 0xf1, 0xc6, 0x6a, 0x64, 0xd9, 0x51, 0x9e, 0xcc, 0x67, 0x8b, 0xa7, 0xe5, 0x3f, 0x4b,
+	0xcb, 0xaf, 0xad, 0x70, 0xb7, 0x1a, 0x19, 0x9d, 0xee, 0x86, 0x8a, 0x3c, 0x83, 0xa9, 0x56, 0xbc,
+	0xf1, 0x32, 0xd9, 0x24, 0x48, 0x9e, 0x6a, 0xc5, 0x3f, 0xb6, 0x1d, 0x16, 0xbf, 0x13, 0x98, 0x8e,
+	0x13, 0xe4, 0x2d, 0x00, 0x47, 0x8d, 0x92, 0xdb, 0x46, 0xc9, 0x2c, 0xc9, 0x27, 0xf3, 0xd9, 0xe2,
+	0xf9, 0x81, 0xfc, 0x2d, 0x9a, 0x5e, 0x30, 0xbc, 0x51, 0x92, 0x0b, 0x27, 0x94, 0xa4, 0xe9, 0x30,
+	0xf2, 0x49, 0x92, 0x2b, 0x78, 0xb8, 0x14, 0x92, 0x37, 0xbd, 0xda, 0x6c, 0x3b, 0xb4, 0xd9, 0x51,
+	0x3e, 0x99, 0xa7, 0x74, 0xe6, 0xb1, 0x2f, 0x11, 0x22, 0x35, 0x9c, 0x7d, 0x17, 0x52, 0xd8, 0x15,
+	0x8e, 0xb4, 0x46, 0x48, 0xe1, 0xb2, 0x49, 0xa0, 0x92, 0xf1, 0x2c, 0xd2, 0xdf, 0x4b, 0xe1, 0x8a,
+	0x0f, 0xf0, 0xf8, 0x70, 0x27, 0xc9, 0xe0, 0xd4, 0x46, 0x6c, 0xb0, 0x68, 0x6c, 0xbd, 0x7d, 0x6c,
+	0xa4, 0x05, 0x83, 0x52, 0xba, 0x07, 0x0a, 0x0d, 0xe4, 0x6f, 0xc7, 0xad, 0x56, 0xd2, 0x22, 0x79,
+	0x05, 0xc7, 0xe1, 0x61, 0x82, 0xd6, 0x6c, 0x71, 0x3e, 0xdc, 0x78, 0x78, 0xac, 0xbe, 0x2e, 0xdf,
+	0xf9, 0x8a, 0x46, 0x12, 0x39, 0x83, 0x63, 0xe3, 0xc7, 0x83, 0xfa, 0x94, 0xc6, 0x86, 0x9c, 0xc3,
+	0x89, 0xc1, 0xd6, 0x2a, 0x39, 0x18, 0x3c, 0x74, 0x0b, 0x06, 0x70, 0xad, 0x94, 0xf3, 0x16, 0xa3,
+	0x21, 0x9f, 0x01, 0xf6, 0xfb, 0x49, 0x7e, 0x60, 0xed, 0x7f, 0x61, 0xb8, 0xb8, 0xba, 0x87, 0x11,
+	0x7f, 0xbe, 0x78, 0x50, 0x27, 0xd7, 0x2f, 0xbf, 0xbd, 0xb8, 0x13, 0x6e, 0xb5, 0x5d, 0x96, 0x4c,
+	0x75, 0xd5, 0x1a, 0x37, 0xbc, 0xad, 0x62, 0xf4, 0xf4, 0xfa, 0xae, 0x0a, 0x69, 0x0b, 0x29, 0x5d,
+	0x9e, 0x84, 0xfa, 0xf5, 0x9f, 0x01, 0x00, 0x43, 0x4b, 0xb0, 0xd4, 0xc2, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
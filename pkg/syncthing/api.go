@@ -117,7 +117,7 @@ func (api APIClient) do(method, p string, params map[string]string, respObj inte
 }
 
 func setLocalFolderType(ctx context.Context, c APIClient, t string, idPathMap map[string]string) error {
-	config := makeConfig(false, idPathMap, t)
+	config := makeConfig(false, idPathMap, func(string) string { return t })
 	err := ioutil.WriteFile(cfgdir.Expand("config.xml"), []byte(config), 0644)
 	if err != nil {
 		return errors.WithContext("write config", err)
@@ -40,12 +40,27 @@ func (c Client) GetIDPathMap() map[string]string {
 	return idPathMap
 }
 
+// GetReceiveOnlyFolders returns the IDs of the folders that are only mounted
+// as read-only.
+func (c Client) GetReceiveOnlyFolders() []string {
+	var ids []string
+	for _, m := range c.mounts {
+		if m.ReadOnly {
+			ids = append(ids, m.ID())
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // BindVolume represents a bind volume used by a single service, along with any
 // subdirectories that are masked off by native volumes mounted into this
 // service.
 type BindVolume struct {
 	LocalPath string
 	Masks     []string
+	// ReadOnly is true if the volume is mounted as read-only.
+	ReadOnly bool
 }
 
 type Mount struct {
@@ -56,6 +71,9 @@ type Mount struct {
 	// only be set if Include is nil and SyncAll is true.
 	Ignore  []string
 	SyncAll bool
+	// ReadOnly is true if all the volumes synced by this mount are
+	// read-only, in which case changes in the sandbox aren't synced back.
+	ReadOnly bool
 }
 
 // GetStignore returns the stignore file needed to include only the paths in
@@ -156,17 +174,19 @@ func NewClient(volumes []BindVolume) Client {
 		// the desired files.
 		if isDir(volume.LocalPath) {
 			allMounts = append(allMounts, Mount{
-				Path:    volume.LocalPath,
-				SyncAll: true,
-				Ignore:  collapseIgnores(volume.Masks),
+				Path:     volume.LocalPath,
+				SyncAll:  true,
+				Ignore:   collapseIgnores(volume.Masks),
+				ReadOnly: volume.ReadOnly,
 			})
 		} else {
 			if len(volume.Masks) > 0 {
 				log.WithField("volume", volume).Warn("Volume has masked subdirectories, but is not a directory. Ignoring.")
 			}
 			allMounts = append(allMounts, Mount{
-				Path:    filepath.Dir(volume.LocalPath),
-				Include: []string{filepath.Base(volume.LocalPath)},
+				Path:     filepath.Dir(volume.LocalPath),
+				Include:  []string{filepath.Base(volume.LocalPath)},
+				ReadOnly: volume.ReadOnly,
 			})
 		}
 	}
@@ -209,6 +229,10 @@ func NewClient(volumes []BindVolume) Client {
 				continue
 			}
 
+			// The collapsed mount can only be read-only if all of the
+			// volumes within it are read-only.
+			parent.ReadOnly = parent.ReadOnly && mount.ReadOnly
+
 			switch {
 			case parent.SyncAll:
 				// If the parent already syncs this mount, then any includes for
@@ -301,8 +325,10 @@ func (c Client) Run(ctx context.Context, ncc node.ControllerClient,
 		return nil, errors.WithContext("write config", err)
 	}
 
-	finishedInitialSync := make(chan struct{}, 1)
-	go runSyncCompletionServer(ctx, ncc, auth, finishedInitialSync)
+	// The buffer is large enough to hold a notification for every folder so
+	// that the initial sync never blocks on the notifier.
+	syncedFolders := make(chan string, len(idPathMap)+1)
+	go runSyncCompletionServer(ctx, ncc, auth, idPathMap, syncedFolders)
 
 	var out bytes.Buffer
 	cmd := exec.CommandContext(ctx, stbinPath(), "-verbose", "-home", cfgdir.Expand(""),
@@ -322,7 +348,8 @@ func (c Client) Run(ctx context.Context, ncc node.ControllerClient,
 
 	initialSyncErr := make(chan error)
 	go func() {
-		initialSyncErr <- c.performInitialSync(initialSyncCtx, fmt.Sprintf("127.0.0.1:%d", TunneledAPIPort), idPathMap)
+		initialSyncErr <- c.performInitialSync(initialSyncCtx, fmt.Sprintf("127.0.0.1:%d", TunneledAPIPort),
+			idPathMap, syncedFolders)
 	}()
 
 	select {
@@ -340,7 +367,7 @@ func (c Client) Run(ctx context.Context, ncc node.ControllerClient,
 		}
 	}
 
-	finishedInitialSync <- struct{}{}
+	close(syncedFolders)
 
 	select {
 	case err := <-tunnelsErr:
@@ -379,7 +406,11 @@ func (c Client) startTunnels(tm tunnel.Manager) chan error {
 	return errChan
 }
 
-func (c Client) performInitialSync(ctx context.Context, remoteAPIAddr string, idPathMap map[string]string) error {
+// performInitialSync waits for all the folders to sync. The ID of each folder
+// is sent on syncedFolders as soon as it has synced so that services that only
+// mount that folder can start.
+func (c Client) performInitialSync(ctx context.Context, remoteAPIAddr string, idPathMap map[string]string,
+	syncedFolders chan<- string) error {
 	localAPI := APIClient{fmt.Sprintf("127.0.0.1:%d", APIPort)}
 	remoteAPI := APIClient{remoteAPIAddr}
 
@@ -399,8 +430,24 @@ func (c Client) performInitialSync(ctx context.Context, remoteAPIAddr string, id
 		return errors.WithContext("wait for initial scan", err)
 	}
 
-	if err := waitUntilSynced(ctx, localAPI, folders); err != nil {
-		return errors.WithContext("wait for initial sync", err)
+	// Wait for the folders in parallel so that small folders aren't blocked
+	// on large ones.
+	syncErrs := make(chan error, len(folders))
+	for _, folder := range folders {
+		folder := folder
+		go func() {
+			err := waitUntilSynced(ctx, localAPI, []string{folder})
+			if err == nil {
+				syncedFolders <- folder
+			}
+			syncErrs <- err
+		}()
+	}
+
+	for range folders {
+		if err := <-syncErrs; err != nil {
+			return errors.WithContext("wait for initial sync", err)
+		}
 	}
 
 	if err := setLocalFolderType(ctx, localAPI, "sendreceive", idPathMap); err != nil {
@@ -458,7 +505,7 @@ func (c Client) WriteConfig(idPathMap map[string]string) error {
 	}
 
 	fileMap := map[string]string{
-		"config.xml": makeConfig(false, idPathMap, "sendonly"),
+		"config.xml": makeConfig(false, idPathMap, func(string) string { return "sendonly" }),
 		"cert.pem":   cert,
 		"key.pem":    key,
 	}
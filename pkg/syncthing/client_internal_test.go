@@ -396,6 +396,32 @@ func TestCalculateMounts(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Read-only volumes",
+			volumes: []BindVolume{
+				{LocalPath: "/Users/kevin/config", ReadOnly: true},
+				{LocalPath: "/Users/kevin/config/nested", ReadOnly: true},
+				{LocalPath: "/Users/kevin/kelda.io", ReadOnly: true},
+				{LocalPath: "/Users/kevin/kelda.io/data"},
+			},
+			dirs: []string{
+				"/Users/kevin/config",
+				"/Users/kevin/config/nested",
+				"/Users/kevin/kelda.io",
+				"/Users/kevin/kelda.io/data",
+			},
+			exp: []Mount{
+				{
+					Path:     "/Users/kevin/config",
+					SyncAll:  true,
+					ReadOnly: true,
+				},
+				{
+					Path:    "/Users/kevin/kelda.io",
+					SyncAll: true,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -11,7 +11,16 @@ import (
 	"github.com/kelda/blimp/pkg/proto/node"
 )
 
-func runSyncCompletionServer(ctx context.Context, ncc node.ControllerClient, auth *auth.BlimpAuth, syncedChan <-chan struct{}) {
+// runSyncCompletionServer reports the sync status of each folder to the node
+// controller. The IDs of folders that have finished their initial sync are
+// received on syncedFolders, which is closed once all folders have synced.
+func runSyncCompletionServer(ctx context.Context, ncc node.ControllerClient, auth *auth.BlimpAuth,
+	idPathMap map[string]string, syncedFolders <-chan string) {
+	folders := map[string]*node.FolderSyncStatus{}
+	for id, path := range idPathMap {
+		folders[id] = &node.FolderSyncStatus{Path: path}
+	}
+
 	var hasSynced bool
 	runServer := func() error {
 		log.Debug("Starting sync status server")
@@ -36,8 +45,16 @@ func runSyncCompletionServer(ctx context.Context, ncc node.ControllerClient, aut
 		log.Debug("Connected to node controller")
 		for {
 			select {
-			case <-syncedChan:
-				hasSynced = true
+			case id, ok := <-syncedFolders:
+				if !ok {
+					hasSynced = true
+					// Stop selecting on the closed channel.
+					syncedFolders = nil
+					break
+				}
+				if folder, ok := folders[id]; ok {
+					folder.Synced = true
+				}
 			case err := <-recvChan:
 				if err != nil {
 					return errors.WithContext("receive", err)
@@ -45,6 +62,17 @@ func runSyncCompletionServer(ctx context.Context, ncc node.ControllerClient, aut
 			}
 
 			log.WithField("synced", hasSynced).Debug("Sending sync status")
+			err = conn.Send(&node.SyncStatusResponse{
+				Msg: &node.SyncStatusResponse_Folders{
+					Folders: &node.FolderSyncStatuses{Folders: folders},
+				},
+			})
+			if err != nil {
+				return errors.WithContext("send update", err)
+			}
+
+			// The overall status is sent separately for node controllers
+			// that don't understand the per-folder status.
 			err = conn.Send(&node.SyncStatusResponse{
 				Msg: &node.SyncStatusResponse_Synced{
 					Synced: hasSynced,
@@ -51,26 +51,39 @@ rGQDF3ZOuIeJi9F0RrGggLGHORlhAV3yZf97FaAmOKK4+l+co/E7IoZaUftsg6qr
 8MZvkAZ/5Xhhb4W1Ls3rhN5a4Ef4CZE=
 -----END EC PRIVATE KEY-----`
 
-func MapToArgs(m map[string]string) []string {
+// receiveOnlySuffix is appended to the arguments for folders that should be
+// receive-only in the sandbox.
+const receiveOnlySuffix = ",receiveonly"
+
+func MapToArgs(m map[string]string, receiveOnly map[string]bool) []string {
 	var args []string
 	for id, path := range m {
-		args = append(args, id+","+path)
+		arg := id + "," + path
+		if receiveOnly[id] {
+			arg += receiveOnlySuffix
+		}
+		args = append(args, arg)
 	}
 	// Make the order of the args consistent to avoid unnecessary restarts.
 	sort.Strings(args)
 	return args
 }
 
-func ArgsToMap(args []string) map[string]string {
-	m := map[string]string{}
+func ArgsToMap(args []string) (folders map[string]string, receiveOnly map[string]bool) {
+	folders = map[string]string{}
+	receiveOnly = map[string]bool{}
 	for _, arg := range args {
-		kv := strings.Split(arg, ",")
-		path := kv[1]
+		kv := strings.SplitN(arg, ",", 2)
+		id, path := kv[0], kv[1]
+		if strings.HasSuffix(path, receiveOnlySuffix) {
+			path = strings.TrimSuffix(path, receiveOnlySuffix)
+			receiveOnly[id] = true
+		}
 
-		m[kv[0]] = path
+		folders[id] = path
 	}
 
-	return m
+	return folders, receiveOnly
 }
 
 func MakeMarkers(folders map[string]string) error {
@@ -87,16 +100,23 @@ func MakeMarkers(folders map[string]string) error {
 	return nil
 }
 
-func MakeServer(folders map[string]string) string {
-	return makeConfig(true, folders, "sendreceive")
+// MakeServer returns the Syncthing config for the sandbox. Folders in
+// receiveOnly never send changes back to the CLI.
+func MakeServer(folders map[string]string, receiveOnly map[string]bool) string {
+	return makeConfig(true, folders, func(id string) string {
+		if receiveOnly[id] {
+			return "receiveonly"
+		}
+		return "sendreceive"
+	})
 }
 
-func makeConfig(server bool, folders map[string]string, folderType string) string {
+func makeConfig(server bool, folders map[string]string, folderType func(id string) string) string {
 	// A folder is a map from folder ID to a path.
 
 	var folderStrs []string
 	for id, path := range folders {
-		folderStrs = append(folderStrs, makeFolder(id, path, folderType))
+		folderStrs = append(folderStrs, makeFolder(id, path, folderType(id)))
 	}
 
 	var listenAddress, address string
@@ -0,0 +1,82 @@
+package tunnel
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kelda/blimp/pkg/errors"
+	protoAuth "github.com/kelda/blimp/pkg/proto/auth"
+	"github.com/kelda/blimp/pkg/proto/node"
+)
+
+// Conn is a connection to a port in the sandbox over a tunnel. Unlike Client,
+// it's used directly rather than by proxying a local listener.
+type Conn struct {
+	tnl         node.Controller_TunnelClient
+	cancel      func()
+	pendingData []byte
+}
+
+// Dial opens a tunnel to the given port on the given pod.
+func Dial(ncc node.ControllerClient, auth *protoAuth.BlimpAuth, name string, port uint32) (*Conn, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tnl, err := ncc.Tunnel(ctx)
+	if err != nil {
+		cancel()
+		return nil, errors.WithContext("establish tunnel", err)
+	}
+
+	err = tnl.Send(&node.TunnelMsg{Msg: &node.TunnelMsg_Header{
+		Header: &node.TunnelHeader{
+			Auth: auth,
+			Name: name,
+			Port: port,
+		}}})
+	if err != nil {
+		cancel()
+		return nil, errors.WithContext("send tunnel header", err)
+	}
+
+	return &Conn{tnl: tnl, cancel: cancel}, nil
+}
+
+func (c *Conn) Read(b []byte) (int, error) {
+	if len(c.pendingData) == 0 {
+		msg, err := c.tnl.Recv()
+		switch {
+		case err == io.EOF:
+			return 0, io.EOF
+		case status.Code(err) == codes.OutOfRange:
+			return 0, errors.New("unknown tunnel destination")
+		case err != nil:
+			return 0, errors.WithContext("receive on tunnel", err)
+		case msg.GetEof() != nil:
+			return 0, io.EOF
+		}
+		c.pendingData = msg.GetBuf()
+	}
+
+	n := copy(b, c.pendingData)
+	c.pendingData = c.pendingData[n:]
+	return n, nil
+}
+
+func (c *Conn) Write(b []byte) (int, error) {
+	if err := c.tnl.Send(&node.TunnelMsg{Msg: &node.TunnelMsg_Buf{Buf: b}}); err != nil {
+		return 0, errors.WithContext("send on tunnel", err)
+	}
+	return len(b), nil
+}
+
+// Close closes the tunnel. Note that tunnels don't support half-closing the
+// connection, so no more data can be read after calling Close.
+func (c *Conn) Close() error {
+	//nolint:errcheck // The stream is canceled regardless.
+	c.tnl.Send(&node.TunnelMsg{Msg: &node.TunnelMsg_Eof{Eof: &node.EOF{}}})
+	err := c.tnl.CloseSend()
+	c.cancel()
+	return err
+}
@@ -19,17 +19,38 @@ func NewManager(ncc node.ControllerClient, auth *auth.BlimpAuth) Manager {
 	return Manager{ncc, auth}
 }
 
+// Run listens on the given local address, and forwards connections to the
+// service's port. It blocks until the listener fails.
 func (m Manager) Run(hostIP string, hostPort uint32, serviceName string, servicePort uint32, readyNotifier chan struct{}) error {
+	ln, err := m.Listen(hostIP, hostPort, serviceName, false)
+	if err != nil {
+		return err
+	}
+
+	if readyNotifier != nil {
+		close(readyNotifier)
+	}
+
+	return m.Serve(ln, serviceName, servicePort)
+}
+
+// Listen listens on the given local address. If `fallback` is true and the
+// port is already in use, it listens on a free port chosen by the OS instead.
+// The port that was actually bound can be read from the listener's address.
+func (m Manager) Listen(hostIP string, hostPort uint32, serviceName string, fallback bool) (net.Listener, error) {
 	addr := fmt.Sprintf("%s:%d", hostIP, hostPort)
 	ln, err := net.Listen("tcp", addr)
+	if err != nil && fallback && strings.Contains(err.Error(), "address already in use") {
+		ln, err = net.Listen("tcp", fmt.Sprintf("%s:0", hostIP))
+	}
 	if err != nil {
 		switch {
 		case strings.Contains(err.Error(), "permission denied"):
-			return errors.NewFriendlyError("Permission denied while listening for connections\n"+
+			return nil, errors.NewFriendlyError("Permission denied while listening for connections\n"+
 				"Make sure that the local port for the service %q is above 1024.\n\n"+
 				"The full error was:\n%s", serviceName, err)
 		case strings.Contains(err.Error(), "address already in use"):
-			return errors.NewFriendlyError("Another process is already listening on the same port\n"+
+			return nil, errors.NewFriendlyError("Another process is already listening on the same port\n"+
 				"If you have been using docker-compose, make sure to run docker-compose down.\n"+
 				"Make sure that the there aren't any other "+
 				"services listening locally on port %d. This can be checked with the following command:\n"+
@@ -37,12 +58,13 @@ func (m Manager) Run(hostIP string, hostPort uint32, serviceName string, service
 				"The full error was:\n%s", hostPort, hostPort, err)
 		}
 
-		return errors.WithContext("listen locally", err)
-	}
-
-	if readyNotifier != nil {
-		close(readyNotifier)
+		return nil, errors.WithContext("listen locally", err)
 	}
+	return ln, nil
+}
 
+// Serve forwards the connections accepted by `ln` to the service's port. It
+// blocks until the listener is closed.
+func (m Manager) Serve(ln net.Listener, serviceName string, servicePort uint32) error {
 	return Client(m.ncc, ln, m.auth, serviceName, servicePort)
 }
@@ -0,0 +1,108 @@
+package tunnel
+
+import (
+	"context"
+	"net"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/proto/node"
+)
+
+// reverseRetryInterval is how long RunReverse waits before reconnecting to
+// the node controller.
+const reverseRetryInterval = 5 * time.Second
+
+// ReverseForward forwards connections to `host.docker.internal` on Port in the
+// sandbox to LocalAddr.
+type ReverseForward struct {
+	Port      uint32
+	LocalAddr string
+}
+
+// RunReverse forwards connections from the sandbox to the local machine until
+// `ctx` is cancelled. It reconnects if the connection to the node controller
+// drops.
+func (m Manager) RunReverse(ctx context.Context, forwards []ReverseForward) {
+	localAddrs := map[uint32]string{}
+	var ports []uint32
+	for _, fwd := range forwards {
+		localAddrs[fwd.Port] = fwd.LocalAddr
+		ports = append(ports, fwd.Port)
+	}
+
+	for {
+		err := m.runReverseOnce(ctx, ports, localAddrs)
+		if ctx.Err() != nil {
+			return
+		}
+		log.WithError(err).Debug("Reverse tunnel disconnected. Reconnecting.")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reverseRetryInterval):
+		}
+	}
+}
+
+func (m Manager) runReverseOnce(ctx context.Context, ports []uint32, localAddrs map[uint32]string) error {
+	stream, err := m.ncc.ReverseTunnel(ctx, &node.ReverseTunnelRequest{
+		Auth:  m.auth,
+		Ports: ports,
+	})
+	if err != nil {
+		return errors.WithContext("start reverse tunnel", err)
+	}
+
+	for {
+		conn, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		go m.acceptReverse(conn, localAddrs[conn.GetPort()])
+	}
+}
+
+func (m Manager) acceptReverse(conn *node.ReverseTunnelConn, localAddr string) {
+	fields := log.Fields{
+		"port":  conn.GetPort(),
+		"local": localAddr,
+	}
+
+	// Don't accept the connection if we can't forward it. The gateway drops
+	// connections that aren't accepted.
+	local, err := net.Dial("tcp", localAddr)
+	if err != nil {
+		log.WithError(err).WithFields(fields).Warn("Failed to connect to local address for host.docker.internal")
+		return
+	}
+	defer local.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tnl, err := m.ncc.AcceptReverseTunnel(ctx)
+	if err != nil {
+		log.WithError(err).WithFields(fields).Error("failed to establish reverse tunnel")
+		cancel()
+		return
+	}
+
+	err = tnl.Send(&node.TunnelMsg{Msg: &node.TunnelMsg_ReverseHeader{
+		ReverseHeader: &node.ReverseTunnelHeader{
+			Auth: m.auth,
+			Id:   conn.GetId(),
+		}}})
+	if err != nil {
+		log.WithError(err).WithFields(fields).Error("failed to send reverse tunnel header")
+		//nolint:errcheck // Nothing we could do to handle this anyway.
+		tnl.CloseSend()
+		cancel()
+		return
+	}
+
+	log.WithFields(fields).Trace("new reverse connection")
+	streamBidirectional(local, tnl, cancel)
+	log.WithFields(fields).Trace("finish reverse connection")
+}
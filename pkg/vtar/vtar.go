@@ -0,0 +1,335 @@
+// Package vtar implements the archive format and protocol used to export and
+// import the contents of volumes.
+//
+// The CLI connects to the volume transfer pod over a node controller tunnel,
+// and starts by sending Magic. Connections that don't start with Magic (such
+// as readiness probes) are ignored by the pod.
+//
+// For exports, the pod responds with a status line, followed by the
+// gzip-compressed tar archive of the volume.
+//
+// For imports, the CLI sends the gzip-compressed tar archive, and the pod
+// responds with a status line once the archive has been extracted.
+//
+// The end of the archive is detected from the gzip stream itself, rather
+// than by closing the connection, since tunnels don't support half-closed
+// connections.
+package vtar
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+// Magic is the first message sent by the client.
+const Magic = "BLIMP-VTAR\n"
+
+const statusOK = "ok"
+
+// WriteStatus sends the result of an operation to the other side of the
+// connection.
+func WriteStatus(w io.Writer, err error) error {
+	status := statusOK
+	if err != nil {
+		// The status is newline delimited, so the error can't contain any
+		// newlines.
+		status = "error: " + strings.Replace(err.Error(), "\n", " ", -1)
+	}
+	_, writeErr := fmt.Fprintln(w, status)
+	return writeErr
+}
+
+// ReadStatus reads the result of an operation sent by WriteStatus.
+func ReadStatus(r *bufio.Reader) error {
+	status, err := r.ReadString('\n')
+	if err != nil {
+		return errors.WithContext("read status", err)
+	}
+
+	status = strings.TrimSuffix(status, "\n")
+	if status == statusOK {
+		return nil
+	}
+	return errors.New("%s", strings.TrimPrefix(status, "error: "))
+}
+
+// Archive writes a gzip-compressed tar archive of the contents of dir to w.
+// Ownership, permissions, modification times and symlinks are preserved.
+func Archive(w io.Writer, dir string) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return errors.WithContext("get relative path", err)
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err = os.Readlink(path)
+			if err != nil {
+				return errors.WithContext("read symlink", err)
+			}
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return errors.WithContext("make header", err)
+		}
+		hdr.Name = filepath.ToSlash(relPath)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		hdr.Format = tar.FormatPAX
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return errors.WithContext("write header", err)
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return errors.WithContext("open file", err)
+		}
+		defer f.Close()
+
+		if _, err := io.Copy(tw, f); err != nil {
+			return errors.WithContext("write file", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.WithContext("close tar", err)
+	}
+	return gzw.Close()
+}
+
+// Extract extracts the archive written by Archive into dir. It only consumes
+// the archive from r, so r may contain more data afterwards.
+func Extract(r io.Reader, dir string) error {
+	dir = filepath.Clean(dir)
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.WithContext("read gzip header", err)
+	}
+	gzr.Multistream(false)
+
+	// Directory modification times are set after all files are extracted
+	// since creating files within the directory modifies its mtime.
+	dirTimes := map[string]time.Time{}
+
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.WithContext("read tar", err)
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if !withinDir(dir, target) {
+			return errors.New("archive contains invalid path %q", hdr.Name)
+		}
+
+		if err := extractEntry(tr, hdr, dir, target); err != nil {
+			return errors.WithContext(fmt.Sprintf("extract %s", hdr.Name), err)
+		}
+
+		if hdr.Typeflag == tar.TypeDir {
+			dirTimes[target] = hdr.ModTime
+		}
+	}
+
+	for path, mtime := range dirTimes {
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			return errors.WithContext("set modification time", err)
+		}
+	}
+
+	// Consume the gzip footer so that its checksum is verified.
+	if _, err := io.Copy(ioutil.Discard, gzr); err != nil {
+		return errors.WithContext("read gzip footer", err)
+	}
+	return nil
+}
+
+func extractEntry(tr *tar.Reader, hdr *tar.Header, dir, target string) error {
+	// The extraction runs as root, so entries must never be written through
+	// a symlink. Otherwise, an archive could create a symlink pointing
+	// outside dir, and then write files through it.
+	if err := checkNoSymlinks(dir, filepath.Dir(target)); err != nil {
+		return err
+	}
+
+	// Replace existing symlinks rather than following them when writing the
+	// entry.
+	if fi, err := os.Lstat(target); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(target); err != nil {
+			return errors.WithContext("remove existing symlink", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return errors.WithContext("make parent directory", err)
+	}
+
+	mode := os.FileMode(hdr.Mode).Perm()
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if err := os.MkdirAll(target, mode); err != nil {
+			return err
+		}
+	case tar.TypeReg, tar.TypeRegA:
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(f, tr)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	case tar.TypeSymlink:
+		// Symlinks are created as is, even if they point outside dir, since
+		// they're resolved within the container that mounts the volume.
+		// Later entries are never written through them.
+		if err := os.Symlink(hdr.Linkname, target); err != nil {
+			return err
+		}
+	case tar.TypeLink:
+		linkTarget := filepath.Join(dir, filepath.FromSlash(hdr.Linkname))
+		if linkTarget == dir || !withinDir(dir, linkTarget) {
+			return errors.New("invalid hard link target %q", hdr.Linkname)
+		}
+		if err := checkNoSymlinks(dir, filepath.Dir(linkTarget)); err != nil {
+			return err
+		}
+		if err := os.Link(linkTarget, target); err != nil {
+			return err
+		}
+	default:
+		// Device files, FIFOs, etc aren't supported.
+		return nil
+	}
+
+	// Only root can change the ownership of files. Non-root extraction is
+	// only expected in tests.
+	if os.Geteuid() == 0 {
+		if err := os.Lchown(target, hdr.Uid, hdr.Gid); err != nil {
+			return errors.WithContext("set owner", err)
+		}
+	}
+
+	if hdr.Typeflag == tar.TypeSymlink {
+		return nil
+	}
+
+	// Set the mode explicitly since the modes passed when creating files
+	// are affected by the umask, and don't include the setuid, setgid and
+	// sticky bits.
+	if err := os.Chmod(target, os.FileMode(hdr.Mode)&os.ModePerm|specialBits(hdr.Mode)); err != nil {
+		return errors.WithContext("set mode", err)
+	}
+	return os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+}
+
+// withinDir returns whether path is dir, or is contained by dir. Both paths
+// must be clean.
+func withinDir(dir, path string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// checkNoSymlinks returns an error if any of the components of path below
+// dir are symlinks. Components that don't exist yet are created as
+// directories by the caller, so they're safe.
+func checkNoSymlinks(dir, path string) error {
+	relPath, err := filepath.Rel(dir, path)
+	if err != nil {
+		return errors.WithContext("get relative path", err)
+	}
+	if relPath == "." {
+		return nil
+	}
+
+	curr := dir
+	for _, part := range strings.Split(relPath, string(filepath.Separator)) {
+		curr = filepath.Join(curr, part)
+		fi, err := os.Lstat(curr)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if fi.Mode()&os.ModeSymlink != 0 {
+			relCurr, _ := filepath.Rel(dir, curr)
+			return errors.New("path traverses symlink %q", filepath.ToSlash(relCurr))
+		}
+	}
+	return nil
+}
+
+func specialBits(mode int64) (fileMode os.FileMode) {
+	if mode&04000 != 0 {
+		fileMode |= os.ModeSetuid
+	}
+	if mode&02000 != 0 {
+		fileMode |= os.ModeSetgid
+	}
+	if mode&01000 != 0 {
+		fileMode |= os.ModeSticky
+	}
+	return fileMode
+}
+
+// Validate reads the archive from r, and returns an error if it's truncated
+// or corrupted.
+func Validate(r io.Reader) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.WithContext("read gzip header", err)
+	}
+	gzr.Multistream(false)
+
+	tr := tar.NewReader(gzr)
+	for {
+		_, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.WithContext("read tar", err)
+		}
+	}
+
+	if _, err := io.Copy(ioutil.Discard, gzr); err != nil {
+		return errors.WithContext("read gzip footer", err)
+	}
+	return nil
+}
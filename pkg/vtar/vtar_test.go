@@ -0,0 +1,230 @@
+package vtar
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+func TestArchiveExtract(t *testing.T) {
+	src, err := ioutil.TempDir("", "vtar-src")
+	require.NoError(t, err)
+	defer os.RemoveAll(src)
+
+	mtime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "data", "nested"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "data", "nested", "file"), []byte("contents"), 0640))
+	require.NoError(t, os.Chmod(filepath.Join(src, "data", "nested", "file"), 0640))
+	require.NoError(t, os.Chtimes(filepath.Join(src, "data", "nested", "file"), mtime, mtime))
+	require.NoError(t, os.Symlink("nested/file", filepath.Join(src, "data", "link")))
+	require.NoError(t, os.Symlink("/usr/lib/libc.so", filepath.Join(src, "data", "absolute")))
+	require.NoError(t, os.Symlink("../../shared", filepath.Join(src, "data", "outside")))
+
+	var archive bytes.Buffer
+	require.NoError(t, Archive(&archive, src))
+
+	// Add trailing data to make sure that Extract doesn't consume past the
+	// end of the archive.
+	archive.WriteString("trailer")
+
+	dst, err := ioutil.TempDir("", "vtar-dst")
+	require.NoError(t, err)
+	defer os.RemoveAll(dst)
+
+	require.NoError(t, Extract(&archive, dst))
+	assert.Equal(t, "trailer", archive.String())
+
+	contents, err := ioutil.ReadFile(filepath.Join(dst, "data", "nested", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "contents", string(contents))
+
+	fileInfo, err := os.Stat(filepath.Join(dst, "data", "nested", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), fileInfo.Mode())
+	assert.True(t, mtime.Equal(fileInfo.ModTime()))
+
+	dirInfo, err := os.Stat(filepath.Join(dst, "data", "nested"))
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeDir|0700, dirInfo.Mode())
+
+	// Symlinks are preserved, even if they point outside the volume.
+	for name, exp := range map[string]string{
+		"link":     "nested/file",
+		"absolute": "/usr/lib/libc.so",
+		"outside":  "../../shared",
+	} {
+		link, err := os.Readlink(filepath.Join(dst, "data", name))
+		require.NoError(t, err)
+		assert.Equal(t, exp, link)
+	}
+}
+
+func TestExtractInvalidPath(t *testing.T) {
+	var archive bytes.Buffer
+	gzw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gzw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     "../escape",
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+	}))
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+
+	dst, err := ioutil.TempDir("", "vtar-dst")
+	require.NoError(t, err)
+	defer os.RemoveAll(dst)
+
+	assert.Error(t, Extract(&archive, dst))
+}
+
+func TestExtractSymlinkEscape(t *testing.T) {
+	outside, err := ioutil.TempDir("", "vtar-outside")
+	require.NoError(t, err)
+	defer os.RemoveAll(outside)
+
+	// The symlinks in the archive are extracted, but later entries can't be
+	// written through them.
+	tests := []struct {
+		name    string
+		headers []tar.Header
+	}{
+		{
+			name: "absolute symlink",
+			headers: []tar.Header{
+				{Name: "a", Typeflag: tar.TypeSymlink, Linkname: outside},
+				{Name: "a/x", Typeflag: tar.TypeReg, Mode: 0644},
+			},
+		},
+		{
+			name: "relative symlink",
+			headers: []tar.Header{
+				{Name: "a", Typeflag: tar.TypeSymlink, Linkname: "../" + filepath.Base(outside)},
+				{Name: "a/x", Typeflag: tar.TypeReg, Mode: 0644},
+			},
+		},
+		{
+			name: "directory through symlink",
+			headers: []tar.Header{
+				{Name: "a", Typeflag: tar.TypeSymlink, Linkname: outside},
+				{Name: "a/x/", Typeflag: tar.TypeDir, Mode: 0755},
+			},
+		},
+		{
+			name: "existing symlink",
+			headers: []tar.Header{
+				{Name: "existing/x", Typeflag: tar.TypeReg, Mode: 0644},
+			},
+		},
+		{
+			name: "hard link through existing symlink",
+			headers: []tar.Header{
+				{Name: "x", Typeflag: tar.TypeLink, Linkname: "existing/secret"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			var archive bytes.Buffer
+			gzw := gzip.NewWriter(&archive)
+			tw := tar.NewWriter(gzw)
+			for _, hdr := range test.headers {
+				hdr := hdr
+				require.NoError(t, tw.WriteHeader(&hdr))
+			}
+			require.NoError(t, tw.Close())
+			require.NoError(t, gzw.Close())
+
+			dst, err := ioutil.TempDir("", "vtar-dst")
+			require.NoError(t, err)
+			defer os.RemoveAll(dst)
+
+			// A symlink that was already in the volume, e.g. from a previous
+			// import.
+			require.NoError(t, ioutil.WriteFile(filepath.Join(outside, "secret"), nil, 0600))
+			require.NoError(t, os.Symlink(outside, filepath.Join(dst, "existing")))
+
+			assert.Error(t, Extract(&archive, dst))
+			_, err = os.Lstat(filepath.Join(outside, "x"))
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
+
+func TestExtractReplacesSymlink(t *testing.T) {
+	outside, err := ioutil.TempDir("", "vtar-outside")
+	require.NoError(t, err)
+	defer os.RemoveAll(outside)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0600))
+
+	// A later entry at the same path as a symlink replaces the symlink
+	// rather than writing to its target.
+	var archive bytes.Buffer
+	gzw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gzw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     "a",
+		Typeflag: tar.TypeSymlink,
+		Linkname: filepath.Join(outside, "secret"),
+	}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     "a",
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+		Size:     int64(len("overwritten")),
+	}))
+	_, err = tw.Write([]byte("overwritten"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+
+	dst, err := ioutil.TempDir("", "vtar-dst")
+	require.NoError(t, err)
+	defer os.RemoveAll(dst)
+
+	require.NoError(t, Extract(&archive, dst))
+
+	contents, err := ioutil.ReadFile(filepath.Join(dst, "a"))
+	require.NoError(t, err)
+	assert.Equal(t, "overwritten", string(contents))
+
+	contents, err = ioutil.ReadFile(filepath.Join(outside, "secret"))
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(contents))
+}
+
+func TestValidate(t *testing.T) {
+	src, err := ioutil.TempDir("", "vtar-src")
+	require.NoError(t, err)
+	defer os.RemoveAll(src)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "file"), bytes.Repeat([]byte("a"), 4096), 0644))
+
+	var archive bytes.Buffer
+	require.NoError(t, Archive(&archive, src))
+
+	assert.NoError(t, Validate(bytes.NewReader(archive.Bytes())))
+	assert.Error(t, Validate(bytes.NewReader(archive.Bytes()[:archive.Len()-10])))
+}
+
+func TestStatus(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatus(&buf, nil))
+	require.NoError(t, WriteStatus(&buf, errors.New("multi\nline")))
+
+	r := bufio.NewReader(&buf)
+	assert.NoError(t, ReadStatus(r))
+	assert.EqualError(t, ReadStatus(r), "multi line")
+}
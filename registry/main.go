@@ -1,19 +1,25 @@
 package main
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/cesanta/docker_auth/auth_server/api"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/kelda/blimp/pkg/auth"
 	clusterAuth "github.com/kelda/blimp/pkg/auth"
 	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/kube"
 )
 
 func init() {
@@ -42,7 +48,11 @@ func main() {
 	case "auth":
 		err = authenticate(string(stdin))
 	case "authz":
-		err = authorize(string(stdin))
+		var quota *quotaChecker
+		quota, err = newQuotaChecker()
+		if err == nil {
+			err = authorize(string(stdin), quota)
+		}
 	default:
 		log.WithField("mode", args[0]).Error("Unrecognized mode")
 		os.Exit(1)
@@ -63,6 +73,17 @@ func authenticate(input string) error {
 		return errors.New("malformed authentication input")
 	}
 
+	// The cluster manager logs in as the admin to garbage collect images.
+	if credentials[0] == auth.RegistryAdminUsername {
+		adminPassword := os.Getenv("BLIMP_REGISTRY_ADMIN_PASSWORD")
+		if adminPassword == "" ||
+			subtle.ConstantTimeCompare([]byte(credentials[1]), []byte(adminPassword)) != 1 {
+			return errors.New("invalid admin password")
+		}
+		fmt.Printf(`{"labels": {"admin": ["true"]}}`)
+		return nil
+	}
+
 	blimpAuth, err := auth.BlimpRegistryAuth{
 		Username: credentials[0],
 		Password: credentials[1],
@@ -80,21 +101,92 @@ func authenticate(input string) error {
 }
 
 // authorized validates that the user is attempting to interact with an image
-// in their namespace.
-func authorize(input string) error {
+// in their namespace, and that pushes don't exceed the namespace's quota.
+func authorize(input string, quota *quotaChecker) error {
 	var authReqInfo api.AuthRequestInfo
 	err := json.Unmarshal([]byte(input), &authReqInfo)
 	if err != nil {
 		return errors.WithContext("parse input", err)
 	}
 
+	// The admin can access all namespaces, and list the registry's catalog.
+	if len(authReqInfo.Labels["admin"]) == 1 && authReqInfo.Labels["admin"][0] == "true" {
+		return nil
+	}
+
 	if len(authReqInfo.Labels["namespace"]) != 1 {
 		return errors.New("missing namespace label")
 	}
 
 	namespace := authReqInfo.Labels["namespace"][0]
-	if strings.HasPrefix(authReqInfo.Name, namespace+"/") {
+	if !strings.HasPrefix(authReqInfo.Name, namespace+"/") {
+		return errors.New("not within user's namespace")
+	}
+
+	for _, action := range authReqInfo.Actions {
+		if action == "push" {
+			return quota.check(namespace)
+		}
+	}
+	return nil
+}
+
+// quotaChecker enforces the quota set by $BLIMP_REGISTRY_QUOTA on the
+// registry storage used by each namespace. The usage is periodically computed
+// by the cluster manager's garbage collector, so the quota is only
+// approximately enforced.
+type quotaChecker struct {
+	quota      resource.Quantity
+	kubeClient kubernetes.Interface
+}
+
+// newQuotaChecker returns nil if no quota is configured. Otherwise, the quota
+// must be valid so that a misconfigured registry doesn't silently allow
+// unlimited pushes.
+func newQuotaChecker() (*quotaChecker, error) {
+	quotaStr := os.Getenv("BLIMP_REGISTRY_QUOTA")
+	if quotaStr == "" {
+		return nil, nil
+	}
+
+	quota, err := resource.ParseQuantity(quotaStr)
+	if err != nil {
+		return nil, errors.WithContext("parse registry quota", err)
+	}
+
+	kubeClient, _, err := kube.GetClient()
+	if err != nil {
+		return nil, errors.WithContext("get kube client", err)
+	}
+	return &quotaChecker{quota: quota, kubeClient: kubeClient}, nil
+}
+
+// check returns an error if the namespace's images use more than the quota.
+// Pushes are rejected if the usage can't be read, except when the garbage
+// collector hasn't computed it yet.
+func (c *quotaChecker) check(namespace string) error {
+	if c == nil {
 		return nil
 	}
-	return errors.New("not within user's namespace")
+
+	ns, err := c.kubeClient.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+	if err != nil {
+		return errors.WithContext("get namespace", err)
+	}
+
+	usageStr, ok := ns.Annotations[kube.RegistryUsageAnnotation]
+	if !ok {
+		return nil
+	}
+
+	usage, err := strconv.ParseInt(usageStr, 10, 64)
+	if err != nil {
+		return errors.WithContext("parse registry usage", err)
+	}
+
+	if usage >= c.quota.Value() {
+		return errors.New("namespace %s is using %s of registry storage, which exceeds its quota of %s",
+			namespace, resource.NewQuantity(usage, resource.BinarySI), c.quota.String())
+	}
+	return nil
 }
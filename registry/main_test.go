@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kelda/blimp/pkg/kube"
+)
+
+func TestQuotaCheck(t *testing.T) {
+	namespace := func(name, usage string) *corev1.Namespace {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if usage != "" {
+			ns.Annotations = map[string]string{kube.RegistryUsageAnnotation: usage}
+		}
+		return ns
+	}
+	checker := &quotaChecker{
+		quota: resource.MustParse("1Ki"),
+		kubeClient: fakeKube.NewSimpleClientset(
+			namespace("under", "1000"),
+			namespace("over", "1024"),
+			namespace("uncomputed", ""),
+			namespace("malformed", "lots"),
+		),
+	}
+
+	assert.NoError(t, checker.check("under"))
+	assert.Error(t, checker.check("over"))
+
+	// The garbage collector may not have computed the usage yet.
+	assert.NoError(t, checker.check("uncomputed"))
+
+	// Pushes should be rejected if the usage can't be read.
+	assert.Error(t, checker.check("malformed"))
+	assert.Error(t, checker.check("missing"))
+
+	// Pushes are unlimited if there's no quota.
+	var noQuota *quotaChecker
+	assert.NoError(t, noQuota.check("over"))
+}
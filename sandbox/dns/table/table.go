@@ -0,0 +1,226 @@
+// Package table implements the DNS server that resolves service names to the
+// IPs of their pods within a sandbox.
+package table
+
+import (
+	"bytes"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers/core/v1"
+
+	"github.com/kelda/blimp/pkg/hostgateway"
+	"github.com/kelda/blimp/pkg/metadata"
+)
+
+const dnsTTL = 60 // Seconds
+
+// Table answers DNS queries for a sandbox. Service names and aliases are
+// resolved to the IPs of the sandbox's pods, and all other names are resolved
+// using the host's resolver.
+type Table struct {
+	namespace string
+	lister    listers.PodLister
+
+	// gatewayIP is the IP that `host.docker.internal` resolves to. It's
+	// the IP of this pod since the host gateway runs alongside the DNS server.
+	gatewayIP net.IP
+
+	recordLock sync.Mutex
+	records    map[string][]net.IP
+
+	// numLookups is the number of internal names that have been resolved. It's
+	// used to rotate the order of the IPs of services with multiple replicas,
+	// so that connections are spread between the replicas.
+	numLookups int
+}
+
+// UpdateTable refreshes the records from the pods in the namespace.
+func (table *Table) UpdateTable() {
+	table.recordLock.Lock()
+	defer table.recordLock.Unlock()
+
+	pods, err := table.lister.Pods(table.namespace).
+		List(labels.Set(
+			map[string]string{"blimp.customerPod": "true"},
+		).AsSelector())
+	if err != nil {
+		// We won't retry updating the table if the list fails, but the list
+		// should never fail since the lister is backed by the local cache
+		// managed by the informer.
+		log.WithError(err).Error("Failed to list pods")
+		return
+	}
+
+	records := podsToDNS(pods)
+	table.records = records
+}
+
+// ServeDNS implements dns.Handler.
+func (table *Table) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	defer w.Close()
+
+	resp := table.genResponse(req)
+	if resp == nil {
+		return
+	}
+
+	if err := w.WriteMsg(resp); err != nil {
+		log.WithError(err).Error("Failed to send DNS response")
+	}
+}
+
+func (table *Table) genResponse(req *dns.Msg) *dns.Msg {
+	resp := &dns.Msg{}
+	if len(req.Question) != 1 {
+		return resp.SetRcode(req, dns.RcodeNotImplemented)
+	}
+
+	q := req.Question[0]
+	// If the request is for an IPv6 address, simply return an empty answer to
+	// indicate that there may be answers for other query types, such as IPv4.
+	if q.Qtype == dns.TypeAAAA {
+		return resp.SetReply(req)
+	}
+
+	if q.Qclass != dns.ClassINET || q.Qtype != dns.TypeA {
+		return resp.SetRcode(req, dns.RcodeNotImplemented)
+	}
+
+	ips := table.LookupA(q.Name)
+	if len(ips) == 0 {
+		// Even though the client asked for a Kelda hostname that we know
+		// nothing about, it's possible we'll learn about it in the future.  For
+		// now, we'll just not respond, the client will time out, and try again
+		// later.  Hopefully by then we have a response for them -- or if not,
+		// eventually they'll give up.
+		//
+		// XXX: The above logic is correct for Kelda hostname, but
+		// we're also doing the same thing for failures to resolve external
+		// hosts.  This isn't entirely correct, it would be much better to return
+		// whatever upstream gave us in case of a failure.
+		return nil
+	}
+
+	resp.SetReply(req)
+	for _, ip := range ips {
+		resp.Answer = append(resp.Answer, &dns.A{
+			Hdr: dns.RR_Header{
+				Name:   q.Name,
+				Rrtype: dns.TypeA,
+				Class:  dns.ClassINET,
+				Ttl:    dnsTTL,
+			},
+			A: ip,
+		})
+	}
+	return resp
+}
+
+// LookupA returns the IPv4 addresses that the name resolves to.
+func (table *Table) LookupA(name string) []net.IP {
+	name = strings.TrimRight(strings.ToLower(name), ".")
+
+	// Try to see if it's an internal name first. If not, we'll fallback to
+	// external DNS.
+	table.recordLock.Lock()
+	internalIPs := rotate(table.records[name], table.numLookups)
+	if len(internalIPs) != 0 {
+		table.numLookups++
+	}
+	table.recordLock.Unlock()
+	if len(internalIPs) != 0 {
+		return internalIPs
+	}
+
+	// Services named `host.docker.internal` take precedence over the host
+	// gateway.
+	if name == hostgateway.Hostname && table.gatewayIP != nil {
+		return []net.IP{table.gatewayIP}
+	}
+
+	if strings.Count(name, ".") == 0 {
+		// It's definitely an internal hostname, so don't bother looking it up
+		// externally.
+		return nil
+	}
+
+	ipStrs, err := lookupHost(name)
+	if err != nil {
+		log.WithError(err).Debug("Failed to lookup external record: ", name)
+		return nil
+	}
+
+	var ips []net.IP
+	for _, ipStr := range ipStrs {
+		if ip := net.ParseIP(ipStr); ip != nil && ip.To4() != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// New creates a Table for the pods in the given namespace. The table is empty
+// until UpdateTable is called.
+func New(namespace string, lister listers.PodLister, gatewayIP net.IP) *Table {
+	return &Table{
+		namespace: namespace,
+		lister:    lister,
+		gatewayIP: gatewayIP,
+	}
+}
+
+// rotate returns a copy of the IPs that starts at the nth IP, wrapping
+// around to the beginning.
+func rotate(ips []net.IP, n int) []net.IP {
+	if len(ips) == 0 {
+		return nil
+	}
+
+	start := n % len(ips)
+	return append(append([]net.IP{}, ips[start:]...), ips[:start]...)
+}
+
+func podsToDNS(pods []*corev1.Pod) map[string][]net.IP {
+	records := map[string][]net.IP{}
+	for _, pod := range pods {
+		ip := net.ParseIP(pod.Status.PodIP)
+		if ip == nil {
+			continue
+		}
+
+		// Services with multiple replicas resolve to the IPs of all of their
+		// replicas.
+		serviceName := strings.ToLower(pod.Labels["blimp.service"])
+		records[serviceName] = append(records[serviceName], ip)
+
+		// Add aliases to DNS.
+		aliases, ok := pod.Annotations[metadata.AliasesKey]
+		if !ok {
+			continue
+		}
+
+		for _, alias := range metadata.ParseAliases(aliases) {
+			alias = strings.ToLower(alias)
+			records[alias] = append(records[alias], ip)
+		}
+	}
+
+	// Sort the IPs so that the order of the responses doesn't depend on the
+	// order of the pods.
+	for _, ips := range records {
+		ips := ips
+		sort.Slice(ips, func(i, j int) bool {
+			return bytes.Compare(ips[i], ips[j]) < 0
+		})
+	}
+	return records
+}
+
+var lookupHost = net.LookupHost
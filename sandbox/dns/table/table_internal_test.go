@@ -0,0 +1,141 @@
+package table
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kelda/blimp/pkg/errors"
+)
+
+func TestLookupA(t *testing.T) {
+	tests := []struct {
+		name               string
+		records            map[string][]net.IP
+		gatewayIP          net.IP
+		req                string
+		expIPs             []net.IP
+		lookupExternalHost func(string) ([]string, error)
+	}{
+		{
+			name: "internal hostname",
+			records: map[string][]net.IP{
+				"host": {net.IPv4(8, 8, 8, 8)},
+			},
+			req: "host.",
+			expIPs: []net.IP{
+				net.IPv4(8, 8, 8, 8),
+			},
+		},
+		{
+			name: "internal with tld",
+			records: map[string][]net.IP{
+				"dev.kelda": {net.IPv4(8, 8, 8, 8)},
+			},
+			req: "dev.kelda.",
+			expIPs: []net.IP{
+				net.IPv4(8, 8, 8, 8),
+			},
+		},
+		{
+			name: "external hostname",
+			records: map[string][]net.IP{
+				"does-not-match": {net.IPv4(8, 8, 8, 8)},
+			},
+			req: "google.com.",
+			expIPs: []net.IP{
+				net.IPv4(9, 9, 9, 9),
+			},
+			lookupExternalHost: func(host string) ([]string, error) {
+				if host == "google.com" {
+					return []string{"9.9.9.9"}, nil
+				}
+				return nil, errors.New("unknown host")
+			},
+		},
+		{
+			name: "external hostname with multiple IPs",
+			records: map[string][]net.IP{
+				"does-not-match": {net.IPv4(8, 8, 8, 8)},
+			},
+			req: "google.com.",
+			expIPs: []net.IP{
+				net.IPv4(9, 9, 9, 9),
+				net.IPv4(10, 10, 10, 10),
+			},
+			lookupExternalHost: func(host string) ([]string, error) {
+				if host == "google.com" {
+					return []string{"9.9.9.9", "10.10.10.10"}, nil
+				}
+				return nil, errors.New("unknown host")
+			},
+		},
+		{
+			name:      "host gateway",
+			records:   map[string][]net.IP{},
+			gatewayIP: net.IPv4(10, 0, 0, 1),
+			req:       "host.docker.internal.",
+			expIPs: []net.IP{
+				net.IPv4(10, 0, 0, 1),
+			},
+		},
+		{
+			name: "service overrides host gateway",
+			records: map[string][]net.IP{
+				"host.docker.internal": {net.IPv4(8, 8, 8, 8)},
+			},
+			gatewayIP: net.IPv4(10, 0, 0, 1),
+			req:       "host.docker.internal.",
+			expIPs: []net.IP{
+				net.IPv4(8, 8, 8, 8),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		lookupHost = test.lookupExternalHost
+		tbl := Table{records: test.records, gatewayIP: test.gatewayIP}
+		assert.Equal(t, test.expIPs, tbl.LookupA(test.req), test.name)
+	}
+}
+
+func TestLookupARoundRobin(t *testing.T) {
+	tbl := Table{records: map[string][]net.IP{
+		"web": {net.IPv4(10, 0, 0, 1), net.IPv4(10, 0, 0, 2), net.IPv4(10, 0, 0, 3)},
+	}}
+
+	// Each lookup should return all the IPs, starting with a different one.
+	assert.Equal(t, []net.IP{net.IPv4(10, 0, 0, 1), net.IPv4(10, 0, 0, 2), net.IPv4(10, 0, 0, 3)},
+		tbl.LookupA("web."))
+	assert.Equal(t, []net.IP{net.IPv4(10, 0, 0, 2), net.IPv4(10, 0, 0, 3), net.IPv4(10, 0, 0, 1)},
+		tbl.LookupA("web."))
+	assert.Equal(t, []net.IP{net.IPv4(10, 0, 0, 3), net.IPv4(10, 0, 0, 1), net.IPv4(10, 0, 0, 2)},
+		tbl.LookupA("web."))
+	assert.Equal(t, []net.IP{net.IPv4(10, 0, 0, 1), net.IPv4(10, 0, 0, 2), net.IPv4(10, 0, 0, 3)},
+		tbl.LookupA("web."))
+}
+
+func TestPodsToDNS(t *testing.T) {
+	makePod := func(svc, ip string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"blimp.service": svc},
+			},
+			Status: corev1.PodStatus{PodIP: ip},
+		}
+	}
+
+	pods := []*corev1.Pod{
+		makePod("Web", "10.0.0.3"),
+		makePod("Web", "10.0.0.1"),
+		makePod("db", "10.0.0.2"),
+		makePod("pending", ""),
+	}
+	assert.Equal(t, map[string][]net.IP{
+		"web": {net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.3")},
+		"db":  {net.ParseIP("10.0.0.2")},
+	}, podsToDNS(pods))
+}
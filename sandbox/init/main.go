@@ -44,6 +44,9 @@ func main() {
 		log.Fatal("NAMESPACE environment variable is required")
 	}
 
+	// The pod name is optional since it's only used for reporting progress.
+	podName := os.Getenv("POD_NAME")
+
 	waitSpecRaw, err := ioutil.ReadFile("/etc/blimp/wait-spec")
 	if err != nil {
 		log.WithError(err).Fatal("Failed to read wait spec")
@@ -56,7 +59,7 @@ func main() {
 
 	log.WithField("waitSpec", waitSpec).Info("Started")
 	for {
-		if err := runOnce(nodeControllerHost, namespace, waitSpec); err != nil {
+		if err := runOnce(nodeControllerHost, namespace, podName, waitSpec); err != nil {
 			log.WithError(err).Error("Failed to run. Retrying in 10 seconds.")
 			time.Sleep(10 * time.Second)
 		} else {
@@ -66,7 +69,7 @@ func main() {
 	}
 }
 
-func runOnce(nodeControllerHost, namespace string, waitSpec protoWait.WaitSpec) error {
+func runOnce(nodeControllerHost, namespace, podName string, waitSpec protoWait.WaitSpec) error {
 	log.Info("Initiating CheckReady request")
 
 	// Connect to the node controller.
@@ -84,6 +87,7 @@ func runOnce(nodeControllerHost, namespace string, waitSpec protoWait.WaitSpec)
 	isReadyStream, err := client.CheckReady(context.TODO(), &protoWait.CheckReadyRequest{
 		Namespace: namespace,
 		WaitSpec:  &waitSpec,
+		PodName:   podName,
 	})
 	if err != nil {
 		return err
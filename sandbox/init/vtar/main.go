@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/kelda/blimp/pkg/errors"
+	"github.com/kelda/blimp/pkg/vtar"
+)
+
+// vtar serves a single export or import of a volume directory, and then
+// exits. See pkg/vtar for the protocol.
+func main() {
+	mode := flag.String("mode", "", "Either export or import")
+	dir := flag.String("dir", "", "The volume directory to export or import")
+	port := flag.Int("port", 0, "The port to listen for connections on")
+	flag.Parse()
+
+	var handler func(net.Conn, string) error
+	switch *mode {
+	case "export":
+		handler = export
+	case "import":
+		handler = importArchive
+	default:
+		log.Fatal("mode must be export or import")
+	}
+
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
+	if err != nil {
+		log.WithError(err).Fatal("Failed to listen")
+	}
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			log.WithError(err).Fatal("Failed to accept connection")
+		}
+
+		// Ignore connections that aren't from the CLI, such as readiness
+		// probes.
+		magic := make([]byte, len(vtar.Magic))
+		if _, err := io.ReadFull(conn, magic); err != nil || string(magic) != vtar.Magic {
+			conn.Close()
+			continue
+		}
+
+		log.WithField("mode", *mode).WithField("dir", *dir).Info("Starting transfer")
+		err = handler(conn, *dir)
+		conn.Close()
+		if err != nil {
+			log.WithError(err).Fatal("Transfer failed")
+		}
+		log.Info("Transfer complete")
+		return
+	}
+}
+
+func export(conn net.Conn, dir string) error {
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			err = errors.New("volume does not exist")
+		}
+		//nolint:errcheck // The transfer is aborted anyway.
+		vtar.WriteStatus(conn, err)
+		return err
+	}
+
+	if err := vtar.WriteStatus(conn, nil); err != nil {
+		return errors.WithContext("write status", err)
+	}
+
+	// If the archive fails partway through, the CLI detects that it's
+	// truncated.
+	return vtar.Archive(conn, dir)
+}
+
+func importArchive(conn net.Conn, dir string) error {
+	// Extract into a temporary directory so that the volume is only replaced
+	// if the entire archive is extracted successfully.
+	tmpDir := dir + ".blimp-import"
+	err := extract(conn, dir, tmpDir)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+	}
+
+	if statusErr := vtar.WriteStatus(conn, err); statusErr != nil && err == nil {
+		return errors.WithContext("write status", statusErr)
+	}
+	return err
+}
+
+func extract(r io.Reader, dir, tmpDir string) error {
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return errors.WithContext("clear temporary directory", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(tmpDir), 0755); err != nil {
+		return errors.WithContext("make parent directory", err)
+	}
+
+	if err := vtar.Extract(r, tmpDir); err != nil {
+		return errors.WithContext("extract archive", err)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.WithContext("remove old volume contents", err)
+	}
+
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return errors.WithContext("replace volume", err)
+	}
+	return nil
+}
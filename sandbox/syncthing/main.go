@@ -14,7 +14,7 @@ import (
 )
 
 func main() {
-	folders := syncthing.ArgsToMap(os.Args[1:])
+	folders, receiveOnly := syncthing.ArgsToMap(os.Args[1:])
 
 	err := syncthing.MakeMarkers(folders)
 	if err != nil {
@@ -29,7 +29,7 @@ func main() {
 	}
 
 
-	homePath := fmt.Sprintf("/pv/syncthing-config/%s", configHash(folders))
+	homePath := fmt.Sprintf("/pv/syncthing-config/%s", configHash(folders, receiveOnly))
 
 	if _, err := os.Stat(homePath); os.IsNotExist(err) {
 		// This homePath is new, so create it. Copy from /var/syncthing/config/
@@ -44,7 +44,7 @@ func main() {
 			panic(err)
 		}
 
-		configFile := syncthing.MakeServer(folders)
+		configFile := syncthing.MakeServer(folders, receiveOnly)
 		configPath := filepath.Join(homePath, "config.xml")
 		err = ioutil.WriteFile(configPath, []byte(configFile), 0655)
 		if err != nil {
@@ -61,10 +61,15 @@ func main() {
 	}
 }
 
-func configHash(folders map[string]string) string {
+func configHash(folders map[string]string, receiveOnly map[string]bool) string {
 	type kv struct{Key, Value string}
 	slice := []kv{}
 	for k, v := range folders {
+		// Only change the hash for receive-only folders so that existing
+		// configs are reused.
+		if receiveOnly[k] {
+			v += ",receiveonly"
+		}
 		slice = append(slice, kv{k, v})
 	}
 	sort.Slice(slice, func(i, j int) bool {